@@ -7,6 +7,7 @@ require (
 	github.com/aws/aws-sdk-go v1.43.22
 	github.com/bxcodec/faker/v3 v3.8.0
 	github.com/deepmap/oapi-codegen v1.9.1
+	github.com/go-chi/chi v4.1.2+incompatible
 	github.com/go-chi/chi/v5 v5.0.7
 	github.com/go-chi/render v1.0.1
 	github.com/go-redis/redis/v8 v8.11.5
@@ -15,7 +16,7 @@ require (
 	github.com/redhatinsights/edge-api v0.0.0-20220322130231-f06b21f7de90
 	github.com/redhatinsights/platform-go-middlewares v0.12.0
 	github.com/sirupsen/logrus v1.8.1
-	go.uber.org/atomic v1.9.0
+	github.com/spf13/viper v1.10.1
 	golang.org/x/crypto v0.0.0-20220321153916-2c7772ba3064
 	google.golang.org/grpc v1.43.0
 	google.golang.org/protobuf v1.27.1
@@ -52,9 +53,9 @@ require (
 	github.com/spf13/cast v1.4.1 // indirect
 	github.com/spf13/jwalterweatherman v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	github.com/spf13/viper v1.10.1 // indirect
 	github.com/subosito/gotenv v1.2.0 // indirect
 	github.com/yuin/gopher-lua v0.0.0-20220413183635-c841877397d8 // indirect
+	go.uber.org/atomic v1.9.0 // indirect
 	golang.org/x/net v0.0.0-20220225172249-27dd8689420f // indirect
 	golang.org/x/sys v0.0.0-20220319134239-a9b59b0215f8 // indirect
 	golang.org/x/text v0.3.7 // indirect
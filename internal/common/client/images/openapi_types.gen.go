@@ -3,6 +3,19 @@
 // Code generated by github.com/deepmap/oapi-codegen version (devel) DO NOT EDIT.
 package images
 
+import (
+	"time"
+)
+
+// Defines values for ImageParentRelation.
+const (
+	ImageParentRelationCloned ImageParentRelation = "cloned"
+
+	ImageParentRelationRestored ImageParentRelation = "restored"
+
+	ImageParentRelationUpgraded ImageParentRelation = "upgraded"
+)
+
 // Defines values for Status.
 const (
 	StatusBuilding Status = "building"
@@ -12,6 +25,24 @@ const (
 	StatusSuccess Status = "success"
 )
 
+// CatalogEntry defines model for CatalogEntry.
+type CatalogEntry struct {
+	// Number of images, not deleted, using the tag or package.
+	Count int `json:"count"`
+
+	// Unique identifiers of the images using the tag or package.
+	Images []UUID `json:"images"`
+	Name   string `json:"name"`
+}
+
+// CloneImageRequest defines model for CloneImageRequest.
+type CloneImageRequest struct {
+	Name *Name `json:"name,omitempty"`
+}
+
+// Checksum of the ostree commit.
+type Commit string
+
 // CreateImageRequest defines model for CreateImageRequest.
 type CreateImageRequest struct {
 	Description  *Description  `json:"description,omitempty"`
@@ -19,6 +50,8 @@ type CreateImageRequest struct {
 	Name         *Name         `json:"name,omitempty"`
 	OutputType   *OutputTypes  `json:"output_type,omitempty"`
 	Packages     *Packages     `json:"packages,omitempty"`
+
+	// Uuids of third-party repositories of the account, in order.
 	Repositories *Repositories `json:"repositories,omitempty"`
 	SshKey       *SSHKey       `json:"sshKey,omitempty"`
 	Tags         *Tags         `json:"tags,omitempty"`
@@ -43,6 +76,47 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// ImageDescendant defines model for ImageDescendant.
+type ImageDescendant struct {
+	Descendants *[]ImageDescendant `json:"descendants,omitempty"`
+	Parent      *ImageParent       `json:"parent,omitempty"`
+	Uuid        *UUID              `json:"uuid,omitempty"`
+	Version     *Version           `json:"version,omitempty"`
+}
+
+// ImageDevice defines model for ImageDevice.
+type ImageDevice struct {
+	Name Name `json:"name"`
+	Uuid UUID `json:"uuid"`
+}
+
+// ImageInUseError defines model for ImageInUseError.
+type ImageInUseError struct {
+	// Number of devices assigned to the image or about to be by an active rollout.
+	BlockingDevices int    `json:"blocking_devices"`
+	Code            int    `json:"code"`
+	Message         string `json:"message"`
+}
+
+// ImageLineageResponse defines model for ImageLineageResponse.
+type ImageLineageResponse struct {
+	// Parents of the image, from the nearest to the origin.
+	Ancestors   *[]ImageParent     `json:"ancestors,omitempty"`
+	Descendants *[]ImageDescendant `json:"descendants,omitempty"`
+	Uuid        *UUID              `json:"uuid,omitempty"`
+	Version     *Version           `json:"version,omitempty"`
+}
+
+// ImageParent defines model for ImageParent.
+type ImageParent struct {
+	Relation *ImageParentRelation `json:"relation,omitempty"`
+	Uuid     *UUID                `json:"uuid,omitempty"`
+	Version  *Version             `json:"version,omitempty"`
+}
+
+// ImageParentRelation defines model for ImageParent.Relation.
+type ImageParentRelation string
+
 // ImageResponse defines model for ImageResponse.
 type ImageResponse struct {
 	CreatedAt    *CreatedAt    `json:"created_at,omitempty"`
@@ -51,12 +125,59 @@ type ImageResponse struct {
 	Distribution *Distribution `json:"distribution,omitempty"`
 	Name         *Name         `json:"name,omitempty"`
 	OutputType   *OutputTypes  `json:"output_type,omitempty"`
+
+	// Url of the ostree http remote of the image, for images built as edge commits.
+	RepoUrl *string `json:"repo_url,omitempty"`
+
+	// Uuids of third-party repositories of the account, in order.
+	Repositories *Repositories `json:"repositories,omitempty"`
 	Status       *Status       `json:"status,omitempty"`
 	UpdatedAt    *UpdatedAt    `json:"updated_at,omitempty"`
 	Uuid         *UUID         `json:"uuid,omitempty"`
 	Version      *Version      `json:"version,omitempty"`
 }
 
+// Fields of the image matching the query, HTML-escaped, their matching words surrounded by <b> and </b>. Tags, packages and repositories are listed in one string, separated by spaces.
+type ImageSearchHighlights struct {
+	Description *string `json:"description,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Packages    *string `json:"packages,omitempty"`
+	Repos       *string `json:"repos,omitempty"`
+	Tags        *string `json:"tags,omitempty"`
+}
+
+// ImageSearchResult defines model for ImageSearchResult.
+type ImageSearchResult struct {
+	// Fields of the image matching the query, HTML-escaped, their matching words surrounded by <b> and </b>. Tags, packages and repositories are listed in one string, separated by spaces.
+	Highlights ImageSearchHighlights `json:"highlights"`
+	Image      ImageResponse         `json:"image"`
+
+	// Relevance of the image for the query, the higher the better. Ranks only compare the results of the same search.
+	Rank float64 `json:"rank"`
+}
+
+// ImageVersionDevicesResponse defines model for ImageVersionDevicesResponse.
+type ImageVersionDevicesResponse struct {
+	// Devices assigned to the version.
+	Assigned []ImageDevice `json:"assigned"`
+
+	// Devices running the version, as last checked in.
+	Running []ImageDevice `json:"running"`
+	Version Version       `json:"version"`
+}
+
+// ImageVersionResponse defines model for ImageVersionResponse.
+type ImageVersionResponse struct {
+	// Checksum of the ostree commit.
+	Commit    *Commit      `json:"commit,omitempty"`
+	CreatedAt *CreatedAt   `json:"created_at,omitempty"`
+	Locked    *bool        `json:"locked,omitempty"`
+	Parent    *ImageParent `json:"parent,omitempty"`
+	Status    *Status      `json:"status,omitempty"`
+	Uuid      *UUID        `json:"uuid,omitempty"`
+	Version   *Version     `json:"version,omitempty"`
+}
+
 // Name defines model for Name.
 type Name string
 
@@ -66,18 +187,54 @@ type OutputTypes []string
 // Packages defines model for Packages.
 type Packages []string
 
-// Repositories defines model for Repositories.
-type Repositories []Repository
+// Uuids of third-party repositories of the account, in order.
+type Repositories []string
 
-// Repository defines model for Repository.
-type Repository struct {
-	Name *string `json:"name,omitempty"`
-	Url  *string `json:"url,omitempty"`
+// RepositoryRequest defines model for RepositoryRequest.
+type RepositoryRequest struct {
+	BaseUrl *string `json:"base_url,omitempty"`
+
+	// Whether packages must be signed by the gpg key, which is then required.
+	GpgCheck *bool `json:"gpg_check,omitempty"`
+
+	// Armored public key block, or its url, the packages of the repository are signed with.
+	GpgKey *string `json:"gpg_key,omitempty"`
+	Name   *Name   `json:"name,omitempty"`
+
+	// Priority of the repository between 1 and 99, the lower the higher. Defaults to 99.
+	Priority *int `json:"priority,omitempty"`
+}
+
+// RepositoryResponse defines model for RepositoryResponse.
+type RepositoryResponse struct {
+	BaseUrl   *string    `json:"base_url,omitempty"`
+	CreatedAt *CreatedAt `json:"created_at,omitempty"`
+	GpgCheck  *bool      `json:"gpg_check,omitempty"`
+	GpgKey    *string    `json:"gpg_key,omitempty"`
+	Name      *Name      `json:"name,omitempty"`
+	Priority  *int       `json:"priority,omitempty"`
+	UpdatedAt *UpdatedAt `json:"updated_at,omitempty"`
+	Uuid      *UUID      `json:"uuid,omitempty"`
+}
+
+// RetentionPolicy defines model for RetentionPolicy.
+type RetentionPolicy struct {
+	// Versions younger than this number of days are kept.
+	KeepDays *int `json:"keep_days,omitempty"`
+
+	// Number of successful versions to keep.
+	KeepLast *int `json:"keep_last,omitempty"`
 }
 
 // SSHKey defines model for SSHKey.
 type SSHKey string
 
+// SetImageVersionCommitRequest defines model for SetImageVersionCommitRequest.
+type SetImageVersionCommitRequest struct {
+	// Checksum of the ostree commit.
+	Commit Commit `json:"commit"`
+}
+
 // Status defines model for Status.
 type Status string
 
@@ -130,22 +287,118 @@ type GetImagesParams struct {
 
 	// field: filter by status
 	Status *string `json:"status,omitempty"`
+
+	// Maximum number of images in the page, between 1 and 100.
+	Limit *int `json:"limit,omitempty"`
+
+	// Number of images to skip before the page.
+	Offset *int `json:"offset,omitempty"`
+
+	// field: list soft-deleted images instead, filters, sorting and pagination then don't apply
+	Deleted *bool `json:"deleted,omitempty"`
 }
 
 // CreateImageJSONBody defines parameters for CreateImage.
 type CreateImageJSONBody CreateImageRequest
 
+// SearchImagesParams defines parameters for SearchImages.
+type SearchImagesParams struct {
+	// Words to look for, like: nginx berlin
+	Q string `json:"q"`
+
+	// Maximum number of images found, between 1 and 100.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// DeleteImageParams defines parameters for DeleteImage.
+type DeleteImageParams struct {
+	// Permanently delete the image and everything it owns, organization admins only.
+	Purge *bool `json:"purge,omitempty"`
+
+	// Delete the image even if devices use it, organization admins only.
+	Force *bool `json:"force,omitempty"`
+
+	// ETag of the image as last read, the request fails with 412 if the image was changed since.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetImageParams defines parameters for GetImage.
+type GetImageParams struct {
+	// field: return the image as it was at this time
+	AsOf *time.Time `json:"as_of,omitempty"`
+}
+
 // UpdateImageJSONBody defines parameters for UpdateImage.
 type UpdateImageJSONBody UpdateImageRequest
 
+// UpdateImageParams defines parameters for UpdateImage.
+type UpdateImageParams struct {
+	// ETag of the image as last read, the request fails with 412 if the image was changed since.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// CloneImageJSONBody defines parameters for CloneImage.
+type CloneImageJSONBody CloneImageRequest
+
+// DeleteImagesImageIdUpdateParams defines parameters for DeleteImagesImageIdUpdate.
+type DeleteImagesImageIdUpdateParams struct {
+	// ETag of the image as last read, the request fails with 412 if the image was changed since.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
 // CreateNewVersionJSONBody defines parameters for CreateNewVersion.
 type CreateNewVersionJSONBody UpgradeImageRequest
 
+// CreateNewVersionParams defines parameters for CreateNewVersion.
+type CreateNewVersionParams struct {
+	// ETag of the image as last read, the request fails with 412 if the image was changed since.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// SetImageVersionCommitJSONBody defines parameters for SetImageVersionCommit.
+type SetImageVersionCommitJSONBody SetImageVersionCommitRequest
+
+// GetUsedPackagesParams defines parameters for GetUsedPackages.
+type GetUsedPackagesParams struct {
+	// field: list only the packages starting with the prefix, whatever the case
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// CreateRepositoryJSONBody defines parameters for CreateRepository.
+type CreateRepositoryJSONBody RepositoryRequest
+
+// UpdateRepositoryJSONBody defines parameters for UpdateRepository.
+type UpdateRepositoryJSONBody RepositoryRequest
+
+// SetRetentionPolicyJSONBody defines parameters for SetRetentionPolicy.
+type SetRetentionPolicyJSONBody RetentionPolicy
+
+// GetTagsParams defines parameters for GetTags.
+type GetTagsParams struct {
+	// field: list only the tags starting with the prefix, whatever the case
+	Prefix *string `json:"prefix,omitempty"`
+}
+
 // CreateImageJSONRequestBody defines body for CreateImage for application/json ContentType.
 type CreateImageJSONRequestBody CreateImageJSONBody
 
 // UpdateImageJSONRequestBody defines body for UpdateImage for application/json ContentType.
 type UpdateImageJSONRequestBody UpdateImageJSONBody
 
+// CloneImageJSONRequestBody defines body for CloneImage for application/json ContentType.
+type CloneImageJSONRequestBody CloneImageJSONBody
+
 // CreateNewVersionJSONRequestBody defines body for CreateNewVersion for application/json ContentType.
 type CreateNewVersionJSONRequestBody CreateNewVersionJSONBody
+
+// SetImageVersionCommitJSONRequestBody defines body for SetImageVersionCommit for application/json ContentType.
+type SetImageVersionCommitJSONRequestBody SetImageVersionCommitJSONBody
+
+// CreateRepositoryJSONRequestBody defines body for CreateRepository for application/json ContentType.
+type CreateRepositoryJSONRequestBody CreateRepositoryJSONBody
+
+// UpdateRepositoryJSONRequestBody defines body for UpdateRepository for application/json ContentType.
+type UpdateRepositoryJSONRequestBody UpdateRepositoryJSONBody
+
+// SetRetentionPolicyJSONRequestBody defines body for SetRetentionPolicy for application/json ContentType.
+type SetRetentionPolicyJSONRequestBody SetRetentionPolicyJSONBody
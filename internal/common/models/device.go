@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Device is a model for storing edge devices.
+type Device struct {
+	Model
+
+	// composite indexes (account, uuid)
+	Account string `gorm:"index:idx_device,priority:1" json:"account"`
+	UUID    string `gorm:"type:varchar(36);index:idx_device,priority:2" json:"uuid"`
+
+	// device fields
+	Name     string         `json:"name"`
+	Commit   string         `gorm:"type:varchar(64)" json:"commit"`
+	LastSeen time.Time      `json:"last_seen"`
+	Tags     pq.StringArray `gorm:"type:text[]" json:"tags"`
+
+	// assigned image version, empty if the device is not assigned to any image
+	ImageUUID    string `gorm:"type:varchar(36);index" json:"image_uuid"`
+	ImageVersion uint   `json:"image_version"`
+
+	// image version the commit resolved to, empty if the commit isn't one of a known version
+	RunningImageUUID    string `gorm:"type:varchar(36)" json:"running_image_uuid"`
+	RunningImageVersion uint   `json:"running_image_version"`
+
+	// health the device reported the last time it checked in
+	StagedCommit string        `gorm:"type:varchar(64)" json:"staged_commit"`
+	Status       string        `gorm:"type:text" json:"status"` // rpm-ostree status summary
+	Greenboot    string        `json:"greenboot"`
+	Uptime       time.Duration `json:"uptime"`
+
+	// sha256 of the secret the device checks in with, the secret itself is never stored
+	TokenHash string `gorm:"type:varchar(64)" json:"-"`
+}
+
+// DeviceTag is a model for looking up devices by tag, one row per tag of a device.
+// It mirrors Device.Tags, so device groups are evaluated in SQL.
+type DeviceTag struct {
+	Model
+
+	// composite index (account, name, device_uuid)
+	Account    string `gorm:"index:idx_device_tag,priority:1" json:"account"`
+	Name       string `gorm:"index:idx_device_tag,priority:2" json:"name"`
+	DeviceUUID string `gorm:"type:varchar(36);index:idx_device_tag,priority:3" json:"device_uuid"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Voucher is a model for storing FDO ownership vouchers.
+type Voucher struct {
+	Model
+
+	// composite unique index (account, guid)
+	Account string `gorm:"uniqueIndex:idx_voucher,priority:1" json:"account"`
+	GUID    string `gorm:"type:varchar(36);uniqueIndex:idx_voucher,priority:2" json:"guid"`
+
+	// voucher fields
+	DeviceInfo      string `json:"device_info"`
+	ProtocolVersion uint   `json:"protocol_version"`
+	Data            []byte `json:"data"` // CBOR encoded voucher
+
+	// onboarding fields
+	Status      string    `json:"status"`
+	DeviceUUID  string    `gorm:"type:varchar(36)" json:"device_uuid"`
+	OnboardedAt time.Time `json:"onboarded_at"`
+}
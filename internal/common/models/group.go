@@ -0,0 +1,37 @@
+package models
+
+import "github.com/lib/pq"
+
+// DeviceGroup is a model for storing groups of devices.
+type DeviceGroup struct {
+	Model
+
+	// composite indexes (account, uuid)
+	Account string `gorm:"index:idx_device_group,priority:1" json:"account"`
+	UUID    string `gorm:"type:varchar(36);index:idx_device_group,priority:2" json:"uuid"`
+
+	// group fields
+	Name       string         `json:"name"`
+	Membership string         `json:"membership"`
+	Selector   pq.StringArray `gorm:"type:text[]" json:"selector"`
+}
+
+// DeviceGroupMember is a model for storing the devices listed by static groups.
+type DeviceGroupMember struct {
+	Model
+
+	// composite indexes (account, group_uuid) and (account, device_uuid)
+	Account    string `gorm:"index:idx_device_group_member,priority:1;index:idx_device_group_member_device,priority:1" json:"account"`
+	GroupUUID  string `gorm:"type:varchar(36);index:idx_device_group_member,priority:2" json:"group_uuid"`
+	DeviceUUID string `gorm:"type:varchar(36);index:idx_device_group_member_device,priority:2" json:"device_uuid"`
+}
+
+// DeviceGroupTag is a model for storing the tags dynamic groups select their devices by, one row per tag.
+type DeviceGroupTag struct {
+	Model
+
+	// composite index (account, group_uuid)
+	Account   string `gorm:"index:idx_device_group_tag,priority:1" json:"account"`
+	GroupUUID string `gorm:"type:varchar(36);index:idx_device_group_tag,priority:2" json:"group_uuid"`
+	Name      string `json:"name"`
+}
@@ -21,7 +21,6 @@ type Image struct {
 	Installer   Installer      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"installer"`
 	Tags        []Tag          `gorm:"many2many:all_tags;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"tags"`
 	Packages    []Package      `gorm:"many2many:all_packages;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"packages"`
-	Repos       []Repo         `gorm:"many2many:all_repos;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"repos"`
 	OutputTypes pq.StringArray `gorm:"type:text[]" json:"output_types"`
 
 	// IDs
@@ -43,15 +42,15 @@ type Installer struct {
 	ImageID uint `json:"image_id"`
 }
 
-// Packages is a model for storing packages.
+// Packages is a model for storing the package catalog of an account, images share the packages they have.
 type Package struct {
 	Model
 
-	// index account
-	Account string `gorm:"index:idx_packages,priority:1" json:"account"`
+	// composite unique index (account, name)
+	Account string `gorm:"uniqueIndex:idx_package_name,priority:1" json:"account"`
 
 	// package fields
-	Name string `json:"name"`
+	Name string `gorm:"uniqueIndex:idx_package_name,priority:2" json:"name"`
 
 	// IDs
 }
@@ -71,29 +70,15 @@ type User struct {
 	ImageID uint `json:"image_id"`
 }
 
-// Tags is a model for storing tags.
+// Tags is a model for storing the tag catalog of an account, images share the tags they have.
 type Tag struct {
 	Model
 
-	// index account
-	Account string `gorm:"index:idx_tags,priority:1" json:"account"`
+	// composite unique index (account, name)
+	Account string `gorm:"uniqueIndex:idx_tag_name,priority:1" json:"account"`
 
 	// tags fields
-	Name string `json:"name"`
-
-	// IDs
-}
-
-// Repo is a model for storing repositories.
-type Repo struct {
-	Model
-
-	// index account
-	Account string `gorm:"index:idx_repo,priority:1" json:"account"`
-
-	// repository fields
-	Name string `json:"name"`
-	URL  string `json:"url"`
+	Name string `gorm:"uniqueIndex:idx_tag_name,priority:2" json:"name"`
 
 	// IDs
 }
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// MaintenanceWindow is a model for storing the recurring maintenance windows of devices.
+type MaintenanceWindow struct {
+	Model
+
+	// composite indexes (account, uuid)
+	Account string `gorm:"index:idx_maintenance_window,priority:1" json:"account"`
+	UUID    string `gorm:"type:varchar(36);index:idx_maintenance_window,priority:2" json:"uuid"`
+
+	// window fields
+	Name     string        `json:"name"`
+	Schedule string        `json:"schedule"`
+	Duration time.Duration `json:"duration"`
+	Timezone string        `json:"timezone"`
+}
+
+// MaintenanceWindowTarget is a model for storing the devices and groups a window applies to,
+// one row per target with either the device or the group set.
+type MaintenanceWindowTarget struct {
+	Model
+
+	// composite indexes (account, window_uuid), (account, device_uuid) and (account, group_uuid)
+	Account    string `gorm:"index:idx_maintenance_window_target,priority:1;index:idx_maintenance_window_target_device,priority:1;index:idx_maintenance_window_target_group,priority:1" json:"account"`
+	WindowUUID string `gorm:"type:varchar(36);index:idx_maintenance_window_target,priority:2" json:"window_uuid"`
+	DeviceUUID string `gorm:"type:varchar(36);index:idx_maintenance_window_target_device,priority:2" json:"device_uuid"`
+	GroupUUID  string `gorm:"type:varchar(36);index:idx_maintenance_window_target_group,priority:2" json:"group_uuid"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// Repo is a model for storing the third-party repositories of an account, images reference them by uuid.
+type Repo struct {
+	Model
+
+	// composite indexes (account, uuid)
+	Account string `gorm:"index:idx_repo,priority:1" json:"account"`
+	UUID    string `gorm:"type:varchar(36);index:idx_repo,priority:2" json:"uuid"`
+
+	// repository fields
+	Name     string `json:"name"`
+	BaseURL  string `json:"base_url"`
+	GPGKey   string `gorm:"type:text" json:"gpg_key"`
+	GPGCheck bool   `json:"gpg_check"`
+	Priority int    `json:"priority"`
+}
+
+// ImageRepo is a model for storing the repositories an image references, one row per repository, in order.
+type ImageRepo struct {
+	Model
+
+	// composite indexes (account, image_uuid) and (account, repo_uuid)
+	Account   string `gorm:"index:idx_image_repo,priority:1;index:idx_image_repo_repo,priority:1" json:"account"`
+	ImageUUID string `gorm:"type:varchar(36);index:idx_image_repo,priority:2" json:"image_uuid"`
+	RepoUUID  string `gorm:"type:varchar(36);index:idx_image_repo_repo,priority:2" json:"repo_uuid"`
+}
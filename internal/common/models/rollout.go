@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Rollout is a model for storing the rollouts of image versions to devices.
+type Rollout struct {
+	Model
+
+	// composite indexes (account, uuid)
+	Account string `gorm:"index:idx_rollout,priority:1" json:"account"`
+	UUID    string `gorm:"type:varchar(36);index:idx_rollout,priority:2" json:"uuid"`
+
+	// image version rolled out
+	ImageUUID    string `gorm:"type:varchar(36)" json:"image_uuid"`
+	ImageVersion uint   `json:"image_version"`
+
+	// plan the rollout follows
+	Stages           pq.Int64Array `gorm:"type:integer[]" json:"stages"`
+	SoakTime         time.Duration `json:"soak_time"`
+	FailureThreshold uint          `json:"failure_threshold"`
+	RollbackFailed   bool          `json:"rollback_failed"`
+
+	// progress of the rollout
+	State            string `json:"state"`
+	Stage            uint   `json:"stage"`
+	AcceptedFailures uint   `json:"accepted_failures"`
+
+	// lease of the replica following the rollout, empty if none does
+	LeaseToken     string    `gorm:"type:varchar(36)" json:"-"`
+	LeaseExpiresAt time.Time `json:"-"`
+}
+
+// RolloutTransaction is a model for storing the update of a single device within a rollout.
+type RolloutTransaction struct {
+	Model
+
+	// composite unique index (account, rollout_uuid, device_uuid)
+	Account     string `gorm:"uniqueIndex:idx_rollout_transaction,priority:1" json:"account"`
+	RolloutUUID string `gorm:"type:varchar(36);uniqueIndex:idx_rollout_transaction,priority:2" json:"rollout_uuid"`
+	DeviceUUID  string `gorm:"type:varchar(36);uniqueIndex:idx_rollout_transaction,priority:3" json:"device_uuid"`
+
+	// transaction fields
+	Status          string    `json:"status"`
+	Reason          string    `json:"reason"`
+	StatusUpdatedAt time.Time `json:"status_updated_at"`
+
+	// assignment of the device before the transaction, empty if it was unassigned
+	PreviousImageUUID    string `gorm:"type:varchar(36)" json:"previous_image_uuid"`
+	PreviousImageVersion uint   `json:"previous_image_version"`
+	// whether the previous assignment was restored once the transaction failed
+	Restored bool `json:"restored"`
+}
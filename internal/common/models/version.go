@@ -0,0 +1,47 @@
+package models
+
+// ImageVersion is a model for storing snapshots of image versions.
+type ImageVersion struct {
+	Model
+
+	// composite unique index (account, image_uuid, version)
+	Account   string `gorm:"uniqueIndex:idx_image_version,priority:1" json:"account"`
+	ImageUUID string `gorm:"type:varchar(36);uniqueIndex:idx_image_version,priority:2" json:"image_uuid"`
+	Version   uint   `gorm:"uniqueIndex:idx_image_version,priority:3" json:"version"`
+
+	// version fields
+	Status string `json:"status"`
+	Locked bool   `gorm:"default:false" json:"locked"`
+	Commit string `gorm:"type:varchar(64);index" json:"commit"` // ostree commit, empty until the build completed
+	Data   string `gorm:"type:text" json:"data"`                // JSON encoded image
+
+	// lineage fields, empty if the version isn't derived from another one
+	ParentUUID    string `gorm:"type:varchar(36);index" json:"parent_uuid"`
+	ParentVersion uint   `json:"parent_version"`
+	Relation      string `json:"relation"`
+}
+
+// RetentionPolicy is a model for storing the version retention policy of an account.
+type RetentionPolicy struct {
+	Model
+
+	// unique index account
+	Account string `gorm:"uniqueIndex:idx_retention_policy" json:"account"`
+
+	// policy fields
+	KeepLast uint `json:"keep_last"`
+	KeepDays uint `json:"keep_days"`
+}
+
+// ImageMetadataChange is a model for storing the state an image had before it was changed within a version.
+type ImageMetadataChange struct {
+	Model
+
+	// composite index (account, image_uuid, version)
+	Account   string `gorm:"index:idx_image_metadata_change,priority:1" json:"account"`
+	ImageUUID string `gorm:"type:varchar(36);index:idx_image_metadata_change,priority:2" json:"image_uuid"`
+	Version   uint   `gorm:"index:idx_image_metadata_change,priority:3" json:"version"`
+
+	// state of the version before the change
+	Data string `gorm:"type:text" json:"data"` // JSON encoded image
+}
@@ -14,6 +14,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// APIPath is the path all APIs are mounted under.
+const APIPath = "/api/edge/v1"
+
 // RunHTTPServer runs an http server.
 func RunHTTPServer(cfg *config.EdgeConfig, createHandler func(router chi.Router) http.Handler) {
 	apiRouter := chi.NewRouter()
@@ -21,7 +24,7 @@ func RunHTTPServer(cfg *config.EdgeConfig, createHandler func(router chi.Router)
 
 	rootRouter := chi.NewRouter()
 	// we are mounting all APIs under /api/edge/v1 path
-	rootRouter.Mount("/api/edge/v1", createHandler(apiRouter))
+	rootRouter.Mount(APIPath, createHandler(apiRouter))
 
 	logrus.Info("Starting HTTP server")
 
@@ -5,7 +5,15 @@ import (
 
 	"encoding/json"
 	"errors"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/ostree"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
 	"github.com/go-chi/render"
 	"gorm.io/gorm"
 )
@@ -44,17 +52,88 @@ func NewNotFound(message string) APIError {
 	}
 }
 
+// NewForbidden creates a new Forbidden
+func NewForbidden(message string) APIError {
+	return APIError{
+		message: errors.New("Forbidden: " + message).Error(),
+		code:    http.StatusForbidden,
+	}
+}
+
+// NewPreconditionFailed creates a new PreconditionFailed
+func NewPreconditionFailed(message string) APIError {
+	return APIError{
+		message: errors.New("Precondition Failed: " + message).Error(),
+		code:    http.StatusPreconditionFailed,
+	}
+}
+
+// NewConflict creates a new Conflict
+func NewConflict(message string) APIError {
+	return APIError{
+		message: errors.New("Conflict: " + message).Error(),
+		code:    http.StatusConflict,
+	}
+}
+
+// NewNotImplemented creates a new NotImplemented
+func NewNotImplemented(message string) APIError {
+	return APIError{
+		message: errors.New("Not Implemented: " + message).Error(),
+		code:    http.StatusNotImplemented,
+	}
+}
+
 // HandleImageErrors handles errors from the image domain
 func HandleImageErrors(w http.ResponseWriter, r *http.Request, err error) {
+	var inUse image.InUseError
+	if errors.As(err, &inUse) {
+		conflict := NewConflict(err.Error())
+		render.Status(r, conflict.Code())
+		render.JSON(w, r, struct {
+			Code            int    `json:"code"`
+			Message         string `json:"message"`
+			BlockingDevices int    `json:"blocking_devices"`
+		}{conflict.Code(), conflict.Error(), inUse.Devices})
+		return
+	}
+	if errors.Is(err, ostree.ErrInvalidTarball) {
+		render.Status(r, NewBadRequest(err.Error()).Code())
+		render.JSON(w, r, NewBadRequest(err.Error()))
+		return
+	}
 	switch err {
 	case image.ErrImageNotFound:
 		render.Status(r, NewNotFound(err.Error()).Code())
 		render.JSON(w, r, err)
+	case image.ErrVersionNotFound, image.ErrRetentionPolicyNotFound, ostree.ErrFileNotFound, ostree.ErrInvalidPath,
+		repo.ErrRepoNotFound:
+		render.Status(r, NewNotFound(err.Error()).Code())
+		render.JSON(w, r, NewNotFound(err.Error()))
 	case image.ErrAlreadyBuilding, image.ErrEmptyContext,
 		image.ErrInvalidStatus, image.ErrInvalidVersion, image.ErrInvalidUser,
-		image.ErrInvalidOutputType:
+		image.ErrInvalidOutputType, image.ErrInvalidRetentionPolicy,
+		image.ErrInvalidRelation, image.ErrInvalidParent, image.ErrInvalidCommit,
+		image.ErrInvalidSortField, image.ErrInvalidPage, image.ErrInvalidRepo, image.ErrUnknownRepo,
+		image.ErrInvalidSearchQuery,
+		common.ErrInvalidName, repo.ErrEmptyContext, repo.ErrInvalidBaseURL, repo.ErrInvalidGPGKey,
+		repo.ErrMissingGPGKey, repo.ErrInvalidPriority,
+		ostree.ErrInvalidRepo, ostree.ErrNotPublishable, ostree.ErrNotArchiveRepo, ostree.ErrMetadataTooLong,
+		ostree.ErrCommitMismatch:
 		render.Status(r, NewBadRequest(err.Error()).Code())
 		render.JSON(w, r, NewBadRequest(err.Error()))
+	case repo.ErrRepoInUse:
+		render.Status(r, NewConflict(err.Error()).Code())
+		render.JSON(w, r, NewConflict(err.Error()))
+	case image.ErrPreconditionFailed:
+		render.Status(r, NewPreconditionFailed(err.Error()).Code())
+		render.JSON(w, r, NewPreconditionFailed(err.Error()))
+	case common.ErrNotOrgAdmin:
+		render.Status(r, NewForbidden(err.Error()).Code())
+		render.JSON(w, r, NewForbidden(err.Error()))
+	case common.ErrUnavailable:
+		render.Status(r, NewNotImplemented(err.Error()).Code())
+		render.JSON(w, r, NewNotImplemented(err.Error()))
 	case gorm.ErrRecordNotFound:
 		render.Status(r, NewNotFound(err.Error()).Code())
 		render.JSON(w, r, NewNotFound(err.Error()))
@@ -64,6 +143,38 @@ func HandleImageErrors(w http.ResponseWriter, r *http.Request, err error) {
 	}
 }
 
+// HandleDeviceErrors handles errors from the device, group, rollout and fdo domains
+func HandleDeviceErrors(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case device.ErrDeviceNotFound, group.ErrGroupNotFound, fdo.ErrVoucherNotFound,
+		rollout.ErrRolloutNotFound, rollout.ErrTransactionNotFound, maintenance.ErrWindowNotFound:
+		render.Status(r, NewNotFound(err.Error()).Code())
+		render.JSON(w, r, NewNotFound(err.Error()))
+	case device.ErrEmptyContext, device.ErrInvalidCommit, device.ErrInvalidAssignment,
+		device.ErrInvalidGreenboot, device.ErrInvalidUptime, device.ErrInvalidCheckIn,
+		common.ErrInvalidName, group.ErrEmptyContext, group.ErrInvalidMembership, group.ErrUnknownDevice,
+		fdo.ErrEmptyContext, fdo.ErrInvalidVoucher, fdo.ErrInvalidGUID,
+		rollout.ErrEmptyContext, rollout.ErrNoDevices, rollout.ErrUnknownTarget, rollout.ErrVersionNotSuccessful,
+		rollout.ErrInvalidRolloutVersion, rollout.ErrInvalidStatus, rollout.ErrInvalidPlan,
+		maintenance.ErrEmptyContext, maintenance.ErrInvalidSchedule, maintenance.ErrInvalidDuration,
+		maintenance.ErrInvalidTimezone, maintenance.ErrInvalidTarget, maintenance.ErrUnknownTarget:
+		render.Status(r, NewBadRequest(err.Error()).Code())
+		render.JSON(w, r, NewBadRequest(err.Error()))
+	case fdo.ErrVoucherExists, fdo.ErrAlreadyOnboarded, rollout.ErrInvalidTransition, rollout.ErrInvalidStateChange:
+		render.Status(r, NewConflict(err.Error()).Code())
+		render.JSON(w, r, NewConflict(err.Error()))
+	case device.ErrInvalidToken:
+		render.Status(r, NewForbidden(err.Error()).Code())
+		render.JSON(w, r, NewForbidden(err.Error()))
+	case common.ErrUnavailable:
+		render.Status(r, NewNotImplemented(err.Error()).Code())
+		render.JSON(w, r, NewNotImplemented(err.Error()))
+	default:
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, NewInternalServerError())
+	}
+}
+
 // UnmarshalJSON is a custom unmarshaller for APIError
 func (e *APIError) UnmarshalJSON(data []byte) error {
 	var err struct {
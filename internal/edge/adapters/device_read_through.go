@@ -0,0 +1,111 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ReadThroughDeviceRepository is an implementation of the Device.Repository
+type ReadThroughDeviceRepository struct {
+	rdb *RedisDeviceRepository // redis client
+	gdb *GormDeviceRepository  // gorm client
+}
+
+// NewReadThroughDeviceRepository returns a new ReadThroughDeviceRepository
+func NewReadThroughDeviceRepository(rdb redis.UniversalClient, gdb *gorm.DB, cfg RedisConfig) *ReadThroughDeviceRepository {
+	return &ReadThroughDeviceRepository{
+		rdb: NewRedisDeviceRepository(rdb, cfg),
+		gdb: NewGormDeviceRepository(gdb),
+	}
+}
+
+// CreateDevice creates a new device, implementing the Device.Repository interface.
+func (r *ReadThroughDeviceRepository) CreateDevice(ctx context.Context, device *device.Device) error {
+	// write devices synchronously, gorm stamps the device times before it's cached
+	if err := r.gdb.CreateDevice(ctx, device); err != nil {
+		return err
+	}
+	// try to create device in redis
+	if err := r.rdb.CreateDevice(ctx, device); err != nil {
+		log.WithField("uuid", device.UUID()).Error(err) // if redis fails, log error
+	}
+	return nil
+}
+
+// GetDevice returns the device with the given UUID, implementing the Device.Repository interface.
+func (r *ReadThroughDeviceRepository) GetDevice(ctx context.Context, uuid string) (*device.Device, error) {
+	device, err := r.rdb.GetDevice(ctx, uuid) // try to get device from redis
+	if err != nil {                           // if redis fails, try gorm
+		device, err = r.gdb.GetDevice(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+		// if gorm succeeds, update redis
+		if err := r.rdb.CreateDevice(ctx, device); err != nil {
+			log.WithField("uuid", uuid).Error(err) // if redis fails, log error
+		}
+	}
+	return device, nil
+}
+
+// UpdateDevice updates the device with the given UUID, implementing the Device.Repository interface.
+// Gorm is the source of truth, redis is refreshed only once the update is stored.
+func (r *ReadThroughDeviceRepository) UpdateDevice(ctx context.Context, uuid string, updateFn func(device *device.Device) (*device.Device, error)) error {
+	if err := r.gdb.UpdateDevice(ctx, uuid, updateFn); err != nil { // update device in gorm, synchronously
+		return err
+	}
+	r.refreshCache(ctx, uuid)
+	return nil
+}
+
+// DeleteDevice deletes the device with the given UUID, implementing the Device.Repository interface.
+func (r *ReadThroughDeviceRepository) DeleteDevice(ctx context.Context, uuid string) error {
+	if err := r.gdb.DeleteDevice(ctx, uuid); err != nil { // delete device from gorm, synchronously
+		return err
+	}
+	// try to delete device from redis
+	if err := r.rdb.DeleteDevice(ctx, uuid); err != nil {
+		log.WithField("uuid", uuid).Error(err) // if redis fails, log error
+	}
+	return nil
+}
+
+// GetDevices returns a list of devices, implementing the Device.Repository interface.
+func (r *ReadThroughDeviceRepository) GetDevices(ctx context.Context) ([]*device.Device, error) {
+	return r.gdb.GetDevices(ctx) // cached devices expire, only gorm has the full list
+}
+
+// GetImageDevices returns the devices assigned to or running a version of the image with the given UUID,
+// implementing the Device.Repository interface.
+func (r *ReadThroughDeviceRepository) GetImageDevices(ctx context.Context, imageUUID string) ([]*device.Device, error) {
+	return r.gdb.GetImageDevices(ctx, imageUUID) // cached devices expire, only gorm has the full list
+}
+
+// GetDriftedDevices returns the devices not running the image version they are assigned to,
+// implementing the Device.Repository interface.
+func (r *ReadThroughDeviceRepository) GetDriftedDevices(ctx context.Context) ([]*device.Device, error) {
+	return r.gdb.GetDriftedDevices(ctx) // cached devices expire, only gorm has the full list
+}
+
+// GetDrift counts the drift of the devices per image they are assigned to, implementing the Device.Repository interface.
+func (r *ReadThroughDeviceRepository) GetDrift(ctx context.Context) ([]device.ImageDrift, error) {
+	return r.gdb.GetDrift(ctx) // cached devices expire, only gorm has the full list
+}
+
+// refreshCache replaces the cached device with the one stored in gorm, dropping it if that fails.
+func (r *ReadThroughDeviceRepository) refreshCache(ctx context.Context, uuid string) {
+	device, err := r.gdb.GetDevice(ctx, uuid)
+	if err == nil {
+		err = r.rdb.CreateDevice(ctx, device)
+	}
+	if err != nil {
+		log.WithField("uuid", uuid).Error(err) // if refresh fails, log error and drop the stale device
+		if err := r.rdb.DeleteDevice(ctx, uuid); err != nil {
+			log.WithField("uuid", uuid).Error(err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package adapters
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/ostree"
+	log "github.com/sirupsen/logrus"
+)
+
+// FilesystemOstreeStorage is a local filesystem implementation of the ostree.Storage interface.
+type FilesystemOstreeStorage struct {
+	root string
+}
+
+// NewFilesystemOstreeStorage returns a new local filesystem implementation of the ostree.Storage interface,
+// storing the files under the given root directory.
+func NewFilesystemOstreeStorage(root string) *FilesystemOstreeStorage {
+	if root == "" {
+		panic("root cannot be empty")
+	}
+	return &FilesystemOstreeStorage{root: root}
+}
+
+// Write stores the content read from r at the given path, implementing the ostree.Storage interface.
+// The content is written to a temporary file renamed once complete, so that readers never see a partial file.
+func (s *FilesystemOstreeStorage) Write(ctx context.Context, path string, r io.Reader) error {
+	log.WithField("path", path).Debug("filesystem write ostree file")
+	name := filepath.Join(s.root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(name), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // already renamed on success
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Open returns the content of the file stored at the given path, implementing the ostree.Storage interface.
+func (s *FilesystemOstreeStorage) Open(ctx context.Context, path string) (io.ReadCloser, error) {
+	log.WithField("path", path).Debug("filesystem open ostree file")
+	file, err := os.Open(filepath.Join(s.root, filepath.FromSlash(path)))
+	if os.IsNotExist(err) {
+		return nil, ostree.ErrFileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info, err := file.Stat(); err != nil || info.IsDir() {
+		_ = file.Close()
+		return nil, ostree.ErrFileNotFound
+	}
+	return file, nil
+}
@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/ostree"
+)
+
+func TestFilesystemOstreeStorage_Open(t *testing.T) {
+	storage := NewFilesystemOstreeStorage(t.TempDir())
+	if err := storage.Write(context.Background(), "0000000/image-uuid/config", strings.NewReader("old")); err != nil {
+		t.Fatalf("FilesystemOstreeStorage.Write() error = %v", err)
+	}
+	if err := storage.Write(context.Background(), "0000000/image-uuid/config", strings.NewReader("[core]")); err != nil {
+		t.Fatalf("FilesystemOstreeStorage.Write() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "should open the replaced file",
+			path: "0000000/image-uuid/config",
+			want: "[core]",
+		},
+		{
+			name:    "should fail, unknown file",
+			path:    "0000000/image-uuid/summary",
+			wantErr: ostree.ErrFileNotFound,
+		},
+		{
+			name:    "should fail, directory",
+			path:    "0000000/image-uuid",
+			wantErr: ostree.ErrFileNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.Open(context.Background(), tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FilesystemOstreeStorage.Open() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			defer got.Close()
+			content, _ := ioutil.ReadAll(got)
+			if string(content) != tt.want {
+				t.Errorf("FilesystemOstreeStorage.Open() = %v, want %v", string(content), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package adapters
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GormCatalogRepository is a GORM implementation of the Image.CatalogRepository interface.
+type GormCatalogRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCatalogRepository returns a new GORM implementation of the Image.CatalogRepository interface.
+func NewGormCatalogRepository(db *gorm.DB) *GormCatalogRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormCatalogRepository{db: db}
+}
+
+// GetTagCatalog returns the entries of the tags starting with the prefix, implementing the Image.CatalogRepository interface.
+func (r *GormCatalogRepository) GetTagCatalog(ctx context.Context, prefix string) ([]*image.CatalogEntry, error) {
+	log.WithField("prefix", prefix).Debug("gorm get tag catalog")
+	return r.getCatalog(ctx, "tags", "all_tags", "tag_id", prefix)
+}
+
+// GetPackageCatalog returns the entries of the packages starting with the prefix, implementing the Image.CatalogRepository interface.
+func (r *GormCatalogRepository) GetPackageCatalog(ctx context.Context, prefix string) ([]*image.CatalogEntry, error) {
+	log.WithField("prefix", prefix).Debug("gorm get package catalog")
+	return r.getCatalog(ctx, "packages", "all_packages", "package_id", prefix)
+}
+
+// getCatalog returns the entries of the catalog table starting with the prefix, along with the images of the account
+// joined to them. Entries no image uses anymore are left out.
+func (r *GormCatalogRepository) getCatalog(ctx context.Context, table, joinTable, joinColumn, prefix string) ([]*image.CatalogEntry, error) {
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Name string
+		UUID string
+	}
+	if err := r.db.Table(table).Select(table+".name AS name, images.uuid AS uuid").
+		Joins("JOIN "+joinTable+" ON "+joinTable+"."+joinColumn+" = "+table+".id").
+		Joins("JOIN images ON images.id = "+joinTable+".image_id").
+		Where(table+".account = ? AND images.account = ? AND images.deleted_at IS NULL", account.String(), account.String()).
+		Where("LOWER("+table+".name) LIKE ? ESCAPE '\\'", escapeLike(strings.ToLower(prefix))+"%").
+		Order(table + ".name").Order("images.id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	entries := []*image.CatalogEntry{}
+	for start := 0; start < len(rows); {
+		end := start
+		var uuids []string
+		for ; end < len(rows) && rows[end].Name == rows[start].Name; end++ {
+			uuids = append(uuids, rows[end].UUID)
+		}
+		entry, err := image.NewCatalogEntry(rows[start].Name, uuids...)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+		start = end
+	}
+	image.SortCatalog(entries)
+	return entries, nil
+}
@@ -0,0 +1,179 @@
+package adapters
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/redhatinsights/edge-api/config"
+	"gorm.io/gorm"
+)
+
+// catalogCount is the name of a catalog entry along with the number of images using it.
+type catalogCount struct {
+	name  string
+	count int
+}
+
+// catalogCounts returns the names of the entries along with the number of images using them, in order.
+func catalogCounts(entries []*image.CatalogEntry) []catalogCount {
+	counts := []catalogCount{}
+	for _, entry := range entries {
+		counts = append(counts, catalogCount{name: entry.Name(), count: entry.Count()})
+	}
+	return counts
+}
+
+func TestNewGormCatalogRepository(t *testing.T) {
+	gormClient := &gorm.DB{}
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want *GormCatalogRepository
+	}{
+		{
+			name: "should return a new gorm catalog repository",
+			args: args{
+				db: gormClient,
+			},
+			want: &GormCatalogRepository{
+				db: gormClient,
+			},
+		},
+		{
+			name: "should panic if db is nil",
+			args: args{
+				db: nil,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				// recover from panic if one occured.
+				if recover() != nil && tt.want != nil {
+					t.Errorf("NewGormCatalogRepository() panicked")
+				}
+			}()
+			t.Parallel()
+			if got := NewGormCatalogRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewGormCatalogRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormCatalogRepository_GetTagCatalog(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	images := NewGormImageRepository(gormClient)
+	for _, img := range []image.Image{validImage, anotherValidImage} {
+		img := img
+		if err := images.CreateImage(context.Background(), &img); err != nil {
+			t.Fatalf("failed to create image: %s", err)
+		}
+	}
+	// the images share their tags, one of them gets its own
+	err := images.UpdateImage(context.Background(), anotherValidImage.UUID(), func(current *image.Image) (*image.Image, error) {
+		current.AddTag(common.NewTag("berlin"))
+		return current, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to update image: %s", err)
+	}
+	repository := NewGormCatalogRepository(gormClient)
+
+	tests := []struct {
+		name    string
+		prefix  string
+		delete  bool
+		want    []catalogCount
+		wantErr bool
+		auth    bool
+	}{
+		{
+			name:   "should list the tags, most used first",
+			prefix: "",
+			want:   []catalogCount{{name: "tag1", count: 2}, {name: "tag2", count: 2}, {name: "berlin", count: 1}},
+		},
+		{
+			name:   "should list the tags starting with the prefix, whatever their case",
+			prefix: "TAG2",
+			want:   []catalogCount{{name: "tag2", count: 2}},
+		},
+		{
+			name:   "should not count the deleted images",
+			prefix: "",
+			delete: true,
+			want:   []catalogCount{{name: "tag1", count: 1}, {name: "tag2", count: 1}},
+		},
+		{
+			name:    "should fail to list the tags, bad account",
+			prefix:  "",
+			wantErr: true,
+			auth:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config.Get().Auth = tt.auth
+			defer func() {
+				config.Get().Auth = false
+			}()
+			if tt.delete {
+				if err := images.DeleteImage(context.Background(), anotherValidImage.UUID()); err != nil {
+					t.Fatalf("failed to delete image: %s", err)
+				}
+			}
+			got, err := repository.GetTagCatalog(context.Background(), tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GormCatalogRepository.GetTagCatalog() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if counts := catalogCounts(got); !reflect.DeepEqual(counts, tt.want) {
+				t.Errorf("GormCatalogRepository.GetTagCatalog() = %v, want %v", counts, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormCatalogRepository_GetPackageCatalog(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	images := NewGormImageRepository(gormClient)
+	for _, img := range []image.Image{validImage, anotherValidImage} {
+		img := img
+		if err := images.CreateImage(context.Background(), &img); err != nil {
+			t.Fatalf("failed to create image: %s", err)
+		}
+	}
+	repository := NewGormCatalogRepository(gormClient)
+
+	got, err := repository.GetPackageCatalog(context.Background(), "vi")
+	if err != nil {
+		t.Fatalf("GormCatalogRepository.GetPackageCatalog() error = %v", err)
+	}
+	// every image using the package is listed, to find them all
+	if len(got) != 1 || got[0].Name() != "vim" ||
+		!reflect.DeepEqual(got[0].Images(), []string{validImage.UUID(), anotherValidImage.UUID()}) {
+		t.Errorf("GormCatalogRepository.GetPackageCatalog() = %v, want vim used by both images", got)
+	}
+	// the required packages are stored once, whatever the number of images
+	var packages int64
+	gormClient.Table("packages").Where("name = ?", "ansible").Count(&packages)
+	if packages != 1 {
+		t.Errorf("GormImageRepository.CreateImage() stored %d ansible packages, want 1", packages)
+	}
+}
@@ -0,0 +1,283 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GormDeviceRepository is a GORM implementation of the Device.Repository interface.
+type GormDeviceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDeviceRepository returns a new GORM implementation of the Device.Repository interface.
+func NewGormDeviceRepository(db *gorm.DB) *GormDeviceRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormDeviceRepository{db: db}
+}
+
+// CreateDevice creates a new device, implementing the Device.Repository interface.
+func (r *GormDeviceRepository) CreateDevice(ctx context.Context, device *device.Device) error {
+	log.Debug("gorm create device")
+	account, err := device.Account()
+	if err != nil {
+		return err
+	}
+	device.Touch(time.Now())
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if assignment := device.Assignment(); !assignment.IsZero() {
+			if err := shareImage(tx, account, assignment.ImageUUID()); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(device.MarshalGorm()).Error; err != nil {
+			return err
+		}
+		return setDeviceTags(tx, account, device.UUID(), device.Tags().StringArray())
+	})
+}
+
+// GetDevice returns the device with the given UUID, implementing the Device.Repository interface.
+func (r *GormDeviceRepository) GetDevice(ctx context.Context, uuid string) (*device.Device, error) {
+	log.WithField("uuid", uuid).Debug("gorm get device")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getDevice(r.db, account, uuid)
+}
+
+// UpdateDevice updates the device with the given UUID, implementing the Device.Repository interface.
+func (r *GormDeviceRepository) UpdateDevice(ctx context.Context, uuid string, updateFn func(device *device.Device) (*device.Device, error)) error {
+	log.WithField("uuid", uuid).Debug("gorm update device")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		current, err := getDevice(tx, account, uuid)
+		if err != nil {
+			return err
+		}
+		updatedDevice, err := updateFn(current)
+		if err != nil {
+			return err
+		}
+		if assignment := updatedDevice.Assignment(); !assignment.IsZero() && assignment != current.Assignment() {
+			if err := shareImage(tx, account, assignment.ImageUUID()); err != nil {
+				return err
+			}
+		}
+		updatedDevice.Touch(time.Now())
+		// select all fields, so a device can be unassigned or untagged
+		err = tx.Session(&gorm.Session{SkipHooks: true}).Model(&models.Device{}).
+			Where("account = ? AND uuid = ?", account.String(), uuid).
+			Select("name", "commit", "last_seen", "tags", "image_uuid", "image_version",
+				"running_image_uuid", "running_image_version", "staged_commit", "status", "greenboot", "uptime",
+				"updated_at").
+			Updates(updatedDevice.MarshalGorm()).Error
+		if err != nil {
+			return err
+		}
+		return setDeviceTags(tx, account, uuid, updatedDevice.Tags().StringArray())
+	})
+}
+
+// DeleteDevice deletes the device with the given UUID, implementing the Device.Repository interface.
+func (r *GormDeviceRepository) DeleteDevice(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("gorm delete device")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("account = ? AND uuid = ?", account.String(), uuid).Delete(&models.Device{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return device.ErrDeviceNotFound
+		}
+		// a deleted device is no longer a member of any group
+		err := tx.Unscoped().Where("account = ? AND device_uuid = ?", account.String(), uuid).
+			Delete(&models.DeviceGroupMember{}).Error
+		if err != nil {
+			return err
+		}
+		return setDeviceTags(tx, account, uuid, nil)
+	})
+}
+
+// GetDevices returns all devices, implementing the Device.Repository interface.
+func (r *GormDeviceRepository) GetDevices(ctx context.Context) ([]*device.Device, error) {
+	log.Debug("gorm get devices")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var deviceModels []models.Device
+	if err := r.db.Where("account = ?", account.String()).Order("created_at").Find(&deviceModels).Error; err != nil {
+		return nil, err
+	}
+	return unmarshalDevices(common.ContextWithAccount(context.Background(), account), deviceModels)
+}
+
+// GetImageDevices returns the devices assigned to or running a version of the image with the given UUID,
+// implementing the Device.Repository interface.
+func (r *GormDeviceRepository) GetImageDevices(ctx context.Context, imageUUID string) ([]*device.Device, error) {
+	log.WithField("uuid", imageUUID).Debug("gorm get image devices")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var deviceModels []models.Device
+	err = r.db.Where("account = ? AND (image_uuid = ? OR running_image_uuid = ?)", account.String(), imageUUID, imageUUID).
+		Order("created_at").Find(&deviceModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalDevices(common.ContextWithAccount(context.Background(), account), deviceModels)
+}
+
+// driftedDevices selects the assigned devices that reported a commit
+// which doesn't resolve to their assigned version, as device.Device.Drift.
+const driftedDevices = `image_uuid <> '' AND "commit" <> '' AND
+	(running_image_uuid = '' OR running_image_uuid <> image_uuid OR running_image_version <> image_version)`
+
+// driftKind is the kind of drift of a device, as device.Device.Drift, empty if it doesn't drift.
+const driftKind = `CASE
+	WHEN "commit" = '' THEN ''
+	WHEN running_image_uuid = '' THEN ?
+	WHEN running_image_uuid <> image_uuid THEN ?
+	WHEN running_image_version < image_version THEN ?
+	WHEN running_image_version > image_version THEN ?
+	ELSE '' END`
+
+// GetDriftedDevices returns the devices not running the image version they are assigned to,
+// implementing the Device.Repository interface.
+func (r *GormDeviceRepository) GetDriftedDevices(ctx context.Context) ([]*device.Device, error) {
+	log.Debug("gorm get drifted devices")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var deviceModels []models.Device
+	err = r.db.Where("account = ? AND "+driftedDevices, account.String()).Order("created_at").Find(&deviceModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalDevices(common.ContextWithAccount(context.Background(), account), deviceModels)
+}
+
+// GetDrift counts the drift of the devices per image they are assigned to, sorted by image uuid,
+// implementing the Device.Repository interface.
+func (r *GormDeviceRepository) GetDrift(ctx context.Context) ([]device.ImageDrift, error) {
+	log.Debug("gorm get drift")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		ImageUUID string
+		Drift     string
+		Devices   int
+	}
+	err = r.db.Model(&models.Device{}).
+		Select("image_uuid, "+driftKind+" AS drift, COUNT(*) AS devices", device.UnknownCommit.String(),
+			device.OtherImage.String(), device.Behind.String(), device.Ahead.String()).
+		Where("account = ? AND image_uuid <> ''", account.String()).
+		Group("image_uuid, drift").Order("image_uuid").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	var summaries []device.ImageDrift
+	drifts := make(map[device.Drift]int)
+	for i, row := range rows {
+		drift, err := device.NewDriftFromString(row.Drift)
+		if err != nil {
+			return nil, err
+		}
+		drifts[drift] = row.Devices
+		if i == len(rows)-1 || rows[i+1].ImageUUID != row.ImageUUID { // last row of the image
+			summaries = append(summaries, device.NewImageDrift(row.ImageUUID, drifts))
+			drifts = make(map[device.Drift]int)
+		}
+	}
+	return summaries, nil
+}
+
+// getDevice returns the device with the given UUID of the account, using the given connection.
+func getDevice(db *gorm.DB, account common.Account, uuid string) (*device.Device, error) {
+	var deviceModel models.Device
+	err := db.Where("account = ? AND uuid = ?", account.String(), uuid).First(&deviceModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, device.ErrDeviceNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return unmarshalDevice(common.ContextWithAccount(context.Background(), account), deviceModel)
+}
+
+// setDeviceTags replaces the tag rows of the device with the given UUID of the account, using the given connection.
+func setDeviceTags(db *gorm.DB, account common.Account, uuid string, tags []string) error {
+	err := db.Unscoped().Where("account = ? AND device_uuid = ?", account.String(), uuid).
+		Delete(&models.DeviceTag{}).Error
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(tags))
+	var tagModels []models.DeviceTag
+	for _, tag := range tags {
+		if !seen[tag] {
+			seen[tag] = true
+			tagModels = append(tagModels, models.DeviceTag{Account: account.String(), Name: tag, DeviceUUID: uuid})
+		}
+	}
+	if len(tagModels) == 0 {
+		return nil
+	}
+	return db.Create(&tagModels).Error
+}
+
+// unmarshalDevice unmarshals a device model into a domain device.
+func unmarshalDevice(ctx context.Context, deviceModel models.Device) (*device.Device, error) {
+	running, err := device.NewAssignment(deviceModel.RunningImageUUID, deviceModel.RunningImageVersion)
+	if err != nil {
+		return nil, err
+	}
+	health, err := device.NewHealth(deviceModel.StagedCommit, deviceModel.Status, deviceModel.Greenboot,
+		deviceModel.Uptime)
+	if err != nil {
+		return nil, err
+	}
+	newDevice, err := device.UnmarshalDeviceFromDatabase(ctx, deviceModel.UUID, deviceModel.Name,
+		deviceModel.Commit, deviceModel.ImageUUID, deviceModel.ImageVersion, deviceModel.Tags,
+		running, health, device.NewTokenFromHash(deviceModel.TokenHash),
+		deviceModel.LastSeen, deviceModel.CreatedAt, deviceModel.UpdatedAt, deviceModel.DeletedAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	return &newDevice, nil
+}
+
+// unmarshalDevices unmarshals array of device models into domain devices.
+func unmarshalDevices(ctx context.Context, deviceModels []models.Device) ([]*device.Device, error) {
+	devices := make([]*device.Device, len(deviceModels))
+	for i, deviceModel := range deviceModels {
+		newDevice, err := unmarshalDevice(ctx, deviceModel)
+		if err != nil {
+			return nil, err
+		}
+		devices[i] = newDevice
+	}
+	return devices, nil
+}
@@ -0,0 +1,369 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/google/uuid"
+)
+
+// newTestDevice returns a device assigned to the first version of the valid image.
+func newTestDevice(t *testing.T, name string) device.Device {
+	newDevice, err := device.NewDeviceWithContext(context.Background(), uuid.NewString(), name,
+		strings.Repeat("ab", 32), validImage.UUID(), 1, []string{"kiosk"})
+	if err != nil {
+		t.Fatalf("failed to create device: %s", err)
+	}
+	return newDevice
+}
+
+func TestGormDeviceRepository_GetDevice(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormDeviceRepository(gormClient)
+	kiosk := newTestDevice(t, "kiosk")
+	if err := repository.CreateDevice(context.Background(), &kiosk); err != nil {
+		t.Errorf("failed to create device: %s", err)
+	}
+	tests := []struct {
+		name    string
+		uuid    string
+		want    *device.Device
+		wantErr error
+	}{
+		{
+			name:    "should get the device",
+			uuid:    kiosk.UUID(),
+			want:    &kiosk,
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, unknown device",
+			uuid:    uuid.NewString(),
+			wantErr: device.ErrDeviceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.GetDevice(context.Background(), tt.uuid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormDeviceRepository.GetDevice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if string(got.MarshalRedis()) != string(tt.want.MarshalRedis()) {
+				t.Errorf("GormDeviceRepository.GetDevice() = %s, want %s", got.MarshalRedis(), tt.want.MarshalRedis())
+			}
+		})
+	}
+}
+
+func TestGormDeviceRepository_UpdateDevice(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormDeviceRepository(gormClient)
+	kiosk := newTestDevice(t, "kiosk")
+	if err := repository.CreateDevice(context.Background(), &kiosk); err != nil {
+		t.Errorf("failed to create device: %s", err)
+	}
+	reassigned, _ := device.NewAssignment(anotherValidImage.UUID(), 2)
+	tests := []struct {
+		name       string
+		uuid       string
+		assignment device.Assignment
+		wantErr    error
+	}{
+		{
+			name:       "should assign another image",
+			uuid:       kiosk.UUID(),
+			assignment: reassigned,
+			wantErr:    nil,
+		},
+		{
+			name:       "should unassign the device",
+			uuid:       kiosk.UUID(),
+			assignment: device.Assignment{},
+			wantErr:    nil,
+		},
+		{
+			name:    "should fail, unknown device",
+			uuid:    uuid.NewString(),
+			wantErr: device.ErrDeviceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := repository.UpdateDevice(context.Background(), tt.uuid, func(d *device.Device) (*device.Device, error) {
+				d.Assign(tt.assignment)
+				return d, nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormDeviceRepository.UpdateDevice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, err := repository.GetDevice(context.Background(), tt.uuid)
+			if err != nil {
+				t.Errorf("failed to get device: %s", err)
+				return
+			}
+			if got.Assignment() != tt.assignment || !got.UpdatedAt().After(kiosk.UpdatedAt()) {
+				t.Errorf("GormDeviceRepository.UpdateDevice() assignment = %v, updated at %v, want %v after %v",
+					got.Assignment(), got.UpdatedAt(), tt.assignment, kiosk.UpdatedAt())
+			}
+		})
+	}
+}
+
+func TestGormDeviceRepository_CheckIn(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormDeviceRepository(gormClient)
+	kiosk := newTestDevice(t, "kiosk")
+	if err := repository.CreateDevice(context.Background(), &kiosk); err != nil {
+		t.Errorf("failed to create device: %s", err)
+	}
+	booted, _ := device.NewCommit(strings.Repeat("cd", 32))
+	running, _ := device.NewAssignment(validImage.UUID(), 1)
+	health, _ := device.NewHealth(strings.Repeat("ef", 32), "idle", "green", time.Hour)
+	err := repository.UpdateDevice(context.Background(), kiosk.UUID(), func(d *device.Device) (*device.Device, error) {
+		return d, d.CheckIn(booted, running, health, time.Now())
+	})
+	if err != nil {
+		t.Fatalf("GormDeviceRepository.UpdateDevice() error = %v", err)
+	}
+	got, err := repository.GetDevice(context.Background(), kiosk.UUID())
+	if err != nil {
+		t.Fatalf("failed to get device: %s", err)
+	}
+	if got.Commit() != booted || got.Running() != running || got.Health() != health || got.LastSeen().IsZero() {
+		t.Errorf("GormDeviceRepository.UpdateDevice() = %s, want booted %v running %v with %+v",
+			got.MarshalRedis(), booted, running, health)
+	}
+}
+
+func TestGormDeviceRepository_DeleteDevice(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormDeviceRepository(gormClient)
+	kiosk := newTestDevice(t, "kiosk")
+	if err := repository.CreateDevice(context.Background(), &kiosk); err != nil {
+		t.Errorf("failed to create device: %s", err)
+	}
+	tests := []struct {
+		name    string
+		uuid    string
+		wantErr error
+	}{
+		{
+			name:    "should delete the device",
+			uuid:    kiosk.UUID(),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, already deleted",
+			uuid:    kiosk.UUID(),
+			wantErr: device.ErrDeviceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repository.DeleteDevice(context.Background(), tt.uuid); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormDeviceRepository.DeleteDevice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGormDeviceRepository_GetDevices(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormDeviceRepository(gormClient)
+	var want []string
+	for _, name := range []string{"kiosk", "register"} {
+		newDevice := newTestDevice(t, name)
+		if err := repository.CreateDevice(context.Background(), &newDevice); err != nil {
+			t.Errorf("failed to create device: %s", err)
+		}
+		want = append(want, newDevice.UUID())
+	}
+	got, err := repository.GetDevices(context.Background())
+	if err != nil {
+		t.Errorf("GormDeviceRepository.GetDevices() error = %v", err)
+		return
+	}
+	if gotUUIDs := deviceUUIDs(got); !reflect.DeepEqual(gotUUIDs, want) {
+		t.Errorf("GormDeviceRepository.GetDevices() = %v, want %v", gotUUIDs, want)
+	}
+}
+
+// deviceUUIDs returns the uuids of the given devices.
+func deviceUUIDs(devices []*device.Device) []string {
+	var uuids []string
+	for _, device := range devices {
+		uuids = append(uuids, device.UUID())
+	}
+	return uuids
+}
+
+func TestGormDeviceRepository_GetImageDevices(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormDeviceRepository(gormClient)
+	otherImageUUID := uuid.NewString()
+	assigned := newTestDevice(t, "assigned")
+	other, _ := device.NewAssignment(otherImageUUID, 1)
+	v1, _ := device.NewAssignment(validImage.UUID(), 1)
+	running := newTestDevice(t, "running")
+	running.Assign(other)
+	if err := running.CheckIn(running.Commit(), v1, device.Health{}, time.Now()); err != nil {
+		t.Fatalf("failed to check in device: %s", err)
+	}
+	unrelated := newTestDevice(t, "unrelated")
+	unrelated.Assign(other)
+	for _, d := range []*device.Device{&assigned, &running, &unrelated} {
+		if err := repository.CreateDevice(context.Background(), d); err != nil {
+			t.Fatalf("failed to create device: %s", err)
+		}
+	}
+	tests := []struct {
+		name      string
+		imageUUID string
+		want      []string
+	}{
+		{
+			name:      "should list the devices assigned to or running the image",
+			imageUUID: validImage.UUID(),
+			want:      []string{assigned.UUID(), running.UUID()},
+		},
+		{
+			name:      "should list the devices assigned to the other image",
+			imageUUID: otherImageUUID,
+			want:      []string{running.UUID(), unrelated.UUID()},
+		},
+		{
+			name:      "should list no device",
+			imageUUID: uuid.NewString(),
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.GetImageDevices(context.Background(), tt.imageUUID)
+			if err != nil {
+				t.Errorf("GormDeviceRepository.GetImageDevices() error = %v", err)
+				return
+			}
+			if gotUUIDs := deviceUUIDs(got); !reflect.DeepEqual(gotUUIDs, tt.want) {
+				t.Errorf("GormDeviceRepository.GetImageDevices() = %v, want %v", gotUUIDs, tt.want)
+			}
+		})
+	}
+}
+
+// newDriftedDevices stores a device per drift, assigned to the second version of the valid image,
+// with one in sync, one unassigned and one not checked in, returning them by name.
+func newDriftedDevices(t *testing.T, repository *GormDeviceRepository) map[string]*device.Device {
+	v1, _ := device.NewAssignment(validImage.UUID(), 1)
+	v2, _ := device.NewAssignment(validImage.UUID(), 2)
+	v3, _ := device.NewAssignment(validImage.UUID(), 3)
+	other, _ := device.NewAssignment(uuid.NewString(), 2)
+	running := map[string]device.Assignment{
+		"in-sync":        v2,
+		"behind":         v1,
+		"ahead":          v3,
+		"other-image":    other,
+		"unknown-commit": {},
+		"unassigned":     v1,
+	}
+	devices := make(map[string]*device.Device)
+	for _, name := range []string{"in-sync", "behind", "ahead", "other-image", "unknown-commit", "unassigned"} {
+		newDevice := newTestDevice(t, name)
+		newDevice.Assign(v2)
+		if name == "unassigned" {
+			newDevice.Assign(device.Assignment{})
+		}
+		if err := newDevice.CheckIn(newDevice.Commit(), running[name], device.Health{}, time.Now()); err != nil {
+			t.Fatalf("failed to check in device: %s", err)
+		}
+		devices[name] = &newDevice
+	}
+	notCheckedIn, err := device.NewDeviceWithContext(context.Background(), uuid.NewString(), "not-checked-in",
+		"", validImage.UUID(), 2, nil)
+	if err != nil {
+		t.Fatalf("failed to create device: %s", err)
+	}
+	devices["not-checked-in"] = &notCheckedIn
+	for _, name := range []string{"in-sync", "behind", "ahead", "other-image", "unknown-commit", "unassigned",
+		"not-checked-in"} {
+		if err := repository.CreateDevice(context.Background(), devices[name]); err != nil {
+			t.Fatalf("failed to create device: %s", err)
+		}
+	}
+	return devices
+}
+
+func TestGormDeviceRepository_GetDriftedDevices(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormDeviceRepository(gormClient)
+	devices := newDriftedDevices(t, repository)
+	got, err := repository.GetDriftedDevices(context.Background())
+	if err != nil {
+		t.Fatalf("GormDeviceRepository.GetDriftedDevices() error = %v", err)
+	}
+	var want []string
+	for _, name := range []string{"behind", "ahead", "other-image", "unknown-commit"} {
+		want = append(want, devices[name].UUID())
+	}
+	if gotUUIDs := deviceUUIDs(got); !reflect.DeepEqual(gotUUIDs, want) {
+		t.Errorf("GormDeviceRepository.GetDriftedDevices() = %v, want %v", gotUUIDs, want)
+	}
+	for _, d := range got {
+		if d.Drift().IsZero() {
+			t.Errorf("GormDeviceRepository.GetDriftedDevices() device %s doesn't drift", d.Name())
+		}
+	}
+}
+
+func TestGormDeviceRepository_GetDrift(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormDeviceRepository(gormClient)
+	devices := newDriftedDevices(t, repository)
+	var all []*device.Device
+	for _, d := range devices {
+		all = append(all, d)
+	}
+	got, err := repository.GetDrift(context.Background())
+	if err != nil {
+		t.Fatalf("GormDeviceRepository.GetDrift() error = %v", err)
+	}
+	if want := device.SummarizeDrift(all); !reflect.DeepEqual(got, want) {
+		t.Errorf("GormDeviceRepository.GetDrift() = %+v, want %+v", got, want)
+	}
+	if len(got) != 1 || got[0].Assigned() != 6 || got[0].Count(device.Drift{}) != 2 {
+		t.Errorf("GormDeviceRepository.GetDrift() = %+v, want 6 assigned devices, 2 of them in sync", got)
+	}
+}
@@ -0,0 +1,184 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GormGroupRepository is a GORM implementation of the Group.Repository interface.
+type GormGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewGormGroupRepository returns a new GORM implementation of the Group.Repository interface.
+func NewGormGroupRepository(db *gorm.DB) *GormGroupRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormGroupRepository{db: db}
+}
+
+// CreateGroup creates a new group along with its members or selector tags, implementing the Group.Repository interface.
+func (r *GormGroupRepository) CreateGroup(ctx context.Context, deviceGroup *group.DeviceGroup) error {
+	log.WithField("uuid", deviceGroup.UUID()).Debug("gorm create group")
+	account, err := deviceGroup.Account()
+	if err != nil {
+		return err
+	}
+	deviceGroup.Touch(time.Now())
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(deviceGroup.MarshalGorm()).Error; err != nil {
+			return err
+		}
+		if devices := deviceGroup.Devices(); len(devices) > 0 {
+			members := make([]models.DeviceGroupMember, len(devices))
+			for i, deviceUUID := range devices {
+				members[i] = models.DeviceGroupMember{Account: account.String(), GroupUUID: deviceGroup.UUID(), DeviceUUID: deviceUUID}
+			}
+			if err := tx.Create(&members).Error; err != nil {
+				return err
+			}
+		}
+		if selector := deviceGroup.Selector().StringArray(); len(selector) > 0 {
+			tags := make([]models.DeviceGroupTag, len(selector))
+			for i, tag := range selector {
+				tags[i] = models.DeviceGroupTag{Account: account.String(), GroupUUID: deviceGroup.UUID(), Name: tag}
+			}
+			if err := tx.Create(&tags).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetGroup returns the group with the given UUID, implementing the Group.Repository interface.
+func (r *GormGroupRepository) GetGroup(ctx context.Context, uuid string) (*group.DeviceGroup, error) {
+	log.WithField("uuid", uuid).Debug("gorm get group")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var groupModel models.DeviceGroup
+	err = r.db.Where("account = ? AND uuid = ?", account.String(), uuid).First(&groupModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, group.ErrGroupNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	groups, err := r.unmarshalGroups(account, []models.DeviceGroup{groupModel})
+	if err != nil {
+		return nil, err
+	}
+	return groups[0], nil
+}
+
+// GetGroups returns all groups, implementing the Group.Repository interface.
+func (r *GormGroupRepository) GetGroups(ctx context.Context) ([]*group.DeviceGroup, error) {
+	log.Debug("gorm get groups")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var groupModels []models.DeviceGroup
+	if err := r.db.Where("account = ?", account.String()).Order("created_at").Find(&groupModels).Error; err != nil {
+		return nil, err
+	}
+	return r.unmarshalGroups(account, groupModels)
+}
+
+// GetMembers returns the devices that are members of the group with the given UUID, implementing the Group.Repository interface.
+// Static groups join their member rows, dynamic groups select the devices having a tag row for every tag of the selector.
+func (r *GormGroupRepository) GetMembers(ctx context.Context, uuid string) ([]*device.Device, error) {
+	log.WithField("uuid", uuid).Debug("gorm get group members")
+	deviceGroup, err := r.GetGroup(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	account, _ := deviceGroup.Account()
+	var members *gorm.DB
+	if deviceGroup.Membership() == group.Static {
+		members = r.db.Model(&models.DeviceGroupMember{}).Select("device_uuid").
+			Where("account = ? AND group_uuid = ?", account.String(), uuid)
+	} else {
+		selector := deviceGroup.Selector().StringArray()
+		members = r.db.Model(&models.DeviceTag{}).Select("device_uuid").
+			Where("account = ? AND name IN ?", account.String(), selector).
+			Group("device_uuid").Having("COUNT(DISTINCT name) = ?", len(selector))
+	}
+	var deviceModels []models.Device
+	err = r.db.Where("account = ? AND uuid IN (?)", account.String(), members).Order("created_at").Find(&deviceModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalDevices(common.ContextWithAccount(context.Background(), account), deviceModels)
+}
+
+// GetDeviceGroups returns the groups the device with the given UUID is a member of, implementing the Group.Repository interface.
+// A device is a member of a dynamic group if every tag of the selector joins a tag of the device.
+func (r *GormGroupRepository) GetDeviceGroups(ctx context.Context, deviceUUID string) ([]*group.DeviceGroup, error) {
+	log.WithField("uuid", deviceUUID).Debug("gorm get device groups")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getDevice(r.db, account, deviceUUID); err != nil {
+		return nil, err
+	}
+	static := r.db.Model(&models.DeviceGroupMember{}).Select("group_uuid").
+		Where("account = ? AND device_uuid = ?", account.String(), deviceUUID)
+	dynamic := r.db.Table("device_group_tags AS gt").Select("gt.group_uuid").
+		Joins("LEFT JOIN device_tags AS dt ON dt.account = gt.account AND dt.name = gt.name "+
+			"AND dt.device_uuid = ? AND dt.deleted_at IS NULL", deviceUUID).
+		Where("gt.account = ? AND gt.deleted_at IS NULL", account.String()).
+		Group("gt.group_uuid").Having("COUNT(dt.id) = COUNT(*)")
+	var groupModels []models.DeviceGroup
+	err = r.db.Where("account = ? AND (uuid IN (?) OR uuid IN (?))", account.String(), static, dynamic).
+		Order("created_at").Find(&groupModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.unmarshalGroups(account, groupModels)
+}
+
+// unmarshalGroups unmarshals group models of the account into domain groups, loading the members of static groups.
+func (r *GormGroupRepository) unmarshalGroups(account common.Account, groupModels []models.DeviceGroup) ([]*group.DeviceGroup, error) {
+	var staticUUIDs []string
+	for _, groupModel := range groupModels {
+		if groupModel.Membership == group.Static.String() {
+			staticUUIDs = append(staticUUIDs, groupModel.UUID)
+		}
+	}
+	devices := make(map[string][]string)
+	if len(staticUUIDs) > 0 {
+		var memberModels []models.DeviceGroupMember
+		err := r.db.Where("account = ? AND group_uuid IN ?", account.String(), staticUUIDs).
+			Order("id").Find(&memberModels).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, memberModel := range memberModels {
+			devices[memberModel.GroupUUID] = append(devices[memberModel.GroupUUID], memberModel.DeviceUUID)
+		}
+	}
+	ctx := common.ContextWithAccount(context.Background(), account)
+	groups := make([]*group.DeviceGroup, len(groupModels))
+	for i, groupModel := range groupModels {
+		deviceGroup, err := group.UnmarshalDeviceGroupFromDatabase(ctx, groupModel.UUID, groupModel.Name,
+			groupModel.Membership, devices[groupModel.UUID], groupModel.Selector,
+			groupModel.CreatedAt, groupModel.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		groups[i] = &deviceGroup
+	}
+	return groups, nil
+}
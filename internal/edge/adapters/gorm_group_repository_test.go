@@ -0,0 +1,221 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/google/uuid"
+)
+
+// newTestGroup returns a group listing the given devices, or selecting devices by the given tags.
+func newTestGroup(t *testing.T, name string, devices, selector []string) group.DeviceGroup {
+	newGroup, err := group.NewDeviceGroupWithContext(context.Background(), uuid.NewString(), name, devices, selector)
+	if err != nil {
+		t.Fatalf("failed to create group: %s", err)
+	}
+	return newGroup
+}
+
+// groupUUIDs returns the uuids of the given groups.
+func groupUUIDs(groups []*group.DeviceGroup) []string {
+	uuids := make([]string, len(groups))
+	for i, g := range groups {
+		uuids[i] = g.UUID()
+	}
+	return uuids
+}
+
+// setupGroups stores three tagged devices and a static and two dynamic groups over them.
+func setupGroups(t *testing.T) (devices []device.Device, groups []group.DeviceGroup) {
+	deviceRepository := NewGormDeviceRepository(gormClient)
+	groupRepository := NewGormGroupRepository(gormClient)
+	for _, tags := range [][]string{{"kiosk", "store-42"}, {"kiosk", "store-7"}, nil} {
+		newDevice, err := device.NewDeviceWithContext(context.Background(), uuid.NewString(), "device", "", "", 0, tags)
+		if err != nil {
+			t.Fatalf("failed to create device: %s", err)
+		}
+		if err := deviceRepository.CreateDevice(context.Background(), &newDevice); err != nil {
+			t.Fatalf("failed to store device: %s", err)
+		}
+		devices = append(devices, newDevice)
+	}
+	groups = []group.DeviceGroup{
+		newTestGroup(t, "static", []string{devices[2].UUID(), devices[0].UUID()}, nil),
+		newTestGroup(t, "kiosks", nil, []string{"kiosk"}),
+		newTestGroup(t, "store-42 kiosks", nil, []string{"kiosk", "store-42", "kiosk"}),
+	}
+	for i := range groups {
+		if err := groupRepository.CreateGroup(context.Background(), &groups[i]); err != nil {
+			t.Fatalf("failed to store group: %s", err)
+		}
+	}
+	return devices, groups
+}
+
+func TestGormGroupRepository_GetGroup(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormGroupRepository(gormClient)
+	devices, groups := setupGroups(t)
+	tests := []struct {
+		name        string
+		uuid        string
+		wantDevices []string
+		wantErr     error
+	}{
+		{
+			name:        "should get the static group with its devices",
+			uuid:        groups[0].UUID(),
+			wantDevices: []string{devices[2].UUID(), devices[0].UUID()},
+			wantErr:     nil,
+		},
+		{
+			name:    "should get the dynamic group",
+			uuid:    groups[2].UUID(),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, unknown group",
+			uuid:    uuid.NewString(),
+			wantErr: group.ErrGroupNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.GetGroup(context.Background(), tt.uuid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormGroupRepository.GetGroup() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.UUID() != tt.uuid || !reflect.DeepEqual(got.Devices(), tt.wantDevices) {
+				t.Errorf("GormGroupRepository.GetGroup() = %s %v, want %s %v", got.UUID(), got.Devices(), tt.uuid, tt.wantDevices)
+			}
+		})
+	}
+}
+
+func TestGormGroupRepository_GetMembers(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormGroupRepository(gormClient)
+	devices, groups := setupGroups(t)
+	tests := []struct {
+		name    string
+		uuid    string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "should list the devices of the static group",
+			uuid:    groups[0].UUID(),
+			want:    []string{devices[0].UUID(), devices[2].UUID()},
+			wantErr: nil,
+		},
+		{
+			name:    "should list the devices having the tag",
+			uuid:    groups[1].UUID(),
+			want:    []string{devices[0].UUID(), devices[1].UUID()},
+			wantErr: nil,
+		},
+		{
+			name:    "should list the devices having all the tags",
+			uuid:    groups[2].UUID(),
+			want:    []string{devices[0].UUID()},
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, unknown group",
+			uuid:    uuid.NewString(),
+			wantErr: group.ErrGroupNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.GetMembers(context.Background(), tt.uuid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormGroupRepository.GetMembers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !reflect.DeepEqual(deviceUUIDs(got), tt.want) {
+				t.Errorf("GormGroupRepository.GetMembers() = %v, want %v", deviceUUIDs(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestGormGroupRepository_GetDeviceGroups(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormGroupRepository(gormClient)
+	deviceRepository := NewGormDeviceRepository(gormClient)
+	devices, groups := setupGroups(t)
+	// untagging a device takes it out of the dynamic groups
+	err := deviceRepository.UpdateDevice(context.Background(), devices[1].UUID(), func(d *device.Device) (*device.Device, error) {
+		d.RemoveTag(d.Tags().Tags()...)
+		return d, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to update device: %s", err)
+	}
+	tests := []struct {
+		name    string
+		uuid    string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "should list the static and dynamic groups of the device",
+			uuid:    devices[0].UUID(),
+			want:    []string{groups[0].UUID(), groups[1].UUID(), groups[2].UUID()},
+			wantErr: nil,
+		},
+		{
+			name:    "should list no group, the device was untagged",
+			uuid:    devices[1].UUID(),
+			want:    []string{},
+			wantErr: nil,
+		},
+		{
+			name:    "should list the static group of the untagged device",
+			uuid:    devices[2].UUID(),
+			want:    []string{groups[0].UUID()},
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, unknown device",
+			uuid:    uuid.NewString(),
+			wantErr: device.ErrDeviceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.GetDeviceGroups(context.Background(), tt.uuid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormGroupRepository.GetDeviceGroups() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !reflect.DeepEqual(groupUUIDs(got), tt.want) {
+				t.Errorf("GormGroupRepository.GetDeviceGroups() = %v, want %v", groupUUIDs(got), tt.want)
+			}
+		})
+	}
+}
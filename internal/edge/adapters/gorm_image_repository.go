@@ -2,7 +2,10 @@ package adapters
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Avielyo10/edge-api/internal/common/models"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
@@ -32,7 +35,28 @@ func NewGormImageRepository(db *gorm.DB) *GormImageRepository {
 // CreateImage creates a new image, implementing the Image.Repository interface.
 func (r *GormImageRepository) CreateImage(ctx context.Context, image *image.Image) error {
 	log.Debug("gorm create image")
-	return r.db.Create(image.MarshalGorm()).Error
+	account, err := image.Account()
+	if err != nil {
+		return err
+	}
+	image.Touch(time.Now())
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model := image.MarshalGorm()
+		// tags and packages are entries of the catalogs of the account, shared with the other images
+		if err := tx.Omit("Tags", "Packages").Create(model).Error; err != nil {
+			return err
+		}
+		if err := saveImageCatalog(tx, model.ID, model); err != nil {
+			return err
+		}
+		if err := saveImageRepos(tx, account, image); err != nil {
+			return err
+		}
+		if err := indexImage(tx, model.ID); err != nil {
+			return err
+		}
+		return saveSnapshot(tx, account, image)
+	})
 }
 
 // GetImage returns the image with the given UUID, implementing the Image.Repository interface.
@@ -42,68 +66,272 @@ func (r *GormImageRepository) GetImage(ctx context.Context, uuid string) (*image
 	if err != nil {
 		return nil, err
 	}
-	var imageModel models.Image
-	if err := r.db.Preload(clause.Associations).Where("account = ? AND uuid = ?", account.String(), uuid).First(&imageModel).Error; err != nil {
-		return nil, err
-	}
-	newImage, err := image.UnmarshalImageFromDatabase(context.Background(),
-		uuid, imageModel.Name, imageModel.Description, imageModel.Distribution, imageModel.Status,
-		imageModel.User.Name, imageModel.User.SSHKey, imageModel.OutputTypes,
-		unmarshalTags(imageModel.Tags), unmarshalPackages(imageModel.Packages), imageModel.Version, nil,
-		imageModel.CreatedAt, imageModel.UpdatedAt, imageModel.DeletedAt.Time)
-	return &newImage, err
+	return getImage(r.db, account, uuid)
 }
 
 // UpdateImage updates the image with the given UUID, implementing the Image.Repository interface.
+// The image is locked from the read to the write, so concurrent updates apply one after the other,
+// each seeing the image the previous one left. The update only applies if it matches the precondition
+// carried by the context, otherwise image.ErrPreconditionFailed is returned.
 func (r *GormImageRepository) UpdateImage(ctx context.Context, uuid string, updateFn func(image *image.Image) (*image.Image, error)) error {
 	log.WithField("uuid", uuid).Debug("gorm update image")
-	image, err := r.GetImage(ctx, uuid)
-	if err != nil {
-		return err
-	}
-	updatedImage, err := updateFn(image)
-	if err != nil {
-		return err
-	}
 	account, err := common.GetAccountFromContext(ctx)
 	if err != nil {
 		return err
 	}
-	return r.db.Model(&models.Image{}).
-		Where("account = ? and uuid = ?", account.String(), image.UUID()).
-		Updates(updatedImage.MarshalGorm()).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		imageID, err := lockImage(tx, account, uuid)
+		if err != nil {
+			return err
+		}
+		current, err := getImage(tx, account, uuid)
+		if err != nil {
+			return err
+		}
+		if err := image.CheckPrecondition(ctx, *current); err != nil {
+			return err
+		}
+		// keep what the update is conditioned on, updateFn may change the image in place
+		version, updatedAt := current.Version().Uint(), current.UpdatedAt()
+		updatedImage, err := updateFn(current)
+		if err != nil {
+			return err
+		}
+		updatedImage.Touch(time.Now())
+		model := updatedImage.MarshalGorm()
+		// skip hooks, so gorm keeps the update time of the domain image instead of setting its own,
+		// and select every field, so they can be emptied
+		result := tx.Session(&gorm.Session{SkipHooks: true}).Model(&models.Image{}).
+			Where("account = ? AND uuid = ? AND version = ? AND updated_at = ?", account.String(), uuid, version, updatedAt).
+			Select("name", "description", "distribution", "status", "version", "output_types", "updated_at").
+			Updates(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 { // changed by someone else since it was read
+			return image.ErrPreconditionFailed
+		}
+		if err := saveImageAssociations(tx, imageID, model); err != nil {
+			return err
+		}
+		if err := saveImageRepos(tx, account, updatedImage); err != nil {
+			return err
+		}
+		if err := indexImage(tx, imageID); err != nil {
+			return err
+		}
+		return saveSnapshot(tx, account, updatedImage)
+	})
 }
 
-// DeleteImage deletes the image with the given UUID, implementing the Image.Repository interface.
+// DeleteImage soft-deletes the image with the given UUID, implementing the Image.Repository interface.
+// The image's relations are kept, so it can be restored later on. The image is locked, then only deleted
+// if it matches the precondition and passes the in use check carried by the context.
 func (r *GormImageRepository) DeleteImage(ctx context.Context, uuid string) error {
 	log.WithField("uuid", uuid).Debug("gorm delete image")
 	account, err := common.GetAccountFromContext(ctx)
 	if err != nil {
 		return err
 	}
-	// delete image's has one/many/many2many relations when deleting an image
-	return r.db.Select(clause.Associations).
-		Where("account = ?", account.String()).
-		Delete(&models.Image{UUID: uuid}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		imageID, err := lockImage(tx, account, uuid)
+		if err != nil {
+			return err
+		}
+		if _, ok := image.PreconditionFromContext(ctx); ok {
+			current, err := getImage(tx, account, uuid)
+			if err != nil {
+				return err
+			}
+			if err := image.CheckPrecondition(ctx, *current); err != nil {
+				return err
+			}
+		}
+		if err := image.CheckNotInUse(ctx); err != nil {
+			return err
+		}
+		return tx.Delete(&models.Image{}, imageID).Error
+	})
+}
+
+// getImage returns the image with the given UUID of the account, using the given connection.
+// It returns image.ErrImageNotFound if there is none.
+func getImage(db *gorm.DB, account common.Account, uuid string) (*image.Image, error) {
+	var imageModel models.Image
+	err := db.Preload(clause.Associations).Where("account = ? AND uuid = ?", account.String(), uuid).First(&imageModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, image.ErrImageNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	repos, err := getImageRepos(db, account.String(), uuid)
+	if err != nil {
+		return nil, err
+	}
+	newImage, err := image.UnmarshalImageFromDatabase(common.ContextWithAccount(context.Background(), account),
+		uuid, imageModel.Name, imageModel.Description, imageModel.Distribution, imageModel.Status,
+		imageModel.User.Name, imageModel.User.SSHKey, imageModel.OutputTypes,
+		unmarshalTags(imageModel.Tags), unmarshalPackages(imageModel.Packages), imageModel.Version, repos[uuid],
+		imageModel.CreatedAt, imageModel.UpdatedAt, imageModel.DeletedAt.Time)
+	return &newImage, err
 }
 
-// GetImages returns all images, implementing the Image.Repository interface.
-func (r *GormImageRepository) GetImages(ctx context.Context) ([]*image.Image, error) {
+// GetImages returns the page of images the spec lists along with the total number of images matching it,
+// implementing the Image.Repository interface.
+func (r *GormImageRepository) GetImages(ctx context.Context, spec image.Spec) ([]*image.Image, int, error) {
 	log.Debug("gorm get images")
 	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	matching := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("account = ?", account.String())
+		if spec.Name() != "" {
+			db = db.Where("LOWER(name) LIKE ? ESCAPE '\\'", "%"+escapeLike(strings.ToLower(spec.Name()))+"%")
+		}
+		if !spec.Status().IsZero() {
+			db = db.Where("status = ?", spec.Status().String())
+		}
+		return db
+	}
+	var total int64
+	if err := r.db.Model(&models.Image{}).Scopes(matching).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	query := r.db.Preload(clause.Associations).Scopes(matching)
+	for _, sort := range spec.Sorts() {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: sort.Field().String()}, Desc: sort.Desc()})
+	}
+	query = query.Order("id").Offset(spec.Offset())
+	if spec.Limit() > 0 {
+		query = query.Limit(spec.Limit())
+	}
+	var imageModels []models.Image
+	if err := query.Find(&imageModels).Error; err != nil {
+		return nil, 0, err
+	}
+	images, err := unmarshalImages(r.db, imageModels)
+	return images, int(total), err
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, with a backslash.
+func escapeLike(pattern string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(pattern)
+}
+
+// GetDeletedImages returns all soft-deleted images, implementing the Image.Repository interface.
+func (r *GormImageRepository) GetDeletedImages(ctx context.Context) ([]*image.Image, error) {
+	log.Debug("gorm get deleted images")
+	account, err := common.GetAccountFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 	var imageModels []models.Image
-	if err := r.db.Preload(clause.Associations).Where("account = ?", account.String()).Find(&imageModels).Error; err != nil {
+	if err := r.db.Unscoped().Preload(clause.Associations).
+		Where("account = ? AND deleted_at IS NOT NULL", account.String()).Find(&imageModels).Error; err != nil {
 		return nil, err
 	}
+	return unmarshalImages(r.db, imageModels)
+}
+
+// RestoreImage restores the soft-deleted image with the given UUID, implementing the Image.Repository interface.
+func (r *GormImageRepository) RestoreImage(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("gorm restore image")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result := r.db.Unscoped().Model(&models.Image{}).
+		Where("account = ? AND uuid = ? AND deleted_at IS NOT NULL", account.String(), uuid).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return image.ErrImageNotFound
+	}
+	return nil
+}
+
+// PurgeImage permanently deletes the image with the given UUID, deleted or not, along with its references
+// to packages, tags and repositories, installer, user, versions and row of the search index,
+// implementing the Image.Repository interface. The packages and tags no other image of the account has
+// are deleted from its catalogs as well. The image is locked, then only purged if it matches the precondition
+// and passes the in use check carried by the context.
+func (r *GormImageRepository) PurgeImage(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("gorm purge image")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var imageModel models.Image
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("account = ? AND uuid = ?", account.String(), uuid).First(&imageModel).Error
+		if err == gorm.ErrRecordNotFound {
+			return image.ErrImageNotFound
+		} else if err != nil {
+			return err
+		}
+		// read along with its associations once locked
+		if err := tx.Unscoped().Preload(clause.Associations).First(&imageModel, imageModel.ID).Error; err != nil {
+			return err
+		}
+		if _, ok := image.PreconditionFromContext(ctx); ok {
+			current, err := unmarshalImages(tx, []models.Image{imageModel})
+			if err != nil {
+				return err
+			}
+			if err := image.CheckPrecondition(ctx, *current[0]); err != nil {
+				return err
+			}
+		}
+		if err := image.CheckNotInUse(ctx); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM image_search WHERE image_id = ?", imageModel.ID).Error; err != nil {
+			return err
+		}
+		// deletes the image, its has one relations and many2many join rows
+		if err := tx.Unscoped().Select(clause.Associations).Delete(&imageModel).Error; err != nil {
+			return err
+		}
+		if err := pruneImageCatalog(tx, imageModel); err != nil {
+			return err
+		}
+		// repositories belong to the account, only the references of the image go
+		if err := tx.Unscoped().Where("account = ? AND image_uuid = ?", account.String(), uuid).
+			Delete(&models.ImageRepo{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("account = ? AND image_uuid = ?", account.String(), uuid).
+			Delete(&models.ImageMetadataChange{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("account = ? AND image_uuid = ?", account.String(), uuid).
+			Delete(&models.ImageVersion{}).Error
+	})
+}
+
+// unmarshalImages unmarshals array of image models of an account into domain images, loading their repositories.
+func unmarshalImages(db *gorm.DB, imageModels []models.Image) ([]*image.Image, error) {
 	images := make([]*image.Image, len(imageModels))
+	if len(imageModels) == 0 {
+		return images, nil
+	}
+	uuids := make([]string, len(imageModels))
+	for i, imageModel := range imageModels {
+		uuids[i] = imageModel.UUID
+	}
+	repos, err := getImageRepos(db, imageModels[0].Account, uuids...)
+	if err != nil {
+		return nil, err
+	}
 	for i, imageModel := range imageModels {
 		image, err := image.UnmarshalImageFromDatabase(context.Background(),
 			imageModel.UUID, imageModel.Name, imageModel.Description, imageModel.Distribution, imageModel.Status,
 			imageModel.User.Name, imageModel.User.SSHKey, imageModel.OutputTypes,
-			unmarshalTags(imageModel.Tags), unmarshalPackages(imageModel.Packages), imageModel.Version, nil,
+			unmarshalTags(imageModel.Tags), unmarshalPackages(imageModel.Packages), imageModel.Version, repos[imageModel.UUID],
 			imageModel.CreatedAt, imageModel.UpdatedAt, imageModel.DeletedAt.Time)
 		images[i] = &image
 		if err != nil {
@@ -113,6 +341,154 @@ func (r *GormImageRepository) GetImages(ctx context.Context) ([]*image.Image, er
 	return images, nil
 }
 
+// lockImage locks the image with the given UUID of the account until the end of the transaction,
+// returning its id. SQLite has no row locks, its transactions take the database write lock when they begin instead.
+func lockImage(tx *gorm.DB, account common.Account, uuid string) (uint, error) {
+	var imageModel models.Image
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+		Where("account = ? AND uuid = ?", account.String(), uuid).First(&imageModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return 0, image.ErrImageNotFound
+	} else if err != nil {
+		return 0, err
+	}
+	return imageModel.ID, nil
+}
+
+// shareImage locks the image with the given UUID of the account against deletion until the end of the transaction,
+// if it is stored, so the in use check of a concurrent delete sees the devices or rollouts assigned to it.
+func shareImage(tx *gorm.DB, account common.Account, uuid string) error {
+	return tx.Clauses(clause.Locking{Strength: "SHARE"}).Select("id").
+		Where("account = ? AND uuid = ?", account.String(), uuid).Find(&[]models.Image{}).Error
+}
+
+// saveImageAssociations replaces the user, installer, packages and tags of the stored image with the ones of the model.
+func saveImageAssociations(tx *gorm.DB, imageID uint, model *models.Image) error {
+	user := model.User
+	user.ImageID = imageID
+	if err := saveHasOne(tx, imageID, &user, "name", "ssh_key"); err != nil {
+		return err
+	}
+	installer := model.Installer
+	installer.ImageID = imageID
+	if err := saveHasOne(tx, imageID, &installer, "iso_url", "compose_job_id", "checksum"); err != nil {
+		return err
+	}
+	for _, catalog := range catalogTables() {
+		if err := tx.Exec("DELETE FROM "+catalog.joinTable+" WHERE image_id = ?", imageID).Error; err != nil {
+			return err
+		}
+	}
+	return saveImageCatalog(tx, imageID, model)
+}
+
+// saveImageCatalog joins the image to the entries of the tag and package catalogs of the account it has,
+// adding the ones the catalogs miss. Entries are shared by the images of the account, they are only deleted
+// by purging the last image that has them.
+func saveImageCatalog(tx *gorm.DB, imageID uint, model *models.Image) error {
+	tags := make([]string, len(model.Tags))
+	for i, tag := range model.Tags {
+		tags[i] = tag.Name
+	}
+	packages := make([]string, len(model.Packages))
+	for i, pkg := range model.Packages {
+		packages[i] = pkg.Name
+	}
+	if err := joinCatalog(tx, "tags", "all_tags", "tag_id", model.Account, imageID, &model.Tags, tags); err != nil {
+		return err
+	}
+	return joinCatalog(tx, "packages", "all_packages", "package_id", model.Account, imageID, &model.Packages, packages)
+}
+
+// joinCatalog adds the entries to the catalog table unless it has them already, then joins the image
+// to the entries with the given names.
+func joinCatalog(tx *gorm.DB, table, joinTable, joinColumn, account string, imageID uint, entries interface{}, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account"}, {Name: "name"}},
+		DoNothing: true,
+	}).Create(entries).Error; err != nil {
+		return err
+	}
+	var ids []uint
+	if err := tx.Table(table).Where("account = ? AND name IN ?", account, names).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	joins := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		joins[i] = map[string]interface{}{"image_id": imageID, joinColumn: id}
+	}
+	return tx.Table(joinTable).Create(&joins).Error
+}
+
+// pruneImageCatalog deletes the packages and tags of the purged image model that no image of the account has anymore,
+// deleted images included since they can be restored.
+func pruneImageCatalog(tx *gorm.DB, imageModel models.Image) error {
+	tagIDs := make([]uint, len(imageModel.Tags))
+	for i, tag := range imageModel.Tags {
+		tagIDs[i] = tag.ID
+	}
+	packageIDs := make([]uint, len(imageModel.Packages))
+	for i, pkg := range imageModel.Packages {
+		packageIDs[i] = pkg.ID
+	}
+	if err := pruneCatalog(tx, "tags", "all_tags", "tag_id", tagIDs); err != nil {
+		return err
+	}
+	return pruneCatalog(tx, "packages", "all_packages", "package_id", packageIDs)
+}
+
+// pruneCatalog deletes the entries of the catalog table with the given ids that no image is joined to.
+func pruneCatalog(tx *gorm.DB, table, joinTable, joinColumn string, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Exec("DELETE FROM "+table+" WHERE id IN ? AND NOT EXISTS "+
+		"(SELECT 1 FROM "+joinTable+" WHERE "+joinTable+"."+joinColumn+" = "+table+".id)", ids).Error
+}
+
+// saveHasOne updates the given columns of the row the image has one of, creating the row if the image has none yet.
+func saveHasOne(tx *gorm.DB, imageID uint, row interface{}, columns ...string) error {
+	result := tx.Model(row).Where("image_id = ?", imageID).Select(columns).Updates(row)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	return tx.Create(row).Error
+}
+
+// saveImageRepos replaces the references of the image to repositories of the account, keeping their order.
+func saveImageRepos(tx *gorm.DB, account common.Account, i *image.Image) error {
+	if err := tx.Unscoped().Where("account = ? AND image_uuid = ?", account.String(), i.UUID()).
+		Delete(&models.ImageRepo{}).Error; err != nil {
+		return err
+	}
+	uuids := i.Repos().UUIDs()
+	if len(uuids) == 0 {
+		return nil
+	}
+	imageRepos := make([]models.ImageRepo, len(uuids))
+	for j, repoUUID := range uuids {
+		imageRepos[j] = models.ImageRepo{Account: account.String(), ImageUUID: i.UUID(), RepoUUID: repoUUID}
+	}
+	return tx.Create(&imageRepos).Error
+}
+
+// getImageRepos returns the uuids of the repositories referenced by the given images of the account, by image.
+func getImageRepos(db *gorm.DB, account string, imageUUIDs ...string) (map[string][]string, error) {
+	var imageRepos []models.ImageRepo
+	if err := db.Where("account = ? AND image_uuid IN ?", account, imageUUIDs).
+		Order("id").Find(&imageRepos).Error; err != nil {
+		return nil, err
+	}
+	repos := make(map[string][]string, len(imageUUIDs))
+	for _, imageRepo := range imageRepos {
+		repos[imageRepo.ImageUUID] = append(repos[imageRepo.ImageUUID], imageRepo.RepoUUID)
+	}
+	return repos, nil
+}
+
 // unmarshalPackages unmarshals array of package models into a string array
 func unmarshalPackages(packages []models.Package) []string {
 	var packagesStr []string
@@ -131,8 +507,8 @@ func unmarshalTags(tags []models.Tag) []string {
 	return tagsStr
 }
 
-// NewGormClient returns a new GormClient.
-func NewGormClient(cfg *config.EdgeConfig) *gorm.DB {
+// OpenGormDB opens the database of the given configuration, postgres or sqlite, leaving its schema as is.
+func OpenGormDB(cfg *config.EdgeConfig) (*gorm.DB, error) {
 	var dia gorm.Dialector
 	if cfg.Database.Type == "pgsql" {
 		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d",
@@ -144,25 +520,33 @@ func NewGormClient(cfg *config.EdgeConfig) *gorm.DB {
 		)
 		dia = postgres.Open(dsn)
 	} else {
-		dia = sqlite.Open(cfg.Database.Name)
-	}
-	db, err := gorm.Open(dia, &gorm.Config{})
-	if err != nil {
-		panic(err)
+		// begin transactions with the write lock, so they serialize like locking reads do on postgres
+		dsn := cfg.Database.Name + "?_txlock=immediate"
+		if strings.Contains(cfg.Database.Name, "?") {
+			dsn = cfg.Database.Name + "&_txlock=immediate"
+		}
+		dia = sqlite.Open(dsn)
 	}
-	return GormAutoMigrate(db)
+	return gorm.Open(dia, &gorm.Config{})
 }
 
-// GormAutoMigrate runs auto-migration on the given database.
-func GormAutoMigrate(db *gorm.DB) *gorm.DB {
-	if err := db.AutoMigrate(
-		&models.Image{},
-		&models.Installer{},
-		&models.Tag{},
-		&models.Package{},
-		&models.User{},
-	); err != nil {
-		panic(err)
+// NewGormClient opens the database of the given configuration, applying the pending migrations if migrate is true.
+// ErrSchemaTooNew is returned if its schema is newer than this binary knows, migrations left pending are only logged.
+func NewGormClient(cfg *config.EdgeConfig, migrate bool) (*gorm.DB, error) {
+	db, err := OpenGormDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	migrator := NewGormMigrator(db, GormMigrations)
+	if migrate {
+		if err := migrator.Up(); err != nil {
+			return nil, err
+		}
+	}
+	if err := migrator.CheckCurrent(); errors.Is(err, ErrSchemaOutdated) {
+		log.WithError(err).Warn("the database has pending migrations, run `edge migrate up` to apply them")
+	} else if err != nil {
+		return nil, err
 	}
-	return db
+	return db, nil
 }
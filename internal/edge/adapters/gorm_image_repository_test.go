@@ -5,12 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Avielyo10/edge-api/internal/common/models"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image/imagetest"
 	"github.com/google/uuid"
 	"github.com/redhatinsights/edge-api/config"
 	"gorm.io/gorm"
@@ -26,7 +30,10 @@ func setupGorm(t *testing.T) {
 	config.Init()
 	dbName = fmt.Sprintf("%s.db", t.Name())
 	config.Get().Database.Name = dbName
-	gormClient = NewGormClient(config.Get())
+	var err error
+	if gormClient, err = NewGormClient(config.Get(), true); err != nil {
+		t.Fatalf("failed to open db: %s", err)
+	}
 }
 
 // TeardownGorm is a helper function to tear down the gorm db connection.
@@ -44,6 +51,23 @@ func teardownGorm(t *testing.T) {
 	}
 }
 
+// newTestGormClient returns a gorm client of a new database, removed once the test is done.
+func newTestGormClient(t *testing.T) *gorm.DB {
+	config.Init()
+	config.Get().Database.Name = filepath.Join(t.TempDir(), "edge.db")
+	db, err := NewGormClient(config.Get(), true)
+	if err != nil {
+		t.Fatalf("failed to open db: %s", err)
+	}
+	return db
+}
+
+func TestGormImageRepository_Conformance(t *testing.T) {
+	imagetest.TestRepository(t, func(t *testing.T) image.Repository {
+		return NewGormImageRepository(newTestGormClient(t))
+	})
+}
+
 func TestNewGormImageRepository(t *testing.T) {
 	gormClient := &gorm.DB{}
 	type args struct {
@@ -302,6 +326,241 @@ func TestGormImageRepository_UpdateImage(t *testing.T) {
 	}
 }
 
+func TestGormImageRepository_UpdateImage_Precondition(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormImageRepository(gormClient)
+	if err := repository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	current, err := repository.GetImage(context.Background(), validImage.UUID())
+	if err != nil {
+		t.Errorf("failed to get image: %s", err)
+	}
+	etag := current.ETag()
+	if etag != validImage.ETag() {
+		t.Errorf("stored image etag = %s, want %s", etag, validImage.ETag())
+	}
+	updateFn := func(image *image.Image) (*image.Image, error) {
+		image.SetNameAndDesc(common.Name{}, "new desc")
+		return image, nil
+	}
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		wantErr error
+	}{
+		{
+			name:    "should update an image, matching etag",
+			ifMatch: etag,
+			wantErr: nil,
+		},
+		{
+			name:    "should fail to update an image, stale etag",
+			ifMatch: etag,
+			wantErr: image.ErrPreconditionFailed,
+		},
+		{
+			name:    "should update an image, wildcard",
+			ifMatch: "*",
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := image.ContextWithPrecondition(context.Background(), tt.ifMatch)
+			if err := repository.UpdateImage(ctx, validImage.UUID(), updateFn); err != tt.wantErr {
+				t.Errorf("GormImageRepository.UpdateImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			updated, err := repository.GetImage(context.Background(), validImage.UUID())
+			if err != nil {
+				t.Errorf("failed to get image: %s", err)
+				return
+			}
+			if changed := updated.ETag() != etag; changed != (tt.wantErr == nil) {
+				t.Errorf("GormImageRepository.UpdateImage() etag changed = %v, want %v", changed, tt.wantErr == nil)
+			}
+			etag = updated.ETag()
+		})
+	}
+}
+
+func TestGormImageRepository_UpdateImage_Associations(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormImageRepository(gormClient)
+	if err := repository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	// replaces the whole content of the image, as restoring a version does
+	err := repository.UpdateImage(context.Background(), validImage.UUID(), func(current *image.Image) (*image.Image, error) {
+		updated, err := image.NewImageWithContext(context.Background(), current.UUID(), "kiosk", "", "rhel8", "success",
+			"kiosk-user", validImage.User().SSHKey(), []string{"rhel-edge-commit"}, []string{"kiosk"}, []string{"vim"},
+			current.Version().Uint(), nil)
+		return &updated, err
+	})
+	if err != nil {
+		t.Fatalf("GormImageRepository.UpdateImage() error = %v", err)
+	}
+	got, err := repository.GetImage(context.Background(), validImage.UUID())
+	if err != nil {
+		t.Fatalf("failed to get image: %s", err)
+	}
+	if got.Description() != "" || got.User().Username() != "kiosk-user" ||
+		!reflect.DeepEqual(got.Tags().StringArray(), []string{"kiosk"}) || len(got.Repos().UUIDs()) != 0 {
+		t.Errorf("GormImageRepository.UpdateImage() = %q %v %v %v, want the replaced content", got.Description(),
+			got.User().Username(), got.Tags(), got.Repos().UUIDs())
+	}
+	// the replaced rows are deleted, not left behind, the catalogs keep their entries for the other images
+	var tags, packages, users, catalogTags int64
+	gormClient.Table("all_tags").Count(&tags)
+	gormClient.Table("all_packages").Count(&packages)
+	gormClient.Unscoped().Model(&models.User{}).Count(&users)
+	gormClient.Unscoped().Model(&models.Tag{}).Count(&catalogTags)
+	if tags != 1 || packages != int64(len(got.Packages().StringArray())) || users != 1 {
+		t.Errorf("GormImageRepository.UpdateImage() left %d tags, %d packages and %d users", tags, packages, users)
+	}
+	if want := int64(len(validImage.Tags().MarshalGorm("")) + 1); catalogTags != want {
+		t.Errorf("GormImageRepository.UpdateImage() left %d tags in the catalog, want %d", catalogTags, want)
+	}
+}
+
+func TestGormImageRepository_UpdateImage_Concurrent(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormImageRepository(gormClient)
+	if err := repository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	// every update upgrades the image, only the first one may start a build
+	const updates = 5
+	errs := make(chan error, updates)
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repository.UpdateImage(context.Background(), validImage.UUID(), func(current *image.Image) (*image.Image, error) {
+				time.Sleep(10 * time.Millisecond) // keep the image locked while the others try to update it
+				return current, current.Upgrade()
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	var upgraded int
+	for err := range errs {
+		switch {
+		case err == nil:
+			upgraded++
+		case !errors.Is(err, image.ErrAlreadyBuilding):
+			t.Errorf("GormImageRepository.UpdateImage() error = %v, wantErr %v", err, image.ErrAlreadyBuilding)
+		}
+	}
+	if upgraded != 1 {
+		t.Errorf("GormImageRepository.UpdateImage() upgraded the image %d times, want 1", upgraded)
+	}
+	got, err := repository.GetImage(context.Background(), validImage.UUID())
+	if err != nil {
+		t.Fatalf("failed to get image: %s", err)
+	}
+	if got.Version().Uint() != 2 || !got.Status().IsBuilding() {
+		t.Errorf("GormImageRepository.UpdateImage() = version %d %s, want version 2 building", got.Version().Uint(), got.Status())
+	}
+}
+
+func TestGormImageRepository_DeleteImage_Precondition(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormImageRepository(gormClient)
+	if err := repository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	current, err := repository.GetImage(context.Background(), validImage.UUID())
+	if err != nil {
+		t.Errorf("failed to get image: %s", err)
+	}
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		wantErr error
+	}{
+		{
+			name:    "should fail to delete an image, stale etag",
+			ifMatch: `"1-0"`,
+			wantErr: image.ErrPreconditionFailed,
+		},
+		{
+			name:    "should delete an image, matching etag",
+			ifMatch: current.ETag(),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail to delete an image, already deleted",
+			ifMatch: current.ETag(),
+			wantErr: image.ErrImageNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := image.ContextWithPrecondition(context.Background(), tt.ifMatch)
+			if err := repository.DeleteImage(ctx, validImage.UUID()); err != tt.wantErr {
+				t.Errorf("GormImageRepository.DeleteImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGormImageRepository_DeleteImage_InUse(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormImageRepository(gormClient)
+	if err := repository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	inUse := func(ctx context.Context, versions ...image.Version) error {
+		return image.InUseError{Devices: 1}
+	}
+	notInUse := func(ctx context.Context, versions ...image.Version) error {
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		check   image.InUseCheck
+		wantErr error
+	}{
+		{
+			name:    "should fail to delete an image, in use",
+			check:   inUse,
+			wantErr: image.InUseError{Devices: 1},
+		},
+		{
+			name:    "should delete an image, not in use",
+			check:   notInUse,
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := image.ContextWithInUseCheck(context.Background(), tt.check)
+			if err := repository.DeleteImage(ctx, validImage.UUID()); err != tt.wantErr {
+				t.Errorf("GormImageRepository.DeleteImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestGormImageRepository_DeleteImage(t *testing.T) {
 	setupGorm(t)
 	defer teardownGorm(t)
@@ -340,7 +599,7 @@ func TestGormImageRepository_DeleteImage(t *testing.T) {
 				ctx:  context.Background(),
 				uuid: validImage.UUID(),
 			},
-			wantErr: false,
+			wantErr: true,
 			auth:    false,
 		},
 		{
@@ -350,7 +609,7 @@ func TestGormImageRepository_DeleteImage(t *testing.T) {
 				ctx:  context.Background(),
 				uuid: uuid.NewString(),
 			},
-			wantErr: false,
+			wantErr: true,
 			auth:    false,
 		},
 		{
@@ -390,16 +649,22 @@ func TestGormImageRepository_GetImages(t *testing.T) {
 		t.Errorf("failed to create image: %s", err)
 	}
 
+	secondByName, _ := image.NewSpec("", "", "name", 1, 1)
+	byName, _ := image.NewSpec("IMAGE-2", "success", "", 0, 0)
+	byStatus, _ := image.NewSpec("", "error", "", 0, 0)
+
 	type args struct {
-		ctx context.Context
+		ctx  context.Context
+		spec image.Spec
 	}
 	tests := []struct {
-		name    string
-		r       *GormImageRepository
-		args    args
-		want    []*image.Image
-		wantErr bool
-		auth    bool
+		name      string
+		r         *GormImageRepository
+		args      args
+		want      []*image.Image
+		wantTotal int
+		wantErr   bool
+		auth      bool
 	}{
 		{
 			name: "should get all images",
@@ -411,8 +676,43 @@ func TestGormImageRepository_GetImages(t *testing.T) {
 				&validImage,
 				&anotherValidImage,
 			},
-			wantErr: false,
-			auth:    false,
+			wantTotal: 2,
+			wantErr:   false,
+			auth:      false,
+		},
+		{
+			name: "should get a page of images sorted by name",
+			r:    repository,
+			args: args{
+				ctx:  context.Background(),
+				spec: secondByName,
+			},
+			want: []*image.Image{
+				&anotherValidImage,
+			},
+			wantTotal: 2,
+		},
+		{
+			name: "should get images matching name and status",
+			r:    repository,
+			args: args{
+				ctx:  context.Background(),
+				spec: byName,
+			},
+			want: []*image.Image{
+				&anotherValidImage,
+			},
+			wantTotal: 1,
+		},
+		{
+			name: "should get no images matching status",
+			r:    repository,
+			args: args{
+				ctx:  context.Background(),
+				spec: byStatus,
+			},
+			want:      []*image.Image{},
+			wantTotal: 0,
 		},
 		{
 			name: "should fail to get all images, bad account",
@@ -432,12 +732,15 @@ func TestGormImageRepository_GetImages(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			config.Get().Auth = tt.auth
-			got, err := tt.r.GetImages(tt.args.ctx)
+			got, total, err := tt.r.GetImages(tt.args.ctx, tt.args.spec)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GormImageRepository.GetImages() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !tt.wantErr {
+				if total != tt.wantTotal {
+					t.Errorf("GormImageRepository.GetImages() total = %v, want %v", total, tt.wantTotal)
+				}
 				if len(got) != len(tt.want) {
 					t.Errorf("GormImageRepository.GetImages() = %v, want %v", got, tt.want)
 				}
@@ -527,71 +830,290 @@ func TestUnmarshalTags(t *testing.T) {
 }
 
 func TestNewGormClient(t *testing.T) {
-	type args struct {
-		cfg *config.EdgeConfig
+	config.Init()
+	config.Get().Database.Name = filepath.Join(t.TempDir(), "edge.db")
+	// the cases share the database, in order
+	tests := []struct {
+		name        string
+		migrate     bool
+		wantErr     error
+		wantPending bool
+	}{
+		{
+			name:        "should create gorm client, migrations left pending",
+			migrate:     false,
+			wantErr:     nil,
+			wantPending: true,
+		},
+		{
+			name:    "should create gorm client, migrations applied",
+			migrate: true,
+			wantErr: nil,
+		},
+		{
+			name:    "should create gorm client, schema up to date",
+			migrate: false,
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGormClient(config.Get(), tt.migrate)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewGormClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.wantErr != nil) {
+				t.Errorf("NewGormClient() = %v, wantErr %v", got, tt.wantErr)
+			}
+			err = NewGormMigrator(got, GormMigrations).CheckCurrent()
+			if pending := errors.Is(err, ErrSchemaOutdated); pending != tt.wantPending {
+				t.Errorf("NewGormClient() pending migrations = %v, want %v", pending, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestGormImageRepository_GetDeletedImages(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormImageRepository(gormClient)
+	for _, img := range []*image.Image{&validImage, &anotherValidImage} {
+		if err := repository.CreateImage(context.Background(), img); err != nil {
+			t.Errorf("failed to create image: %s", err)
+		}
+	}
+	// only the deleted image should be listed, the other one is kept
+	if err := repository.DeleteImage(context.Background(), validImage.UUID()); err != nil {
+		t.Errorf("failed to delete image: %s", err)
 	}
+
 	tests := []struct {
-		name   string
-		args   args
-		want   *gorm.DB
-		dbType string
+		name        string
+		r           *GormImageRepository
+		ctx         context.Context
+		wantDeleted []string
+		wantActive  []string
+		wantErr     bool
+		auth        bool
 	}{
 		{
-			name: "should create gorm client, mysql",
-			args: args{
-				cfg: config.Get(),
-			},
-			want:   &gorm.DB{},
-			dbType: "mysql",
+			name:        "should get the deleted image only",
+			r:           repository,
+			ctx:         context.Background(),
+			wantDeleted: []string{validImage.UUID()},
+			wantActive:  []string{anotherValidImage.UUID()},
+			wantErr:     false,
+			auth:        false,
+		},
+		{
+			name:    "should fail to get deleted images, bad account",
+			r:       repository,
+			ctx:     context.Background(),
+			wantErr: true,
+			auth:    true,
 		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewGormClient(tt.args.cfg); got == tt.want {
-				t.Errorf("NewGormClient() = %v, want %v", got, tt.want)
+			config.Get().Auth = tt.auth
+			defer func() { config.Get().Auth = false }()
+			got, err := tt.r.GetDeletedImages(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GormImageRepository.GetDeletedImages() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotUUIDs := imageUUIDs(got); !reflect.DeepEqual(gotUUIDs, tt.wantDeleted) {
+				t.Errorf("GormImageRepository.GetDeletedImages() = %v, want %v", gotUUIDs, tt.wantDeleted)
+			}
+			active, _, err := tt.r.GetImages(tt.ctx, image.Spec{})
+			if err != nil {
+				t.Errorf("failed to get images: %s", err)
+			}
+			if gotUUIDs := imageUUIDs(active); !reflect.DeepEqual(gotUUIDs, tt.wantActive) {
+				t.Errorf("GormImageRepository.GetImages() = %v, want %v", gotUUIDs, tt.wantActive)
 			}
 		})
 	}
 }
 
-func TestGormAutoMigrate(t *testing.T) {
-	gdb := NewGormClient(config.Get())
-	type args struct {
-		db *gorm.DB
+func TestGormImageRepository_RestoreImage(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormImageRepository(gormClient)
+	if err := repository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	if err := repository.DeleteImage(context.Background(), validImage.UUID()); err != nil {
+		t.Errorf("failed to delete image: %s", err)
 	}
+
 	tests := []struct {
-		name string
-		args args
-		want *gorm.DB
+		name    string
+		r       *GormImageRepository
+		uuid    string
+		wantErr error
+		auth    bool
 	}{
 		{
-			name: "should auto migrate",
-			args: args{
-				db: gdb,
-			},
-			want: gdb,
+			name:    "should restore a deleted image",
+			r:       repository,
+			uuid:    validImage.UUID(),
+			wantErr: nil,
 		},
 		{
-			name: "should panic",
-			args: args{
-				db: &gorm.DB{},
-			},
-			want: nil,
+			name:    "should fail to restore an image, already restored",
+			r:       repository,
+			uuid:    validImage.UUID(),
+			wantErr: image.ErrImageNotFound,
+		},
+		{
+			name:    "should fail to restore an image, unknown uuid",
+			r:       repository,
+			uuid:    uuid.NewString(),
+			wantErr: image.ErrImageNotFound,
+		},
+		{
+			name:    "should fail to restore an image, bad account",
+			r:       repository,
+			uuid:    validImage.UUID(),
+			wantErr: common.ErrNoAccount,
+			auth:    true,
 		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			defer func() {
-				// recover from panic if one occured.
-				if recover() != nil {
-					teardownGorm(t)
+			config.Get().Auth = tt.auth
+			defer func() { config.Get().Auth = false }()
+			if err := tt.r.RestoreImage(context.Background(), tt.uuid); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormImageRepository.RestoreImage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil {
+				got, err := tt.r.GetImage(context.Background(), tt.uuid)
+				if err != nil {
+					t.Errorf("failed to get restored image: %s", err)
+					return
 				}
-			}()
-			if got := GormAutoMigrate(tt.args.db); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GormAutoMigrate() = %v, want %v", got, tt.want)
+				if !reflect.DeepEqual(got.Tags(), validImage.Tags()) {
+					t.Errorf("GormImageRepository.RestoreImage() tags = %v, want %v", got.Tags(), validImage.Tags())
+				}
+			}
+		})
+	}
+}
+
+func TestGormImageRepository_PurgeImage(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormImageRepository(gormClient)
+	// the only image with the "lonely" tag and the "nano" package
+	lonelyImage, err := image.NewImageWithContext(context.Background(), uuid.NewString(), "lonely-image", "",
+		"rhel8", "success", "redhat-user", validImage.User().SSHKey(), []string{"rhel-edge-commit"},
+		[]string{"tag1", "lonely"}, []string{"vim", "nano"}, 1, nil)
+	if err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	for _, img := range []*image.Image{&validImage, &anotherValidImage, &lonelyImage} {
+		if err := repository.CreateImage(context.Background(), img); err != nil {
+			t.Errorf("failed to create image: %s", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		r       *GormImageRepository
+		uuid    string
+		ifMatch string
+		wantErr error
+		auth    bool
+	}{
+		{
+			name:    "should fail to purge an image, precondition failed",
+			r:       repository,
+			uuid:    validImage.UUID(),
+			ifMatch: `"1-0"`,
+			wantErr: image.ErrPreconditionFailed,
+		},
+		{
+			name:    "should purge an image, matching its etag",
+			r:       repository,
+			uuid:    validImage.UUID(),
+			ifMatch: validImage.ETag(),
+			wantErr: nil,
+		},
+		{
+			name:    "should purge an image, with packages and tags no other image has",
+			r:       repository,
+			uuid:    lonelyImage.UUID(),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail to purge an image, already purged",
+			r:       repository,
+			uuid:    validImage.UUID(),
+			wantErr: image.ErrImageNotFound,
+		},
+		{
+			name:    "should fail to purge an image, bad account",
+			r:       repository,
+			uuid:    anotherValidImage.UUID(),
+			wantErr: common.ErrNoAccount,
+			auth:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config.Get().Auth = tt.auth
+			defer func() { config.Get().Auth = false }()
+			ctx := image.ContextWithPrecondition(context.Background(), tt.ifMatch)
+			if err := tt.r.PurgeImage(ctx, tt.uuid); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormImageRepository.PurgeImage() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
+
+	// nothing the purged images owned is left behind, the other image is untouched,
+	// the tags and packages it has stay in the catalogs of the account
+	for _, table := range []struct {
+		name string
+		want int
+	}{
+		{name: "images", want: 1},
+		{name: "tags", want: len(anotherValidImage.Tags().MarshalGorm(""))},
+		{name: "packages", want: len(anotherValidImage.Packages().MarshalGorm(""))},
+		{name: "all_tags", want: len(anotherValidImage.Tags().MarshalGorm(""))},
+		{name: "all_packages", want: len(anotherValidImage.Packages().MarshalGorm(""))},
+		{name: "image_repos", want: len(anotherValidImage.Repos().UUIDs())},
+		{name: "installers", want: 1},
+		{name: "users", want: 1},
+		{name: "image_versions", want: 1},
+		{name: "image_search", want: 1},
+	} {
+		var count int64
+		if err := gormClient.Table(table.name).Count(&count).Error; err != nil {
+			t.Errorf("failed to count %s: %s", table.name, err)
+		}
+		if count != int64(table.want) {
+			t.Errorf("GormImageRepository.PurgeImage() left %d rows of %s, want %d", count, table.name, table.want)
+		}
+	}
+}
+
+// imageUUIDs returns the uuids of the given images, nil if there are none
+func imageUUIDs(images []*image.Image) []string {
+	var uuids []string
+	for _, img := range images {
+		uuids = append(uuids, img.UUID())
+	}
+	return uuids
 }
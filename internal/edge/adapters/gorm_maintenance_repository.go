@@ -0,0 +1,170 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GormMaintenanceRepository is a GORM implementation of the Maintenance.Repository interface.
+type GormMaintenanceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormMaintenanceRepository returns a new GORM implementation of the Maintenance.Repository interface.
+func NewGormMaintenanceRepository(db *gorm.DB) *GormMaintenanceRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormMaintenanceRepository{db: db}
+}
+
+// CreateWindow creates a new window along with its targets, implementing the Maintenance.Repository interface.
+func (r *GormMaintenanceRepository) CreateWindow(ctx context.Context, window *maintenance.Window) error {
+	log.WithField("uuid", window.UUID()).Debug("gorm create maintenance window")
+	account, err := window.Account()
+	if err != nil {
+		return err
+	}
+	window.Touch(time.Now())
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(window.MarshalGorm()).Error; err != nil {
+			return err
+		}
+		var targets []models.MaintenanceWindowTarget
+		for _, deviceUUID := range window.Devices() {
+			targets = append(targets, models.MaintenanceWindowTarget{Account: account.String(),
+				WindowUUID: window.UUID(), DeviceUUID: deviceUUID})
+		}
+		for _, groupUUID := range window.Groups() {
+			targets = append(targets, models.MaintenanceWindowTarget{Account: account.String(),
+				WindowUUID: window.UUID(), GroupUUID: groupUUID})
+		}
+		return tx.Create(&targets).Error
+	})
+}
+
+// GetWindow returns the window with the given UUID, implementing the Maintenance.Repository interface.
+func (r *GormMaintenanceRepository) GetWindow(ctx context.Context, uuid string) (*maintenance.Window, error) {
+	log.WithField("uuid", uuid).Debug("gorm get maintenance window")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var windowModel models.MaintenanceWindow
+	err = r.db.Where("account = ? AND uuid = ?", account.String(), uuid).First(&windowModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, maintenance.ErrWindowNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	windows, err := r.unmarshalWindows(account, []models.MaintenanceWindow{windowModel})
+	if err != nil {
+		return nil, err
+	}
+	return windows[0], nil
+}
+
+// GetWindows returns all windows, implementing the Maintenance.Repository interface.
+func (r *GormMaintenanceRepository) GetWindows(ctx context.Context) ([]*maintenance.Window, error) {
+	log.Debug("gorm get maintenance windows")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var windowModels []models.MaintenanceWindow
+	if err := r.db.Where("account = ?", account.String()).Order("created_at").Find(&windowModels).Error; err != nil {
+		return nil, err
+	}
+	return r.unmarshalWindows(account, windowModels)
+}
+
+// DeleteWindow deletes the window with the given UUID along with its targets, implementing the Maintenance.Repository interface.
+func (r *GormMaintenanceRepository) DeleteWindow(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("gorm delete maintenance window")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("account = ? AND uuid = ?", account.String(), uuid).Delete(&models.MaintenanceWindow{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return maintenance.ErrWindowNotFound
+		}
+		return tx.Where("account = ? AND window_uuid = ?", account.String(), uuid).
+			Delete(&models.MaintenanceWindowTarget{}).Error
+	})
+}
+
+// GetTargetWindows returns the windows applying to the given device or to any of the given groups,
+// implementing the Maintenance.Repository interface.
+func (r *GormMaintenanceRepository) GetTargetWindows(ctx context.Context, deviceUUID string,
+	groupUUIDs []string) ([]*maintenance.Window, error) {
+	log.WithField("uuid", deviceUUID).Debug("gorm get target maintenance windows")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	targeted := r.db.Model(&models.MaintenanceWindowTarget{}).Select("window_uuid")
+	if len(groupUUIDs) > 0 {
+		targeted = targeted.Where("account = ? AND (device_uuid = ? OR group_uuid IN ?)",
+			account.String(), deviceUUID, groupUUIDs)
+	} else {
+		targeted = targeted.Where("account = ? AND device_uuid = ?", account.String(), deviceUUID)
+	}
+	var windowModels []models.MaintenanceWindow
+	err = r.db.Where("account = ? AND uuid IN (?)", account.String(), targeted).
+		Order("created_at").Find(&windowModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.unmarshalWindows(account, windowModels)
+}
+
+// unmarshalWindows unmarshals window models of the account into domain windows, loading their targets.
+func (r *GormMaintenanceRepository) unmarshalWindows(account common.Account,
+	windowModels []models.MaintenanceWindow) ([]*maintenance.Window, error) {
+	uuids := make([]string, len(windowModels))
+	for i, windowModel := range windowModels {
+		uuids[i] = windowModel.UUID
+	}
+	devices := make(map[string][]string)
+	groups := make(map[string][]string)
+	if len(uuids) > 0 {
+		var targetModels []models.MaintenanceWindowTarget
+		err := r.db.Where("account = ? AND window_uuid IN ?", account.String(), uuids).
+			Order("id").Find(&targetModels).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, targetModel := range targetModels {
+			if targetModel.DeviceUUID != "" {
+				devices[targetModel.WindowUUID] = append(devices[targetModel.WindowUUID], targetModel.DeviceUUID)
+			} else {
+				groups[targetModel.WindowUUID] = append(groups[targetModel.WindowUUID], targetModel.GroupUUID)
+			}
+		}
+	}
+	ctx := common.ContextWithAccount(context.Background(), account)
+	windows := make([]*maintenance.Window, len(windowModels))
+	for i, windowModel := range windowModels {
+		window, err := maintenance.UnmarshalWindowFromDatabase(ctx, windowModel.UUID, windowModel.Name,
+			windowModel.Schedule, windowModel.Duration, windowModel.Timezone,
+			devices[windowModel.UUID], groups[windowModel.UUID],
+			windowModel.CreatedAt, windowModel.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		windows[i] = &window
+	}
+	return windows, nil
+}
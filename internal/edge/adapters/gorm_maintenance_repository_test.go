@@ -0,0 +1,119 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/google/uuid"
+)
+
+// newTestWindow returns a nightly window applying to the given devices and groups.
+func newTestWindow(t *testing.T, devices, groups []string) maintenance.Window {
+	window, err := maintenance.NewWindowWithContext(context.Background(), uuid.NewString(), "nightly",
+		"0 2 * * *", 2*time.Hour, "Europe/Paris", devices, groups)
+	if err != nil {
+		t.Fatalf("failed to create window: %s", err)
+	}
+	return window
+}
+
+// windowUUIDs returns the uuids of the given windows.
+func windowUUIDs(windows []*maintenance.Window) []string {
+	uuids := make([]string, len(windows))
+	for i, w := range windows {
+		uuids[i] = w.UUID()
+	}
+	return uuids
+}
+
+func TestGormMaintenanceRepository_GetTargetWindows(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormMaintenanceRepository(gormClient)
+	devices, groups := setupGroups(t)
+	windows := []maintenance.Window{
+		newTestWindow(t, []string{devices[0].UUID()}, nil),
+		newTestWindow(t, nil, []string{groups[1].UUID()}),
+		newTestWindow(t, []string{devices[2].UUID()}, []string{groups[2].UUID()}),
+	}
+	for i := range windows {
+		if err := repository.CreateWindow(context.Background(), &windows[i]); err != nil {
+			t.Fatalf("failed to store window: %s", err)
+		}
+	}
+	tests := []struct {
+		name   string
+		device string
+		groups []string
+		want   []string
+	}{
+		{
+			name:   "should list the windows of the device and of its groups",
+			device: devices[0].UUID(),
+			groups: []string{groups[1].UUID(), groups[2].UUID()},
+			want:   []string{windows[0].UUID(), windows[1].UUID(), windows[2].UUID()},
+		},
+		{
+			name:   "should list the windows of the group",
+			device: devices[1].UUID(),
+			groups: []string{groups[1].UUID()},
+			want:   []string{windows[1].UUID()},
+		},
+		{
+			name:   "should list the windows of the device without groups",
+			device: devices[2].UUID(),
+			want:   []string{windows[2].UUID()},
+		},
+		{
+			name:   "should list no window",
+			device: uuid.NewString(),
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.GetTargetWindows(context.Background(), tt.device, tt.groups)
+			if err != nil {
+				t.Errorf("GormMaintenanceRepository.GetTargetWindows() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(windowUUIDs(got), tt.want) {
+				t.Errorf("GormMaintenanceRepository.GetTargetWindows() = %v, want %v", windowUUIDs(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestGormMaintenanceRepository_DeleteWindow(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormMaintenanceRepository(gormClient)
+	window := newTestWindow(t, []string{uuid.NewString()}, []string{uuid.NewString()})
+	if err := repository.CreateWindow(context.Background(), &window); err != nil {
+		t.Fatalf("failed to store window: %s", err)
+	}
+	got, err := repository.GetWindow(context.Background(), window.UUID())
+	if err != nil {
+		t.Fatalf("GormMaintenanceRepository.GetWindow() error = %v", err)
+	}
+	if got.Timezone() != "Europe/Paris" || !reflect.DeepEqual(got.Devices(), window.Devices()) ||
+		!reflect.DeepEqual(got.Groups(), window.Groups()) {
+		t.Errorf("GormMaintenanceRepository.GetWindow() = %+v, want %+v", got, window)
+	}
+	if err := repository.DeleteWindow(context.Background(), window.UUID()); err != nil {
+		t.Fatalf("GormMaintenanceRepository.DeleteWindow() error = %v", err)
+	}
+	if _, err := repository.GetWindow(context.Background(), window.UUID()); !errors.Is(err, maintenance.ErrWindowNotFound) {
+		t.Errorf("GormMaintenanceRepository.GetWindow() error = %v after delete, want %v", err, maintenance.ErrWindowNotFound)
+	}
+	if err := repository.DeleteWindow(context.Background(), window.UUID()); !errors.Is(err, maintenance.ErrWindowNotFound) {
+		t.Errorf("GormMaintenanceRepository.DeleteWindow() error = %v twice, want %v", err, maintenance.ErrWindowNotFound)
+	}
+}
@@ -0,0 +1,398 @@
+package adapters
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// GormMigrations are the versioned migrations of the edge database schema, for both postgres and sqlite.
+// A released migration is never edited, a schema change goes in a new migration along with the models change.
+var GormMigrations = []Migration{
+	{Version: 1, Name: "baseline", Up: baselineUp, Down: baselineDown},
+	{Version: 2, Name: "tag_and_package_catalog", Up: catalogUp, Down: catalogDown},
+	{Version: 3, Name: "image_search", Up: searchUp, Down: searchDown},
+}
+
+// baselineTables returns the tables of the baseline schema, as the models were when migrations were introduced.
+// They are frozen here, so the baseline creates the same schema whatever the models become.
+func baselineTables() []interface{} {
+	type Model struct {
+		ID        uint `gorm:"primarykey"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		DeletedAt gorm.DeletedAt
+	}
+	type Installer struct {
+		Model
+		Account      string `gorm:"index:idx_installer,priority:1"`
+		ISOURL       string
+		ComposeJobID string
+		Checksum     string
+		ImageID      uint
+	}
+	type User struct {
+		Model
+		Account string `gorm:"index:idx_user,priority:1"`
+		Name    string
+		SSHKey  string
+		ImageID uint
+	}
+	type Tag struct {
+		Model
+		Account string `gorm:"index:idx_tags,priority:1"`
+		Name    string
+	}
+	type Package struct {
+		Model
+		Account string `gorm:"index:idx_packages,priority:1"`
+		Name    string
+	}
+	type Image struct {
+		Model
+		Account      string `gorm:"index:idx_image,priority:1"`
+		UUID         string `gorm:"type:varchar(36);index:idx_image,priority:2"`
+		Name         string
+		Description  string
+		Distribution string
+		Status       string
+		Version      uint           `gorm:"default:1"`
+		User         User           `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+		Installer    Installer      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+		Tags         []Tag          `gorm:"many2many:all_tags;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+		Packages     []Package      `gorm:"many2many:all_packages;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+		OutputTypes  pq.StringArray `gorm:"type:text[]"`
+	}
+	type ImageVersion struct {
+		Model
+		Account       string `gorm:"uniqueIndex:idx_image_version,priority:1"`
+		ImageUUID     string `gorm:"type:varchar(36);uniqueIndex:idx_image_version,priority:2"`
+		Version       uint   `gorm:"uniqueIndex:idx_image_version,priority:3"`
+		Status        string
+		Locked        bool   `gorm:"default:false"`
+		Commit        string `gorm:"type:varchar(64);index"`
+		Data          string `gorm:"type:text"`
+		ParentUUID    string `gorm:"type:varchar(36);index"`
+		ParentVersion uint
+		Relation      string
+	}
+	type ImageMetadataChange struct {
+		Model
+		Account   string `gorm:"index:idx_image_metadata_change,priority:1"`
+		ImageUUID string `gorm:"type:varchar(36);index:idx_image_metadata_change,priority:2"`
+		Version   uint   `gorm:"index:idx_image_metadata_change,priority:3"`
+		Data      string `gorm:"type:text"`
+	}
+	type RetentionPolicy struct {
+		Model
+		Account  string `gorm:"uniqueIndex:idx_retention_policy"`
+		KeepLast uint
+		KeepDays uint
+	}
+	type Device struct {
+		Model
+		Account             string `gorm:"index:idx_device,priority:1"`
+		UUID                string `gorm:"type:varchar(36);index:idx_device,priority:2"`
+		Name                string
+		Commit              string `gorm:"type:varchar(64)"`
+		LastSeen            time.Time
+		Tags                pq.StringArray `gorm:"type:text[]"`
+		ImageUUID           string         `gorm:"type:varchar(36);index"`
+		ImageVersion        uint
+		RunningImageUUID    string `gorm:"type:varchar(36)"`
+		RunningImageVersion uint
+		StagedCommit        string `gorm:"type:varchar(64)"`
+		Status              string `gorm:"type:text"`
+		Greenboot           string
+		Uptime              time.Duration
+		TokenHash           string `gorm:"type:varchar(64)"`
+	}
+	type Voucher struct {
+		Model
+		Account         string `gorm:"uniqueIndex:idx_voucher,priority:1"`
+		GUID            string `gorm:"type:varchar(36);uniqueIndex:idx_voucher,priority:2"`
+		DeviceInfo      string
+		ProtocolVersion uint
+		Data            []byte
+		Status          string
+		DeviceUUID      string `gorm:"type:varchar(36)"`
+		OnboardedAt     time.Time
+	}
+	type DeviceTag struct {
+		Model
+		Account    string `gorm:"index:idx_device_tag,priority:1"`
+		Name       string `gorm:"index:idx_device_tag,priority:2"`
+		DeviceUUID string `gorm:"type:varchar(36);index:idx_device_tag,priority:3"`
+	}
+	type DeviceGroup struct {
+		Model
+		Account    string `gorm:"index:idx_device_group,priority:1"`
+		UUID       string `gorm:"type:varchar(36);index:idx_device_group,priority:2"`
+		Name       string
+		Membership string
+		Selector   pq.StringArray `gorm:"type:text[]"`
+	}
+	type DeviceGroupMember struct {
+		Model
+		Account    string `gorm:"index:idx_device_group_member,priority:1;index:idx_device_group_member_device,priority:1"`
+		GroupUUID  string `gorm:"type:varchar(36);index:idx_device_group_member,priority:2"`
+		DeviceUUID string `gorm:"type:varchar(36);index:idx_device_group_member_device,priority:2"`
+	}
+	type DeviceGroupTag struct {
+		Model
+		Account   string `gorm:"index:idx_device_group_tag,priority:1"`
+		GroupUUID string `gorm:"type:varchar(36);index:idx_device_group_tag,priority:2"`
+		Name      string
+	}
+	type Rollout struct {
+		Model
+		Account          string `gorm:"index:idx_rollout,priority:1"`
+		UUID             string `gorm:"type:varchar(36);index:idx_rollout,priority:2"`
+		ImageUUID        string `gorm:"type:varchar(36)"`
+		ImageVersion     uint
+		Stages           pq.Int64Array `gorm:"type:integer[]"`
+		SoakTime         time.Duration
+		FailureThreshold uint
+		RollbackFailed   bool
+		State            string
+		Stage            uint
+		AcceptedFailures uint
+		LeaseToken       string `gorm:"type:varchar(36)"`
+		LeaseExpiresAt   time.Time
+	}
+	type RolloutTransaction struct {
+		Model
+		Account              string `gorm:"uniqueIndex:idx_rollout_transaction,priority:1"`
+		RolloutUUID          string `gorm:"type:varchar(36);uniqueIndex:idx_rollout_transaction,priority:2"`
+		DeviceUUID           string `gorm:"type:varchar(36);uniqueIndex:idx_rollout_transaction,priority:3"`
+		Status               string
+		Reason               string
+		StatusUpdatedAt      time.Time
+		PreviousImageUUID    string `gorm:"type:varchar(36)"`
+		PreviousImageVersion uint
+		Restored             bool
+	}
+	type MaintenanceWindow struct {
+		Model
+		Account  string `gorm:"index:idx_maintenance_window,priority:1"`
+		UUID     string `gorm:"type:varchar(36);index:idx_maintenance_window,priority:2"`
+		Name     string
+		Schedule string
+		Duration time.Duration
+		Timezone string
+	}
+	type MaintenanceWindowTarget struct {
+		Model
+		Account    string `gorm:"index:idx_maintenance_window_target,priority:1;index:idx_maintenance_window_target_device,priority:1;index:idx_maintenance_window_target_group,priority:1"`
+		WindowUUID string `gorm:"type:varchar(36);index:idx_maintenance_window_target,priority:2"`
+		DeviceUUID string `gorm:"type:varchar(36);index:idx_maintenance_window_target_device,priority:2"`
+		GroupUUID  string `gorm:"type:varchar(36);index:idx_maintenance_window_target_group,priority:2"`
+	}
+	type Repo struct {
+		Model
+		Account  string `gorm:"index:idx_repo,priority:1"`
+		UUID     string `gorm:"type:varchar(36);index:idx_repo,priority:2"`
+		Name     string
+		BaseURL  string
+		GPGKey   string `gorm:"type:text"`
+		GPGCheck bool
+		Priority int
+	}
+	type ImageRepo struct {
+		Model
+		Account   string `gorm:"index:idx_image_repo,priority:1;index:idx_image_repo_repo,priority:1"`
+		ImageUUID string `gorm:"type:varchar(36);index:idx_image_repo,priority:2"`
+		RepoUUID  string `gorm:"type:varchar(36);index:idx_image_repo_repo,priority:2"`
+	}
+	return []interface{}{
+		&Image{}, &Installer{}, &Tag{}, &Package{}, &User{},
+		&ImageVersion{}, &ImageMetadataChange{}, &RetentionPolicy{},
+		&Device{}, &Voucher{}, &DeviceTag{},
+		&DeviceGroup{}, &DeviceGroupMember{}, &DeviceGroupTag{},
+		&Rollout{}, &RolloutTransaction{},
+		&MaintenanceWindow{}, &MaintenanceWindowTarget{},
+		&Repo{}, &ImageRepo{},
+	}
+}
+
+// baselineUp creates the baseline schema. Databases created before migrations only get what they miss,
+// so they are adopted as they are.
+func baselineUp(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(baselineTables()...); err != nil {
+		return err
+	}
+	// repos used to be stored inline, once per image, they aren't repositories of any account
+	if tx.Migrator().HasTable("all_repos") {
+		if err := tx.Migrator().DropTable("all_repos"); err != nil {
+			return err
+		}
+	}
+	return tx.Exec("DELETE FROM repos WHERE uuid IS NULL OR uuid = ''").Error
+}
+
+// baselineDown drops every table of the baseline schema, the join tables of images included.
+func baselineDown(tx *gorm.DB) error {
+	tables := append([]interface{}{"all_tags", "all_packages"}, baselineTables()...)
+	return tx.Migrator().DropTable(tables...)
+}
+
+// catalogTables returns the tables of the tag and package catalogs, along with their join tables to images
+// and the columns joining them.
+func catalogTables() []struct{ table, joinTable, joinColumn string } {
+	return []struct{ table, joinTable, joinColumn string }{
+		{table: "tags", joinTable: "all_tags", joinColumn: "tag_id"},
+		{table: "packages", joinTable: "all_packages", joinColumn: "package_id"},
+	}
+}
+
+// catalogUp turns the tags and packages, one row per image, into catalogs of the accounts, one row per name.
+// The images are joined to the first row of each name, the other rows are deleted, then names are made unique.
+func catalogUp(tx *gorm.DB) error {
+	type Tag struct {
+		Account string `gorm:"uniqueIndex:idx_tag_name,priority:1"`
+		Name    string `gorm:"uniqueIndex:idx_tag_name,priority:2"`
+	}
+	type Package struct {
+		Account string `gorm:"uniqueIndex:idx_package_name,priority:1"`
+		Name    string `gorm:"uniqueIndex:idx_package_name,priority:2"`
+	}
+	for _, catalog := range catalogTables() {
+		statements := []string{
+			"CREATE TEMPORARY TABLE catalog_joins AS SELECT DISTINCT j.image_id AS image_id, " +
+				"(SELECT MIN(kept.id) FROM " + catalog.table + " kept WHERE kept.account = c.account AND kept.name = c.name) AS entry_id " +
+				"FROM " + catalog.joinTable + " j JOIN " + catalog.table + " c ON c.id = j." + catalog.joinColumn,
+			"DELETE FROM " + catalog.joinTable,
+			"INSERT INTO " + catalog.joinTable + " (image_id, " + catalog.joinColumn + ") SELECT image_id, entry_id FROM catalog_joins",
+			"DROP TABLE catalog_joins",
+			"DELETE FROM " + catalog.table + " WHERE id NOT IN (SELECT MIN(id) FROM " + catalog.table + " GROUP BY account, name)",
+			// entries are never deleted, a soft-deleted one would be missing from the images using it
+			"UPDATE " + catalog.table + " SET deleted_at = NULL",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+	}
+	// the unique indexes start with the account, they replace the account indexes
+	for _, index := range []struct {
+		model         interface{}
+		name, account string
+	}{
+		{model: &Tag{}, name: "idx_tag_name", account: "idx_tags"},
+		{model: &Package{}, name: "idx_package_name", account: "idx_packages"},
+	} {
+		if !tx.Migrator().HasIndex(index.model, index.name) {
+			if err := tx.Migrator().CreateIndex(index.model, index.name); err != nil {
+				return err
+			}
+		}
+		if tx.Migrator().HasIndex(index.model, index.account) {
+			if err := tx.Migrator().DropIndex(index.model, index.account); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// catalogDown restores the account indexes in place of the unique names, the catalogs stay deduplicated.
+func catalogDown(tx *gorm.DB) error {
+	type Tag struct {
+		Account string `gorm:"index:idx_tags,priority:1"`
+		Name    string `gorm:"uniqueIndex:idx_tag_name"`
+	}
+	type Package struct {
+		Account string `gorm:"index:idx_packages,priority:1"`
+		Name    string `gorm:"uniqueIndex:idx_package_name"`
+	}
+	for _, index := range []struct {
+		model         interface{}
+		name, account string
+	}{
+		{model: &Tag{}, name: "idx_tag_name", account: "idx_tags"},
+		{model: &Package{}, name: "idx_package_name", account: "idx_packages"},
+	} {
+		if err := tx.Migrator().DropIndex(index.model, index.name); err != nil {
+			return err
+		}
+		if err := tx.Migrator().CreateIndex(index.model, index.account); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// searchUp creates the full-text index of the images, one row per image with the text of its fields, then fills it
+// with the stored images. Postgres indexes the weighted tsvector of the fields with GIN. SQLite uses an FTS5 table
+// when built with it, the sqlite_fts5 tag of go-sqlite3, a plain table searched with LIKE otherwise.
+func searchUp(tx *gorm.DB) error {
+	aggregate := "group_concat(%s, ' ')"
+	var statements []string
+	switch {
+	case tx.Dialector.Name() == "postgres":
+		aggregate = "string_agg(%s, ' ')"
+		statements = []string{
+			"CREATE TABLE IF NOT EXISTS image_search (" +
+				"image_id bigint PRIMARY KEY REFERENCES images(id) ON DELETE CASCADE, account text NOT NULL, " +
+				"name text NOT NULL DEFAULT '', description text NOT NULL DEFAULT '', tags text NOT NULL DEFAULT '', " +
+				"packages text NOT NULL DEFAULT '', repos text NOT NULL DEFAULT '', " +
+				"document tsvector GENERATED ALWAYS AS (" +
+				"setweight(to_tsvector('english', name), 'A') || " +
+				"setweight(to_tsvector('english', tags), 'B') || " +
+				"setweight(to_tsvector('english', packages), 'B') || " +
+				"setweight(to_tsvector('english', repos), 'C') || " +
+				"setweight(to_tsvector('english', description), 'D')) STORED)",
+			"CREATE INDEX IF NOT EXISTS idx_image_search_document ON image_search USING GIN (document)",
+			"CREATE INDEX IF NOT EXISTS idx_image_search_account ON image_search (account)",
+		}
+	case hasFTS5(tx):
+		statements = []string{
+			"CREATE VIRTUAL TABLE IF NOT EXISTS image_search USING fts5(" +
+				"image_id UNINDEXED, account UNINDEXED, name, description, tags, packages, repos)",
+		}
+	default:
+		statements = []string{
+			"CREATE TABLE IF NOT EXISTS image_search (" +
+				"image_id integer PRIMARY KEY, account text NOT NULL, name text NOT NULL DEFAULT '', " +
+				"description text NOT NULL DEFAULT '', tags text NOT NULL DEFAULT '', " +
+				"packages text NOT NULL DEFAULT '', repos text NOT NULL DEFAULT '')",
+			"CREATE INDEX IF NOT EXISTS idx_image_search_account ON image_search (account)",
+		}
+	}
+	// deleted images are indexed too, they may be restored
+	statements = append(statements, "DELETE FROM image_search",
+		"INSERT INTO image_search (image_id, account, name, description, tags, packages, repos) "+
+			"SELECT images.id, images.account, COALESCE(images.name, ''), COALESCE(images.description, ''), "+
+			"COALESCE((SELECT "+fmt.Sprintf(aggregate, "tags.name")+" FROM all_tags "+
+			"JOIN tags ON tags.id = all_tags.tag_id WHERE all_tags.image_id = images.id), ''), "+
+			"COALESCE((SELECT "+fmt.Sprintf(aggregate, "packages.name")+" FROM all_packages "+
+			"JOIN packages ON packages.id = all_packages.package_id WHERE all_packages.image_id = images.id), ''), "+
+			"COALESCE((SELECT "+fmt.Sprintf(aggregate, "repos.name")+" FROM image_repos "+
+			"JOIN repos ON repos.account = image_repos.account AND repos.uuid = image_repos.repo_uuid "+
+			"AND repos.deleted_at IS NULL WHERE image_repos.account = images.account "+
+			"AND image_repos.image_uuid = images.uuid AND image_repos.deleted_at IS NULL), '') "+
+			"FROM images")
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// searchDown drops the full-text index of the images.
+func searchDown(tx *gorm.DB) error {
+	return tx.Exec("DROP TABLE IF EXISTS image_search").Error
+}
+
+// hasFTS5 returns true if the database is a SQLite one with the FTS5 extension.
+func hasFTS5(db *gorm.DB) bool {
+	if db.Dialector.Name() != "sqlite" {
+		return false
+	}
+	var used bool
+	return db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&used).Error == nil && used
+}
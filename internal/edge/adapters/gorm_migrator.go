@@ -0,0 +1,283 @@
+package adapters
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Migration errors
+var (
+	ErrSchemaTooNew          = errors.New("database schema is newer than the migrations this binary knows")
+	ErrSchemaOutdated        = errors.New("database schema has pending migrations")
+	ErrUnknownMigration      = errors.New("unknown migration version")
+	ErrNoMigrationToRollBack = errors.New("no migration to roll back")
+	ErrIrreversibleMigration = errors.New("migration cannot be rolled back")
+)
+
+// migrationLockID is the postgres advisory lock held while migrating, so replicas starting together migrate once.
+const migrationLockID = 4242_0001
+
+// Migration is a versioned change of the database schema, it runs within a transaction.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	// Down reverts Up, nil if the migration cannot be rolled back.
+	Down func(tx *gorm.DB) error
+}
+
+// MigrationStatus is the state of a migration in a database.
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	// Known is false for migrations applied by a newer binary.
+	Known bool
+}
+
+// schemaMigration is a model for storing the migration history, one row per applied migration.
+type schemaMigration struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// TableName is the name of the migration history table.
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// GormMigrator applies and rolls back versioned migrations, recording them in the migration history.
+type GormMigrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewGormMigrator returns a new migrator of the given migrations, the versions must be unique.
+func NewGormMigrator(db *gorm.DB, migrations []Migration) *GormMigrator {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for i, migration := range sorted {
+		if migration.Version == 0 || (i > 0 && sorted[i-1].Version == migration.Version) {
+			panic(fmt.Sprintf("invalid migration version %d", migration.Version))
+		}
+	}
+	return &GormMigrator{db: db, migrations: sorted}
+}
+
+// Latest returns the version of the last migration known, 0 if there are none.
+func (m *GormMigrator) Latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the version of the last migration applied, 0 if the database wasn't migrated.
+func (m *GormMigrator) Version() (uint, error) {
+	history, err := m.history(m.db)
+	if err != nil {
+		return 0, err
+	}
+	var version uint
+	for v := range history {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// Status returns the state of every known migration, followed by the ones applied by a newer binary.
+func (m *GormMigrator) Status() ([]MigrationStatus, error) {
+	history, err := m.history(m.db)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, Known: true}
+		if applied, ok := history[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = applied.AppliedAt
+			delete(history, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	unknown := make([]MigrationStatus, 0, len(history))
+	for _, applied := range history {
+		unknown = append(unknown, MigrationStatus{Version: applied.Version, Name: applied.Name, Applied: true,
+			AppliedAt: applied.AppliedAt})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+	return append(statuses, unknown...), nil
+}
+
+// Check returns ErrSchemaTooNew if the database has migrations this binary doesn't know.
+func (m *GormMigrator) Check() error {
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if version > m.Latest() {
+		return fmt.Errorf("%w: schema version %d, latest known %d", ErrSchemaTooNew, version, m.Latest())
+	}
+	return nil
+}
+
+// CheckCurrent returns ErrSchemaTooNew like Check, or ErrSchemaOutdated if some migrations are pending.
+func (m *GormMigrator) CheckCurrent() error {
+	if err := m.Check(); err != nil {
+		return err
+	}
+	history, err := m.history(m.db)
+	if err != nil {
+		return err
+	}
+	var pending []uint
+	for _, migration := range m.migrations {
+		if _, ok := history[migration.Version]; !ok {
+			pending = append(pending, migration.Version)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %v", ErrSchemaOutdated, pending)
+	}
+	return nil
+}
+
+// Up applies every pending migration.
+func (m *GormMigrator) Up() error {
+	return m.To(m.Latest())
+}
+
+// Down rolls back the last applied migration.
+func (m *GormMigrator) Down() error {
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return ErrNoMigrationToRollBack
+	}
+	var previous uint
+	for _, migration := range m.migrations {
+		if migration.Version < version {
+			previous = migration.Version
+		}
+	}
+	return m.To(previous)
+}
+
+// To applies the pending migrations up to the given version, then rolls back the ones after it, newest first.
+// Version 0 rolls back every migration.
+func (m *GormMigrator) To(version uint) error {
+	if err := m.Check(); err != nil {
+		return err
+	}
+	if version != 0 && !m.isKnown(version) {
+		return fmt.Errorf("%w: %d", ErrUnknownMigration, version)
+	}
+	for _, migration := range m.migrations {
+		if migration.Version <= version {
+			if err := m.apply(migration); err != nil {
+				return err
+			}
+		}
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if m.migrations[i].Version > version {
+			if err := m.revert(m.migrations[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isKnown returns true if a migration of the given version is known.
+func (m *GormMigrator) isKnown(version uint) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// apply runs the migration and records it, unless it was already applied.
+func (m *GormMigrator) apply(migration Migration) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		history, err := m.lockHistory(tx)
+		if err != nil {
+			return err
+		}
+		if _, ok := history[migration.Version]; ok {
+			return nil
+		}
+		log.WithFields(log.Fields{"version": migration.Version, "name": migration.Name}).Info("applying migration")
+		if err := migration.Up(tx); err != nil {
+			return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+		return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name,
+			AppliedAt: time.Now().UTC()}).Error
+	})
+}
+
+// revert rolls back the migration and removes it from the history, unless it wasn't applied.
+func (m *GormMigrator) revert(migration Migration) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		history, err := m.lockHistory(tx)
+		if err != nil {
+			return err
+		}
+		if _, ok := history[migration.Version]; !ok {
+			return nil
+		}
+		if migration.Down == nil {
+			return fmt.Errorf("%w: %d %s", ErrIrreversibleMigration, migration.Version, migration.Name)
+		}
+		log.WithFields(log.Fields{"version": migration.Version, "name": migration.Name}).Info("rolling back migration")
+		if err := migration.Down(tx); err != nil {
+			return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+		return tx.Delete(&schemaMigration{}, migration.Version).Error
+	})
+}
+
+// lockHistory serializes migrations on postgres, then returns the history as seen within the transaction.
+// SQLite serializes writing transactions on its own.
+func (m *GormMigrator) lockHistory(tx *gorm.DB) (map[uint]schemaMigration, error) {
+	if tx.Dialector.Name() == "postgres" {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error; err != nil {
+			return nil, err
+		}
+	}
+	return m.history(tx)
+}
+
+// history returns the applied migrations by version, creating the history table if needed.
+func (m *GormMigrator) history(db *gorm.DB) (map[uint]schemaMigration, error) {
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+	var applied []schemaMigration
+	if err := db.Find(&applied).Error; err != nil {
+		return nil, err
+	}
+	history := make(map[uint]schemaMigration, len(applied))
+	for _, migration := range applied {
+		history[migration.Version] = migration
+	}
+	return history, nil
+}
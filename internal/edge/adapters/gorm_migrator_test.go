@@ -0,0 +1,281 @@
+package adapters
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/redhatinsights/edge-api/config"
+	"gorm.io/gorm"
+)
+
+// widget is the table of the test migration following the baseline.
+type widget struct {
+	ID   uint `gorm:"primarykey"`
+	Name string
+}
+
+// widgetsVersion is the version of the test migration, following the last one of the edge schema.
+var widgetsVersion = GormMigrations[len(GormMigrations)-1].Version + 1
+
+var widgetsMigration = Migration{
+	Version: widgetsVersion,
+	Name:    "widgets",
+	Up: func(tx *gorm.DB) error {
+		return tx.Migrator().CreateTable(&widget{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&widget{})
+	},
+}
+
+// openTestDB opens a sqlite db without migrating it, removed once the test is done.
+func openTestDB(t *testing.T) *gorm.DB {
+	config.Init()
+	name := fmt.Sprintf("%s.db", t.Name())
+	config.Get().Database.Name = name
+	db, err := OpenGormDB(config.Get())
+	if err != nil {
+		t.Fatalf("failed to open db: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Remove(name); err != nil {
+			t.Errorf("failed to remove db file: %s", err)
+		}
+	})
+	return db
+}
+
+func TestGormMigrator_To(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewGormMigrator(db, append([]Migration{widgetsMigration}, GormMigrations...))
+
+	tests := []struct {
+		name        string
+		migrate     func() error
+		wantVersion uint
+		wantImages  bool
+		wantWidgets bool
+		wantErr     error
+	}{
+		{
+			name:        "should apply every migration",
+			migrate:     migrator.Up,
+			wantVersion: widgetsVersion,
+			wantImages:  true,
+			wantWidgets: true,
+		},
+		{
+			name:        "should roll back the last migration",
+			migrate:     migrator.Down,
+			wantVersion: widgetsVersion - 1,
+			wantImages:  true,
+		},
+		{
+			name:        "should apply the migrations up to the version",
+			migrate:     func() error { return migrator.To(widgetsVersion) },
+			wantVersion: widgetsVersion,
+			wantImages:  true,
+			wantWidgets: true,
+		},
+		{
+			name:        "should fail, unknown version",
+			migrate:     func() error { return migrator.To(widgetsVersion + 1) },
+			wantVersion: widgetsVersion,
+			wantImages:  true,
+			wantWidgets: true,
+			wantErr:     ErrUnknownMigration,
+		},
+		{
+			name:    "should roll back every migration",
+			migrate: func() error { return migrator.To(0) },
+		},
+		{
+			name:    "should fail, nothing to roll back",
+			migrate: migrator.Down,
+			wantErr: ErrNoMigrationToRollBack,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.migrate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormMigrator.To() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if version, err := migrator.Version(); err != nil || version != tt.wantVersion {
+				t.Errorf("GormMigrator.Version() = %v, %v, want %v", version, err, tt.wantVersion)
+			}
+			if got := db.Migrator().HasTable(&models.Image{}); got != tt.wantImages {
+				t.Errorf("images table exists = %v, want %v", got, tt.wantImages)
+			}
+			if got := db.Migrator().HasTable(&widget{}); got != tt.wantWidgets {
+				t.Errorf("widgets table exists = %v, want %v", got, tt.wantWidgets)
+			}
+		})
+	}
+}
+
+func TestGormMigrator_Status(t *testing.T) {
+	db := openTestDB(t)
+	if err := NewGormMigrator(db, append([]Migration{widgetsMigration}, GormMigrations...)).Up(); err != nil {
+		t.Fatalf("failed to migrate: %s", err)
+	}
+	// this binary only knows the edge schema, the widgets were migrated by a newer one
+	migrator := NewGormMigrator(db, GormMigrations)
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("GormMigrator.Status() error = %v", err)
+	}
+	last := len(statuses) - 1
+	if len(statuses) != len(GormMigrations)+1 || !statuses[0].Known || !statuses[0].Applied ||
+		statuses[last].Known || statuses[last].Version != widgetsVersion || statuses[last].Name != "widgets" {
+		t.Errorf("GormMigrator.Status() = %+v, want the edge schema then the unknown widgets", statuses)
+	}
+	for _, err := range []error{migrator.Check(), migrator.Up(), migrator.Down()} {
+		if !errors.Is(err, ErrSchemaTooNew) {
+			t.Errorf("GormMigrator error = %v, wantErr %v", err, ErrSchemaTooNew)
+		}
+	}
+}
+
+func TestGormMigrator_Baseline(t *testing.T) {
+	db := openTestDB(t)
+	// a database auto-migrated before migrations existed, with a repo stored inline
+	if err := db.AutoMigrate(&models.Image{}, &models.Repo{}); err != nil {
+		t.Fatalf("failed to auto migrate: %s", err)
+	}
+	img := models.Image{Account: "0000000", UUID: "a9c8dbb5-a35e-4a7b-8b2c-3e3b3e0c8a6a", Name: "kiosk"}
+	if err := db.Create(&img).Error; err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	if err := db.Create(&models.Repo{Account: "0000000", Name: "inline"}).Error; err != nil {
+		t.Fatalf("failed to create repo: %s", err)
+	}
+
+	if err := NewGormMigrator(db, GormMigrations).Up(); err != nil {
+		t.Fatalf("GormMigrator.Up() error = %v", err)
+	}
+	var images, repos int64
+	db.Model(&models.Image{}).Where("uuid = ?", img.UUID).Count(&images)
+	db.Unscoped().Model(&models.Repo{}).Count(&repos)
+	if images != 1 || repos != 0 {
+		t.Errorf("GormMigrator.Up() kept %d images and %d repos, want 1 and 0", images, repos)
+	}
+	if !db.Migrator().HasTable(&models.ImageRepo{}) {
+		t.Errorf("GormMigrator.Up() didn't create the missing tables")
+	}
+}
+
+func TestGormMigrator_Irreversible(t *testing.T) {
+	db := openTestDB(t)
+	irreversible := widgetsMigration
+	irreversible.Down = nil
+	migrator := NewGormMigrator(db, append([]Migration{irreversible}, GormMigrations...))
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("failed to migrate: %s", err)
+	}
+	if err := migrator.Down(); !errors.Is(err, ErrIrreversibleMigration) {
+		t.Errorf("GormMigrator.Down() error = %v, wantErr %v", err, ErrIrreversibleMigration)
+	}
+	if version, _ := migrator.Version(); version != widgetsVersion {
+		t.Errorf("GormMigrator.Version() = %v, want %v", version, widgetsVersion)
+	}
+}
+
+func TestGormMigrator_Catalog(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewGormMigrator(db, GormMigrations)
+	if err := migrator.To(1); err != nil {
+		t.Fatalf("failed to migrate the baseline: %s", err)
+	}
+	// before the catalog, every image had its own rows, even of the same tag
+	images := []models.Image{
+		{Account: "0000000", UUID: "a9c8dbb5-a35e-4a7b-8b2c-3e3b3e0c8a6a", Name: "kiosk",
+			Tags: []models.Tag{{Account: "0000000", Name: "berlin"}, {Account: "0000000", Name: "kiosk"}}},
+		{Account: "0000000", UUID: "0f1a6a3e-5b1d-4a0e-9a53-2f3c9f0b7e11", Name: "other-kiosk",
+			Tags: []models.Tag{{Account: "0000000", Name: "kiosk"}}},
+		{Account: "0000001", UUID: "5c2f1e7a-3d4b-4c8e-a9f0-1b2c3d4e5f60", Name: "kiosk",
+			Tags: []models.Tag{{Account: "0000001", Name: "kiosk"}}},
+	}
+	for _, img := range images {
+		img := img
+		if err := db.Create(&img).Error; err != nil {
+			t.Fatalf("failed to create image: %s", err)
+		}
+	}
+
+	if err := migrator.To(2); err != nil {
+		t.Fatalf("GormMigrator.To() error = %v", err)
+	}
+	var tags, joins int64
+	db.Unscoped().Model(&models.Tag{}).Count(&tags)
+	db.Table("all_tags").Count(&joins)
+	if tags != 3 || joins != 4 {
+		t.Errorf("GormMigrator.To() left %d tags joined %d times, want 3 tags joined 4 times", tags, joins)
+	}
+	for _, want := range images {
+		var got models.Image
+		if err := db.Preload("Tags").Where("account = ? AND uuid = ?", want.Account, want.UUID).First(&got).Error; err != nil {
+			t.Fatalf("failed to get image: %s", err)
+		}
+		if len(got.Tags) != len(want.Tags) {
+			t.Errorf("GormMigrator.To() image %s has tags %v, want %v", want.UUID, got.Tags, want.Tags)
+		}
+	}
+	// names are unique within an account
+	if err := db.Create(&models.Tag{Account: "0000000", Name: "kiosk"}).Error; err == nil {
+		t.Errorf("GormMigrator.To() didn't make the tag names unique")
+	}
+
+	if err := migrator.To(1); err != nil {
+		t.Fatalf("GormMigrator.To() error = %v", err)
+	}
+	if err := db.Create(&models.Tag{Account: "0000000", Name: "kiosk"}).Error; err != nil {
+		t.Errorf("GormMigrator.To() kept the tag names unique: %s", err)
+	}
+}
+
+func TestGormMigrator_Search(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewGormMigrator(db, GormMigrations)
+	if err := migrator.To(2); err != nil {
+		t.Fatalf("failed to migrate the catalog: %s", err)
+	}
+	// the images stored before the index are indexed along with their tags, packages and repositories
+	kiosk := models.Image{Account: "0000000", UUID: "a9c8dbb5-a35e-4a7b-8b2c-3e3b3e0c8a6a", Name: "kiosk",
+		Description: "Berlin warehouse", Tags: []models.Tag{{Account: "0000000", Name: "berlin"}},
+		Packages: []models.Package{{Account: "0000000", Name: "nginx"}}}
+	if err := db.Create(&kiosk).Error; err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	epel := models.Repo{Account: "0000000", UUID: "0f1a6a3e-5b1d-4a0e-9a53-2f3c9f0b7e11", Name: "epel"}
+	if err := db.Create(&epel).Error; err != nil {
+		t.Fatalf("failed to create repo: %s", err)
+	}
+	if err := db.Create(&models.ImageRepo{Account: "0000000", ImageUUID: kiosk.UUID, RepoUUID: epel.UUID}).Error; err != nil {
+		t.Fatalf("failed to create image repo: %s", err)
+	}
+
+	if err := migrator.To(3); err != nil {
+		t.Fatalf("GormMigrator.To() error = %v", err)
+	}
+	var row struct {
+		Account, Name, Description, Tags, Packages, Repos string
+	}
+	if err := db.Table("image_search").Where("image_id = ?", kiosk.ID).Take(&row).Error; err != nil {
+		t.Fatalf("GormMigrator.To() didn't index the image: %s", err)
+	}
+	if row.Account != "0000000" || row.Name != "kiosk" || row.Description != "Berlin warehouse" ||
+		row.Tags != "berlin" || row.Packages != "nginx" || row.Repos != "epel" {
+		t.Errorf("GormMigrator.To() indexed %+v", row)
+	}
+
+	if err := migrator.To(2); err != nil {
+		t.Fatalf("GormMigrator.To() error = %v", err)
+	}
+	if db.Migrator().HasTable("image_search") {
+		t.Errorf("GormMigrator.To() kept the index")
+	}
+}
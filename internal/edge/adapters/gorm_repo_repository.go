@@ -0,0 +1,147 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GormRepoRepository is a GORM implementation of the Repo.Repository interface.
+type GormRepoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRepoRepository returns a new GORM implementation of the Repo.Repository interface.
+func NewGormRepoRepository(db *gorm.DB) *GormRepoRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormRepoRepository{db: db}
+}
+
+// CreateRepo creates a new repository, implementing the Repo.Repository interface.
+func (r *GormRepoRepository) CreateRepo(ctx context.Context, repository *repo.Repo) error {
+	log.WithField("uuid", repository.UUID()).Debug("gorm create repo")
+	repository.Touch(time.Now())
+	return r.db.Create(repository.MarshalGorm()).Error
+}
+
+// GetRepo returns the repository with the given UUID, implementing the Repo.Repository interface.
+func (r *GormRepoRepository) GetRepo(ctx context.Context, uuid string) (*repo.Repo, error) {
+	log.WithField("uuid", uuid).Debug("gorm get repo")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getRepo(r.db, account, uuid)
+}
+
+// GetRepos returns all repositories, implementing the Repo.Repository interface.
+func (r *GormRepoRepository) GetRepos(ctx context.Context) ([]*repo.Repo, error) {
+	log.Debug("gorm get repos")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var repoModels []models.Repo
+	if err := r.db.Where("account = ?", account.String()).Order("created_at").Find(&repoModels).Error; err != nil {
+		return nil, err
+	}
+	repos := make([]*repo.Repo, len(repoModels))
+	for i, repoModel := range repoModels {
+		if repos[i], err = unmarshalRepo(account, repoModel); err != nil {
+			return nil, err
+		}
+	}
+	return repos, nil
+}
+
+// UpdateRepo updates the repository with the given UUID, implementing the Repo.Repository interface.
+func (r *GormRepoRepository) UpdateRepo(ctx context.Context, uuid string, updateFn func(r *repo.Repo) (*repo.Repo, error)) error {
+	log.WithField("uuid", uuid).Debug("gorm update repo")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		current, err := getRepo(tx, account, uuid)
+		if err != nil {
+			return err
+		}
+		// updateFn may change the repository in place
+		name := current.Name()
+		updatedRepo, err := updateFn(current)
+		if err != nil {
+			return err
+		}
+		updatedRepo.Touch(time.Now())
+		// select every field, so gpg check can be turned off and the gpg key removed
+		if err := tx.Model(&models.Repo{}).Where("account = ? AND uuid = ?", account.String(), uuid).
+			Select("name", "base_url", "gpg_key", "gpg_check", "priority", "updated_at").
+			Updates(updatedRepo.MarshalGorm()).Error; err != nil {
+			return err
+		}
+		// the images are searched by the names of their repositories
+		if name == updatedRepo.Name() {
+			return nil
+		}
+		return indexRepoImages(tx, account, uuid)
+	})
+}
+
+// DeleteRepo deletes the repository with the given UUID, implementing the Repo.Repository interface.
+// Deleted images still reference their repositories, as they can be restored.
+func (r *GormRepoRepository) DeleteRepo(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("gorm delete repo")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var references int64
+		if err := tx.Model(&models.ImageRepo{}).Where("account = ? AND repo_uuid = ?", account.String(), uuid).
+			Count(&references).Error; err != nil {
+			return err
+		}
+		if references > 0 {
+			return repo.ErrRepoInUse
+		}
+		result := tx.Where("account = ? AND uuid = ?", account.String(), uuid).Delete(&models.Repo{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repo.ErrRepoNotFound
+		}
+		return nil
+	})
+}
+
+// getRepo returns the repository with the given UUID of the account, using the given connection.
+func getRepo(db *gorm.DB, account common.Account, uuid string) (*repo.Repo, error) {
+	var repoModel models.Repo
+	err := db.Where("account = ? AND uuid = ?", account.String(), uuid).First(&repoModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, repo.ErrRepoNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return unmarshalRepo(account, repoModel)
+}
+
+// unmarshalRepo unmarshals a repository model of the account into a domain repository.
+func unmarshalRepo(account common.Account, repoModel models.Repo) (*repo.Repo, error) {
+	repository, err := repo.UnmarshalRepoFromDatabase(common.ContextWithAccount(context.Background(), account),
+		repoModel.UUID, repoModel.Name, repoModel.BaseURL, repoModel.GPGKey, repoModel.GPGCheck, repoModel.Priority,
+		repoModel.CreatedAt, repoModel.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &repository, nil
+}
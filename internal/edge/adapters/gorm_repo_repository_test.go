@@ -0,0 +1,122 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+	"github.com/google/uuid"
+)
+
+// newTestRepo returns a repository of the default account.
+func newTestRepo(t *testing.T, name string) repo.Repo {
+	r, err := repo.NewRepoWithContext(context.Background(), uuid.NewString(), name,
+		"https://dl.fedoraproject.org/pub/epel/8/Everything/$basearch/",
+		"https://dl.fedoraproject.org/pub/epel/RPM-GPG-KEY-EPEL-8", true, 10)
+	if err != nil {
+		t.Fatalf("failed to create repo: %s", err)
+	}
+	return r
+}
+
+func TestGormRepoRepository_UpdateRepo(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormRepoRepository(gormClient)
+	epel := newTestRepo(t, "epel")
+	if err := repository.CreateRepo(context.Background(), &epel); err != nil {
+		t.Fatalf("failed to store repo: %s", err)
+	}
+	err := repository.UpdateRepo(context.Background(), epel.UUID(), func(r *repo.Repo) (*repo.Repo, error) {
+		return r, r.Update("epel-mirror", "https://mirror.example.com/epel/8/", "", false, 0)
+	})
+	if err != nil {
+		t.Fatalf("GormRepoRepository.UpdateRepo() error = %v", err)
+	}
+	got, err := repository.GetRepo(context.Background(), epel.UUID())
+	if err != nil {
+		t.Fatalf("GormRepoRepository.GetRepo() error = %v", err)
+	}
+	if got.Name().String() != "epel-mirror" || got.BaseURL() != "https://mirror.example.com/epel/8/" ||
+		got.GPGKey() != "" || got.GPGCheck() || got.Priority() != repo.DefaultPriority {
+		t.Errorf("GormRepoRepository.UpdateRepo() = %v %v %v %v %v", got.Name(), got.BaseURL(), got.GPGKey(),
+			got.GPGCheck(), got.Priority())
+	}
+	repos, err := repository.GetRepos(context.Background())
+	if err != nil || len(repos) != 1 {
+		t.Errorf("GormRepoRepository.GetRepos() = %v, %v, want the updated repo", repos, err)
+	}
+	err = repository.UpdateRepo(context.Background(), uuid.NewString(), func(r *repo.Repo) (*repo.Repo, error) {
+		return r, nil
+	})
+	if !errors.Is(err, repo.ErrRepoNotFound) {
+		t.Errorf("GormRepoRepository.UpdateRepo() error = %v, wantErr %v", err, repo.ErrRepoNotFound)
+	}
+}
+
+func TestGormRepoRepository_DeleteRepo(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormRepoRepository(gormClient)
+	imageRepository := NewGormImageRepository(gormClient)
+	used, unused := newTestRepo(t, "used"), newTestRepo(t, "unused")
+	for _, r := range []*repo.Repo{&used, &unused} {
+		if err := repository.CreateRepo(context.Background(), r); err != nil {
+			t.Fatalf("failed to store repo: %s", err)
+		}
+	}
+	img, err := image.NewImageWithContext(context.Background(), uuid.NewString(), "kiosk", "", "rhel8", "success",
+		validImage.User().Username(), validImage.User().SSHKey(), []string{"rhel-edge-commit"}, nil, nil, 1,
+		[]string{used.UUID()})
+	if err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	if err := imageRepository.CreateImage(context.Background(), &img); err != nil {
+		t.Fatalf("failed to store image: %s", err)
+	}
+	if err := imageRepository.DeleteImage(context.Background(), img.UUID()); err != nil {
+		t.Fatalf("failed to delete image: %s", err)
+	}
+
+	tests := []struct {
+		name    string
+		uuid    string
+		wantErr error
+	}{
+		{
+			name:    "should delete an unused repo",
+			uuid:    unused.UUID(),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail to delete a repo, already deleted",
+			uuid:    unused.UUID(),
+			wantErr: repo.ErrRepoNotFound,
+		},
+		{
+			name:    "should fail to delete a repo a deleted image references",
+			uuid:    used.UUID(),
+			wantErr: repo.ErrRepoInUse,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repository.DeleteRepo(context.Background(), tt.uuid); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormRepoRepository.DeleteRepo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// purging the image releases the repo
+	if err := imageRepository.PurgeImage(context.Background(), img.UUID()); err != nil {
+		t.Fatalf("failed to purge image: %s", err)
+	}
+	if err := repository.DeleteRepo(context.Background(), used.UUID()); err != nil {
+		t.Errorf("GormRepoRepository.DeleteRepo() error = %v, wantErr %v", err, nil)
+	}
+}
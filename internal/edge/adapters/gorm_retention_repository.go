@@ -0,0 +1,76 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormRetentionRepository is a GORM implementation of the Image.RetentionRepository interface.
+type GormRetentionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRetentionRepository returns a new GORM implementation of the Image.RetentionRepository interface.
+func NewGormRetentionRepository(db *gorm.DB) *GormRetentionRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormRetentionRepository{db: db}
+}
+
+// GetRetentionPolicy returns the retention policy of the account, implementing the Image.RetentionRepository interface.
+func (r *GormRetentionRepository) GetRetentionPolicy(ctx context.Context) (*image.RetentionPolicy, error) {
+	log.Debug("gorm get retention policy")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var policyModel models.RetentionPolicy
+	err = r.db.Where("account = ?", account.String()).First(&policyModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, image.ErrRetentionPolicyNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	policy, err := image.UnmarshalRetentionPolicyFromDatabase(policyModel.Account, policyModel.KeepLast, policyModel.KeepDays)
+	return &policy, err
+}
+
+// SetRetentionPolicy creates or replaces the retention policy of the account, implementing the Image.RetentionRepository interface.
+func (r *GormRetentionRepository) SetRetentionPolicy(ctx context.Context, policy *image.RetentionPolicy) error {
+	log.Debug("gorm set retention policy")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account"}},
+		DoUpdates: clause.AssignmentColumns([]string{"keep_last", "keep_days", "updated_at"}),
+	}).Create(policy.MarshalGorm(account.String())).Error
+}
+
+// GetRetentionPolicies returns the retention policies of all accounts, implementing the Image.RetentionRepository interface.
+func (r *GormRetentionRepository) GetRetentionPolicies(ctx context.Context) ([]*image.RetentionPolicy, error) {
+	log.Debug("gorm get retention policies")
+	var policyModels []models.RetentionPolicy
+	if err := r.db.Find(&policyModels).Error; err != nil {
+		return nil, err
+	}
+	policies := make([]*image.RetentionPolicy, 0, len(policyModels))
+	for _, policyModel := range policyModels {
+		policy, err := image.UnmarshalRetentionPolicyFromDatabase(policyModel.Account, policyModel.KeepLast, policyModel.KeepDays)
+		if err != nil {
+			log.WithField("account", policyModel.Account).Error(err) // skip broken policies, never prune with them
+			continue
+		}
+		policies = append(policies, &policy)
+	}
+	return policies, nil
+}
@@ -0,0 +1,214 @@
+package adapters
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/redhatinsights/edge-api/config"
+	"gorm.io/gorm"
+)
+
+func TestNewGormRetentionRepository(t *testing.T) {
+	gormClient := &gorm.DB{}
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want *GormRetentionRepository
+	}{
+		{
+			name: "should return a new gorm retention repository",
+			args: args{
+				db: gormClient,
+			},
+			want: &GormRetentionRepository{
+				db: gormClient,
+			},
+		},
+		{
+			name: "should panic if db is nil",
+			args: args{
+				db: nil,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				// recover from panic if one occured.
+				if recover() != nil && tt.want != nil {
+					t.Errorf("NewGormRetentionRepository() panicked")
+				}
+			}()
+			t.Parallel()
+			if got := NewGormRetentionRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewGormRetentionRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormRetentionRepository_SetRetentionPolicy(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormRetentionRepository(gormClient)
+	first, _ := image.NewRetentionPolicy(5, 0)
+	second, _ := image.NewRetentionPolicy(3, 30)
+
+	type args struct {
+		ctx    context.Context
+		policy *image.RetentionPolicy
+	}
+	tests := []struct {
+		name    string
+		r       *GormRetentionRepository
+		args    args
+		wantErr bool
+		auth    bool
+	}{
+		{
+			name: "should create a retention policy",
+			r:    repository,
+			args: args{
+				ctx:    context.Background(),
+				policy: &first,
+			},
+			wantErr: false,
+			auth:    false,
+		},
+		{
+			name: "should replace the retention policy",
+			r:    repository,
+			args: args{
+				ctx:    context.Background(),
+				policy: &second,
+			},
+			wantErr: false,
+			auth:    false,
+		},
+		{
+			name: "should fail to set a retention policy, bad account",
+			r:    repository,
+			args: args{
+				ctx:    context.Background(),
+				policy: &first,
+			},
+			wantErr: true,
+			auth:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config.Get().Auth = tt.auth
+			defer func() {
+				config.Get().Auth = false
+			}()
+			if err := tt.r.SetRetentionPolicy(tt.args.ctx, tt.args.policy); (err != nil) != tt.wantErr {
+				t.Errorf("GormRetentionRepository.SetRetentionPolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := tt.r.GetRetentionPolicy(tt.args.ctx)
+			if err != nil {
+				t.Errorf("failed to get retention policy: %s", err)
+				return
+			}
+			if got.KeepLast() != tt.args.policy.KeepLast() || got.KeepDays() != tt.args.policy.KeepDays() ||
+				got.Account() != common.DefaultAccount {
+				t.Errorf("GormRetentionRepository.GetRetentionPolicy() = %v, want %v", got, tt.args.policy)
+			}
+		})
+	}
+}
+
+func TestGormRetentionRepository_GetRetentionPolicy(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		r       *GormRetentionRepository
+		args    args
+		wantErr error
+	}{
+		{
+			name: "should fail, no retention policy",
+			r:    NewGormRetentionRepository(gormClient),
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: image.ErrRetentionPolicyNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.r.GetRetentionPolicy(tt.args.ctx); err != tt.wantErr {
+				t.Errorf("GormRetentionRepository.GetRetentionPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGormRetentionRepository_GetRetentionPolicies(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormRetentionRepository(gormClient)
+	policy, _ := image.NewRetentionPolicy(5, 0)
+	if err := repository.SetRetentionPolicy(context.Background(), &policy); err != nil {
+		t.Errorf("failed to set retention policy: %s", err)
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		r       *GormRetentionRepository
+		args    args
+		want    []common.Account
+		wantErr bool
+	}{
+		{
+			name: "should get all retention policies",
+			r:    repository,
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    []common.Account{common.DefaultAccount},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.GetRetentionPolicies(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GormRetentionRepository.GetRetentionPolicies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var gotAccounts []common.Account
+			for _, policy := range got {
+				gotAccounts = append(gotAccounts, policy.Account())
+			}
+			if !reflect.DeepEqual(gotAccounts, tt.want) {
+				t.Errorf("GormRetentionRepository.GetRetentionPolicies() = %v, want %v", gotAccounts, tt.want)
+			}
+		})
+	}
+}
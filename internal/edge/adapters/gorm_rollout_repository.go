@@ -0,0 +1,336 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GormRolloutRepository is a GORM implementation of the Rollout.Repository interface.
+type GormRolloutRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRolloutRepository returns a new GORM implementation of the Rollout.Repository interface.
+func NewGormRolloutRepository(db *gorm.DB) *GormRolloutRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormRolloutRepository{db: db}
+}
+
+// CreateRollout creates a new rollout along with its transactions, implementing the Rollout.Repository interface.
+func (r *GormRolloutRepository) CreateRollout(ctx context.Context, newRollout *rollout.Rollout) error {
+	log.WithField("uuid", newRollout.UUID()).Debug("gorm create rollout")
+	account, err := newRollout.Account()
+	if err != nil {
+		return err
+	}
+	newRollout.Touch(time.Now())
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := shareImage(tx, account, newRollout.Assignment().ImageUUID()); err != nil {
+			return err
+		}
+		if err := tx.Create(newRollout.MarshalGorm()).Error; err != nil {
+			return err
+		}
+		transactions := make([]models.RolloutTransaction, len(newRollout.Transactions()))
+		for i, transaction := range newRollout.Transactions() {
+			transactions[i] = *transaction.MarshalGorm(account.String(), newRollout.UUID())
+		}
+		return tx.Create(&transactions).Error
+	})
+}
+
+// GetRollout returns the rollout with the given UUID, implementing the Rollout.Repository interface.
+func (r *GormRolloutRepository) GetRollout(ctx context.Context, uuid string) (*rollout.Rollout, error) {
+	log.WithField("uuid", uuid).Debug("gorm get rollout")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rolloutModel models.Rollout
+	err = r.db.Where("account = ? AND uuid = ?", account.String(), uuid).First(&rolloutModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, rollout.ErrRolloutNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	var transactionModels []models.RolloutTransaction
+	err = r.db.Where("account = ? AND rollout_uuid = ?", account.String(), uuid).Order("id").Find(&transactionModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalRollout(account, rolloutModel, transactionModels)
+}
+
+// UpdateTransaction updates the transaction of the device with the given UUID in the given rollout,
+// implementing the Rollout.Repository interface.
+// The update is conditioned on the status it was read with, so concurrent transitions don't overwrite each other.
+func (r *GormRolloutRepository) UpdateTransaction(ctx context.Context, uuid, deviceUUID string,
+	updateFn func(t *rollout.Transaction) (*rollout.Transaction, error)) error {
+	log.WithFields(log.Fields{"uuid": uuid, "device": deviceUUID}).Debug("gorm update rollout transaction")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var transactionModel models.RolloutTransaction
+		err := tx.Where("account = ? AND rollout_uuid = ? AND device_uuid = ?", account.String(), uuid, deviceUUID).
+			First(&transactionModel).Error
+		if err == gorm.ErrRecordNotFound {
+			return rollout.ErrTransactionNotFound
+		} else if err != nil {
+			return err
+		}
+		current, err := unmarshalTransaction(transactionModel)
+		if err != nil {
+			return err
+		}
+		updated, err := updateFn(&current)
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&models.RolloutTransaction{}).
+			Where("id = ? AND status = ?", transactionModel.ID, transactionModel.Status).
+			Select("status", "reason", "status_updated_at", "previous_image_uuid", "previous_image_version",
+				"restored").
+			Updates(updated.MarshalGorm(account.String(), uuid))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 { // moved by someone else since it was read
+			return rollout.ErrInvalidTransition
+		}
+		// the rollout was updated along with its transaction
+		return tx.Model(&models.Rollout{}).Where("account = ? AND uuid = ?", account.String(), uuid).
+			Update("updated_at", time.Now().UTC().Truncate(time.Microsecond)).Error
+	})
+}
+
+// UpdateRollout updates the state of the rollout with the given UUID along with the transactions it moved,
+// implementing the Rollout.Repository interface.
+// Like UpdateTransaction, each moved transaction is conditioned on the status it was read with,
+// and the rollout on the state and stage it was read with.
+func (r *GormRolloutRepository) UpdateRollout(ctx context.Context, uuid string,
+	updateFn func(r *rollout.Rollout) (*rollout.Rollout, error)) error {
+	log.WithField("uuid", uuid).Debug("gorm update rollout")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var rolloutModel models.Rollout
+		err := tx.Where("account = ? AND uuid = ?", account.String(), uuid).First(&rolloutModel).Error
+		if err == gorm.ErrRecordNotFound {
+			return rollout.ErrRolloutNotFound
+		} else if err != nil {
+			return err
+		}
+		var transactionModels []models.RolloutTransaction
+		err = tx.Where("account = ? AND rollout_uuid = ?", account.String(), uuid).Order("id").Find(&transactionModels).Error
+		if err != nil {
+			return err
+		}
+		current, err := unmarshalRollout(account, rolloutModel, transactionModels)
+		if err != nil {
+			return err
+		}
+		updated, err := updateFn(current)
+		if err != nil {
+			return err
+		}
+		for i, transaction := range updated.Transactions() {
+			if transaction.Status().String() == transactionModels[i].Status {
+				continue
+			}
+			result := tx.Model(&models.RolloutTransaction{}).
+				Where("id = ? AND status = ?", transactionModels[i].ID, transactionModels[i].Status).
+				Select("status", "reason", "status_updated_at", "previous_image_uuid", "previous_image_version",
+					"restored").
+				Updates(transaction.MarshalGorm(account.String(), uuid))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 { // moved by someone else since it was read
+				return rollout.ErrInvalidTransition
+			}
+		}
+		updated.Touch(time.Now())
+		result := tx.Model(&models.Rollout{}).
+			Where("id = ? AND state = ? AND stage = ?", rolloutModel.ID, rolloutModel.State, rolloutModel.Stage).
+			Select("state", "stage", "accepted_failures", "updated_at").
+			Updates(updated.MarshalGorm())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 { // changed by someone else since it was read
+			return rollout.ErrInvalidStateChange
+		}
+		return nil
+	})
+}
+
+// GetActiveRollouts returns the rollouts of the image with the given UUID that are not final,
+// implementing the Rollout.Repository interface.
+func (r *GormRolloutRepository) GetActiveRollouts(ctx context.Context, imageUUID string) ([]*rollout.Rollout, error) {
+	log.WithField("uuid", imageUUID).Debug("gorm get active rollouts")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rolloutModels []models.Rollout
+	err = r.db.Where("account = ? AND image_uuid = ? AND state NOT IN ?", account.String(), imageUUID,
+		[]string{rollout.Aborted.String(), rollout.Completed.String()}).Order("created_at").Find(&rolloutModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return getRolloutTransactions(r.db, rolloutModels)
+}
+
+// ClaimRollouts leases the rollouts of all accounts that aren't settled and no replica follows until the given time,
+// implementing the Rollout.Repository interface.
+// A rollout isn't settled while it is running, a device applies its update or a failed device is to be rolled back.
+// Each rollout is leased on its own, one claimed by another replica since it was read is skipped.
+func (r *GormRolloutRepository) ClaimRollouts(ctx context.Context, lease string,
+	until time.Time) ([]*rollout.Rollout, error) {
+	log.Debug("gorm claim rollouts")
+	now := time.Now().UTC()
+	unsettled := r.db.Model(&models.RolloutTransaction{}).Select("1").
+		Where("rollout_transactions.account = rollouts.account AND rollout_transactions.rollout_uuid = rollouts.uuid").
+		Where(r.db.Where("status IN ?", []string{rollout.Dispatched.String(), rollout.Applying.String()}).
+			Or("status = ? AND restored = ? AND rollouts.rollback_failed = ?", rollout.Failed.String(), false, true))
+	var rolloutModels []models.Rollout
+	err := r.db.Where("lease_token = '' OR lease_expires_at < ?", now).
+		Where(r.db.Where("state = ?", rollout.Running.String()).Or("EXISTS (?)", unsettled)).
+		Order("created_at").Find(&rolloutModels).Error
+	if err != nil {
+		return nil, err
+	}
+	claimed := rolloutModels[:0]
+	for _, rolloutModel := range rolloutModels {
+		ok, err := claimRollout(r.db, rolloutModel.ID, lease, until, now)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			claimed = append(claimed, rolloutModel)
+		}
+	}
+	return getRolloutTransactions(r.db, claimed)
+}
+
+// ClaimRollout leases the rollout with the given UUID until the given time, implementing the Rollout.Repository
+// interface. The lease is renewed if it holds it already, rollout.ErrRolloutLeased is returned if another replica
+// holds a lease that didn't expire.
+func (r *GormRolloutRepository) ClaimRollout(ctx context.Context, uuid, lease string, until time.Time) error {
+	log.WithField("uuid", uuid).Debug("gorm claim rollout")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	var rolloutModel models.Rollout
+	err = r.db.Where("account = ? AND uuid = ?", account.String(), uuid).First(&rolloutModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return rollout.ErrRolloutNotFound
+	} else if err != nil {
+		return err
+	}
+	ok, err := claimRollout(r.db, rolloutModel.ID, lease, until, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return rollout.ErrRolloutLeased
+	}
+	return nil
+}
+
+// ReleaseRollout releases the lease on the rollout with the given UUID, implementing the Rollout.Repository
+// interface. A lease another replica took over since is kept.
+func (r *GormRolloutRepository) ReleaseRollout(ctx context.Context, uuid, lease string) error {
+	log.WithField("uuid", uuid).Debug("gorm release rollout")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	// the lease isn't part of the state of the rollout, it doesn't touch its updated_at
+	return r.db.Model(&models.Rollout{}).
+		Where("account = ? AND uuid = ? AND lease_token = ?", account.String(), uuid, lease).
+		UpdateColumns(map[string]interface{}{"lease_token": "", "lease_expires_at": time.Time{}}).Error
+}
+
+// claimRollout leases the rollout with the given ID until the given time, as long as its lease is free, expired
+// or the given one, returning whether it did.
+func claimRollout(db *gorm.DB, id uint, lease string, until, now time.Time) (bool, error) {
+	// the lease isn't part of the state of the rollout, it doesn't touch its updated_at
+	result := db.Model(&models.Rollout{}).
+		Where("id = ? AND (lease_token IN ? OR lease_expires_at < ?)", id, []string{"", lease}, now).
+		UpdateColumns(map[string]interface{}{"lease_token": lease, "lease_expires_at": until.UTC()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// getRolloutTransactions loads the transactions of the rollout models, returning them as domain rollouts.
+func getRolloutTransactions(db *gorm.DB, rolloutModels []models.Rollout) ([]*rollout.Rollout, error) {
+	rollouts := make([]*rollout.Rollout, len(rolloutModels))
+	for i, rolloutModel := range rolloutModels {
+		account, err := common.NewAccount(rolloutModel.Account)
+		if err != nil {
+			return nil, err
+		}
+		var transactionModels []models.RolloutTransaction
+		err = db.Where("account = ? AND rollout_uuid = ?", rolloutModel.Account, rolloutModel.UUID).
+			Order("id").Find(&transactionModels).Error
+		if err != nil {
+			return nil, err
+		}
+		if rollouts[i], err = unmarshalRollout(account, rolloutModel, transactionModels); err != nil {
+			return nil, err
+		}
+	}
+	return rollouts, nil
+}
+
+// unmarshalRollout unmarshals a rollout model along with its transaction models into a domain rollout.
+func unmarshalRollout(account common.Account, rolloutModel models.Rollout,
+	transactionModels []models.RolloutTransaction) (*rollout.Rollout, error) {
+	var err error
+	transactions := make([]rollout.Transaction, len(transactionModels))
+	for i, transactionModel := range transactionModels {
+		if transactions[i], err = unmarshalTransaction(transactionModel); err != nil {
+			return nil, err
+		}
+	}
+	stages := make([]uint, len(rolloutModel.Stages))
+	for i, stage := range rolloutModel.Stages {
+		stages[i] = uint(stage)
+	}
+	plan, err := rollout.NewPlan(stages, rolloutModel.SoakTime, rolloutModel.FailureThreshold, rolloutModel.RollbackFailed)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := rollout.UnmarshalRolloutFromDatabase(common.ContextWithAccount(context.Background(), account),
+		rolloutModel.UUID, rolloutModel.ImageUUID, rolloutModel.ImageVersion, transactions,
+		plan, rolloutModel.State, int(rolloutModel.Stage), int(rolloutModel.AcceptedFailures),
+		rolloutModel.CreatedAt, rolloutModel.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// unmarshalTransaction unmarshals a transaction model into a domain transaction.
+func unmarshalTransaction(transactionModel models.RolloutTransaction) (rollout.Transaction, error) {
+	return rollout.UnmarshalTransactionFromDatabase(transactionModel.DeviceUUID, transactionModel.Status,
+		transactionModel.Reason, transactionModel.PreviousImageUUID, transactionModel.PreviousImageVersion,
+		transactionModel.Restored, transactionModel.StatusUpdatedAt)
+}
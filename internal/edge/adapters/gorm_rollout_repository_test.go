@@ -0,0 +1,387 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	"github.com/google/uuid"
+	"github.com/redhatinsights/edge-api/config"
+)
+
+func TestGormRolloutRepository_GetRollout(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormRolloutRepository(gormClient)
+	devices := []string{uuid.NewString(), uuid.NewString()}
+	newRollout, err := rollout.NewRolloutWithContext(context.Background(), uuid.NewString(), validImage.UUID(), 1,
+		devices, rollout.DefaultPlan())
+	if err != nil {
+		t.Fatalf("failed to create rollout: %s", err)
+	}
+	if err := repository.CreateRollout(context.Background(), &newRollout); err != nil {
+		t.Fatalf("failed to store rollout: %s", err)
+	}
+	tests := []struct {
+		name    string
+		uuid    string
+		wantErr error
+	}{
+		{
+			name:    "should get the rollout with its transactions",
+			uuid:    newRollout.UUID(),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, unknown rollout",
+			uuid:    uuid.NewString(),
+			wantErr: rollout.ErrRolloutNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.GetRollout(context.Background(), tt.uuid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormRolloutRepository.GetRollout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Assignment() != newRollout.Assignment() || !got.CreatedAt().Equal(newRollout.CreatedAt()) {
+				t.Errorf("GormRolloutRepository.GetRollout() = %v, want %v", got, newRollout)
+			}
+			if !reflect.DeepEqual(got.Plan(), newRollout.Plan()) || got.State() != rollout.Running {
+				t.Errorf("GormRolloutRepository.GetRollout() plan = %v in %v, want %v running",
+					got.Plan(), got.State(), newRollout.Plan())
+			}
+			if got.Progress().Total() != len(devices) || got.Progress().Count(rollout.Pending) != len(devices) {
+				t.Errorf("GormRolloutRepository.GetRollout() progress = %+v, want %d pending", got.Progress(), len(devices))
+			}
+		})
+	}
+}
+
+func TestGormRolloutRepository_UpdateTransaction(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormRolloutRepository(gormClient)
+	deviceUUID := uuid.NewString()
+	newRollout, _ := rollout.NewRolloutWithContext(context.Background(), uuid.NewString(), validImage.UUID(), 1,
+		[]string{deviceUUID}, rollout.DefaultPlan())
+	if err := repository.CreateRollout(context.Background(), &newRollout); err != nil {
+		t.Fatalf("failed to store rollout: %s", err)
+	}
+	previous, _ := device.NewAssignment(anotherValidImage.UUID(), 2)
+	dispatch := func(t *rollout.Transaction) (*rollout.Transaction, error) {
+		return t, t.Dispatch(previous, time.Now())
+	}
+	tests := []struct {
+		name       string
+		uuid       string
+		deviceUUID string
+		wantErr    error
+	}{
+		{
+			name:       "should dispatch the transaction",
+			uuid:       newRollout.UUID(),
+			deviceUUID: deviceUUID,
+			wantErr:    nil,
+		},
+		{
+			name:       "should fail, already dispatched",
+			uuid:       newRollout.UUID(),
+			deviceUUID: deviceUUID,
+			wantErr:    rollout.ErrInvalidTransition,
+		},
+		{
+			name:       "should fail, device not in rollout",
+			uuid:       newRollout.UUID(),
+			deviceUUID: uuid.NewString(),
+			wantErr:    rollout.ErrTransactionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := repository.UpdateTransaction(context.Background(), tt.uuid, tt.deviceUUID, dispatch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormRolloutRepository.UpdateTransaction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, _ := repository.GetRollout(context.Background(), tt.uuid)
+			transaction, _ := got.Transaction(tt.deviceUUID)
+			if transaction.Status() != rollout.Dispatched || transaction.Previous() != previous {
+				t.Errorf("GormRolloutRepository.UpdateTransaction() = %v, want dispatched from %v", transaction, previous)
+			}
+		})
+	}
+}
+
+func TestGormRolloutRepository_UpdateRollout(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormRolloutRepository(gormClient)
+	plan, _ := rollout.NewPlan([]uint{50, 100}, time.Hour, 0, true)
+	newRollout, _ := rollout.NewRolloutWithContext(context.Background(), uuid.NewString(), validImage.UUID(), 1,
+		[]string{uuid.NewString(), uuid.NewString()}, plan)
+	if err := repository.CreateRollout(context.Background(), &newRollout); err != nil {
+		t.Fatalf("failed to store rollout: %s", err)
+	}
+	pause := func(r *rollout.Rollout) (*rollout.Rollout, error) {
+		return r, r.Pause()
+	}
+	abort := func(r *rollout.Rollout) (*rollout.Rollout, error) {
+		return r, r.Abort(time.Now())
+	}
+	tests := []struct {
+		name       string
+		uuid       string
+		updateFn   func(r *rollout.Rollout) (*rollout.Rollout, error)
+		wantState  rollout.State
+		wantFailed int
+		wantErr    error
+	}{
+		{
+			name:      "should pause the rollout",
+			uuid:      newRollout.UUID(),
+			updateFn:  pause,
+			wantState: rollout.Paused,
+			wantErr:   nil,
+		},
+		{
+			name:     "should fail, already paused",
+			uuid:     newRollout.UUID(),
+			updateFn: pause,
+			wantErr:  rollout.ErrInvalidStateChange,
+		},
+		{
+			name:       "should abort the rollout along with its transactions",
+			uuid:       newRollout.UUID(),
+			updateFn:   abort,
+			wantState:  rollout.Aborted,
+			wantFailed: 2,
+			wantErr:    nil,
+		},
+		{
+			name:     "should fail, unknown rollout",
+			uuid:     uuid.NewString(),
+			updateFn: pause,
+			wantErr:  rollout.ErrRolloutNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := repository.UpdateRollout(context.Background(), tt.uuid, tt.updateFn)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormRolloutRepository.UpdateRollout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, _ := repository.GetRollout(context.Background(), tt.uuid)
+			if got.State() != tt.wantState || got.Progress().Count(rollout.Failed) != tt.wantFailed {
+				t.Errorf("GormRolloutRepository.UpdateRollout() = %v with %d failed, want %v with %d failed",
+					got.State(), got.Progress().Count(rollout.Failed), tt.wantState, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestGormRolloutRepository_GetActiveRollouts(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormRolloutRepository(gormClient)
+	var rollouts []rollout.Rollout
+	for _, imageUUID := range []string{validImage.UUID(), validImage.UUID(), uuid.NewString()} {
+		newRollout, _ := rollout.NewRolloutWithContext(context.Background(), uuid.NewString(), imageUUID, 1,
+			[]string{uuid.NewString()}, rollout.DefaultPlan())
+		if err := repository.CreateRollout(context.Background(), &newRollout); err != nil {
+			t.Fatalf("failed to store rollout: %s", err)
+		}
+		rollouts = append(rollouts, newRollout)
+	}
+	err := repository.UpdateRollout(context.Background(), rollouts[1].UUID(), func(r *rollout.Rollout) (*rollout.Rollout, error) {
+		return r, r.Abort(time.Now())
+	})
+	if err != nil {
+		t.Fatalf("failed to abort rollout: %s", err)
+	}
+	got, err := repository.GetActiveRollouts(context.Background(), validImage.UUID())
+	if err != nil {
+		t.Fatalf("GormRolloutRepository.GetActiveRollouts() error = %v", err)
+	}
+	if len(got) != 1 || got[0].UUID() != rollouts[0].UUID() || len(got[0].Transactions()) != 1 {
+		t.Errorf("GormRolloutRepository.GetActiveRollouts() = %v, want only %v with its transaction", got, rollouts[0])
+	}
+}
+
+func TestGormRolloutRepository_ClaimRollouts(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	config.Get().Auth = true
+	defer func() { config.Get().Auth = false }()
+	repository := NewGormRolloutRepository(gormClient)
+	account, _ := common.NewAccount("0000001")
+	otherAccount, _ := common.NewAccount("0000002")
+	accountCtx := common.ContextWithAccount(context.Background(), account)
+	rollbackPlan, _ := rollout.NewPlan([]uint{100}, 0, 100, true)
+	var rollouts []rollout.Rollout
+	for _, ctx := range []context.Context{
+		accountCtx,
+		accountCtx,
+		accountCtx,
+		common.ContextWithAccount(context.Background(), otherAccount),
+	} {
+		newRollout, _ := rollout.NewRolloutWithContext(ctx, uuid.NewString(), validImage.UUID(), 1,
+			[]string{uuid.NewString()}, rollbackPlan)
+		if err := repository.CreateRollout(ctx, &newRollout); err != nil {
+			t.Fatalf("failed to store rollout: %s", err)
+		}
+		rollouts = append(rollouts, newRollout)
+	}
+	// the first aborted rollout never reached its device, the second one has its device to roll back
+	err := repository.UpdateTransaction(accountCtx, rollouts[2].UUID(), rollouts[2].StageDevices()[0],
+		func(tr *rollout.Transaction) (*rollout.Transaction, error) {
+			return tr, tr.Dispatch(device.Assignment{}, time.Now())
+		})
+	if err != nil {
+		t.Fatalf("failed to dispatch transaction: %s", err)
+	}
+	for _, aborted := range rollouts[1:3] {
+		err := repository.UpdateRollout(accountCtx, aborted.UUID(), func(r *rollout.Rollout) (*rollout.Rollout, error) {
+			return r, r.Abort(time.Now())
+		})
+		if err != nil {
+			t.Fatalf("failed to abort rollout: %s", err)
+		}
+	}
+	claim := func(lease string, until time.Time) []string {
+		got, err := repository.ClaimRollouts(context.Background(), lease, until)
+		if err != nil {
+			t.Fatalf("GormRolloutRepository.ClaimRollouts() error = %v", err)
+		}
+		var gotUUIDs []string
+		for _, r := range got {
+			gotUUIDs = append(gotUUIDs, r.UUID())
+			if len(r.Transactions()) != 1 {
+				t.Errorf("GormRolloutRepository.ClaimRollouts() = %v, want it with its transaction", r)
+			}
+		}
+		return gotUUIDs
+	}
+	want := []string{rollouts[0].UUID(), rollouts[2].UUID(), rollouts[3].UUID()}
+	if got := claim("expired-lease", time.Now().Add(-time.Minute)); !reflect.DeepEqual(got, want) {
+		t.Errorf("GormRolloutRepository.ClaimRollouts() = %v, want %v of both accounts", got, want)
+	}
+	if got := claim("lease", time.Now().Add(time.Minute)); !reflect.DeepEqual(got, want) {
+		t.Errorf("GormRolloutRepository.ClaimRollouts() = %v, want %v once their lease expired", got, want)
+	}
+	if got := claim("other-lease", time.Now().Add(time.Minute)); got != nil {
+		t.Errorf("GormRolloutRepository.ClaimRollouts() = %v, want none while they are leased", got)
+	}
+	// once its device was rolled back and its lease released, the aborted rollout is settled
+	err = repository.UpdateTransaction(accountCtx, rollouts[2].UUID(), rollouts[2].StageDevices()[0],
+		func(tr *rollout.Transaction) (*rollout.Transaction, error) {
+			return tr, tr.Restore()
+		})
+	if err != nil {
+		t.Fatalf("failed to restore transaction: %s", err)
+	}
+	for _, claimed := range want[:2] {
+		if err := repository.ReleaseRollout(accountCtx, claimed, "lease"); err != nil {
+			t.Fatalf("GormRolloutRepository.ReleaseRollout() error = %v", err)
+		}
+	}
+	if got, want := claim("other-lease", time.Now().Add(time.Minute)), want[:1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("GormRolloutRepository.ClaimRollouts() = %v, want only the running %v", got, want)
+	}
+}
+
+func TestGormRolloutRepository_ClaimRollout(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormRolloutRepository(gormClient)
+	ctx := context.Background()
+	newRollout, _ := rollout.NewRolloutWithContext(ctx, uuid.NewString(), validImage.UUID(), 1,
+		[]string{uuid.NewString()}, rollout.DefaultPlan())
+	if err := repository.CreateRollout(ctx, &newRollout); err != nil {
+		t.Fatalf("failed to store rollout: %s", err)
+	}
+	stored, _ := repository.GetRollout(ctx, newRollout.UUID())
+	until := time.Now().Add(time.Minute)
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{
+			name:    "should claim the rollout",
+			call:    func() error { return repository.ClaimRollout(ctx, newRollout.UUID(), "lease", until) },
+			wantErr: nil,
+		},
+		{
+			name:    "should renew the lease it holds",
+			call:    func() error { return repository.ClaimRollout(ctx, newRollout.UUID(), "lease", until) },
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, leased by another replica",
+			call:    func() error { return repository.ClaimRollout(ctx, newRollout.UUID(), "other-lease", until) },
+			wantErr: rollout.ErrRolloutLeased,
+		},
+		{
+			name:    "should keep the lease, released by another replica",
+			call:    func() error { return repository.ReleaseRollout(ctx, newRollout.UUID(), "other-lease") },
+			wantErr: nil,
+		},
+		{
+			name:    "should still fail, leased by another replica",
+			call:    func() error { return repository.ClaimRollout(ctx, newRollout.UUID(), "other-lease", until) },
+			wantErr: rollout.ErrRolloutLeased,
+		},
+		{
+			name:    "should release the lease it holds",
+			call:    func() error { return repository.ReleaseRollout(ctx, newRollout.UUID(), "lease") },
+			wantErr: nil,
+		},
+		{
+			name:    "should claim the released rollout",
+			call:    func() error { return repository.ClaimRollout(ctx, newRollout.UUID(), "other-lease", until) },
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, rollout not found",
+			call:    func() error { return repository.ClaimRollout(ctx, uuid.NewString(), "lease", until) },
+			wantErr: rollout.ErrRolloutNotFound,
+		},
+	}
+	for _, tt := range tests { // in order, each step leases the rollout the previous ones left
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != tt.wantErr {
+				t.Errorf("GormRolloutRepository lease error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	// the lease isn't part of the state of the rollout
+	if got, _ := repository.GetRollout(ctx, newRollout.UUID()); !got.UpdatedAt().Equal(stored.UpdatedAt()) {
+		t.Errorf("GormRolloutRepository.ClaimRollout() updated at %v, want %v", got.UpdatedAt(), stored.UpdatedAt())
+	}
+}
@@ -0,0 +1,245 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormSearchRepository is a GORM implementation of the Image.SearchRepository interface,
+// searching the image_search index the image repository keeps.
+type GormSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSearchRepository returns a new GORM implementation of the Image.SearchRepository interface.
+func NewGormSearchRepository(db *gorm.DB) *GormSearchRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormSearchRepository{db: db}
+}
+
+// searchHit is an image matching a search, along with its rank and fields marking the matching words.
+type searchHit struct {
+	ImageID     uint
+	Rank        float64
+	Name        string
+	Description string
+	Tags        string
+	Packages    string
+	Repos       string
+}
+
+// highlights returns the fields of the hit by search field.
+func (h searchHit) highlights() map[image.SearchField]string {
+	return map[image.SearchField]string{
+		image.SearchFieldName:        h.Name,
+		image.SearchFieldDescription: h.Description,
+		image.SearchFieldTags:        h.Tags,
+		image.SearchFieldPackages:    h.Packages,
+		image.SearchFieldRepos:       h.Repos,
+	}
+}
+
+// SearchImages returns the images matching the query, the most relevant first, implementing the
+// Image.SearchRepository interface. The index is searched the way the database supports, see searchUp.
+func (r *GormSearchRepository) SearchImages(ctx context.Context, query image.SearchQuery) ([]*image.SearchResult, error) {
+	log.WithField("query", query.String()).Debug("gorm search images")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var hits []searchHit
+	switch {
+	case r.db.Dialector.Name() == "postgres":
+		hits, err = searchPostgres(r.db, account, query)
+	case isFTS5Table(r.db, "image_search"):
+		hits, err = searchFTS5(r.db, account, query)
+	default:
+		hits, err = searchTable(r.db, account, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return searchResults(r.db, account, hits)
+}
+
+// searchPostgres searches the tsvector of the images, each term of the query matching the start of words.
+func searchPostgres(db *gorm.DB, account common.Account, query image.SearchQuery) ([]searchHit, error) {
+	terms := query.Terms()
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	// the options are bound, as the marks are control characters
+	options := "StartSel=" + image.HighlightStartMark + ", StopSel=" + image.HighlightEndMark + ", HighlightAll=true"
+	headline := func(column string) string {
+		return "ts_headline('english', image_search." + column + ", q, @options) AS " + column
+	}
+	var hits []searchHit
+	err := db.Raw("SELECT image_search.image_id, ts_rank(image_search.document, q) AS rank, "+
+		headline("name")+", "+headline("description")+", "+headline("tags")+", "+
+		headline("packages")+", "+headline("repos")+" "+
+		"FROM image_search JOIN images ON images.id = image_search.image_id, to_tsquery('english', @query) q "+
+		"WHERE image_search.account = @account AND images.deleted_at IS NULL AND image_search.document @@ q "+
+		"ORDER BY rank DESC, image_search.image_id LIMIT @limit",
+		sql.Named("options", options), sql.Named("query", strings.Join(terms, " & ")),
+		sql.Named("account", account.String()), sql.Named("limit", query.Limit())).Scan(&hits).Error
+	return hits, err
+}
+
+// searchFTS5 searches the FTS5 table of the images, each term of the query matching the start of words.
+// Columns are weighted like the ones of the postgres tsvector.
+func searchFTS5(db *gorm.DB, account common.Account, query image.SearchQuery) ([]searchHit, error) {
+	terms := query.Terms()
+	for i, term := range terms {
+		terms[i] = `"` + term + `"*`
+	}
+	// the marks are bound, as they are control characters
+	highlight := func(column int, name string) string {
+		return "highlight(image_search, " + strconv.Itoa(column) + ", @start, @end) AS " + name
+	}
+	var hits []searchHit
+	err := db.Raw("SELECT image_search.image_id, -bm25(image_search, 0, 0, 1, 0.1, 0.4, 0.4, 0.2) AS rank, "+
+		highlight(2, "name")+", "+highlight(3, "description")+", "+highlight(4, "tags")+", "+
+		highlight(5, "packages")+", "+highlight(6, "repos")+" "+
+		"FROM image_search JOIN images ON images.id = image_search.image_id "+
+		"WHERE image_search MATCH @query AND image_search.account = @account AND images.deleted_at IS NULL "+
+		"ORDER BY rank DESC, image_search.image_id LIMIT @limit",
+		sql.Named("start", image.HighlightStartMark), sql.Named("end", image.HighlightEndMark),
+		sql.Named("query", strings.Join(terms, " AND ")), sql.Named("account", account.String()),
+		sql.Named("limit", query.Limit())).Scan(&hits).Error
+	return hits, err
+}
+
+// searchTable searches the plain table of the images of SQLite without FTS5. The rows containing every term
+// are matched, ranked and marked like the images of stores without a full-text index.
+func searchTable(db *gorm.DB, account common.Account, query image.SearchQuery) ([]searchHit, error) {
+	tx := db.Table("image_search").
+		Select("image_search.*").
+		Joins("JOIN images ON images.id = image_search.image_id").
+		Where("image_search.account = ? AND images.deleted_at IS NULL", account.String())
+	for _, term := range query.Terms() {
+		tx = tx.Where("LOWER(image_search.name || ' ' || image_search.description || ' ' || image_search.tags || ' ' || "+
+			"image_search.packages || ' ' || image_search.repos) LIKE ? ESCAPE '\\'", "%"+escapeLike(term)+"%")
+	}
+	var rows []searchHit
+	if err := tx.Order("image_search.image_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	hits := []searchHit{}
+	for _, row := range rows {
+		document := image.NewSearchDocument(row.Name, row.Description,
+			[]string{row.Tags}, []string{row.Packages}, []string{row.Repos})
+		rank, highlights, ok := document.Match(query)
+		if !ok {
+			continue
+		}
+		hit := searchHit{ImageID: row.ImageID, Rank: rank}
+		hit.Name, hit.Description = highlights[image.SearchFieldName], highlights[image.SearchFieldDescription]
+		hit.Tags, hit.Packages = highlights[image.SearchFieldTags], highlights[image.SearchFieldPackages]
+		hit.Repos = highlights[image.SearchFieldRepos]
+		hits = append(hits, hit)
+	}
+	// the most relevant first, like image.SortSearchResults
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Rank > hits[j].Rank })
+	if len(hits) > query.Limit() {
+		hits = hits[:query.Limit()]
+	}
+	return hits, nil
+}
+
+// searchResults loads the images of the hits of the account, returning them as search results in the same order.
+func searchResults(db *gorm.DB, account common.Account, hits []searchHit) ([]*image.SearchResult, error) {
+	results := []*image.SearchResult{}
+	if len(hits) == 0 {
+		return results, nil
+	}
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ImageID
+	}
+	var imageModels []models.Image
+	if err := db.Preload(clause.Associations).Where("account = ? AND id IN ?", account.String(), ids).
+		Find(&imageModels).Error; err != nil {
+		return nil, err
+	}
+	images, err := unmarshalImages(db, imageModels)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*image.Image, len(images))
+	for i, imageModel := range imageModels {
+		byID[imageModel.ID] = images[i]
+	}
+	for _, hit := range hits {
+		// the image may have been deleted since the index was searched
+		if found, ok := byID[hit.ImageID]; ok {
+			results = append(results, image.NewSearchResult(found, hit.Rank, hit.highlights()))
+		}
+	}
+	return results, nil
+}
+
+// isFTS5Table returns true if the table is an FTS5 table of SQLite.
+func isFTS5Table(db *gorm.DB, table string) bool {
+	if db.Dialector.Name() != "sqlite" {
+		return false
+	}
+	var statement string
+	if err := db.Raw("SELECT sql FROM sqlite_master WHERE name = ?", table).Scan(&statement).Error; err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(statement), "using fts5")
+}
+
+// indexImage replaces the row of the image with the given id in the full-text index with the text of its
+// stored fields, the names of its tags, packages and repositories, so it is called once they are saved.
+func indexImage(tx *gorm.DB, imageID uint) error {
+	var imageModel models.Image
+	if err := tx.Unscoped().Preload("Tags").Preload("Packages").First(&imageModel, imageID).Error; err != nil {
+		return err
+	}
+	var repos []string
+	if err := tx.Model(&models.ImageRepo{}).
+		Joins("JOIN repos ON repos.account = image_repos.account AND repos.uuid = image_repos.repo_uuid AND repos.deleted_at IS NULL").
+		Where("image_repos.account = ? AND image_repos.image_uuid = ?", imageModel.Account, imageModel.UUID).
+		Order("image_repos.id").Pluck("repos.name", &repos).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM image_search WHERE image_id = ?", imageID).Error; err != nil {
+		return err
+	}
+	return tx.Exec("INSERT INTO image_search (image_id, account, name, description, tags, packages, repos) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?)", imageID, imageModel.Account, imageModel.Name, imageModel.Description,
+		strings.Join(unmarshalTags(imageModel.Tags), " "), strings.Join(unmarshalPackages(imageModel.Packages), " "),
+		strings.Join(repos, " ")).Error
+}
+
+// indexRepoImages refreshes the rows of the images, deleted or not, referencing the repository of the account
+// with the given UUID in the full-text index, as they hold its name.
+func indexRepoImages(tx *gorm.DB, account common.Account, repoUUID string) error {
+	var imageIDs []uint
+	if err := tx.Unscoped().Model(&models.Image{}).
+		Where("account = ? AND uuid IN (?)", account.String(), tx.Model(&models.ImageRepo{}).
+			Select("image_uuid").Where("account = ? AND repo_uuid = ?", account.String(), repoUUID)).
+		Pluck("id", &imageIDs).Error; err != nil {
+		return err
+	}
+	for _, imageID := range imageIDs {
+		if err := indexImage(tx, imageID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+//go:build sqlite_fts5
+// +build sqlite_fts5
+
+package adapters
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+func TestGormSearchRepository_searchFTS5(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	if !isFTS5Table(gormClient, "image_search") {
+		t.Fatalf("image_search isn't an FTS5 table, though go-sqlite3 is built with FTS5")
+	}
+	images := NewGormImageRepository(gormClient)
+	kiosk := newTestSearchImage(t, "kiosk-berlin", "Kiosk of the Berlin warehouse", []string{"berlin"}, []string{"nginx"}, nil)
+	proxy := newTestSearchImage(t, "proxy", "Serves nginx", []string{"munich"}, []string{"vim"}, nil)
+	for _, img := range []*image.Image{&kiosk, &proxy} {
+		if err := images.CreateImage(context.Background(), img); err != nil {
+			t.Fatalf("failed to create image: %s", err)
+		}
+	}
+	mark := func(word string) string {
+		return image.HighlightStartMark + word + image.HighlightEndMark
+	}
+	tests := []struct {
+		name          string
+		text          string
+		want          []string
+		wantHighlight string
+	}{
+		{
+			name:          "should find the images, weighting the fields",
+			text:          "nginx",
+			want:          []string{"kiosk-berlin", "proxy"},
+			wantHighlight: "",
+		},
+		{
+			name:          "should find the images matching every term, as word starts",
+			text:          "berl kiosk",
+			want:          []string{"kiosk-berlin"},
+			wantHighlight: mark("kiosk") + "-" + mark("berlin"),
+		},
+		{
+			name: "should find nothing",
+			text: "paris",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := image.NewSearchQuery(tt.text, image.DefaultLimit)
+			if err != nil {
+				t.Fatalf("image.NewSearchQuery() error = %v", err)
+			}
+			hits, err := searchFTS5(gormClient, common.DefaultAccount, q)
+			if err != nil {
+				t.Fatalf("searchFTS5() error = %v", err)
+			}
+			results, err := searchResults(gormClient, common.DefaultAccount, hits)
+			if err != nil {
+				t.Fatalf("searchResults() error = %v", err)
+			}
+			if names := searchNames(results); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("searchFTS5() = %v, want %v", names, tt.want)
+			}
+			if tt.wantHighlight != "" && hits[0].Name != tt.wantHighlight {
+				t.Errorf("searchFTS5() name = %q, want %q", hits[0].Name, tt.wantHighlight)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package adapters
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+	"github.com/google/uuid"
+	"github.com/redhatinsights/edge-api/config"
+	"gorm.io/gorm"
+)
+
+// newTestSearchImage returns an image of the default account with the given fields to search.
+func newTestSearchImage(t *testing.T, name, description string, tags, packages, repos []string) image.Image {
+	img, err := image.NewImageWithContext(context.Background(), uuid.NewString(), name, description, "rhel8",
+		"success", "redhat-user", validImage.User().SSHKey(), []string{"rhel-edge-commit"}, tags, packages, 1, repos)
+	if err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	return img
+}
+
+// searchNames returns the names of the images found, in order.
+func searchNames(results []*image.SearchResult) []string {
+	names := []string{}
+	for _, result := range results {
+		names = append(names, result.Image().Name().String())
+	}
+	return names
+}
+
+func TestNewGormSearchRepository(t *testing.T) {
+	gormClient := &gorm.DB{}
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want *GormSearchRepository
+	}{
+		{
+			name: "should return a new gorm search repository",
+			args: args{
+				db: gormClient,
+			},
+			want: &GormSearchRepository{
+				db: gormClient,
+			},
+		},
+		{
+			name: "should panic if db is nil",
+			args: args{
+				db: nil,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				// recover from panic if one occured.
+				if recover() != nil && tt.want != nil {
+					t.Errorf("NewGormSearchRepository() panicked")
+				}
+			}()
+			t.Parallel()
+			if got := NewGormSearchRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewGormSearchRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormSearchRepository_SearchImages(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repos := NewGormRepoRepository(gormClient)
+	epel := newTestRepo(t, "epel")
+	if err := repos.CreateRepo(context.Background(), &epel); err != nil {
+		t.Fatalf("failed to store repo: %s", err)
+	}
+	images := NewGormImageRepository(gormClient)
+	kiosk := newTestSearchImage(t, "kiosk-berlin", "Kiosk of the Berlin warehouse",
+		[]string{"berlin"}, []string{"nginx"}, []string{epel.UUID()})
+	proxy := newTestSearchImage(t, "proxy", "Serves nginx", []string{"munich"}, []string{"vim"}, nil)
+	munich := newTestSearchImage(t, "munich-kiosk", "", []string{"munich"}, []string{"vim"}, nil)
+	for _, img := range []*image.Image{&kiosk, &proxy, &munich} {
+		if err := images.CreateImage(context.Background(), img); err != nil {
+			t.Fatalf("failed to create image: %s", err)
+		}
+	}
+	repository := NewGormSearchRepository(gormClient)
+
+	tests := []struct {
+		name    string
+		text    string
+		setup   func() error
+		want    []string
+		wantErr bool
+		auth    bool
+	}{
+		{
+			name: "should find the images, the most relevant first",
+			text: "nginx",
+			want: []string{"kiosk-berlin", "proxy"},
+		},
+		{
+			name: "should find the images matching every word, as word starts",
+			text: "Berl KIOSK",
+			want: []string{"kiosk-berlin"},
+		},
+		{
+			name: "should find the images by the names of their repositories",
+			text: "epel",
+			want: []string{"kiosk-berlin"},
+		},
+		{
+			name: "should find the images by the new name of their repositories",
+			text: "mirror",
+			setup: func() error {
+				return repos.UpdateRepo(context.Background(), epel.UUID(), func(r *repo.Repo) (*repo.Repo, error) {
+					return r, r.Update("epel-mirror", r.BaseURL(), r.GPGKey(), r.GPGCheck(), r.Priority())
+				})
+			},
+			want: []string{"kiosk-berlin"},
+		},
+		{
+			name: "should find the updated images",
+			text: "munich",
+			setup: func() error {
+				return images.UpdateImage(context.Background(), kiosk.UUID(), func(current *image.Image) (*image.Image, error) {
+					current.SetNameAndDesc(current.Name(), "Kiosk of the Munich warehouse")
+					return current, nil
+				})
+			},
+			want: []string{"munich-kiosk", "proxy", "kiosk-berlin"},
+		},
+		{
+			name: "should not find the deleted images",
+			text: "munich",
+			setup: func() error {
+				return images.DeleteImage(context.Background(), munich.UUID())
+			},
+			want: []string{"proxy", "kiosk-berlin"},
+		},
+		{
+			name: "should find nothing",
+			text: "paris",
+			want: []string{},
+		},
+		{
+			name:    "should fail to search the images, bad account",
+			text:    "nginx",
+			wantErr: true,
+			auth:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config.Get().Auth = tt.auth
+			defer func() {
+				config.Get().Auth = false
+			}()
+			if tt.setup != nil {
+				if err := tt.setup(); err != nil {
+					t.Fatalf("failed to set up: %s", err)
+				}
+			}
+			q, err := image.NewSearchQuery(tt.text, image.DefaultLimit)
+			if err != nil {
+				t.Fatalf("image.NewSearchQuery() error = %v", err)
+			}
+			got, err := repository.SearchImages(context.Background(), q)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GormSearchRepository.SearchImages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if names := searchNames(got); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("GormSearchRepository.SearchImages() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormSearchRepository_SearchImages_Highlights(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	images := NewGormImageRepository(gormClient)
+	kiosk := newTestSearchImage(t, "kiosk-berlin", "Kiosk of the <i>Berlin</i> warehouse", []string{"berlin"}, []string{"nginx"}, nil)
+	if err := images.CreateImage(context.Background(), &kiosk); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	q, err := image.NewSearchQuery("berlin", image.DefaultLimit)
+	if err != nil {
+		t.Fatalf("image.NewSearchQuery() error = %v", err)
+	}
+	got, err := NewGormSearchRepository(gormClient).SearchImages(context.Background(), q)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("GormSearchRepository.SearchImages() = %v, %v, want the image", got, err)
+	}
+	// only the fields matching are highlighted, escaped
+	highlights := got[0].Highlights()
+	want := map[image.SearchField]string{
+		image.SearchFieldName:        "kiosk-<b>berlin</b>",
+		image.SearchFieldDescription: "Kiosk of the &lt;i&gt;<b>Berlin</b>&lt;/i&gt; warehouse",
+		image.SearchFieldTags:        "<b>berlin</b>",
+	}
+	if !reflect.DeepEqual(highlights, want) {
+		t.Errorf("GormSearchRepository.SearchImages() highlights = %v, want %v", highlights, want)
+	}
+	if got[0].Image().UUID() != kiosk.UUID() || strings.Contains(got[0].Image().Description(), image.HighlightStart) {
+		t.Errorf("GormSearchRepository.SearchImages() image = %v, want the stored image", got[0].Image())
+	}
+}
@@ -0,0 +1,287 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormVersionRepository is a GORM implementation of the Image.VersionRepository interface.
+type GormVersionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormVersionRepository returns a new GORM implementation of the Image.VersionRepository interface.
+func NewGormVersionRepository(db *gorm.DB) *GormVersionRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormVersionRepository{db: db}
+}
+
+// GetVersions returns the snapshots of all stored versions of an image, implementing the Image.VersionRepository interface.
+func (r *GormVersionRepository) GetVersions(ctx context.Context, uuid string) ([]*image.Snapshot, error) {
+	log.WithField("uuid", uuid).Debug("gorm get versions")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var versionModels []models.ImageVersion
+	if err := r.db.Where("account = ? AND image_uuid = ?", account.String(), uuid).
+		Order("version desc").Find(&versionModels).Error; err != nil {
+		return nil, err
+	}
+	return unmarshalSnapshots(ctx, versionModels)
+}
+
+// GetVersion returns the snapshot of a stored version of an image, implementing the Image.VersionRepository interface.
+func (r *GormVersionRepository) GetVersion(ctx context.Context, uuid string, version uint) (*image.Snapshot, error) {
+	log.WithFields(log.Fields{"uuid": uuid, "version": version}).Debug("gorm get version")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var versionModel models.ImageVersion
+	err = r.db.Where("account = ? AND image_uuid = ? AND version = ?", account.String(), uuid, version).
+		First(&versionModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, image.ErrVersionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return unmarshalSnapshot(ctx, versionModel)
+}
+
+// GetVersionByCommit returns the snapshot of the version built into the given ostree commit,
+// implementing the Image.VersionRepository interface.
+func (r *GormVersionRepository) GetVersionByCommit(ctx context.Context, commit image.Commit) (*image.Snapshot, error) {
+	log.WithField("commit", commit.String()).Debug("gorm get version by commit")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if commit.IsZero() {
+		return nil, image.ErrVersionNotFound
+	}
+	var versionModel models.ImageVersion
+	// a struct condition quotes the commit column, a keyword in some databases
+	err = r.db.Where(&models.ImageVersion{Account: account.String(), Commit: commit.String()}).
+		First(&versionModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, image.ErrVersionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return unmarshalSnapshot(ctx, versionModel)
+}
+
+// GetChildren returns the snapshots of versions of other images derived from an image,
+// implementing the Image.VersionRepository interface.
+func (r *GormVersionRepository) GetChildren(ctx context.Context, uuid string) ([]*image.Snapshot, error) {
+	log.WithField("uuid", uuid).Debug("gorm get children")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var versionModels []models.ImageVersion
+	if err := r.db.Where("account = ? AND parent_uuid = ? AND image_uuid <> ?", account.String(), uuid, uuid).
+		Order("created_at").Find(&versionModels).Error; err != nil {
+		return nil, err
+	}
+	return unmarshalSnapshots(ctx, versionModels)
+}
+
+// UpdateVersion updates the snapshot of a version of an image, implementing the Image.VersionRepository interface.
+// Only the lock and the commit of a stored version can be changed, its content is immutable.
+func (r *GormVersionRepository) UpdateVersion(ctx context.Context, uuid string, version uint,
+	updateFn func(s *image.Snapshot) (*image.Snapshot, error)) error {
+	log.WithFields(log.Fields{"uuid": uuid, "version": version}).Debug("gorm update version")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	var versionModel models.ImageVersion
+	err = r.db.Where("account = ? AND image_uuid = ? AND version = ?", account.String(), uuid, version).
+		First(&versionModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return image.ErrVersionNotFound
+	} else if err != nil {
+		return err
+	}
+	snapshot, err := unmarshalSnapshot(ctx, versionModel)
+	if err != nil {
+		return err
+	}
+	updatedSnapshot, err := updateFn(snapshot)
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&versionModel).Select("locked", "commit").Updates(map[string]interface{}{
+		"locked": updatedSnapshot.Locked(),
+		"commit": updatedSnapshot.Commit().String(),
+	}).Error
+}
+
+// DeleteVersions deletes versions of an image, implementing the Image.VersionRepository interface.
+// The image is locked, then the versions are only deleted if they pass the in use check carried by the context.
+func (r *GormVersionRepository) DeleteVersions(ctx context.Context, uuid string, versions ...image.Version) error {
+	log.WithFields(log.Fields{"uuid": uuid, "versions": versions}).Debug("gorm delete versions")
+	if len(versions) == 0 {
+		return nil
+	}
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	numbers := make([]uint, len(versions))
+	for i, version := range versions {
+		numbers[i] = version.Uint()
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("account = ? AND uuid = ?", account.String(), uuid).Find(&[]models.Image{}).Error; err != nil {
+			return err
+		}
+		// locked versions are never deleted, even if asked to
+		var locked []uint
+		if err := tx.Model(&models.ImageVersion{}).
+			Where("account = ? AND image_uuid = ? AND version IN ? AND locked = ?", account.String(), uuid, numbers, true).
+			Pluck("version", &locked).Error; err != nil {
+			return err
+		}
+		unlocked := unlockedVersions(versions, locked)
+		if len(unlocked) == 0 {
+			return nil
+		}
+		if err := image.CheckNotInUse(ctx, unlocked...); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().
+			Where("account = ? AND image_uuid = ? AND version IN ? AND locked = ?", account.String(), uuid, numbers, false).
+			Delete(&models.ImageVersion{}).Error; err != nil {
+			return err
+		}
+		// the metadata changes are only meaningful along with their version
+		query := tx.Unscoped().Where("account = ? AND image_uuid = ? AND version IN ?", account.String(), uuid, numbers)
+		if len(locked) > 0 {
+			query = query.Where("version NOT IN ?", locked)
+		}
+		return query.Delete(&models.ImageMetadataChange{}).Error
+	})
+}
+
+// unlockedVersions returns the given versions but the locked ones.
+func unlockedVersions(versions []image.Version, locked []uint) []image.Version {
+	var unlocked []image.Version
+	for _, version := range versions {
+		isLocked := false
+		for _, number := range locked {
+			isLocked = isLocked || version.Uint() == number
+		}
+		if !isLocked {
+			unlocked = append(unlocked, version)
+		}
+	}
+	return unlocked
+}
+
+// GetMetadataChanges returns the metadata changes made within the versions of an image,
+// implementing the Image.VersionRepository interface.
+func (r *GormVersionRepository) GetMetadataChanges(ctx context.Context, uuid string) ([]image.MetadataChange, error) {
+	log.WithField("uuid", uuid).Debug("gorm get metadata changes")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var changeModels []models.ImageMetadataChange
+	if err := r.db.Where("account = ? AND image_uuid = ?", account.String(), uuid).
+		Order("created_at").Find(&changeModels).Error; err != nil {
+		return nil, err
+	}
+	changes := make([]image.MetadataChange, len(changeModels))
+	for i, changeModel := range changeModels {
+		change, err := image.UnmarshalMetadataChangeFromDatabase(ctx, changeModel.Version, changeModel.Data,
+			changeModel.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		changes[i] = change
+	}
+	return changes, nil
+}
+
+// saveSnapshot stores the snapshot of the image's current version, keeping the lock of an existing one,
+// and its parent unless the image's current version was just derived. The state the existing snapshot
+// replaces is kept as a metadata change, so the version can be seen as it was before.
+func saveSnapshot(tx *gorm.DB, account common.Account, image *image.Image) error {
+	versionModel := image.Snapshot().MarshalGorm()
+	if versionModel == nil {
+		return nil
+	}
+	if err := saveMetadataChange(tx, account, image); err != nil {
+		return err
+	}
+	columns := []string{"status", "data", "updated_at"}
+	if versionModel.ParentUUID != "" { // derived again, e.g. upgraded after a rollback
+		columns = append(columns, "parent_uuid", "parent_version", "relation")
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account"}, {Name: "image_uuid"}, {Name: "version"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(versionModel).Error
+}
+
+// saveMetadataChange stores the state of the image's current version before it was changed, if its snapshot
+// was already stored, e.g. the image was edited or built within its version, or rolled back to it.
+func saveMetadataChange(tx *gorm.DB, account common.Account, updated *image.Image) error {
+	var versionModel models.ImageVersion
+	err := tx.Where("account = ? AND image_uuid = ? AND version = ?", account.String(), updated.UUID(),
+		updated.Version().Uint()).First(&versionModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	stored, err := unmarshalSnapshot(updated.Context(), versionModel)
+	if err != nil {
+		return err
+	}
+	change, ok := stored.Image().MetadataChangeTo(*updated)
+	if !ok {
+		return nil
+	}
+	return tx.Create(change.MarshalGorm(account.String(), updated.UUID())).Error
+}
+
+// unmarshalSnapshot unmarshals a database image version into a domain snapshot
+func unmarshalSnapshot(ctx context.Context, versionModel models.ImageVersion) (*image.Snapshot, error) {
+	parent, err := image.UnmarshalParentFromDatabase(versionModel.ParentUUID, versionModel.ParentVersion, versionModel.Relation)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := image.UnmarshalSnapshotFromDatabase(ctx, versionModel.Data, parent, versionModel.Locked,
+		versionModel.Commit, versionModel.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// unmarshalSnapshots unmarshals array of database image versions into domain snapshots
+func unmarshalSnapshots(ctx context.Context, versionModels []models.ImageVersion) ([]*image.Snapshot, error) {
+	snapshots := make([]*image.Snapshot, len(versionModels))
+	for i, versionModel := range versionModels {
+		snapshot, err := unmarshalSnapshot(ctx, versionModel)
+		if err != nil {
+			return nil, err
+		}
+		snapshots[i] = snapshot
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,586 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestNewGormVersionRepository(t *testing.T) {
+	gormClient := &gorm.DB{}
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want *GormVersionRepository
+	}{
+		{
+			name: "should return a new gorm version repository",
+			args: args{
+				db: gormClient,
+			},
+			want: &GormVersionRepository{
+				db: gormClient,
+			},
+		},
+		{
+			name: "should panic if db is nil",
+			args: args{
+				db: nil,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				// recover from panic if one occured.
+				if recover() != nil && tt.want != nil {
+					t.Errorf("NewGormVersionRepository() panicked")
+				}
+			}()
+			t.Parallel()
+			if got := NewGormVersionRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewGormVersionRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormVersionRepository_GetVersions(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	imageRepository := NewGormImageRepository(gormClient)
+	if err := imageRepository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	// each upgrade stores a new version
+	err := imageRepository.UpdateImage(context.Background(), validImage.UUID(), func(i *image.Image) (*image.Image, error) {
+		if err := i.Upgrade(); err != nil {
+			return nil, err
+		}
+		return i, nil
+	})
+	if err != nil {
+		t.Errorf("failed to upgrade image: %s", err)
+	}
+
+	type args struct {
+		ctx  context.Context
+		uuid string
+	}
+	tests := []struct {
+		name    string
+		r       *GormVersionRepository
+		args    args
+		want    []uint
+		wantErr bool
+	}{
+		{
+			name: "should get all versions, newest first",
+			r:    NewGormVersionRepository(gormClient),
+			args: args{
+				ctx:  context.Background(),
+				uuid: validImage.UUID(),
+			},
+			want:    []uint{2, 1},
+			wantErr: false,
+		},
+		{
+			name: "should get no versions, unknown uuid",
+			r:    NewGormVersionRepository(gormClient),
+			args: args{
+				ctx:  context.Background(),
+				uuid: uuid.NewString(),
+			},
+			want:    nil,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.GetVersions(tt.args.ctx, tt.args.uuid)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GormVersionRepository.GetVersions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var gotVersions []uint
+			for _, snapshot := range got {
+				gotVersions = append(gotVersions, snapshot.Version().Uint())
+			}
+			if !reflect.DeepEqual(gotVersions, tt.want) {
+				t.Errorf("GormVersionRepository.GetVersions() = %v, want %v", gotVersions, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormVersionRepository_UpdateVersion(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	if err := NewGormImageRepository(gormClient).CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+
+	type args struct {
+		ctx      context.Context
+		uuid     string
+		version  uint
+		updateFn func(s *image.Snapshot) (*image.Snapshot, error)
+	}
+	tests := []struct {
+		name       string
+		r          *GormVersionRepository
+		args       args
+		wantErr    error
+		wantLocked bool
+	}{
+		{
+			name: "should lock a version",
+			r:    NewGormVersionRepository(gormClient),
+			args: args{
+				ctx:     context.Background(),
+				uuid:    validImage.UUID(),
+				version: 1,
+				updateFn: func(s *image.Snapshot) (*image.Snapshot, error) {
+					s.SetLocked(true)
+					return s, nil
+				},
+			},
+			wantErr:    nil,
+			wantLocked: true,
+		},
+		{
+			name: "should fail to lock, unknown version",
+			r:    NewGormVersionRepository(gormClient),
+			args: args{
+				ctx:     context.Background(),
+				uuid:    validImage.UUID(),
+				version: 42,
+				updateFn: func(s *image.Snapshot) (*image.Snapshot, error) {
+					s.SetLocked(true)
+					return s, nil
+				},
+			},
+			wantErr:    image.ErrVersionNotFound,
+			wantLocked: true,
+		},
+		{
+			name: "should fail to update, just error",
+			r:    NewGormVersionRepository(gormClient),
+			args: args{
+				ctx:     context.Background(),
+				uuid:    validImage.UUID(),
+				version: 1,
+				updateFn: func(s *image.Snapshot) (*image.Snapshot, error) {
+					return nil, errors.New("error")
+				},
+			},
+			wantErr:    errors.New("error"),
+			wantLocked: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.r.UpdateVersion(tt.args.ctx, tt.args.uuid, tt.args.version, tt.args.updateFn)
+			if (err != nil) != (tt.wantErr != nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("GormVersionRepository.UpdateVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			snapshots, err := tt.r.GetVersions(tt.args.ctx, tt.args.uuid)
+			if err != nil || len(snapshots) != 1 {
+				t.Errorf("failed to get versions: %v", err)
+				return
+			}
+			if snapshots[0].Locked() != tt.wantLocked {
+				t.Errorf("GormVersionRepository.UpdateVersion() locked = %v, want %v", snapshots[0].Locked(), tt.wantLocked)
+			}
+		})
+	}
+}
+
+func TestGormVersionRepository_DeleteVersions(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	imageRepository := NewGormImageRepository(gormClient)
+	if err := imageRepository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	err := imageRepository.UpdateImage(context.Background(), validImage.UUID(), func(i *image.Image) (*image.Image, error) {
+		if err := i.Upgrade(); err != nil {
+			return nil, err
+		}
+		return i, nil
+	})
+	if err != nil {
+		t.Errorf("failed to upgrade image: %s", err)
+	}
+	repository := NewGormVersionRepository(gormClient)
+	err = repository.UpdateVersion(context.Background(), validImage.UUID(), 2, func(s *image.Snapshot) (*image.Snapshot, error) {
+		s.SetLocked(true)
+		return s, nil
+	})
+	if err != nil {
+		t.Errorf("failed to lock version: %s", err)
+	}
+	v1, _ := image.NewVersion(1)
+	v2, _ := image.NewVersion(2)
+	inUse := image.ContextWithInUseCheck(context.Background(), func(ctx context.Context, versions ...image.Version) error {
+		return image.InUseError{Devices: len(versions)}
+	})
+
+	type args struct {
+		ctx      context.Context
+		uuid     string
+		versions []image.Version
+	}
+	tests := []struct {
+		name    string
+		r       *GormVersionRepository
+		args    args
+		want    []uint
+		wantErr bool
+	}{
+		{
+			name: "should do nothing, no versions",
+			r:    repository,
+			args: args{
+				ctx:  context.Background(),
+				uuid: validImage.UUID(),
+			},
+			want:    []uint{2, 1},
+			wantErr: false,
+		},
+		{
+			name: "should fail to delete versions, in use",
+			r:    repository,
+			args: args{
+				ctx:      inUse,
+				uuid:     validImage.UUID(),
+				versions: []image.Version{v1, v2},
+			},
+			want:    []uint{2, 1},
+			wantErr: true,
+		},
+		{
+			name: "should delete versions, except the locked one",
+			r:    repository,
+			args: args{
+				ctx:      context.Background(),
+				uuid:     validImage.UUID(),
+				versions: []image.Version{v1, v2},
+			},
+			want:    []uint{2},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.DeleteVersions(tt.args.ctx, tt.args.uuid, tt.args.versions...); (err != nil) != tt.wantErr {
+				t.Errorf("GormVersionRepository.DeleteVersions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			snapshots, err := tt.r.GetVersions(tt.args.ctx, tt.args.uuid)
+			if err != nil {
+				t.Errorf("failed to get versions: %v", err)
+				return
+			}
+			var got []uint
+			for _, snapshot := range snapshots {
+				got = append(got, snapshot.Version().Uint())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GormVersionRepository.DeleteVersions() left = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormVersionRepository_GetVersion(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	imageRepository := NewGormImageRepository(gormClient)
+	if err := imageRepository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	err := imageRepository.UpdateImage(context.Background(), validImage.UUID(), func(i *image.Image) (*image.Image, error) {
+		if err := i.Upgrade(); err != nil {
+			return nil, err
+		}
+		return i, nil
+	})
+	if err != nil {
+		t.Errorf("failed to upgrade image: %s", err)
+	}
+	upgraded, _ := image.NewParent(validImage.UUID(), 1, "upgraded")
+
+	type args struct {
+		ctx     context.Context
+		uuid    string
+		version uint
+	}
+	tests := []struct {
+		name       string
+		r          *GormVersionRepository
+		args       args
+		wantParent image.Parent
+		wantErr    error
+	}{
+		{
+			name: "should get the original version, without parent",
+			r:    NewGormVersionRepository(gormClient),
+			args: args{
+				ctx:     context.Background(),
+				uuid:    validImage.UUID(),
+				version: 1,
+			},
+			wantParent: image.Parent{},
+			wantErr:    nil,
+		},
+		{
+			name: "should get the upgraded version, with its parent",
+			r:    NewGormVersionRepository(gormClient),
+			args: args{
+				ctx:     context.Background(),
+				uuid:    validImage.UUID(),
+				version: 2,
+			},
+			wantParent: upgraded,
+			wantErr:    nil,
+		},
+		{
+			name: "should fail to get a version, unknown version",
+			r:    NewGormVersionRepository(gormClient),
+			args: args{
+				ctx:     context.Background(),
+				uuid:    validImage.UUID(),
+				version: 3,
+			},
+			wantErr: image.ErrVersionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.GetVersion(tt.args.ctx, tt.args.uuid, tt.args.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormVersionRepository.GetVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Version().Uint() != tt.args.version || got.Parent() != tt.wantParent {
+				t.Errorf("GormVersionRepository.GetVersion() = %v, %+v, want %v, %+v",
+					got.Version().Uint(), got.Parent(), tt.args.version, tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestGormVersionRepository_GetVersionByCommit(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	imageRepository := NewGormImageRepository(gormClient)
+	if err := imageRepository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	repository := NewGormVersionRepository(gormClient)
+	built, _ := image.NewCommit(strings.Repeat("ab", 32))
+	err := repository.UpdateVersion(context.Background(), validImage.UUID(), 1, func(s *image.Snapshot) (*image.Snapshot, error) {
+		s.SetCommit(built)
+		return s, nil
+	})
+	if err != nil {
+		t.Errorf("failed to set version commit: %s", err)
+	}
+	unknown, _ := image.NewCommit(strings.Repeat("cd", 32))
+	tests := []struct {
+		name    string
+		commit  image.Commit
+		wantErr error
+	}{
+		{
+			name:    "should get the version built into the commit",
+			commit:  built,
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, unknown commit",
+			commit:  unknown,
+			wantErr: image.ErrVersionNotFound,
+		},
+		{
+			name:    "should fail, no commit",
+			commit:  image.Commit{},
+			wantErr: image.ErrVersionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.GetVersionByCommit(context.Background(), tt.commit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormVersionRepository.GetVersionByCommit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.UUID() != validImage.UUID() || got.Version().Uint() != 1 || got.Commit() != tt.commit {
+				t.Errorf("GormVersionRepository.GetVersionByCommit() = %v version %v with %v, want %v version 1 with %v",
+					got.UUID(), got.Version().Uint(), got.Commit(), validImage.UUID(), tt.commit)
+			}
+		})
+	}
+}
+
+func TestGormVersionRepository_GetChildren(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	imageRepository := NewGormImageRepository(gormClient)
+	if err := imageRepository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	clone, err := validImage.Clone(context.Background(), uuid.NewString(), validImage.Name())
+	if err != nil {
+		t.Errorf("failed to clone image: %s", err)
+	}
+	if err := imageRepository.CreateImage(context.Background(), &clone); err != nil {
+		t.Errorf("failed to create clone: %s", err)
+	}
+	// upgrades are derived from the image itself, they are not children
+	err = imageRepository.UpdateImage(context.Background(), validImage.UUID(), func(i *image.Image) (*image.Image, error) {
+		if err := i.Upgrade(); err != nil {
+			return nil, err
+		}
+		return i, nil
+	})
+	if err != nil {
+		t.Errorf("failed to upgrade image: %s", err)
+	}
+
+	tests := []struct {
+		name string
+		r    *GormVersionRepository
+		uuid string
+		want []string
+	}{
+		{
+			name: "should get the clone",
+			r:    NewGormVersionRepository(gormClient),
+			uuid: validImage.UUID(),
+			want: []string{clone.UUID()},
+		},
+		{
+			name: "should get no children",
+			r:    NewGormVersionRepository(gormClient),
+			uuid: clone.UUID(),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.GetChildren(context.Background(), tt.uuid)
+			if err != nil {
+				t.Errorf("GormVersionRepository.GetChildren() error = %v", err)
+				return
+			}
+			var gotUUIDs []string
+			for _, snapshot := range got {
+				gotUUIDs = append(gotUUIDs, snapshot.UUID())
+				if snapshot.Parent().UUID() != tt.uuid || snapshot.Parent().Relation() != image.Cloned {
+					t.Errorf("GormVersionRepository.GetChildren() parent = %+v, want cloned from %s", snapshot.Parent(), tt.uuid)
+				}
+			}
+			if !reflect.DeepEqual(gotUUIDs, tt.want) {
+				t.Errorf("GormVersionRepository.GetChildren() = %v, want %v", gotUUIDs, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormVersionRepository_GetMetadataChanges(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	imageRepository := NewGormImageRepository(gormClient)
+	if err := imageRepository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	newName, _ := common.NewName("renamed")
+	err := imageRepository.UpdateImage(context.Background(), validImage.UUID(), func(i *image.Image) (*image.Image, error) {
+		i.SetNameAndDesc(newName, "renamed description")
+		return i, nil
+	})
+	if err != nil {
+		t.Errorf("failed to rename image: %s", err)
+	}
+	repository := NewGormVersionRepository(gormClient)
+	v1, _ := image.NewVersion(1)
+
+	tests := []struct {
+		name     string
+		r        *GormVersionRepository
+		versions []image.Version
+		want     []string
+	}{
+		{
+			name: "should get the name before the change",
+			r:    repository,
+			want: []string{validImage.Name().String()},
+		},
+		{
+			name:     "should get no changes, version deleted",
+			r:        repository,
+			versions: []image.Version{v1},
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.DeleteVersions(context.Background(), validImage.UUID(), tt.versions...); err != nil {
+				t.Errorf("failed to delete versions: %v", err)
+				return
+			}
+			got, err := tt.r.GetMetadataChanges(context.Background(), validImage.UUID())
+			if err != nil {
+				t.Errorf("GormVersionRepository.GetMetadataChanges() error = %v", err)
+				return
+			}
+			var gotNames []string
+			for _, change := range got {
+				before := change.Image()
+				gotNames = append(gotNames, before.Name().String())
+				if change.Version() != v1 || before.Description() != validImage.Description() ||
+					before.Status() != validImage.Status() {
+					t.Errorf("GormVersionRepository.GetMetadataChanges() = %+v, want version 1 before the change", change)
+				}
+			}
+			if !reflect.DeepEqual(gotNames, tt.want) {
+				t.Errorf("GormVersionRepository.GetMetadataChanges() = %v, want %v", gotNames, tt.want)
+			}
+		})
+	}
+}
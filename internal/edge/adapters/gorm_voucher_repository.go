@@ -0,0 +1,137 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GormVoucherRepository is a GORM implementation of the FDO.Repository interface.
+type GormVoucherRepository struct {
+	db *gorm.DB
+}
+
+// NewGormVoucherRepository returns a new GORM implementation of the FDO.Repository interface.
+func NewGormVoucherRepository(db *gorm.DB) *GormVoucherRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &GormVoucherRepository{db: db}
+}
+
+// ImportVoucher stores a new voucher, implementing the FDO.Repository interface.
+// fdo.ErrVoucherExists is returned if a voucher of the same device was already imported by the account.
+func (r *GormVoucherRepository) ImportVoucher(ctx context.Context, voucher *fdo.Voucher) error {
+	log.WithField("guid", voucher.GUID().String()).Debug("gorm import voucher")
+	account, err := voucher.Account()
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		_, err := getVoucher(tx, account, voucher.GUID())
+		if err == nil {
+			return fdo.ErrVoucherExists
+		} else if err != fdo.ErrVoucherNotFound {
+			return err
+		}
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		voucher.SetTime(common.NewTime(now, now, time.Time{}))
+		return tx.Create(voucher.MarshalGorm()).Error
+	})
+}
+
+// GetVoucher returns the voucher of the device with the given GUID, implementing the FDO.Repository interface.
+func (r *GormVoucherRepository) GetVoucher(ctx context.Context, guid fdo.GUID) (*fdo.Voucher, error) {
+	log.WithField("guid", guid.String()).Debug("gorm get voucher")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getVoucher(r.db, account, guid)
+}
+
+// GetVouchers returns all vouchers, implementing the FDO.Repository interface.
+func (r *GormVoucherRepository) GetVouchers(ctx context.Context) ([]*fdo.Voucher, error) {
+	log.Debug("gorm get vouchers")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var voucherModels []models.Voucher
+	if err := r.db.Where("account = ?", account.String()).Order("created_at").Find(&voucherModels).Error; err != nil {
+		return nil, err
+	}
+	vouchers := make([]*fdo.Voucher, len(voucherModels))
+	for i, voucherModel := range voucherModels {
+		if vouchers[i], err = unmarshalVoucher(account, voucherModel); err != nil {
+			return nil, err
+		}
+	}
+	return vouchers, nil
+}
+
+// UpdateVoucher updates the voucher of the device with the given GUID, implementing the FDO.Repository interface.
+// Only the onboarding state of a voucher can be changed, the voucher itself is immutable.
+func (r *GormVoucherRepository) UpdateVoucher(ctx context.Context, guid fdo.GUID,
+	updateFn func(voucher *fdo.Voucher) (*fdo.Voucher, error)) error {
+	log.WithField("guid", guid.String()).Debug("gorm update voucher")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		current, err := getVoucher(tx, account, guid)
+		if err != nil {
+			return err
+		}
+		status := current.Status().String()
+		updatedVoucher, err := updateFn(current)
+		if err != nil {
+			return err
+		}
+		// conditioned on the status it was read with, so a device is onboarded only once
+		result := tx.Model(&models.Voucher{}).
+			Where("account = ? AND guid = ? AND status = ?", account.String(), guid.String(), status).
+			Updates(map[string]interface{}{
+				"status":       updatedVoucher.Status().String(),
+				"device_uuid":  updatedVoucher.DeviceUUID(),
+				"onboarded_at": updatedVoucher.OnboardedAt(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 { // onboarded by someone else since it was read
+			return fdo.ErrAlreadyOnboarded
+		}
+		return nil
+	})
+}
+
+// getVoucher returns the voucher of the device with the given GUID of the account, using the given connection.
+func getVoucher(db *gorm.DB, account common.Account, guid fdo.GUID) (*fdo.Voucher, error) {
+	var voucherModel models.Voucher
+	err := db.Where("account = ? AND guid = ?", account.String(), guid.String()).First(&voucherModel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, fdo.ErrVoucherNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return unmarshalVoucher(account, voucherModel)
+}
+
+// unmarshalVoucher unmarshals a voucher model of the account into a domain voucher.
+func unmarshalVoucher(account common.Account, voucherModel models.Voucher) (*fdo.Voucher, error) {
+	voucher, err := fdo.UnmarshalVoucherFromDatabase(common.ContextWithAccount(context.Background(), account),
+		voucherModel.Data, voucherModel.Status, voucherModel.DeviceUUID,
+		voucherModel.OnboardedAt, voucherModel.CreatedAt, voucherModel.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &voucher, nil
+}
@@ -0,0 +1,158 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+	"github.com/google/uuid"
+)
+
+// newTestVoucher returns a voucher of a new device, CBOR encoded by hand with short items only.
+func newTestVoucher(t *testing.T, deviceInfo string) fdo.Voucher {
+	guid := uuid.New()
+	// header: [101, guid, [], deviceInfo, [10], null]
+	header := append([]byte{0x86, 0x18, 0x65, 0x50}, guid[:]...)
+	header = append(header, 0x80, 0x60+byte(len(deviceInfo)))
+	header = append(header, deviceInfo...)
+	header = append(header, 0x81, 0x0a, 0xf6)
+	// voucher: [101, bstr(header), [], null, []]
+	data := append([]byte{0x85, 0x18, 0x65, 0x58, byte(len(header))}, header...)
+	data = append(data, 0x80, 0xf6, 0x80)
+	voucher, err := fdo.ParseVoucher(context.Background(), data)
+	if err != nil {
+		t.Fatalf("failed to parse voucher: %s", err)
+	}
+	return voucher
+}
+
+func TestGormVoucherRepository_ImportVoucher(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormVoucherRepository(gormClient)
+	kiosk := newTestVoucher(t, "kiosk")
+	tests := []struct {
+		name    string
+		voucher fdo.Voucher
+		wantErr error
+	}{
+		{
+			name:    "should import the voucher",
+			voucher: kiosk,
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, voucher already imported",
+			voucher: kiosk,
+			wantErr: fdo.ErrVoucherExists,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := repository.ImportVoucher(context.Background(), &tt.voucher)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormVoucherRepository.ImportVoucher() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, err := repository.GetVoucher(context.Background(), tt.voucher.GUID())
+			if err != nil {
+				t.Errorf("GormVoucherRepository.GetVoucher() error = %v", err)
+				return
+			}
+			if got.DeviceInfo() != "kiosk" || got.Status() != fdo.Imported || got.CreatedAt().IsZero() {
+				t.Errorf("GormVoucherRepository.GetVoucher() = %v, want the imported voucher", got)
+			}
+		})
+	}
+}
+
+func TestGormVoucherRepository_GetVouchers(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormVoucherRepository(gormClient)
+	var want []string
+	for _, deviceInfo := range []string{"kiosk", "register"} {
+		voucher := newTestVoucher(t, deviceInfo)
+		if err := repository.ImportVoucher(context.Background(), &voucher); err != nil {
+			t.Fatalf("failed to import voucher: %s", err)
+		}
+		want = append(want, voucher.GUID().String())
+	}
+	got, err := repository.GetVouchers(context.Background())
+	if err != nil {
+		t.Fatalf("GormVoucherRepository.GetVouchers() error = %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("GormVoucherRepository.GetVouchers() = %d vouchers, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].GUID().String() != want[i] {
+			t.Errorf("GormVoucherRepository.GetVouchers()[%d] = %s, want %s", i, got[i].GUID(), want[i])
+		}
+	}
+}
+
+func TestGormVoucherRepository_UpdateVoucher(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+
+	repository := NewGormVoucherRepository(gormClient)
+	kiosk := newTestVoucher(t, "kiosk")
+	if err := repository.ImportVoucher(context.Background(), &kiosk); err != nil {
+		t.Fatalf("failed to import voucher: %s", err)
+	}
+	deviceUUID := uuid.NewString()
+	onboard := func(v *fdo.Voucher) (*fdo.Voucher, error) {
+		if err := v.Onboard(deviceUUID, time.Now()); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	unknown, _ := fdo.NewGUID(uuid.NewString())
+	tests := []struct {
+		name    string
+		guid    fdo.GUID
+		wantErr error
+	}{
+		{
+			name:    "should onboard the voucher",
+			guid:    kiosk.GUID(),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, already onboarded",
+			guid:    kiosk.GUID(),
+			wantErr: fdo.ErrAlreadyOnboarded,
+		},
+		{
+			name:    "should fail, unknown voucher",
+			guid:    unknown,
+			wantErr: fdo.ErrVoucherNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := repository.UpdateVoucher(context.Background(), tt.guid, onboard)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GormVoucherRepository.UpdateVoucher() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, _ := repository.GetVoucher(context.Background(), tt.guid)
+			if got.Status() != fdo.Onboarded || got.DeviceUUID() != deviceUUID || got.OnboardedAt().IsZero() {
+				t.Errorf("GormVoucherRepository.UpdateVoucher() = %v, want an onboarded voucher", got)
+			}
+		})
+	}
+}
@@ -3,80 +3,173 @@ package adapters
 import (
 	"context"
 
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
 	"github.com/go-redis/redis/v8"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
-// ReadThroughImageRepository is an implementation of the Image.Repository
+// ReadThroughImageRepository is a cache-aside implementation of the Image.Repository,
+// gorm is the source of truth and redis caches the images and list pages as they are read.
+// Writes go to gorm, then invalidate the cache. The stamps of the cache keep a read racing a write
+// from caching what it read before the write.
 type ReadThroughImageRepository struct {
 	rdb *RedisImageRepository // redis client
 	gdb *GormImageRepository  // gorm client
 }
 
 // NewReadThroughImageRepository returns a new ReadThroughImageRepository
-func NewReadThroughImageRepository(rdb *redis.Client, gdb *gorm.DB) *ReadThroughImageRepository {
+func NewReadThroughImageRepository(rdb redis.UniversalClient, gdb *gorm.DB, cfg RedisConfig) *ReadThroughImageRepository {
 	return &ReadThroughImageRepository{
-		rdb: NewRedisImageRepository(rdb),
+		rdb: NewRedisImageRepository(rdb, cfg),
 		gdb: NewGormImageRepository(gdb),
 	}
 }
 
 // CreateImage creates a new image, implementing the Image.Repository interface.
 func (r *ReadThroughImageRepository) CreateImage(ctx context.Context, image *image.Image) error {
-	// try to create image in redis
-	err := r.rdb.CreateImage(ctx, image)
+	account, err := image.Account()
 	if err != nil {
-		log.WithField("uuid", image.UUID()).Error(err) // if redis fails, log error
+		return err
 	}
-	return r.gdb.CreateImage(ctx, image) // write images synchronously
+	if err := r.gdb.CreateImage(ctx, image); err != nil {
+		return err
+	}
+	// the image is cached once read, the cached list pages miss it meanwhile
+	r.invalidate(common.ContextWithAccount(ctx, account), image.UUID())
+	return nil
 }
 
 // GetImage returns the image with the given UUID, implementing the Image.Repository interface.
 func (r *ReadThroughImageRepository) GetImage(ctx context.Context, uuid string) (*image.Image, error) {
-	image, err := r.rdb.GetImage(ctx, uuid) // try to get image from redis
-	if err != nil {                         // if redis fails, try gorm
-		image, err = r.gdb.GetImage(ctx, uuid) // try to get image from gorm
-		if err != nil {                        // if gorm fails, return error
-			return nil, err
-		}
-		// if gorm succeeds, update redis
-		go func() {
-			err = r.rdb.CreateImage(ctx, image)
-			if err != nil { // if redis fails, log error
-				log.WithField("uuid", uuid).Error(err)
-			}
-		}()
+	cached, err := r.rdb.GetImage(ctx, uuid) // try to get image from redis
+	if err == nil {
+		return cached, nil
+	}
+	if err != image.ErrImageNotFound {
+		log.WithField("uuid", uuid).Error(err) // if redis fails, log error and try gorm
+	}
+	// the stamp is read before gorm, so an image updated meanwhile isn't cached as it was
+	stamp, stampErr := r.rdb.ImageStamp(ctx, uuid)
+	image, err := r.gdb.GetImage(ctx, uuid)
+	if err != nil {
+		return nil, err
 	}
+	if stampErr != nil {
+		log.WithField("uuid", uuid).Error(stampErr)
+		return image, nil
+	}
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// cache a copy, the caller may change the image meanwhile
+	read := *image
+	go func() {
+		if err := r.rdb.CacheImage(context.Background(), account, &read, stamp); err != nil {
+			log.WithField("uuid", uuid).Error(err) // if redis fails, log error
+		}
+	}()
 	return image, nil
 }
 
 // UpdateImage updates the image with the given UUID, implementing the Image.Repository interface.
+// Gorm is the source of truth for the precondition check, the cached image is dropped once the update is stored.
 func (r *ReadThroughImageRepository) UpdateImage(ctx context.Context, uuid string, updateFn func(image *image.Image) (*image.Image, error)) error {
-	// try to update image in redis
-	err := r.rdb.UpdateImage(ctx, uuid, updateFn)
-	if err != nil {
-		log.WithField("uuid", uuid).Error(err) // if redis fails, log error
+	if err := r.gdb.UpdateImage(ctx, uuid, updateFn); err != nil { // update image in gorm, synchronously
+		return err
 	}
-	return r.gdb.UpdateImage(ctx, uuid, updateFn) // try to update image in gorm, synchronously
+	r.invalidate(ctx, uuid)
+	return nil
 }
 
 // DeleteImage deletes the image with the given UUID, implementing the Image.Repository interface.
 func (r *ReadThroughImageRepository) DeleteImage(ctx context.Context, uuid string) error {
-	// try to delete image from redis
-	err := r.rdb.DeleteImage(ctx, uuid)
+	if err := r.gdb.DeleteImage(ctx, uuid); err != nil { // delete image from gorm, synchronously
+		return err
+	}
+	r.invalidate(ctx, uuid)
+	return nil
+}
+
+// GetImages returns the page of images the spec lists along with the total number of images matching it,
+// implementing the Image.Repository interface. Pages are cached along with their images.
+func (r *ReadThroughImageRepository) GetImages(ctx context.Context, spec image.Spec) ([]*image.Image, int, error) {
+	uuids, total, stamp, err := r.rdb.GetImageList(ctx, spec)
+	if err == nil {
+		var images []*image.Image
+		if images, err = r.rdb.GetCachedImages(ctx, uuids); err == nil {
+			return images, total, nil
+		}
+	}
+	cacheable := err == redis.Nil
+	if !cacheable {
+		log.Error(err) // if redis fails, log error and don't cache what gorm returns
+	}
+	images, total, err := r.gdb.GetImages(ctx, spec)
+	if err != nil {
+		return nil, 0, err
+	}
+	if cacheable {
+		if err := r.rdb.CacheImageList(ctx, spec, images, total, stamp); err != nil {
+			log.Error(err) // if redis fails, log error
+		}
+	}
+	return images, total, nil
+}
+
+// GetDeletedImages returns a list of soft-deleted images, implementing the Image.Repository interface.
+func (r *ReadThroughImageRepository) GetDeletedImages(ctx context.Context) ([]*image.Image, error) {
+	return r.gdb.GetDeletedImages(ctx) // deleted images are never cached
+}
+
+// RestoreImage restores the soft-deleted image with the given UUID, implementing the Image.Repository interface.
+func (r *ReadThroughImageRepository) RestoreImage(ctx context.Context, uuid string) error {
+	if err := r.gdb.RestoreImage(ctx, uuid); err != nil {
+		return err
+	}
+	r.invalidate(ctx, uuid) // the restored image is back in the list pages
+	r.warm(ctx, uuid)
+	return nil
+}
+
+// PurgeImage permanently deletes the image with the given UUID, implementing the Image.Repository interface.
+func (r *ReadThroughImageRepository) PurgeImage(ctx context.Context, uuid string) error {
+	if err := r.gdb.PurgeImage(ctx, uuid); err != nil { // purge image from gorm, synchronously
+		return err
+	}
+	r.invalidate(ctx, uuid)
+	return nil
+}
+
+// warm caches the image with the given UUID as stored by gorm, so it is served from redis right away.
+// Like a read, the image isn't cached if it is invalidated meanwhile. If anything fails, it is cached once read.
+func (r *ReadThroughImageRepository) warm(ctx context.Context, uuid string) {
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		log.WithField("uuid", uuid).Error(err)
+		return
+	}
+	stamp, err := r.rdb.ImageStamp(ctx, uuid)
 	if err != nil {
 		log.WithField("uuid", uuid).Error(err) // if redis fails, log error
+		return
+	}
+	image, err := r.gdb.GetImage(ctx, uuid)
+	if err != nil {
+		log.WithField("uuid", uuid).Error(err) // stored, but can't be cached
+		return
+	}
+	if err := r.rdb.CacheImage(ctx, account, image, stamp); err != nil {
+		log.WithField("uuid", uuid).Error(err) // if redis fails, log error
 	}
-	return r.gdb.DeleteImage(ctx, uuid) // try to delete image from gorm, synchronously
 }
 
-// GetImages returns a list of images, implementing the Image.Repository interface.
-func (r *ReadThroughImageRepository) GetImages(ctx context.Context) ([]*image.Image, error) {
-	// we can use redis to help get all images, but this needs to be very suphisticated
-	// since each image has an expiration time, and we need to make sure we don't return
-	// wrong output.
-	// for now, we'll just use gorm
-	return r.gdb.GetImages(ctx)
+// invalidate drops the cached image with the given UUID and the cached list pages of its account.
+// If redis fails, they are served until they expire.
+func (r *ReadThroughImageRepository) invalidate(ctx context.Context, uuid string) {
+	if err := r.rdb.InvalidateImage(ctx, uuid); err != nil {
+		log.WithField("uuid", uuid).Error(err) // if redis fails, log error
+	}
 }
@@ -5,7 +5,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image/imagetest"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 
@@ -26,16 +29,7 @@ var (
 		[]string{"tag1", "tag2"},
 		[]string{"vim", "emacs"},
 		1,
-		[]interface{}{
-			map[string]interface{}{
-				"name": "test",
-				"url":  "http://test.com",
-			},
-			map[string]interface{}{
-				"name": "test2",
-				"url":  "http://test2.com",
-			},
-		},
+		[]string{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6ba7b811-9dad-11d1-80b4-00c04fd430c8"},
 	)
 	anotherValidImage, _ = image.NewImageWithContext(
 		context.Background(),
@@ -50,22 +44,19 @@ var (
 		[]string{"tag1", "tag2"},
 		[]string{"vim", "emacs"},
 		1,
-		[]interface{}{
-			map[string]interface{}{
-				"name": "test",
-				"url":  "http://test.com",
-			},
-			map[string]interface{}{
-				"name": "test2",
-				"url":  "http://test2.com",
-			},
-		},
+		[]string{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6ba7b811-9dad-11d1-80b4-00c04fd430c8"},
 	)
 )
 
+func TestReadThroughImageRepository_Conformance(t *testing.T) {
+	imagetest.TestRepository(t, func(t *testing.T) image.Repository {
+		return NewReadThroughImageRepository(newTestRedisClient(t), newTestGormClient(t), NewRedisConfig())
+	})
+}
+
 func TestNewReadThroughImageRepository(t *testing.T) {
 	type args struct {
-		rdb *redis.Client
+		rdb redis.UniversalClient
 		gdb *gorm.DB
 	}
 	tests := []struct {
@@ -79,7 +70,7 @@ func TestNewReadThroughImageRepository(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := NewReadThroughImageRepository(tt.args.rdb, tt.args.gdb); got == tt.want {
+			if got := NewReadThroughImageRepository(tt.args.rdb, tt.args.gdb, NewRedisConfig()); got == tt.want {
 				t.Errorf("NewReadThroughImageRepository() = %v, want %v", got, tt.want)
 			}
 		})
@@ -191,7 +182,8 @@ func TestReadThroughImageRepository_DeleteImage(t *testing.T) {
 
 func TestReadThroughImageRepository_GetImages(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		ctx  context.Context
+		spec image.Spec
 	}
 	tests := []struct {
 		name    string
@@ -206,7 +198,7 @@ func TestReadThroughImageRepository_GetImages(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := tt.r.GetImages(tt.args.ctx)
+			got, _, err := tt.r.GetImages(tt.args.ctx, tt.args.spec)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ReadThroughImageRepository.GetImages() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -228,8 +220,94 @@ func areEqualImages(a, b *image.Image) bool {
 		a.Distribution() != b.Distribution() ||
 		reflect.DeepEqual(a.OutputTypes(), b.OutputTypes()) != true ||
 		reflect.DeepEqual(a.Tags(), b.Tags()) != true ||
-		reflect.DeepEqual(a.Packages(), b.Packages()) != true {
+		reflect.DeepEqual(a.Packages(), b.Packages()) != true ||
+		reflect.DeepEqual(a.Repos(), b.Repos()) != true {
 		return false
 	}
 	return true
 }
+
+func TestReadThroughImageRepository_Cache(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+	setupRedis(t)
+	defer teardownRedis(t)
+
+	repository := NewReadThroughImageRepository(redisClient, gormClient, NewRedisConfig())
+	if err := repository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	spec, _ := image.NewSpec("", "", "", 0, 0)
+	if _, _, err := repository.GetImages(context.Background(), spec); err != nil {
+		t.Fatalf("ReadThroughImageRepository.GetImages() error = %v", err)
+	}
+	// changed behind the cache, the cached page is served until the next write
+	if err := gormClient.Model(&models.Image{}).Where("uuid = ?", validImage.UUID()).
+		Update("description", "behind the cache").Error; err != nil {
+		t.Fatalf("failed to update image: %s", err)
+	}
+
+	tests := []struct {
+		name            string
+		write           func() error
+		wantDescription string
+	}{
+		{
+			name:            "should serve the cached page",
+			write:           func() error { return nil },
+			wantDescription: validImage.Description(),
+		},
+		{
+			name: "should serve the updated image, the write invalidated the page",
+			write: func() error {
+				return repository.UpdateImage(context.Background(), validImage.UUID(), func(img *image.Image) (*image.Image, error) {
+					img.SetNameAndDesc(common.Name{}, img.Description()+", updated")
+					return img, nil
+				})
+			},
+			wantDescription: "behind the cache, updated",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.write(); err != nil {
+				t.Fatalf("failed to write: %s", err)
+			}
+			got, total, err := repository.GetImages(context.Background(), spec)
+			if err != nil || total != 1 || len(got) != 1 {
+				t.Fatalf("ReadThroughImageRepository.GetImages() = %v, %v, %v, want the image", got, total, err)
+			}
+			if got[0].Description() != tt.wantDescription {
+				t.Errorf("ReadThroughImageRepository.GetImages() description = %q, want %q", got[0].Description(),
+					tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestReadThroughImageRepository_RestoreImage(t *testing.T) {
+	setupGorm(t)
+	defer teardownGorm(t)
+	setupRedis(t)
+	defer teardownRedis(t)
+
+	repository := NewReadThroughImageRepository(redisClient, gormClient, NewRedisConfig())
+	if err := repository.CreateImage(context.Background(), &validImage); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	if err := repository.DeleteImage(context.Background(), validImage.UUID()); err != nil {
+		t.Fatalf("failed to delete image: %s", err)
+	}
+	if err := repository.RestoreImage(context.Background(), validImage.UUID()); err != nil {
+		t.Fatalf("ReadThroughImageRepository.RestoreImage() error = %v", err)
+	}
+	// the restored image is cached right away
+	got, err := repository.rdb.GetImage(context.Background(), validImage.UUID())
+	if err != nil {
+		t.Fatalf("RedisImageRepository.GetImage() error = %v, want the restored image cached", err)
+	}
+	if got.UUID() != validImage.UUID() || !got.DeletedAt().IsZero() {
+		t.Errorf("RedisImageRepository.GetImage() = %v, want the restored image", got)
+	}
+}
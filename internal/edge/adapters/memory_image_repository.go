@@ -0,0 +1,311 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrDuplicateImage is the error returned when an image is created with the UUID of a stored one.
+var ErrDuplicateImage = errors.New("image already exists")
+
+// MemoryImageRepository is an in-memory implementation of the Image.Repository, Image.CatalogRepository and
+// Image.SearchRepository interfaces, for development and tests. It follows the GORM implementation: images are scoped by account,
+// soft-deleted images are only seen by GetDeletedImages, RestoreImage and PurgeImage,
+// and lists keep the creation order on equal sorts. Image versions aren't stored, an UnavailableRepository stands in
+// for them, and the repositories of the images aren't checked against the catalog of the account.
+// It is safe for concurrent use, images are copied in and out so callers never share them.
+type MemoryImageRepository struct {
+	mu       sync.RWMutex
+	accounts map[common.Account]*memoryImages
+}
+
+// memoryImages are the stored images of an account, in creation order.
+type memoryImages struct {
+	uuids  []string
+	images map[string]image.Image
+}
+
+// NewMemoryImageRepository returns a new, empty, in-memory implementation of the Image.Repository interface.
+func NewMemoryImageRepository() *MemoryImageRepository {
+	return &MemoryImageRepository{accounts: make(map[common.Account]*memoryImages)}
+}
+
+// CreateImage creates a new image, implementing the Image.Repository interface.
+func (r *MemoryImageRepository) CreateImage(ctx context.Context, img *image.Image) error {
+	log.Debug("memory create image")
+	account, err := img.Account()
+	if err != nil {
+		return err
+	}
+	img.Touch(time.Now())
+	stored, err := copyImage(account, img, img.CreatedAt(), img.UpdatedAt(), img.DeletedAt())
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	images, ok := r.accounts[account]
+	if !ok {
+		images = &memoryImages{images: make(map[string]image.Image)}
+		r.accounts[account] = images
+	}
+	if _, ok := images.images[img.UUID()]; ok {
+		return ErrDuplicateImage
+	}
+	images.uuids = append(images.uuids, img.UUID())
+	images.images[img.UUID()] = *stored
+	return nil
+}
+
+// GetImage returns the image with the given UUID, implementing the Image.Repository interface.
+func (r *MemoryImageRepository) GetImage(ctx context.Context, uuid string) (*image.Image, error) {
+	log.WithField("uuid", uuid).Debug("memory get image")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stored, ok := r.find(account, uuid)
+	if !ok || !stored.DeletedAt().IsZero() {
+		return nil, image.ErrImageNotFound
+	}
+	return copyImage(account, &stored, stored.CreatedAt(), stored.UpdatedAt(), stored.DeletedAt())
+}
+
+// UpdateImage updates the image with the given UUID, implementing the Image.Repository interface.
+// The repository is locked from the read to the write, so concurrent updates apply one after the other,
+// each seeing the image the previous one left. The update only applies if it matches the precondition
+// carried by the context, otherwise image.ErrPreconditionFailed is returned.
+func (r *MemoryImageRepository) UpdateImage(ctx context.Context, uuid string, updateFn func(image *image.Image) (*image.Image, error)) error {
+	log.WithField("uuid", uuid).Debug("memory update image")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.find(account, uuid)
+	if !ok || !stored.DeletedAt().IsZero() {
+		return image.ErrImageNotFound
+	}
+	if err := image.CheckPrecondition(ctx, stored); err != nil {
+		return err
+	}
+	current, err := copyImage(account, &stored, stored.CreatedAt(), stored.UpdatedAt(), stored.DeletedAt())
+	if err != nil {
+		return err
+	}
+	updatedImage, err := updateFn(current)
+	if err != nil {
+		return err
+	}
+	updatedImage.Touch(time.Now())
+	// the identity and creation time of the stored image are kept, whatever updateFn returns
+	updated, err := copyImage(account, updatedImage, stored.CreatedAt(), updatedImage.UpdatedAt(), time.Time{})
+	if err != nil {
+		return err
+	}
+	r.accounts[account].images[uuid] = *updated
+	return nil
+}
+
+// DeleteImage soft-deletes the image with the given UUID, implementing the Image.Repository interface.
+func (r *MemoryImageRepository) DeleteImage(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("memory delete image")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.find(account, uuid)
+	if !ok || !stored.DeletedAt().IsZero() {
+		return image.ErrImageNotFound
+	}
+	if err := image.CheckPrecondition(ctx, stored); err != nil {
+		return err
+	}
+	if err := image.CheckNotInUse(ctx); err != nil {
+		return err
+	}
+	stored.SetTime(common.NewTime(stored.CreatedAt(), stored.UpdatedAt(), time.Now().UTC().Truncate(time.Microsecond)))
+	r.accounts[account].images[uuid] = stored
+	return nil
+}
+
+// GetImages returns the page of images the spec lists along with the total number of images matching it,
+// implementing the Image.Repository interface.
+func (r *MemoryImageRepository) GetImages(ctx context.Context, spec image.Spec) ([]*image.Image, int, error) {
+	log.Debug("memory get images")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	images, err := r.list(account, func(stored image.Image) bool { return stored.DeletedAt().IsZero() })
+	if err != nil {
+		return nil, 0, err
+	}
+	page, total := spec.Apply(images)
+	return page, total, nil
+}
+
+// GetDeletedImages returns all soft-deleted images, implementing the Image.Repository interface.
+func (r *MemoryImageRepository) GetDeletedImages(ctx context.Context) ([]*image.Image, error) {
+	log.Debug("memory get deleted images")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.list(account, func(stored image.Image) bool { return !stored.DeletedAt().IsZero() })
+}
+
+// RestoreImage restores the soft-deleted image with the given UUID, implementing the Image.Repository interface.
+func (r *MemoryImageRepository) RestoreImage(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("memory restore image")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.find(account, uuid)
+	if !ok || stored.DeletedAt().IsZero() {
+		return image.ErrImageNotFound
+	}
+	stored.SetTime(common.NewTime(stored.CreatedAt(), stored.UpdatedAt(), time.Time{}))
+	r.accounts[account].images[uuid] = stored
+	return nil
+}
+
+// PurgeImage permanently deletes the image with the given UUID, deleted or not,
+// implementing the Image.Repository interface.
+func (r *MemoryImageRepository) PurgeImage(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("memory purge image")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.find(account, uuid)
+	if !ok {
+		return image.ErrImageNotFound
+	}
+	if err := image.CheckPrecondition(ctx, stored); err != nil {
+		return err
+	}
+	if err := image.CheckNotInUse(ctx); err != nil {
+		return err
+	}
+	images := r.accounts[account]
+	delete(images.images, uuid)
+	for i, stored := range images.uuids {
+		if stored == uuid {
+			images.uuids = append(images.uuids[:i], images.uuids[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// GetTagCatalog returns the entries of the tags the images use starting with the prefix,
+// implementing the Image.CatalogRepository interface.
+func (r *MemoryImageRepository) GetTagCatalog(ctx context.Context, prefix string) ([]*image.CatalogEntry, error) {
+	log.WithField("prefix", prefix).Debug("memory get tag catalog")
+	images, err := r.activeImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return image.TagCatalog(images, prefix), nil
+}
+
+// GetPackageCatalog returns the entries of the packages the images use starting with the prefix,
+// implementing the Image.CatalogRepository interface.
+func (r *MemoryImageRepository) GetPackageCatalog(ctx context.Context, prefix string) ([]*image.CatalogEntry, error) {
+	log.WithField("prefix", prefix).Debug("memory get package catalog")
+	images, err := r.activeImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return image.PackageCatalog(images, prefix), nil
+}
+
+// SearchImages returns the images matching the query, the most relevant first, implementing the
+// Image.SearchRepository interface. Their repositories aren't searched, the store doesn't know their names.
+func (r *MemoryImageRepository) SearchImages(ctx context.Context, query image.SearchQuery) ([]*image.SearchResult, error) {
+	log.WithField("query", query.String()).Debug("memory search images")
+	images, err := r.activeImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return image.SearchImages(images, query), nil
+}
+
+// activeImages returns copies of the images of the account not deleted, the ones catalogs count and searches find.
+func (r *MemoryImageRepository) activeImages(ctx context.Context) ([]*image.Image, error) {
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.list(account, func(stored image.Image) bool { return stored.DeletedAt().IsZero() })
+}
+
+// find returns the stored image with the given UUID of the account, deleted or not.
+// The caller must hold the lock.
+func (r *MemoryImageRepository) find(account common.Account, uuid string) (image.Image, bool) {
+	images, ok := r.accounts[account]
+	if !ok {
+		return image.Image{}, false
+	}
+	stored, ok := images.images[uuid]
+	return stored, ok
+}
+
+// list returns copies of the stored images of the account the filter keeps, in creation order.
+func (r *MemoryImageRepository) list(account common.Account, filter func(stored image.Image) bool) ([]*image.Image, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := []*image.Image{}
+	images, ok := r.accounts[account]
+	if !ok {
+		return list, nil
+	}
+	for _, uuid := range images.uuids {
+		stored := images.images[uuid]
+		if !filter(stored) {
+			continue
+		}
+		copied, err := copyImage(account, &stored, stored.CreatedAt(), stored.UpdatedAt(), stored.DeletedAt())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, copied)
+	}
+	return list, nil
+}
+
+// copyImage returns a copy of the image sharing nothing with it, in the account and with the given times,
+// the way the GORM implementation unmarshals the stored images.
+func copyImage(account common.Account, img *image.Image, createdAt, updatedAt, deletedAt time.Time) (*image.Image, error) {
+	outputTypes := make([]string, len(img.OutputTypes()))
+	for i, outputType := range img.OutputTypes() {
+		outputTypes[i] = outputType.String()
+	}
+	copied, err := image.UnmarshalImageFromDatabase(common.ContextWithAccount(context.Background(), account),
+		img.UUID(), img.Name().String(), img.Description(), img.Distribution().String(), img.Status().String(),
+		img.User().Username(), img.User().SSHKey(), outputTypes,
+		img.Tags().StringArray(), img.Packages().StringArray(), img.Version().Uint(), img.Repos().UUIDs(),
+		createdAt, updatedAt, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
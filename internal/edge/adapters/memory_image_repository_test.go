@@ -0,0 +1,391 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image/imagetest"
+	"github.com/google/uuid"
+	"github.com/redhatinsights/edge-api/config"
+)
+
+func TestMemoryImageRepository_Conformance(t *testing.T) {
+	imagetest.TestRepository(t, func(t *testing.T) image.Repository {
+		return NewMemoryImageRepository()
+	})
+}
+
+func TestMemoryImageRepository_GetImage(t *testing.T) {
+	config.Init()
+	repository := NewMemoryImageRepository()
+	created := validImage
+	if err := repository.CreateImage(context.Background(), &created); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		uuid    string
+		wantErr error
+	}{
+		{
+			name:    "should get the image",
+			ctx:     context.Background(),
+			uuid:    created.UUID(),
+			wantErr: nil,
+		},
+		{
+			name:    "should not find a missing image",
+			ctx:     context.Background(),
+			uuid:    anotherValidImage.UUID(),
+			wantErr: image.ErrImageNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repository.GetImage(tt.ctx, tt.uuid)
+			if err != tt.wantErr {
+				t.Fatalf("MemoryImageRepository.GetImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (!areEqualImages(got, &created) || !got.UpdatedAt().Equal(created.UpdatedAt())) {
+				t.Errorf("MemoryImageRepository.GetImage() = %v, want %v", got, created)
+			}
+		})
+	}
+}
+
+func TestMemoryImageRepository_GetImage_Account(t *testing.T) {
+	config.Init()
+	config.Get().Auth = true
+	defer func() { config.Get().Auth = false }()
+	repository := NewMemoryImageRepository()
+	account, _ := common.NewAccount("0000001")
+	otherAccount, _ := common.NewAccount("0000002")
+	created, _ := copyImage(account, &validImage, validImage.CreatedAt(), validImage.UpdatedAt(), time.Time{})
+	if err := repository.CreateImage(context.Background(), created); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	if _, err := repository.GetImage(common.ContextWithAccount(context.Background(), account), created.UUID()); err != nil {
+		t.Errorf("MemoryImageRepository.GetImage() error = %v", err)
+	}
+	if _, err := repository.GetImage(common.ContextWithAccount(context.Background(), otherAccount), created.UUID()); err != image.ErrImageNotFound {
+		t.Errorf("MemoryImageRepository.GetImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+}
+
+func TestMemoryImageRepository_CreateImage(t *testing.T) {
+	config.Init()
+	repository := NewMemoryImageRepository()
+	created := validImage
+	if err := repository.CreateImage(context.Background(), &created); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	if err := repository.CreateImage(context.Background(), &created); err != ErrDuplicateImage {
+		t.Errorf("MemoryImageRepository.CreateImage() error = %v, wantErr %v", err, ErrDuplicateImage)
+	}
+	// the stored image is a copy, changing the created or a read one leaves it as it is
+	created.AddTag(common.NewTag("tag3"))
+	got, _ := repository.GetImage(context.Background(), created.UUID())
+	got.AddTag(common.NewTag("tag4"))
+	if got, _ := repository.GetImage(context.Background(), created.UUID()); !areEqualImages(got, &validImage) {
+		t.Errorf("MemoryImageRepository.GetImage() = %v, want %v", got, validImage)
+	}
+}
+
+func TestMemoryImageRepository_UpdateImage(t *testing.T) {
+	config.Init()
+	repository := NewMemoryImageRepository()
+	created := validImage
+	if err := repository.CreateImage(context.Background(), &created); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		uuid    string
+		wantErr error
+	}{
+		{
+			name:    "should update the image",
+			ctx:     context.Background(),
+			uuid:    created.UUID(),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail if the precondition doesn't match",
+			ctx:     image.ContextWithPrecondition(context.Background(), `"stale"`),
+			uuid:    created.UUID(),
+			wantErr: image.ErrPreconditionFailed,
+		},
+		{
+			name:    "should not find a missing image",
+			ctx:     context.Background(),
+			uuid:    anotherValidImage.UUID(),
+			wantErr: image.ErrImageNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			before, _ := repository.GetImage(context.Background(), created.UUID())
+			name, _ := common.NewName(uuid.NewString())
+			err := repository.UpdateImage(tt.ctx, tt.uuid, func(current *image.Image) (*image.Image, error) {
+				current.SetNameAndDesc(name, "updated description")
+				return current, nil
+			})
+			if err != tt.wantErr {
+				t.Fatalf("MemoryImageRepository.UpdateImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got, _ := repository.GetImage(context.Background(), created.UUID())
+			want := before.Name()
+			if tt.wantErr == nil {
+				want = name
+			}
+			if got.Name() != want {
+				t.Errorf("MemoryImageRepository.UpdateImage() name = %s, want %s", got.Name(), want)
+			}
+			if !got.CreatedAt().Equal(created.CreatedAt()) {
+				t.Errorf("MemoryImageRepository.UpdateImage() created at = %v, want %v", got.CreatedAt(), created.CreatedAt())
+			}
+		})
+	}
+}
+
+func TestMemoryImageRepository_UpdateImage_Concurrent(t *testing.T) {
+	config.Init()
+	repository := NewMemoryImageRepository()
+	created := validImage
+	if err := repository.CreateImage(context.Background(), &created); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	// every update upgrades the image, only the first one may start a build
+	const updates = 5
+	errs := make(chan error, updates)
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repository.UpdateImage(context.Background(), created.UUID(), func(current *image.Image) (*image.Image, error) {
+				time.Sleep(10 * time.Millisecond) // keep the image locked while the others try to update it
+				return current, current.Upgrade()
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	var upgraded int
+	for err := range errs {
+		switch {
+		case err == nil:
+			upgraded++
+		case !errors.Is(err, image.ErrAlreadyBuilding):
+			t.Errorf("MemoryImageRepository.UpdateImage() error = %v, wantErr %v", err, image.ErrAlreadyBuilding)
+		}
+	}
+	if upgraded != 1 {
+		t.Errorf("MemoryImageRepository.UpdateImage() upgraded the image %d times, want 1", upgraded)
+	}
+	got, err := repository.GetImage(context.Background(), created.UUID())
+	if err != nil {
+		t.Fatalf("failed to get image: %s", err)
+	}
+	if got.Version().Uint() != 2 || !got.Status().IsBuilding() {
+		t.Errorf("MemoryImageRepository.UpdateImage() = version %d %s, want version 2 building", got.Version().Uint(), got.Status())
+	}
+}
+
+func TestMemoryImageRepository_DeleteImage(t *testing.T) {
+	config.Init()
+	repository := NewMemoryImageRepository()
+	created := validImage
+	if err := repository.CreateImage(context.Background(), &created); err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	if err := repository.DeleteImage(context.Background(), created.UUID()); err != nil {
+		t.Fatalf("MemoryImageRepository.DeleteImage() error = %v", err)
+	}
+	if _, err := repository.GetImage(context.Background(), created.UUID()); err != image.ErrImageNotFound {
+		t.Errorf("MemoryImageRepository.GetImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	if err := repository.UpdateImage(context.Background(), created.UUID(), func(current *image.Image) (*image.Image, error) {
+		return current, nil
+	}); err != image.ErrImageNotFound {
+		t.Errorf("MemoryImageRepository.UpdateImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	deleted, err := repository.GetDeletedImages(context.Background())
+	if err != nil || len(deleted) != 1 || deleted[0].UUID() != created.UUID() || deleted[0].DeletedAt().IsZero() {
+		t.Fatalf("MemoryImageRepository.GetDeletedImages() = %v, %v, want the deleted image", deleted, err)
+	}
+	if err := repository.RestoreImage(context.Background(), created.UUID()); err != nil {
+		t.Fatalf("MemoryImageRepository.RestoreImage() error = %v", err)
+	}
+	if err := repository.RestoreImage(context.Background(), created.UUID()); err != image.ErrImageNotFound {
+		t.Errorf("MemoryImageRepository.RestoreImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	if got, err := repository.GetImage(context.Background(), created.UUID()); err != nil || !got.DeletedAt().IsZero() {
+		t.Errorf("MemoryImageRepository.GetImage() = %v, %v, want the restored image", got, err)
+	}
+	if err := repository.DeleteImage(context.Background(), created.UUID()); err != nil {
+		t.Fatalf("MemoryImageRepository.DeleteImage() error = %v", err)
+	}
+	stale := image.ContextWithPrecondition(context.Background(), `"1-0"`)
+	if err := repository.PurgeImage(stale, created.UUID()); err != image.ErrPreconditionFailed {
+		t.Errorf("MemoryImageRepository.PurgeImage() error = %v, wantErr %v", err, image.ErrPreconditionFailed)
+	}
+	if err := repository.PurgeImage(context.Background(), created.UUID()); err != nil {
+		t.Fatalf("MemoryImageRepository.PurgeImage() error = %v", err)
+	}
+	if err := repository.PurgeImage(context.Background(), created.UUID()); err != image.ErrImageNotFound {
+		t.Errorf("MemoryImageRepository.PurgeImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	if deleted, _ := repository.GetDeletedImages(context.Background()); len(deleted) != 0 {
+		t.Errorf("MemoryImageRepository.GetDeletedImages() = %v, want none", deleted)
+	}
+}
+
+func TestMemoryImageRepository_GetImages(t *testing.T) {
+	config.Init()
+	repository := NewMemoryImageRepository()
+	first, second := validImage, anotherValidImage
+	for _, img := range []*image.Image{&first, &second} {
+		if err := repository.CreateImage(context.Background(), img); err != nil {
+			t.Fatalf("failed to create image: %s", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		spec      func() (image.Spec, error)
+		want      []*image.Image
+		wantTotal int
+	}{
+		{
+			name:      "should list the images in creation order",
+			spec:      func() (image.Spec, error) { return image.NewSpec("", "", "", 0, 0) },
+			want:      []*image.Image{&first, &second},
+			wantTotal: 2,
+		},
+		{
+			name:      "should sort the images by descending name",
+			spec:      func() (image.Spec, error) { return image.NewSpec("", "", "-name", 0, 0) },
+			want:      []*image.Image{&second, &first},
+			wantTotal: 2,
+		},
+		{
+			name:      "should page the images",
+			spec:      func() (image.Spec, error) { return image.NewSpec("", "", "", 1, 1) },
+			want:      []*image.Image{&second},
+			wantTotal: 2,
+		},
+		{
+			name:      "should filter the images by name",
+			spec:      func() (image.Spec, error) { return image.NewSpec("image-2", "", "", 0, 0) },
+			want:      []*image.Image{&second},
+			wantTotal: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := tt.spec()
+			if err != nil {
+				t.Fatalf("image.NewSpec() error = %v", err)
+			}
+			got, total, err := repository.GetImages(context.Background(), spec)
+			if err != nil {
+				t.Fatalf("MemoryImageRepository.GetImages() error = %v", err)
+			}
+			if total != tt.wantTotal || len(got) != len(tt.want) {
+				t.Fatalf("MemoryImageRepository.GetImages() = %d images, total %d, want %d, total %d",
+					len(got), total, len(tt.want), tt.wantTotal)
+			}
+			for i := range got {
+				if !areEqualImages(got[i], tt.want[i]) {
+					t.Errorf("MemoryImageRepository.GetImages()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryImageRepository_GetTagCatalog(t *testing.T) {
+	config.Init()
+	repository := NewMemoryImageRepository()
+	first, second := validImage, anotherValidImage
+	for _, img := range []*image.Image{&first, &second} {
+		if err := repository.CreateImage(context.Background(), img); err != nil {
+			t.Fatalf("failed to create image: %s", err)
+		}
+	}
+	err := repository.UpdateImage(context.Background(), second.UUID(), func(current *image.Image) (*image.Image, error) {
+		current.AddTag(common.NewTag("berlin"))
+		return current, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to update image: %s", err)
+	}
+
+	got, err := repository.GetTagCatalog(context.Background(), "")
+	if err != nil {
+		t.Fatalf("MemoryImageRepository.GetTagCatalog() error = %v", err)
+	}
+	want := []catalogCount{{name: "tag1", count: 2}, {name: "tag2", count: 2}, {name: "berlin", count: 1}}
+	if counts := catalogCounts(got); !reflect.DeepEqual(counts, want) {
+		t.Errorf("MemoryImageRepository.GetTagCatalog() = %v, want %v", counts, want)
+	}
+	// the deleted images aren't counted
+	if err := repository.DeleteImage(context.Background(), second.UUID()); err != nil {
+		t.Fatalf("failed to delete image: %s", err)
+	}
+	got, err = repository.GetPackageCatalog(context.Background(), "vi")
+	if err != nil {
+		t.Fatalf("MemoryImageRepository.GetPackageCatalog() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Name() != "vim" || !reflect.DeepEqual(got[0].Images(), []string{first.UUID()}) {
+		t.Errorf("MemoryImageRepository.GetPackageCatalog() = %v, want vim used by the first image", got)
+	}
+}
+
+func TestMemoryImageRepository_SearchImages(t *testing.T) {
+	config.Init()
+	repository := NewMemoryImageRepository()
+	kiosk := newTestSearchImage(t, "kiosk-berlin", "Kiosk of the Berlin warehouse", []string{"berlin"}, []string{"nginx"}, nil)
+	proxy := newTestSearchImage(t, "proxy", "Serves nginx", []string{"munich"}, []string{"vim"}, nil)
+	for _, img := range []*image.Image{&kiosk, &proxy} {
+		if err := repository.CreateImage(context.Background(), img); err != nil {
+			t.Fatalf("failed to create image: %s", err)
+		}
+	}
+	q, err := image.NewSearchQuery("nginx", image.DefaultLimit)
+	if err != nil {
+		t.Fatalf("image.NewSearchQuery() error = %v", err)
+	}
+	got, err := repository.SearchImages(context.Background(), q)
+	if err != nil {
+		t.Fatalf("MemoryImageRepository.SearchImages() error = %v", err)
+	}
+	if names := searchNames(got); !reflect.DeepEqual(names, []string{"kiosk-berlin", "proxy"}) {
+		t.Errorf("MemoryImageRepository.SearchImages() = %v, want kiosk-berlin then proxy", names)
+	}
+	// the deleted images aren't found
+	if err := repository.DeleteImage(context.Background(), kiosk.UUID()); err != nil {
+		t.Fatalf("failed to delete image: %s", err)
+	}
+	got, err = repository.SearchImages(context.Background(), q)
+	if err != nil {
+		t.Fatalf("MemoryImageRepository.SearchImages() error = %v", err)
+	}
+	if names := searchNames(got); !reflect.DeepEqual(names, []string{"proxy"}) {
+		t.Errorf("MemoryImageRepository.SearchImages() = %v, want proxy", names)
+	}
+}
@@ -0,0 +1,186 @@
+package adapters
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
+)
+
+// Default times to live of the cached entries.
+const (
+	DefaultRedisImageTTL  = 10 * time.Minute
+	DefaultRedisDeviceTTL = 10 * time.Minute
+	DefaultRedisListTTL   = 1 * time.Minute
+)
+
+// DefaultRedisAddr is the address of the redis server when none is configured.
+const DefaultRedisAddr = ":6379"
+
+// ErrInvalidRedisCA is the error returned when the CA file of the redis server holds no PEM certificate.
+var ErrInvalidRedisCA = errors.New("invalid redis ca, no PEM certificate found")
+
+// RedisConfig is the configuration of the redis cache.
+type RedisConfig struct {
+	// Addrs are the addresses of the server, the sentinels or the cluster nodes, host:port.
+	Addrs []string
+	// Username and Password authenticate with an ACL user, only the password with the default user.
+	Username string
+	Password string
+	// DB is the database selected, unsupported in cluster mode.
+	DB int
+	// TLS connects over TLS, verified against the CA in the file TLSCA if given, the system roots otherwise.
+	TLS   bool
+	TLSCA string
+	// MasterName is the name of the master monitored by the sentinels at Addrs, empty without Sentinel.
+	MasterName string
+	// Cluster connects to the cluster the nodes at Addrs belong to.
+	Cluster bool
+	// PoolSize is the maximum number of connections, per node in cluster mode, 0 for the default.
+	PoolSize int
+	// MinIdleConns is the number of idle connections kept open.
+	MinIdleConns int
+
+	// ImageTTL is how long an image stays cached.
+	ImageTTL time.Duration
+	// DeviceTTL is how long a device stays cached.
+	DeviceTTL time.Duration
+	// ListTTL is how long the page of a list query stays cached.
+	ListTTL time.Duration
+}
+
+// NewRedisConfig reads the redis configuration from the environment, falling back to the defaults:
+//
+//	REDIS_ADDRS            comma separated addresses, ":6379" by default
+//	REDIS_USERNAME         ACL user
+//	REDIS_PASSWORD         password of the user
+//	REDIS_DB               database, 0 by default
+//	REDIS_TLS              "true" to connect over TLS, implied by REDIS_TLS_CA
+//	REDIS_TLS_CA           PEM file of the CA of the server
+//	REDIS_SENTINEL_MASTER  master name, the addresses are the ones of the sentinels
+//	REDIS_CLUSTER          "true" if the addresses are the ones of cluster nodes
+//	REDIS_POOL_SIZE        maximum number of connections
+//	REDIS_MIN_IDLE_CONNS   number of idle connections kept open
+//
+// Times to live are durations, like "10m", read from REDIS_IMAGE_TTL, REDIS_DEVICE_TTL and REDIS_LIST_TTL.
+func NewRedisConfig() RedisConfig {
+	options := viper.New()
+	options.SetDefault("REDIS_ADDRS", DefaultRedisAddr)
+	options.SetDefault("REDIS_IMAGE_TTL", DefaultRedisImageTTL)
+	options.SetDefault("REDIS_DEVICE_TTL", DefaultRedisDeviceTTL)
+	options.SetDefault("REDIS_LIST_TTL", DefaultRedisListTTL)
+	options.AutomaticEnv()
+
+	var addrs []string
+	for _, addr := range strings.Split(options.GetString("REDIS_ADDRS"), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		addrs = []string{DefaultRedisAddr}
+	}
+	return RedisConfig{
+		Addrs:        addrs,
+		Username:     options.GetString("REDIS_USERNAME"),
+		Password:     options.GetString("REDIS_PASSWORD"),
+		DB:           options.GetInt("REDIS_DB"),
+		TLS:          options.GetBool("REDIS_TLS") || options.GetString("REDIS_TLS_CA") != "",
+		TLSCA:        options.GetString("REDIS_TLS_CA"),
+		MasterName:   options.GetString("REDIS_SENTINEL_MASTER"),
+		Cluster:      options.GetBool("REDIS_CLUSTER"),
+		PoolSize:     options.GetInt("REDIS_POOL_SIZE"),
+		MinIdleConns: options.GetInt("REDIS_MIN_IDLE_CONNS"),
+		ImageTTL:     positiveOr(options.GetDuration("REDIS_IMAGE_TTL"), DefaultRedisImageTTL),
+		DeviceTTL:    positiveOr(options.GetDuration("REDIS_DEVICE_TTL"), DefaultRedisDeviceTTL),
+		ListTTL:      positiveOr(options.GetDuration("REDIS_LIST_TTL"), DefaultRedisListTTL),
+	}
+}
+
+// positiveOr returns the duration if positive, the fallback otherwise, as redis keeps keys without ttl forever.
+func positiveOr(d, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+// UniversalOptions returns the options of the client of the configuration.
+func (cfg RedisConfig) UniversalOptions() (*redis.UniversalOptions, error) {
+	opts := &redis.UniversalOptions{
+		Addrs:        cfg.Addrs,
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		MasterName:   cfg.MasterName,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+	}
+	if !cfg.TLS {
+		return opts, nil
+	}
+	opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.TLSCA != "" {
+		pem, err := ioutil.ReadFile(cfg.TLSCA)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRedisCA, cfg.TLSCA)
+		}
+		opts.TLSConfig.RootCAs = pool
+	}
+	return opts, nil
+}
+
+// NewRedisClient returns a new client of the configuration: a cluster client in cluster mode,
+// a failover client with a sentinel master name, a single server client otherwise.
+// It panics if the CA of the server can't be read.
+func NewRedisClient(cfg RedisConfig) redis.UniversalClient {
+	opts, err := cfg.UniversalOptions()
+	if err != nil {
+		panic(err)
+	}
+	if cfg.Cluster {
+		return redis.NewClusterClient(opts.Cluster())
+	}
+	if cfg.MasterName != "" {
+		return redis.NewFailoverClient(opts.Failover())
+	}
+	return redis.NewClient(opts.Simple())
+}
+
+// PingRedis returns an error if the redis server can't be reached within the timeout.
+func PingRedis(ctx context.Context, rdb redis.UniversalClient, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return rdb.Ping(ctx).Err()
+}
+
+// accountHashTag returns the hash tag prefixing the Redis keys of the account, so that in cluster mode
+// every key of the account lives in the same slot and the transactions over them stay on a single node.
+func accountHashTag(account common.Account) string {
+	return "{" + account.String() + "}"
+}
+
+// scanKeys returns an iterator over the keys matching the pattern. In cluster mode, it scans the master
+// owning the slot of the pattern, so the pattern must start with the hash tag of the account.
+func scanKeys(ctx context.Context, rdb redis.UniversalClient, pattern string) (*redis.ScanIterator, error) {
+	if cluster, ok := rdb.(*redis.ClusterClient); ok {
+		master, err := cluster.MasterForKey(ctx, pattern)
+		if err != nil {
+			return nil, err
+		}
+		return master.Scan(ctx, 0, pattern, 0).Iterator(), nil
+	}
+	return rdb.Scan(ctx, 0, pattern, 0).Iterator(), nil
+}
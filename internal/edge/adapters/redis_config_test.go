@@ -0,0 +1,193 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestNewRedisConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want RedisConfig
+	}{
+		{
+			name: "should default to a local server",
+			env:  map[string]string{},
+			want: RedisConfig{
+				Addrs:     []string{DefaultRedisAddr},
+				ImageTTL:  DefaultRedisImageTTL,
+				DeviceTTL: DefaultRedisDeviceTTL,
+				ListTTL:   DefaultRedisListTTL,
+			},
+		},
+		{
+			name: "should read the sentinels from the environment",
+			env: map[string]string{
+				"REDIS_ADDRS":           "sentinel-0:26379, sentinel-1:26379,",
+				"REDIS_USERNAME":        "edge",
+				"REDIS_PASSWORD":        "secret",
+				"REDIS_DB":              "2",
+				"REDIS_TLS_CA":          "/etc/redis/ca.pem",
+				"REDIS_SENTINEL_MASTER": "mymaster",
+				"REDIS_POOL_SIZE":       "20",
+				"REDIS_MIN_IDLE_CONNS":  "5",
+				"REDIS_LIST_TTL":        "30s",
+			},
+			want: RedisConfig{
+				Addrs:        []string{"sentinel-0:26379", "sentinel-1:26379"},
+				Username:     "edge",
+				Password:     "secret",
+				DB:           2,
+				TLS:          true,
+				TLSCA:        "/etc/redis/ca.pem",
+				MasterName:   "mymaster",
+				PoolSize:     20,
+				MinIdleConns: 5,
+				ImageTTL:     DefaultRedisImageTTL,
+				DeviceTTL:    DefaultRedisDeviceTTL,
+				ListTTL:      30 * time.Second,
+			},
+		},
+		{
+			name: "should read the cluster mode from the environment",
+			env: map[string]string{
+				"REDIS_ADDRS":   "node-0:6379,node-1:6379,node-2:6379",
+				"REDIS_CLUSTER": "true",
+				"REDIS_TLS":     "true",
+			},
+			want: RedisConfig{
+				Addrs:     []string{"node-0:6379", "node-1:6379", "node-2:6379"},
+				TLS:       true,
+				Cluster:   true,
+				ImageTTL:  DefaultRedisImageTTL,
+				DeviceTTL: DefaultRedisDeviceTTL,
+				ListTTL:   DefaultRedisListTTL,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if got := NewRedisConfig(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRedisConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRedisClient(t *testing.T) {
+	invalidCA := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(invalidCA, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		cfg       RedisConfig
+		want      reflect.Type
+		wantPanic bool
+	}{
+		{
+			name: "should create a client of a single server",
+			cfg:  RedisConfig{Addrs: []string{DefaultRedisAddr}},
+			want: reflect.TypeOf(&redis.Client{}),
+		},
+		{
+			name: "should create a failover client with a sentinel master",
+			cfg:  RedisConfig{Addrs: []string{"sentinel-0:26379", "sentinel-1:26379"}, MasterName: "mymaster"},
+			want: reflect.TypeOf(&redis.Client{}),
+		},
+		{
+			name: "should create a cluster client in cluster mode",
+			cfg:  RedisConfig{Addrs: []string{"node-0:6379"}, Cluster: true},
+			want: reflect.TypeOf(&redis.ClusterClient{}),
+		},
+		{
+			name:      "should panic if the ca is invalid",
+			cfg:       RedisConfig{Addrs: []string{DefaultRedisAddr}, TLS: true, TLSCA: invalidCA},
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantPanic {
+					t.Errorf("NewRedisClient() panic = %v, wantPanic %v", r, tt.wantPanic)
+				}
+			}()
+			got := NewRedisClient(tt.cfg)
+			defer got.Close()
+			if reflect.TypeOf(got) != tt.want {
+				t.Errorf("NewRedisClient() = %T, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedisConfig_UniversalOptions(t *testing.T) {
+	invalidCA := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(invalidCA, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		cfg     RedisConfig
+		wantTLS bool
+		wantErr error
+	}{
+		{
+			name:    "should not use tls",
+			cfg:     RedisConfig{Addrs: []string{DefaultRedisAddr}},
+			wantTLS: false,
+		},
+		{
+			name:    "should use tls with the system roots",
+			cfg:     RedisConfig{Addrs: []string{DefaultRedisAddr}, TLS: true},
+			wantTLS: true,
+		},
+		{
+			name:    "should fail if the ca holds no certificate",
+			cfg:     RedisConfig{Addrs: []string{DefaultRedisAddr}, TLS: true, TLSCA: invalidCA},
+			wantErr: ErrInvalidRedisCA,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cfg.UniversalOptions()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RedisConfig.UniversalOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (got.TLSConfig != nil) != tt.wantTLS {
+				t.Errorf("RedisConfig.UniversalOptions() TLSConfig = %v, wantTLS %v", got.TLSConfig, tt.wantTLS)
+			}
+		})
+	}
+}
+
+func TestPingRedis(t *testing.T) {
+	setupRedis(t)
+	defer teardownRedis(t)
+
+	if err := PingRedis(context.Background(), redisClient, time.Second); err != nil {
+		t.Errorf("PingRedis() error = %v", err)
+	}
+	redisServer.Close()
+	if err := PingRedis(context.Background(), redisClient, time.Second); err == nil {
+		t.Errorf("PingRedis() error = nil, want an error once the server is down")
+	}
+}
@@ -0,0 +1,175 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/go-redis/redis/v8"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RedisDeviceRepository is a Redis implementation of the Device.Repository interface.
+type RedisDeviceRepository struct {
+	db  redis.UniversalClient
+	ttl time.Duration
+}
+
+// NewRedisDeviceRepository returns a new Redis implementation of the Device.Repository interface.
+// Devices are cached for the device ttl of the configuration.
+func NewRedisDeviceRepository(db redis.UniversalClient, cfg RedisConfig) *RedisDeviceRepository {
+	if db == nil {
+		panic("db cannot be nil")
+	}
+	return &RedisDeviceRepository{db: db, ttl: cfg.DeviceTTL}
+}
+
+// deviceKey returns the Redis key of the device with the given UUID.
+func deviceKey(account common.Account, uuid string) string {
+	return fmt.Sprintf("%s:%s:%s", accountHashTag(account), "device", uuid) // <- {account}:device:uuid
+}
+
+// CreateDevice creates a new device, implementing the Device.Repository interface.
+func (r *RedisDeviceRepository) CreateDevice(ctx context.Context, device *device.Device) error {
+	log.Debug("redis create device")
+	account, err := device.Account()
+	if err != nil {
+		return err
+	}
+	return r.db.Set(ctx, deviceKey(account, device.UUID()), device.MarshalRedis(), r.ttl).Err()
+}
+
+// GetDevice returns the device with the given UUID, implementing the Device.Repository interface.
+func (r *RedisDeviceRepository) GetDevice(ctx context.Context, uuid string) (*device.Device, error) {
+	log.WithField("uuid", uuid).Debug("redis get device")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.db.Get(ctx, deviceKey(account, uuid)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	cached, err := device.UnmarshalDeviceFromRedis(common.ContextWithAccount(context.Background(), account), result)
+	if err != nil {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+// UpdateDevice updates the device with the given UUID, implementing the Device.Repository interface.
+// The key is watched, so the update is not applied if the device is changed meanwhile.
+func (r *RedisDeviceRepository) UpdateDevice(ctx context.Context, uuid string, updateFn func(device *device.Device) (*device.Device, error)) error {
+	log.WithField("uuid", uuid).Debug("redis update device")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	key := deviceKey(account, uuid)
+	return r.db.Watch(ctx, func(tx *redis.Tx) error {
+		result, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			return err
+		}
+		cached, err := device.UnmarshalDeviceFromRedis(common.ContextWithAccount(context.Background(), account), result)
+		if err != nil {
+			return err
+		}
+		updatedDevice, err := updateFn(&cached)
+		if err != nil {
+			return err
+		}
+		updatedDevice.Touch(time.Now())
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return pipe.Set(ctx, key, updatedDevice.MarshalRedis(), r.ttl).Err()
+		})
+		return err
+	}, key)
+}
+
+// DeleteDevice deletes the device with the given UUID, implementing the Device.Repository interface.
+func (r *RedisDeviceRepository) DeleteDevice(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("redis delete device")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return r.db.Del(ctx, deviceKey(account, uuid)).Err()
+}
+
+// GetDevices returns a list of devices, implementing the Device.Repository interface.
+func (r *RedisDeviceRepository) GetDevices(ctx context.Context) ([]*device.Device, error) {
+	log.Debug("redis get devices")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := scanKeys(ctx, r.db, deviceKey(account, "*")) // <- {account}:device:*
+	if err != nil {
+		return nil, err
+	}
+	var devices []*device.Device
+	for iter.Next(ctx) {
+		result, err := r.db.Get(ctx, iter.Val()).Bytes()
+		if err != nil {
+			return nil, err
+		}
+		cached, err := device.UnmarshalDeviceFromRedis(common.ContextWithAccount(context.Background(), account), result)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, &cached)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+// GetImageDevices returns the cached devices assigned to or running a version of the image with the given UUID,
+// implementing the Device.Repository interface.
+func (r *RedisDeviceRepository) GetImageDevices(ctx context.Context, imageUUID string) ([]*device.Device, error) {
+	log.WithField("uuid", imageUUID).Debug("redis get image devices")
+	devices, err := r.GetDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var imageDevices []*device.Device
+	for _, d := range devices {
+		if d.Assignment().ImageUUID() == imageUUID || d.Running().ImageUUID() == imageUUID {
+			imageDevices = append(imageDevices, d)
+		}
+	}
+	return imageDevices, nil
+}
+
+// GetDriftedDevices returns the cached devices not running the image version they are assigned to,
+// implementing the Device.Repository interface.
+func (r *RedisDeviceRepository) GetDriftedDevices(ctx context.Context) ([]*device.Device, error) {
+	log.Debug("redis get drifted devices")
+	devices, err := r.GetDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var drifted []*device.Device
+	for _, d := range devices {
+		if !d.Drift().IsZero() {
+			drifted = append(drifted, d)
+		}
+	}
+	return drifted, nil
+}
+
+// GetDrift counts the drift of the cached devices per image they are assigned to,
+// implementing the Device.Repository interface.
+func (r *RedisDeviceRepository) GetDrift(ctx context.Context) ([]device.ImageDrift, error) {
+	log.Debug("redis get drift")
+	devices, err := r.GetDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return device.SummarizeDrift(devices), nil
+}
@@ -0,0 +1,91 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestRedisDeviceRepository_UpdateDevice(t *testing.T) {
+	setupRedis(t)
+	defer teardownRedis(t)
+
+	repository := NewRedisDeviceRepository(redisClient, NewRedisConfig())
+	kiosk := newTestDevice(t, "kiosk")
+	if err := repository.CreateDevice(context.Background(), &kiosk); err != nil {
+		t.Errorf("failed to create device: %s", err)
+	}
+	newName, _ := common.NewName("register")
+	tests := []struct {
+		name    string
+		uuid    string
+		wantErr error
+	}{
+		{
+			name:    "should rename the device",
+			uuid:    kiosk.UUID(),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, device not cached",
+			uuid:    "unknown-uuid",
+			wantErr: redis.Nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := repository.UpdateDevice(context.Background(), tt.uuid, func(d *device.Device) (*device.Device, error) {
+				d.SetName(newName)
+				return d, nil
+			})
+			if err != tt.wantErr {
+				t.Errorf("RedisDeviceRepository.UpdateDevice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, err := repository.GetDevice(context.Background(), tt.uuid)
+			if err != nil {
+				t.Errorf("failed to get device: %s", err)
+				return
+			}
+			if got.Name() != newName || got.Assignment() != kiosk.Assignment() {
+				t.Errorf("RedisDeviceRepository.UpdateDevice() = %s, want renamed %s", got.MarshalRedis(), kiosk.MarshalRedis())
+			}
+		})
+	}
+}
+
+func TestRedisDeviceRepository_GetDevices(t *testing.T) {
+	setupRedis(t)
+	defer teardownRedis(t)
+
+	repository := NewRedisDeviceRepository(redisClient, NewRedisConfig())
+	kiosk := newTestDevice(t, "kiosk")
+	if err := repository.CreateDevice(context.Background(), &kiosk); err != nil {
+		t.Errorf("failed to create device: %s", err)
+	}
+	// images share the keyspace, they must not be listed as devices
+	if err := NewRedisImageRepository(redisClient, NewRedisConfig()).CreateImage(context.Background(), &validImage); err != nil {
+		t.Errorf("failed to create image: %s", err)
+	}
+	got, err := repository.GetDevices(context.Background())
+	if err != nil {
+		t.Errorf("RedisDeviceRepository.GetDevices() error = %v", err)
+		return
+	}
+	if len(got) != 1 || got[0].UUID() != kiosk.UUID() {
+		t.Errorf("RedisDeviceRepository.GetDevices() = %v, want %v", deviceUUIDs(got), kiosk.UUID())
+	}
+	if err := repository.DeleteDevice(context.Background(), kiosk.UUID()); err != nil {
+		t.Errorf("RedisDeviceRepository.DeleteDevice() error = %v", err)
+	}
+	if got, _ := repository.GetDevices(context.Background()); len(got) != 0 {
+		t.Errorf("RedisDeviceRepository.GetDevices() = %v after delete, want none", deviceUUIDs(got))
+	}
+}
@@ -0,0 +1,213 @@
+package adapters
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/go-redis/redis/v8"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// cachedImageList is a cached page of a list query, along with the stamp of the lists it was read at.
+type cachedImageList struct {
+	Stamp int64    `json:"stamp"`
+	Total int      `json:"total"`
+	UUIDs []string `json:"uuids"`
+}
+
+// cachedImageKey returns the Redis key of the image with the given UUID.
+func cachedImageKey(account common.Account, uuid string) string {
+	return fmt.Sprintf("%s:%s:%s", accountHashTag(account), "image", uuid) // <- {account}:image:uuid
+}
+
+// imageStampKey returns the Redis key of the stamp of the image with the given UUID.
+func imageStampKey(account common.Account, uuid string) string {
+	return fmt.Sprintf("%s:%s:%s", accountHashTag(account), "image-stamp", uuid) // <- {account}:image-stamp:uuid
+}
+
+// imageListsKey returns the Redis key of the index set of the cached list pages of the account.
+func imageListsKey(account common.Account) string {
+	return fmt.Sprintf("%s:%s", accountHashTag(account), "image-lists") // <- {account}:image-lists
+}
+
+// imageListsStampKey returns the Redis key of the stamp of the list pages of the account.
+func imageListsStampKey(account common.Account) string {
+	return fmt.Sprintf("%s:%s", accountHashTag(account), "image-lists-stamp") // <- {account}:image-lists-stamp
+}
+
+// imageListKey returns the Redis key of the cached page of the spec, the spec is hashed as names are free text.
+func imageListKey(account common.Account, spec image.Spec) string {
+	sorts := make([]string, len(spec.Sorts()))
+	for i, sort := range spec.Sorts() {
+		sorts[i] = fmt.Sprintf("%s:%t", sort.Field(), sort.Desc())
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%q|%s|%s|%d|%d", spec.Name(), spec.Status(),
+		strings.Join(sorts, ","), spec.Limit(), spec.Offset())))
+	return fmt.Sprintf("%s:%s:%s", accountHashTag(account), "image-list", hex.EncodeToString(sum[:16])) // <- {account}:image-list:hash
+}
+
+// ImageStamp returns the stamp of the image with the given UUID, every invalidation of the image bumps it.
+// Read it before reading the image from the source of truth, then cache the image with it.
+func (r *RedisImageRepository) ImageStamp(ctx context.Context, uuid string) (int64, error) {
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	stamp, err := r.db.Get(ctx, imageStampKey(account, uuid)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return stamp, err
+}
+
+// CacheImage caches the image of the account read from the source of truth, unless it was invalidated
+// since its stamp was read. The account is given, so the image is cached without reading the configuration.
+func (r *RedisImageRepository) CacheImage(ctx context.Context, account common.Account, img *image.Image, stamp int64) error {
+	log.WithField("uuid", img.UUID()).Debug("redis cache image")
+	return r.writeAtStamp(ctx, imageStampKey(account, img.UUID()), stamp, func(pipe redis.Pipeliner) error {
+		return pipe.Set(ctx, cachedImageKey(account, img.UUID()), img.MarshalRedis(), r.imageTTL).Err()
+	})
+}
+
+// GetImageList returns the uuids of the cached page of the spec along with the total number of images matching it,
+// and the stamp of the lists of the account, to cache the page with on a miss. It returns redis.Nil on a miss.
+func (r *RedisImageRepository) GetImageList(ctx context.Context, spec image.Spec) ([]string, int, int64, error) {
+	log.Debug("redis get image list")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	values, err := r.db.MGet(ctx, imageListsStampKey(account), imageListKey(account, spec)).Result()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	var stamp int64
+	if value, ok := values[0].(string); ok {
+		if stamp, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+	value, ok := values[1].(string)
+	if !ok {
+		return nil, 0, stamp, redis.Nil
+	}
+	var list cachedImageList
+	if err := json.Unmarshal([]byte(value), &list); err != nil {
+		return nil, 0, stamp, err
+	}
+	if list.Stamp != stamp { // cached by a read that raced an invalidation
+		return nil, 0, stamp, redis.Nil
+	}
+	return list.UUIDs, list.Total, stamp, nil
+}
+
+// GetCachedImages returns the cached images with the given UUIDs, in order. It returns redis.Nil if any is missing.
+func (r *RedisImageRepository) GetCachedImages(ctx context.Context, uuids []string) ([]*image.Image, error) {
+	log.Debug("redis get cached images")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	images := make([]*image.Image, len(uuids))
+	if len(uuids) == 0 {
+		return images, nil
+	}
+	keys := make([]string, len(uuids))
+	for i, uuid := range uuids {
+		keys[i] = cachedImageKey(account, uuid)
+	}
+	values, err := r.db.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			return nil, redis.Nil
+		}
+		cached, err := image.UnmarshalImageFromRedis(common.ContextWithAccount(context.Background(), account), []byte(data))
+		if err != nil {
+			return nil, err
+		}
+		images[i] = &cached
+	}
+	return images, nil
+}
+
+// CacheImageList caches the page of the spec along with its images, unless the lists of the account
+// were invalidated since their stamp was read. Every invalidation of an image invalidates the lists as well.
+func (r *RedisImageRepository) CacheImageList(ctx context.Context, spec image.Spec, images []*image.Image, total int, stamp int64) error {
+	log.Debug("redis cache image list")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	list := cachedImageList{Stamp: stamp, Total: total, UUIDs: make([]string, len(images))}
+	for i, img := range images {
+		list.UUIDs[i] = img.UUID()
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	listKey, indexKey := imageListKey(account, spec), imageListsKey(account)
+	return r.writeAtStamp(ctx, imageListsStampKey(account), stamp, func(pipe redis.Pipeliner) error {
+		for _, img := range images {
+			pipe.Set(ctx, cachedImageKey(account, img.UUID()), img.MarshalRedis(), r.imageTTL)
+		}
+		pipe.Set(ctx, listKey, data, r.listTTL)
+		pipe.SAdd(ctx, indexKey, listKey)
+		return pipe.Expire(ctx, indexKey, r.listTTL).Err()
+	})
+}
+
+// InvalidateImage drops the cached image with the given UUID and every cached list page of its account.
+// Their stamps are bumped, so what was read before the invalidation is never cached. Stamps never expire,
+// a stamp restarting from zero could match the one a stale read was made at.
+func (r *RedisImageRepository) InvalidateImage(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("redis invalidate image")
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	// pages cached after the members are read carry the previous stamp, they are ignored until they expire
+	indexKey := imageListsKey(account)
+	lists, err := r.db.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+	stampKey := imageStampKey(account, uuid)
+	_, err = r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, stampKey)
+		pipe.Incr(ctx, imageListsStampKey(account))
+		return pipe.Del(ctx, append([]string{cachedImageKey(account, uuid), indexKey}, lists...)...).Err()
+	})
+	return err
+}
+
+// writeAtStamp runs the writes in a transaction, unless the stamp changed since it was read.
+func (r *RedisImageRepository) writeAtStamp(ctx context.Context, stampKey string, stamp int64, write func(pipe redis.Pipeliner) error) error {
+	err := r.db.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, stampKey).Int64()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if current != stamp { // invalidated since it was read, what was read is stale
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, write)
+		return err
+	}, stampKey)
+	if err == redis.TxFailedErr { // invalidated while caching
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestRedisImageRepository_CacheImage(t *testing.T) {
+	setupRedis(t)
+	defer teardownRedis(t)
+	repository := NewRedisImageRepository(redisClient, NewRedisConfig())
+
+	tests := []struct {
+		name       string
+		invalidate bool
+		wantErr    error
+	}{
+		{
+			name:    "should cache the image, not invalidated since read",
+			wantErr: nil,
+		},
+		{
+			name:       "should not cache the image, invalidated since read",
+			invalidate: true,
+			wantErr:    image.ErrImageNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repository.InvalidateImage(context.Background(), validImage.UUID()); err != nil {
+				t.Fatalf("RedisImageRepository.InvalidateImage() error = %v", err)
+			}
+			stamp, err := repository.ImageStamp(context.Background(), validImage.UUID())
+			if err != nil {
+				t.Fatalf("RedisImageRepository.ImageStamp() error = %v", err)
+			}
+			if tt.invalidate { // an update is stored while the image is read
+				if err := repository.InvalidateImage(context.Background(), validImage.UUID()); err != nil {
+					t.Fatalf("RedisImageRepository.InvalidateImage() error = %v", err)
+				}
+			}
+			if err := repository.CacheImage(context.Background(), common.DefaultAccount, &validImage, stamp); err != nil {
+				t.Errorf("RedisImageRepository.CacheImage() error = %v", err)
+			}
+			if _, err := repository.GetImage(context.Background(), validImage.UUID()); err != tt.wantErr {
+				t.Errorf("RedisImageRepository.GetImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedisImageRepository_InvalidateImage_StampNeverExpires(t *testing.T) {
+	setupRedis(t)
+	defer teardownRedis(t)
+	defer redisServer.Close()
+	redisConfig := NewRedisConfig()
+	repository := NewRedisImageRepository(redisClient, redisConfig)
+
+	// an image is read at stamp 1, then it is invalidated while the cache entries it had expire
+	if err := repository.InvalidateImage(context.Background(), validImage.UUID()); err != nil {
+		t.Fatalf("RedisImageRepository.InvalidateImage() error = %v", err)
+	}
+	stamp, err := repository.ImageStamp(context.Background(), validImage.UUID())
+	if err != nil {
+		t.Fatalf("RedisImageRepository.ImageStamp() error = %v", err)
+	}
+	if err := repository.InvalidateImage(context.Background(), validImage.UUID()); err != nil {
+		t.Fatalf("RedisImageRepository.InvalidateImage() error = %v", err)
+	}
+	redisServer.FastForward(2 * redisConfig.ImageTTL)
+	if err := repository.InvalidateImage(context.Background(), validImage.UUID()); err != nil {
+		t.Fatalf("RedisImageRepository.InvalidateImage() error = %v", err)
+	}
+	if err := repository.CacheImage(context.Background(), common.DefaultAccount, &validImage, stamp); err != nil {
+		t.Errorf("RedisImageRepository.CacheImage() error = %v", err)
+	}
+	if _, err := repository.GetImage(context.Background(), validImage.UUID()); err != image.ErrImageNotFound {
+		t.Errorf("RedisImageRepository.GetImage() error = %v, want the stale image not cached", err)
+	}
+}
+
+func TestRedisImageRepository_CacheImageList(t *testing.T) {
+	setupRedis(t)
+	defer teardownRedis(t)
+	repository := NewRedisImageRepository(redisClient, NewRedisConfig())
+	spec, _ := image.NewSpec("test", "", "name", 10, 0)
+
+	tests := []struct {
+		name       string
+		invalidate bool
+		wantErr    error
+	}{
+		{
+			name:    "should cache the page and its images",
+			wantErr: nil,
+		},
+		{
+			name:       "should not cache the page, an image was invalidated since read",
+			invalidate: true,
+			wantErr:    redis.Nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repository.InvalidateImage(context.Background(), anotherValidImage.UUID()); err != nil {
+				t.Fatalf("RedisImageRepository.InvalidateImage() error = %v", err)
+			}
+			_, _, stamp, err := repository.GetImageList(context.Background(), spec)
+			if err != redis.Nil {
+				t.Fatalf("RedisImageRepository.GetImageList() error = %v, wantErr %v", err, redis.Nil)
+			}
+			if tt.invalidate {
+				if err := repository.InvalidateImage(context.Background(), anotherValidImage.UUID()); err != nil {
+					t.Fatalf("RedisImageRepository.InvalidateImage() error = %v", err)
+				}
+			}
+			images := []*image.Image{&validImage, &anotherValidImage}
+			if err := repository.CacheImageList(context.Background(), spec, images, 2, stamp); err != nil {
+				t.Errorf("RedisImageRepository.CacheImageList() error = %v", err)
+			}
+			uuids, total, _, err := repository.GetImageList(context.Background(), spec)
+			if err != tt.wantErr {
+				t.Errorf("RedisImageRepository.GetImageList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := repository.GetCachedImages(context.Background(), uuids)
+			if err != nil || total != 2 || len(got) != 2 || !areEqualImages(got[0], &validImage) ||
+				!areEqualImages(got[1], &anotherValidImage) {
+				t.Errorf("RedisImageRepository.GetCachedImages() = %v, %v, %v, want the cached page", got, total, err)
+			}
+		})
+	}
+}
@@ -2,29 +2,29 @@ package adapters
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
 	"github.com/go-redis/redis/v8"
-	"github.com/redhatinsights/edge-api/config"
 
 	log "github.com/sirupsen/logrus"
 )
 
 // RedisImageRepository is a Redis implementation of the Image.Repository interface.
 type RedisImageRepository struct {
-	db *redis.Client
+	db       redis.UniversalClient
+	imageTTL time.Duration
+	listTTL  time.Duration
 }
 
 // NewRedisImageRepository returns a new Redis implementation of the Image.Repository interface.
-func NewRedisImageRepository(db *redis.Client) *RedisImageRepository {
+// Images and list pages are cached for the ttls of the configuration.
+func NewRedisImageRepository(db redis.UniversalClient, cfg RedisConfig) *RedisImageRepository {
 	if db == nil {
 		panic("db cannot be nil")
 	}
-	return &RedisImageRepository{db: db}
+	return &RedisImageRepository{db: db, imageTTL: cfg.ImageTTL, listTTL: cfg.ListTTL}
 }
 
 // imageKey returns the Redis key for the image.
@@ -33,7 +33,7 @@ func imageKey(image *image.Image) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%s:%s:%s", account.String(), "image", image.UUID()), nil // <- account:image:uuid
+	return cachedImageKey(account, image.UUID()), nil
 }
 
 // CreateImage creates a new image, implementing the Image.Repository interface.
@@ -43,7 +43,8 @@ func (r *RedisImageRepository) CreateImage(ctx context.Context, image *image.Ima
 	if err != nil {
 		return err
 	}
-	return r.db.Set(ctx, key, image.MarshalRedis(), 10*time.Minute).Err()
+	image.Touch(time.Now())
+	return r.db.Set(ctx, key, image.MarshalRedis(), r.imageTTL).Err()
 }
 
 // GetImage returns the image with the given UUID, implementing the Image.Repository interface.
@@ -53,29 +54,57 @@ func (r *RedisImageRepository) GetImage(ctx context.Context, uuid string) (*imag
 	if err != nil {
 		return nil, err
 	}
-	key := fmt.Sprintf("%s:%s:%s", account.String(), "image", uuid) // <- account:image:uuid
-	result, err := r.db.Get(ctx, key).Result()
+	result, err := r.db.Get(ctx, cachedImageKey(account, uuid)).Result()
+	if err == redis.Nil {
+		return nil, image.ErrImageNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	cached, err := image.UnmarshalImageFromRedis(common.ContextWithAccount(context.Background(), account), []byte(result))
 	if err != nil {
 		return nil, err
 	}
-	// Unmarshal the result into an image.
-	var image image.Image
-	err = json.Unmarshal([]byte(result), &image)
-	return &image, err
+	return &cached, nil
 }
 
 // UpdateImage updates the image with the given UUID, implementing the Image.Repository interface.
+// The key is watched, so the update fails with image.ErrPreconditionFailed if the image is changed meanwhile,
+// or if it doesn't match the precondition carried by the context.
 func (r *RedisImageRepository) UpdateImage(ctx context.Context, uuid string, updateFn func(image *image.Image) (*image.Image, error)) error {
 	log.WithField("uuid", uuid).Debug("redis update image")
-	image, err := r.GetImage(ctx, uuid)
+	account, err := common.GetAccountFromContext(ctx)
 	if err != nil {
 		return err
 	}
-	updatedImage, err := updateFn(image)
-	if err != nil {
+	key := cachedImageKey(account, uuid)
+	err = r.db.Watch(ctx, func(tx *redis.Tx) error {
+		result, err := tx.Get(ctx, key).Result()
+		if err == redis.Nil {
+			return image.ErrImageNotFound
+		} else if err != nil {
+			return err
+		}
+		cached, err := image.UnmarshalImageFromRedis(common.ContextWithAccount(context.Background(), account), []byte(result))
+		if err != nil {
+			return err
+		}
+		if err := image.CheckPrecondition(ctx, cached); err != nil {
+			return err
+		}
+		updatedImage, err := updateFn(&cached)
+		if err != nil {
+			return err
+		}
+		updatedImage.Touch(time.Now())
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return pipe.Set(ctx, key, updatedImage.MarshalRedis(), r.imageTTL).Err()
+		})
 		return err
+	}, key)
+	if err == redis.TxFailedErr { // changed by someone else since it was read
+		return image.ErrPreconditionFailed
 	}
-	return r.CreateImage(ctx, updatedImage)
+	return err
 }
 
 // DeleteImage deletes the image with the given UUID, implementing the Image.Repository interface.
@@ -85,44 +114,65 @@ func (r *RedisImageRepository) DeleteImage(ctx context.Context, uuid string) err
 	if err != nil {
 		return err
 	}
-	key := fmt.Sprintf("%s:%s:%s", account.String(), "image", uuid) // <- account:image:uuid
-	return r.db.Del(ctx, key).Err()
+	deleted, err := r.db.Del(ctx, cachedImageKey(account, uuid)).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return image.ErrImageNotFound
+	}
+	return nil
 }
 
-// GetImages returns a list of images, implementing the Image.Repository interface.
-func (r *RedisImageRepository) GetImages(ctx context.Context) ([]*image.Image, error) {
+// GetImages returns the page of cached images the spec lists along with the total number of cached images
+// matching it, implementing the Image.Repository interface.
+func (r *RedisImageRepository) GetImages(ctx context.Context, spec image.Spec) ([]*image.Image, int, error) {
 	log.Debug("redis get images")
 	account, err := common.GetAccountFromContext(ctx)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	iter, err := scanKeys(ctx, r.db, cachedImageKey(account, "*")) // <- {account}:image:*
+	if err != nil {
+		return nil, 0, err
 	}
-	prefix := fmt.Sprintf("%s:%s", account.String(), "image:*") // 000000:image:* <- account:image:*
-	iter := r.db.Scan(ctx, 0, prefix, 0).Iterator()
 	var images []*image.Image
 	for iter.Next(ctx) {
 		result, err := r.db.Get(ctx, iter.Val()).Result()
-		if err != nil {
-			return nil, err
+		if err == redis.Nil { // expired since scanned
+			continue
+		} else if err != nil {
+			return nil, 0, err
 		}
-		// Unmarshal the result into an image.
-		var image image.Image
-		err = json.Unmarshal([]byte(result), &image)
+		cached, err := image.UnmarshalImageFromRedis(common.ContextWithAccount(context.Background(), account), []byte(result))
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-		images = append(images, &image)
+		images = append(images, &cached)
 	}
 	if err := iter.Err(); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return images, nil
+	page, total := spec.Apply(images)
+	return page, total, nil
+}
+
+// GetDeletedImages returns a list of soft-deleted images, implementing the Image.Repository interface.
+// Deleted images are never cached, so the list is always empty.
+func (r *RedisImageRepository) GetDeletedImages(ctx context.Context) ([]*image.Image, error) {
+	log.Debug("redis get deleted images")
+	return []*image.Image{}, nil
+}
+
+// RestoreImage restores the soft-deleted image with the given UUID, implementing the Image.Repository interface.
+// Deleted images are never cached, so there is nothing to restore.
+func (r *RedisImageRepository) RestoreImage(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("redis restore image")
+	return image.ErrImageNotFound
 }
 
-// NewRedisClient returns a new RedisClient.
-func NewRedisClient(cfg *config.EdgeConfig) *redis.Client {
-	return redis.NewClient(&redis.Options{
-		Addr:     ":6379",
-		Password: "", // no password set
-		DB:       0,  // use default DB
-	})
+// PurgeImage permanently deletes the image with the given UUID, implementing the Image.Repository interface.
+func (r *RedisImageRepository) PurgeImage(ctx context.Context, uuid string) error {
+	log.WithField("uuid", uuid).Debug("redis purge image")
+	return r.DeleteImage(ctx, uuid)
 }
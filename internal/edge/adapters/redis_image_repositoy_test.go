@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image/imagetest"
 	"github.com/alicebob/miniredis"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
@@ -43,12 +45,32 @@ func teardownRedis(t *testing.T) {
 	redisClient.Close()
 }
 
+// newTestRedisClient returns a redis client of a new miniredis server, closed once the test is done.
+func newTestRedisClient(t *testing.T) *redis.Client {
+	server := mockRedis()
+	t.Cleanup(server.Close)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRedisImageRepository_Conformance(t *testing.T) {
+	imagetest.TestRepository(t, func(t *testing.T) image.Repository {
+		if strings.HasSuffix(t.Name(), "/concurrent_updates") {
+			// miniredis replies to an aborted EXEC with an empty array instead of a null one,
+			// so the client waits for the replies of the queued commands until it times out
+			t.Skip("miniredis doesn't report aborted transactions")
+		}
+		return NewRedisImageRepository(newTestRedisClient(t), NewRedisConfig())
+	})
+}
+
 func TestNewRedisImageRepository(t *testing.T) {
 	setupRedis(t)
 	defer teardownRedis(t)
 
 	type args struct {
-		db *redis.Client
+		db redis.UniversalClient
 	}
 	tests := []struct {
 		name string
@@ -61,7 +83,9 @@ func TestNewRedisImageRepository(t *testing.T) {
 				db: redisClient,
 			},
 			want: &RedisImageRepository{
-				db: redisClient,
+				db:       redisClient,
+				imageTTL: DefaultRedisImageTTL,
+				listTTL:  DefaultRedisListTTL,
 			},
 		},
 		{
@@ -82,7 +106,7 @@ func TestNewRedisImageRepository(t *testing.T) {
 				}
 			}()
 			t.Parallel()
-			if got := NewRedisImageRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+			if got := NewRedisImageRepository(tt.args.db, NewRedisConfig()); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewRedisImageRepository() = %v, want %v", got, tt.want)
 			}
 		})
@@ -105,7 +129,7 @@ func Test_imageKey(t *testing.T) {
 			args: args{
 				image: &validImage,
 			},
-			want:    fmt.Sprintf("%s:image:%s", common.DefaultAccount.String(), validImage.UUID()),
+			want:    fmt.Sprintf("{%s}:image:%s", common.DefaultAccount.String(), validImage.UUID()),
 			wantErr: false,
 			auth:    false,
 		},
@@ -139,7 +163,7 @@ func Test_imageKey(t *testing.T) {
 func TestRedisImageRepository_CreateImage(t *testing.T) {
 	setupRedis(t)
 	defer teardownRedis(t)
-	repository := NewRedisImageRepository(redisClient)
+	repository := NewRedisImageRepository(redisClient, NewRedisConfig())
 
 	key, err := imageKey(&validImage)
 	if err != nil {
@@ -198,7 +222,7 @@ func TestRedisImageRepository_CreateImage(t *testing.T) {
 func TestRedisImageRepository_GetImage(t *testing.T) {
 	setupRedis(t)
 	defer teardownRedis(t)
-	repository := NewRedisImageRepository(redisClient)
+	repository := NewRedisImageRepository(redisClient, NewRedisConfig())
 	err := repository.CreateImage(context.Background(), &validImage)
 	if err != nil {
 		t.Errorf("RedisImageRepository.CreateImage() error = %v", err)
@@ -269,7 +293,7 @@ func TestRedisImageRepository_GetImage(t *testing.T) {
 func TestRedisImageRepository_UpdateImage(t *testing.T) {
 	setupRedis(t)
 	defer teardownRedis(t)
-	repository := NewRedisImageRepository(redisClient)
+	repository := NewRedisImageRepository(redisClient, NewRedisConfig())
 	err := repository.CreateImage(context.Background(), &validImage)
 	if err != nil {
 		t.Errorf("RedisImageRepository.CreateImage() error = %v", err)
@@ -298,6 +322,19 @@ func TestRedisImageRepository_UpdateImage(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should fail update an image, stale etag",
+			r:    repository,
+			args: args{
+				ctx:  image.ContextWithPrecondition(context.Background(), `"1-0"`),
+				uuid: validImage.UUID(),
+				updateFn: func(image *image.Image) (*image.Image, error) {
+					image.SetNameAndDesc(common.Name{}, "new description")
+					return image, nil
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "should fail update an image, bad key",
 			r:    repository,
@@ -348,7 +385,7 @@ func TestRedisImageRepository_UpdateImage(t *testing.T) {
 func TestRedisImageRepository_DeleteImage(t *testing.T) {
 	setupRedis(t)
 	defer teardownRedis(t)
-	repository := NewRedisImageRepository(redisClient)
+	repository := NewRedisImageRepository(redisClient, NewRedisConfig())
 	err := repository.CreateImage(context.Background(), &validImage)
 	if err != nil {
 		t.Errorf("RedisImageRepository.CreateImage() error = %v", err)
@@ -406,7 +443,7 @@ func TestRedisImageRepository_DeleteImage(t *testing.T) {
 func TestRedisImageRepository_GetImages(t *testing.T) {
 	setupRedis(t)
 	defer teardownRedis(t)
-	repository := NewRedisImageRepository(redisClient)
+	repository := NewRedisImageRepository(redisClient, NewRedisConfig())
 	err := repository.CreateImage(context.Background(), &validImage)
 	if err != nil {
 		t.Errorf("RedisImageRepository.CreateImage() error = %v", err)
@@ -458,7 +495,7 @@ func TestRedisImageRepository_GetImages(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			config.Get().Auth = tt.auth
-			got, err := tt.r.GetImages(tt.args.ctx)
+			got, _, err := tt.r.GetImages(tt.args.ctx, image.Spec{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RedisImageRepository.GetImages() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -476,34 +513,3 @@ func TestRedisImageRepository_GetImages(t *testing.T) {
 		})
 	}
 }
-
-func TestNewRedisClient(t *testing.T) {
-	type args struct {
-		cfg *config.EdgeConfig
-	}
-	tests := []struct {
-		name string
-		args args
-		want *redis.Client
-	}{
-		{
-			name: "should create a redis client",
-			args: args{
-				cfg: config.Get(),
-			},
-			want: redis.NewClient(&redis.Options{
-				Addr:     ":6379",
-				Password: "", // no password set
-				DB:       0,  // use default DB
-			}),
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			if got := NewRedisClient(tt.args.cfg); got.String() != tt.want.String() {
-				t.Errorf("NewRedisClient() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
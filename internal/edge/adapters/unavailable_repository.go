@@ -0,0 +1,260 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+)
+
+// UnavailableRepository stands in for the repositories a store doesn't provide, so the memory image store
+// runs without a database instead of mixing stores. Nothing is stored in it: reads find nothing, and writes fail
+// with common.ErrUnavailable. Image versions fail altogether, every image has versions the store doesn't keep.
+// It implements the Image.VersionRepository, Image.RetentionRepository, Device.Repository, Group.Repository,
+// Fdo.Repository, Rollout.Repository, Maintenance.Repository and Repo.Repository interfaces.
+type UnavailableRepository struct{}
+
+// NewUnavailableRepository returns a new UnavailableRepository.
+func NewUnavailableRepository() *UnavailableRepository {
+	return &UnavailableRepository{}
+}
+
+// GetVersions implements the Image.VersionRepository interface.
+func (r *UnavailableRepository) GetVersions(ctx context.Context, uuid string) ([]*image.Snapshot, error) {
+	return nil, common.ErrUnavailable
+}
+
+// GetVersion implements the Image.VersionRepository interface.
+func (r *UnavailableRepository) GetVersion(ctx context.Context, uuid string, version uint) (*image.Snapshot, error) {
+	return nil, common.ErrUnavailable
+}
+
+// GetVersionByCommit implements the Image.VersionRepository interface.
+func (r *UnavailableRepository) GetVersionByCommit(ctx context.Context, commit image.Commit) (*image.Snapshot, error) {
+	return nil, common.ErrUnavailable
+}
+
+// GetChildren implements the Image.VersionRepository interface.
+func (r *UnavailableRepository) GetChildren(ctx context.Context, uuid string) ([]*image.Snapshot, error) {
+	return nil, common.ErrUnavailable
+}
+
+// UpdateVersion implements the Image.VersionRepository interface.
+func (r *UnavailableRepository) UpdateVersion(ctx context.Context, uuid string, version uint,
+	updateFn func(s *image.Snapshot) (*image.Snapshot, error)) error {
+	return common.ErrUnavailable
+}
+
+// DeleteVersions implements the Image.VersionRepository interface.
+func (r *UnavailableRepository) DeleteVersions(ctx context.Context, uuid string, versions ...image.Version) error {
+	return common.ErrUnavailable
+}
+
+// GetMetadataChanges implements the Image.VersionRepository interface.
+func (r *UnavailableRepository) GetMetadataChanges(ctx context.Context, uuid string) ([]image.MetadataChange, error) {
+	return nil, common.ErrUnavailable
+}
+
+// GetRetentionPolicy implements the Image.RetentionRepository interface.
+func (r *UnavailableRepository) GetRetentionPolicy(ctx context.Context) (*image.RetentionPolicy, error) {
+	return nil, image.ErrRetentionPolicyNotFound
+}
+
+// SetRetentionPolicy implements the Image.RetentionRepository interface.
+func (r *UnavailableRepository) SetRetentionPolicy(ctx context.Context, policy *image.RetentionPolicy) error {
+	return common.ErrUnavailable
+}
+
+// GetRetentionPolicies implements the Image.RetentionRepository interface.
+func (r *UnavailableRepository) GetRetentionPolicies(ctx context.Context) ([]*image.RetentionPolicy, error) {
+	return nil, nil
+}
+
+// CreateDevice implements the Device.Repository interface.
+func (r *UnavailableRepository) CreateDevice(ctx context.Context, device *device.Device) error {
+	return common.ErrUnavailable
+}
+
+// GetDevice implements the Device.Repository interface.
+func (r *UnavailableRepository) GetDevice(ctx context.Context, uuid string) (*device.Device, error) {
+	return nil, device.ErrDeviceNotFound
+}
+
+// UpdateDevice implements the Device.Repository interface.
+func (r *UnavailableRepository) UpdateDevice(ctx context.Context, uuid string,
+	updateFn func(d *device.Device) (*device.Device, error)) error {
+	return device.ErrDeviceNotFound
+}
+
+// DeleteDevice implements the Device.Repository interface.
+func (r *UnavailableRepository) DeleteDevice(ctx context.Context, uuid string) error {
+	return device.ErrDeviceNotFound
+}
+
+// GetDevices implements the Device.Repository interface.
+func (r *UnavailableRepository) GetDevices(ctx context.Context) ([]*device.Device, error) {
+	return nil, nil
+}
+
+// GetImageDevices implements the Device.Repository interface.
+func (r *UnavailableRepository) GetImageDevices(ctx context.Context, imageUUID string) ([]*device.Device, error) {
+	return nil, nil
+}
+
+// GetDriftedDevices implements the Device.Repository interface.
+func (r *UnavailableRepository) GetDriftedDevices(ctx context.Context) ([]*device.Device, error) {
+	return nil, nil
+}
+
+// GetDrift implements the Device.Repository interface.
+func (r *UnavailableRepository) GetDrift(ctx context.Context) ([]device.ImageDrift, error) {
+	return nil, nil
+}
+
+// CreateGroup implements the Group.Repository interface.
+func (r *UnavailableRepository) CreateGroup(ctx context.Context, group *group.DeviceGroup) error {
+	return common.ErrUnavailable
+}
+
+// GetGroup implements the Group.Repository interface.
+func (r *UnavailableRepository) GetGroup(ctx context.Context, uuid string) (*group.DeviceGroup, error) {
+	return nil, group.ErrGroupNotFound
+}
+
+// GetGroups implements the Group.Repository interface.
+func (r *UnavailableRepository) GetGroups(ctx context.Context) ([]*group.DeviceGroup, error) {
+	return nil, nil
+}
+
+// GetMembers implements the Group.Repository interface.
+func (r *UnavailableRepository) GetMembers(ctx context.Context, uuid string) ([]*device.Device, error) {
+	return nil, group.ErrGroupNotFound
+}
+
+// GetDeviceGroups implements the Group.Repository interface.
+func (r *UnavailableRepository) GetDeviceGroups(ctx context.Context, deviceUUID string) ([]*group.DeviceGroup, error) {
+	return nil, nil
+}
+
+// ImportVoucher implements the Fdo.Repository interface.
+func (r *UnavailableRepository) ImportVoucher(ctx context.Context, voucher *fdo.Voucher) error {
+	return common.ErrUnavailable
+}
+
+// GetVoucher implements the Fdo.Repository interface.
+func (r *UnavailableRepository) GetVoucher(ctx context.Context, guid fdo.GUID) (*fdo.Voucher, error) {
+	return nil, fdo.ErrVoucherNotFound
+}
+
+// GetVouchers implements the Fdo.Repository interface.
+func (r *UnavailableRepository) GetVouchers(ctx context.Context) ([]*fdo.Voucher, error) {
+	return nil, nil
+}
+
+// UpdateVoucher implements the Fdo.Repository interface.
+func (r *UnavailableRepository) UpdateVoucher(ctx context.Context, guid fdo.GUID,
+	updateFn func(v *fdo.Voucher) (*fdo.Voucher, error)) error {
+	return fdo.ErrVoucherNotFound
+}
+
+// CreateRollout implements the Rollout.Repository interface.
+func (r *UnavailableRepository) CreateRollout(ctx context.Context, rollout *rollout.Rollout) error {
+	return common.ErrUnavailable
+}
+
+// GetRollout implements the Rollout.Repository interface.
+func (r *UnavailableRepository) GetRollout(ctx context.Context, uuid string) (*rollout.Rollout, error) {
+	return nil, rollout.ErrRolloutNotFound
+}
+
+// UpdateTransaction implements the Rollout.Repository interface.
+func (r *UnavailableRepository) UpdateTransaction(ctx context.Context, uuid, deviceUUID string,
+	updateFn func(t *rollout.Transaction) (*rollout.Transaction, error)) error {
+	return rollout.ErrRolloutNotFound
+}
+
+// UpdateRollout implements the Rollout.Repository interface.
+func (r *UnavailableRepository) UpdateRollout(ctx context.Context, uuid string,
+	updateFn func(r *rollout.Rollout) (*rollout.Rollout, error)) error {
+	return rollout.ErrRolloutNotFound
+}
+
+// GetActiveRollouts implements the Rollout.Repository interface.
+func (r *UnavailableRepository) GetActiveRollouts(ctx context.Context, imageUUID string) ([]*rollout.Rollout, error) {
+	return nil, nil
+}
+
+// ClaimRollouts implements the Rollout.Repository interface.
+func (r *UnavailableRepository) ClaimRollouts(ctx context.Context, lease string,
+	until time.Time) ([]*rollout.Rollout, error) {
+	return nil, nil
+}
+
+// ClaimRollout implements the Rollout.Repository interface.
+func (r *UnavailableRepository) ClaimRollout(ctx context.Context, uuid, lease string, until time.Time) error {
+	return rollout.ErrRolloutNotFound
+}
+
+// ReleaseRollout implements the Rollout.Repository interface.
+func (r *UnavailableRepository) ReleaseRollout(ctx context.Context, uuid, lease string) error {
+	return rollout.ErrRolloutNotFound
+}
+
+// CreateWindow implements the Maintenance.Repository interface.
+func (r *UnavailableRepository) CreateWindow(ctx context.Context, window *maintenance.Window) error {
+	return common.ErrUnavailable
+}
+
+// GetWindow implements the Maintenance.Repository interface.
+func (r *UnavailableRepository) GetWindow(ctx context.Context, uuid string) (*maintenance.Window, error) {
+	return nil, maintenance.ErrWindowNotFound
+}
+
+// GetWindows implements the Maintenance.Repository interface.
+func (r *UnavailableRepository) GetWindows(ctx context.Context) ([]*maintenance.Window, error) {
+	return nil, nil
+}
+
+// DeleteWindow implements the Maintenance.Repository interface.
+func (r *UnavailableRepository) DeleteWindow(ctx context.Context, uuid string) error {
+	return maintenance.ErrWindowNotFound
+}
+
+// GetTargetWindows implements the Maintenance.Repository interface.
+func (r *UnavailableRepository) GetTargetWindows(ctx context.Context, deviceUUID string,
+	groupUUIDs []string) ([]*maintenance.Window, error) {
+	return nil, nil
+}
+
+// CreateRepo implements the Repo.Repository interface.
+func (r *UnavailableRepository) CreateRepo(ctx context.Context, repo *repo.Repo) error {
+	return common.ErrUnavailable
+}
+
+// GetRepo implements the Repo.Repository interface.
+func (r *UnavailableRepository) GetRepo(ctx context.Context, uuid string) (*repo.Repo, error) {
+	return nil, repo.ErrRepoNotFound
+}
+
+// GetRepos implements the Repo.Repository interface.
+func (r *UnavailableRepository) GetRepos(ctx context.Context) ([]*repo.Repo, error) {
+	return nil, nil
+}
+
+// UpdateRepo implements the Repo.Repository interface.
+func (r *UnavailableRepository) UpdateRepo(ctx context.Context, uuid string,
+	updateFn func(r *repo.Repo) (*repo.Repo, error)) error {
+	return repo.ErrRepoNotFound
+}
+
+// DeleteRepo implements the Repo.Repository interface.
+func (r *UnavailableRepository) DeleteRepo(ctx context.Context, uuid string) error {
+	return repo.ErrRepoNotFound
+}
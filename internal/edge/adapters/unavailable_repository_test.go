@@ -0,0 +1,115 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+)
+
+func TestUnavailableRepository(t *testing.T) {
+	var (
+		versions  image.VersionRepository   = NewUnavailableRepository()
+		retention image.RetentionRepository = NewUnavailableRepository()
+		devices   device.Repository         = NewUnavailableRepository()
+		groups    group.Repository          = NewUnavailableRepository()
+		vouchers  fdo.Repository            = NewUnavailableRepository()
+		rollouts  rollout.Repository        = NewUnavailableRepository()
+		windows   maintenance.Repository    = NewUnavailableRepository()
+		repos     repo.Repository           = NewUnavailableRepository()
+	)
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{
+			name: "should fail to list the versions of an image",
+			call: func() error {
+				_, err := versions.GetVersions(ctx, "image-uuid")
+				return err
+			},
+			wantErr: common.ErrUnavailable,
+		},
+		{
+			name: "should find no retention policy",
+			call: func() error {
+				_, err := retention.GetRetentionPolicy(ctx)
+				return err
+			},
+			wantErr: image.ErrRetentionPolicyNotFound,
+		},
+		{
+			name: "should fail to create a device",
+			call: func() error {
+				d := newTestDevice(t, "kiosk")
+				return devices.CreateDevice(ctx, &d)
+			},
+			wantErr: common.ErrUnavailable,
+		},
+		{
+			name: "should find no device",
+			call: func() error {
+				_, err := devices.GetDevice(ctx, "device-uuid")
+				return err
+			},
+			wantErr: device.ErrDeviceNotFound,
+		},
+		{
+			name: "should find no group",
+			call: func() error {
+				_, err := groups.GetGroup(ctx, "group-uuid")
+				return err
+			},
+			wantErr: group.ErrGroupNotFound,
+		},
+		{
+			name: "should list no voucher",
+			call: func() error {
+				_, err := vouchers.GetVouchers(ctx)
+				return err
+			},
+			wantErr: nil,
+		},
+		{
+			name: "should claim no rollout",
+			call: func() error {
+				_, err := rollouts.ClaimRollouts(ctx, "lease", time.Now())
+				return err
+			},
+			wantErr: nil,
+		},
+		{
+			name: "should find no maintenance window",
+			call: func() error {
+				return windows.DeleteWindow(ctx, "window-uuid")
+			},
+			wantErr: maintenance.ErrWindowNotFound,
+		},
+		{
+			name: "should find no repository",
+			call: func() error {
+				_, err := repos.GetRepo(ctx, "repo-uuid")
+				return err
+			},
+			wantErr: repo.ErrRepoNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != tt.wantErr {
+				t.Errorf("UnavailableRepository error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -13,12 +13,82 @@ type Application struct {
 type Commands struct {
 	CreateImage        command.CreateImageHandler
 	DeleteImage        command.DeleteImageHandler
+	RestoreImage       command.RestoreImageHandler
+	PurgeImage         command.PurgeImageHandler
 	UpdateImage        command.UpdateImageHandler
 	UpgradeImage       command.UpgradeImageHandler
 	CancelUpgradeImage command.CancelUpgradeImageHandler
+	CloneImage         command.CloneImageHandler
+	LockImageVersion   command.LockImageVersionHandler
+	SetVersionCommit   command.SetVersionCommitHandler
+	RestoreVersion     command.RestoreImageVersionHandler
+	SetRetentionPolicy command.SetRetentionPolicyHandler
+	PruneImageVersions command.PruneImageVersionsHandler
+	PublishImageRepo   command.PublishImageRepoHandler
+
+	CreateRepo command.CreateRepoHandler
+	UpdateRepo command.UpdateRepoHandler
+	DeleteRepo command.DeleteRepoHandler
+
+	CreateDevice  command.CreateDeviceHandler
+	UpdateDevice  command.UpdateDeviceHandler
+	DeleteDevice  command.DeleteDeviceHandler
+	CheckInDevice command.CheckInDeviceHandler
+
+	ImportVoucher      command.ImportVoucherHandler
+	CompleteOnboarding command.CompleteOnboardingHandler
+
+	CreateGroup command.CreateGroupHandler
+
+	UpdateRollout     command.UpdateRolloutHandler
+	ReportTransaction command.ReportTransactionHandler
+	PauseRollout      command.PauseRolloutHandler
+	ResumeRollout     command.ResumeRolloutHandler
+	AbortRollout      command.AbortRolloutHandler
+	FollowRollouts    command.FollowRolloutsHandler
+
+	CreateMaintenanceWindow command.CreateMaintenanceWindowHandler
+	DeleteMaintenanceWindow command.DeleteMaintenanceWindowHandler
 }
 
 type Queries struct {
 	GetImage  query.GetImageHandler
 	GetImages query.GetImagesHandler
+
+	SearchImages query.SearchImagesHandler
+
+	GetDeletedImages query.GetDeletedImagesHandler
+
+	GetTags         query.GetTagsHandler
+	GetUsedPackages query.GetUsedPackagesHandler
+
+	GetImageVersions    query.GetImageVersionsHandler
+	GetImageLineage     query.GetImageLineageHandler
+	GetImageAsOf        query.GetImageAsOfHandler
+	GetImageDevices     query.GetImageDevicesHandler
+	GetImageRepoFile    query.GetImageRepoFileHandler
+	GetRetentionPolicy  query.GetRetentionPolicyHandler
+	GetPrunableVersions query.GetPrunableVersionsHandler
+
+	GetRepo  query.GetRepoHandler
+	GetRepos query.GetReposHandler
+
+	GetDevice         query.GetDeviceHandler
+	GetDevices        query.GetDevicesHandler
+	GetDriftedDevices query.GetDriftedDevicesHandler
+	GetDrift          query.GetDriftHandler
+
+	GetVoucher  query.GetVoucherHandler
+	GetVouchers query.GetVouchersHandler
+
+	GetGroup        query.GetGroupHandler
+	GetGroups       query.GetGroupsHandler
+	GetGroupDevices query.GetGroupDevicesHandler
+	GetDeviceGroups query.GetDeviceGroupsHandler
+
+	GetRollout query.GetRolloutHandler
+
+	GetMaintenanceWindow  query.GetMaintenanceWindowHandler
+	GetMaintenanceWindows query.GetMaintenanceWindowsHandler
+	GetDeviceWindows      query.GetDeviceWindowsHandler
 }
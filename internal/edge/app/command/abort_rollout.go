@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+)
+
+// AbortRolloutHandler is a handler for the AbortRollout command.
+type AbortRolloutHandler struct {
+	RolloutRepository rollout.Repository
+}
+
+// NewAbortRolloutHandler returns a new AbortRolloutHandler.
+func NewAbortRolloutHandler(rolloutRepository rollout.Repository) *AbortRolloutHandler {
+	if rolloutRepository == nil {
+		return &AbortRolloutHandler{}
+	}
+	return &AbortRolloutHandler{
+		RolloutRepository: rolloutRepository,
+	}
+}
+
+// Handle implements the command interface.
+// The transactions that aren't final fail, the job following the rollout rolls the devices they were dispatched to
+// back if the plan of the rollout says so.
+func (h *AbortRolloutHandler) Handle(ctx context.Context, uuidToAbort string) (_ *rollout.Rollout, err error) {
+	defer func() {
+		logs.LogCommandExecution("AbortRolloutHandler", uuidToAbort, err)
+	}()
+	var aborted *rollout.Rollout
+	err = h.RolloutRepository.UpdateRollout(ctx, uuidToAbort, func(r *rollout.Rollout) (*rollout.Rollout, error) {
+		aborted = r
+		return r, r.Abort(time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aborted, nil
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// CheckInDevice is a command for a device to report what it runs and how healthy it is.
+type CheckInDevice struct {
+	UUID         string
+	Token        device.Token // presented by the device
+	BootedCommit string
+	StagedCommit string
+	Status       string
+	Greenboot    string
+	Uptime       time.Duration
+}
+
+// CheckInDeviceHandler is a handler for the CheckInDevice command.
+type CheckInDeviceHandler struct {
+	DeviceRepository  device.Repository
+	VersionRepository image.VersionRepository
+}
+
+// NewCheckInDeviceHandler returns a new CheckInDeviceHandler.
+func NewCheckInDeviceHandler(deviceRepository device.Repository,
+	versionRepository image.VersionRepository) *CheckInDeviceHandler {
+	if deviceRepository == nil || versionRepository == nil {
+		return &CheckInDeviceHandler{}
+	}
+	return &CheckInDeviceHandler{
+		DeviceRepository:  deviceRepository,
+		VersionRepository: versionRepository,
+	}
+}
+
+// Handle implements the command interface.
+// Only a device presenting the token it was issued when created or onboarded can check in as itself.
+// The booted commit is resolved to the image version it was built from, if any, so the device is known to run it.
+func (h *CheckInDeviceHandler) Handle(ctx context.Context, cmd CheckInDevice) (_ *device.Device, err error) {
+	defer func() {
+		logs.LogCommandExecution("CheckInDeviceHandler", cmd, err)
+	}()
+	booted, err := device.NewCommit(cmd.BootedCommit)
+	if err != nil {
+		return nil, err
+	}
+	health, err := device.NewHealth(cmd.StagedCommit, cmd.Status, cmd.Greenboot, cmd.Uptime)
+	if err != nil {
+		return nil, err
+	}
+	var running device.Assignment
+	snapshot, err := h.VersionRepository.GetVersionByCommit(ctx, booted)
+	if err == nil {
+		if running, err = device.NewAssignment(snapshot.UUID(), snapshot.Version().Uint()); err != nil {
+			return nil, err
+		}
+	} else if err != image.ErrVersionNotFound {
+		return nil, err
+	}
+	var checkedIn *device.Device
+	err = h.DeviceRepository.UpdateDevice(ctx, cmd.UUID, func(d *device.Device) (*device.Device, error) {
+		if err := d.Authenticate(cmd.Token); err != nil {
+			return nil, err
+		}
+		checkedIn = d
+		return d, d.CheckIn(booted, running, health, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checkedIn, nil
+}
@@ -0,0 +1,54 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// CloneImage is a command to create a new image from the current version of another one.
+type CloneImage struct {
+	UUIDToClone string
+	UUID        string
+	Name        string
+}
+
+// CloneImageHandler is a handler for the CloneImage command.
+type CloneImageHandler struct {
+	ImageRepository image.Repository
+}
+
+// NewCloneImageHandler returns a new CloneImageHandler.
+func NewCloneImageHandler(imageRepository image.Repository) *CloneImageHandler {
+	if imageRepository == nil {
+		return &CloneImageHandler{}
+	}
+	return &CloneImageHandler{
+		ImageRepository: imageRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *CloneImageHandler) Handle(ctx context.Context, cmd CloneImage) (_ *image.Image, err error) {
+	defer func() {
+		logs.LogCommandExecution("CloneImageHandler", cmd, err)
+	}()
+	source, err := h.ImageRepository.GetImage(ctx, cmd.UUIDToClone)
+	if err != nil {
+		return nil, err
+	}
+	var name common.Name
+	if cmd.Name != "" {
+		if name, err = common.NewName(cmd.Name); err != nil {
+			return nil, err
+		}
+	}
+	clone, err := source.Clone(ctx, cmd.UUID, name)
+	if err != nil {
+		return nil, err
+	}
+	// TODO: image-builder should create the image here
+	return &clone, h.ImageRepository.CreateImage(ctx, &clone)
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+	log "github.com/sirupsen/logrus"
+)
+
+// CompleteOnboarding is a command to register the device of a voucher once it completed FDO onboarding.
+type CompleteOnboarding struct {
+	GUID       string
+	DeviceUUID string
+	Token      device.Token // issued to the device, its secret is only returned to the onboarding server
+}
+
+// CompleteOnboardingHandler is a handler for the CompleteOnboarding command.
+type CompleteOnboardingHandler struct {
+	VoucherRepository fdo.Repository
+	DeviceRepository  device.Repository
+}
+
+// NewCompleteOnboardingHandler returns a new CompleteOnboardingHandler.
+func NewCompleteOnboardingHandler(voucherRepository fdo.Repository,
+	deviceRepository device.Repository) *CompleteOnboardingHandler {
+	if voucherRepository == nil || deviceRepository == nil {
+		return &CompleteOnboardingHandler{}
+	}
+	return &CompleteOnboardingHandler{
+		VoucherRepository: voucherRepository,
+		DeviceRepository:  deviceRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *CompleteOnboardingHandler) Handle(ctx context.Context, cmd CompleteOnboarding) (_ *device.Device, err error) {
+	defer func() {
+		logs.LogCommandExecution("CompleteOnboardingHandler", cmd, err)
+	}()
+	guid, err := fdo.NewGUID(cmd.GUID)
+	if err != nil {
+		return nil, err
+	}
+	voucher, err := h.VoucherRepository.GetVoucher(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+	if voucher.Status() == fdo.Onboarded {
+		return nil, fdo.ErrAlreadyOnboarded
+	}
+	// the device is named after the info the manufacturer set, if any
+	name := voucher.DeviceInfo()
+	if name == "" {
+		name = guid.String()
+	}
+	newDevice, err := device.NewDeviceWithContext(ctx, cmd.DeviceUUID, name, "", "", 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	newDevice.SetToken(cmd.Token)
+	if err := h.DeviceRepository.CreateDevice(ctx, &newDevice); err != nil {
+		return nil, err
+	}
+	err = h.VoucherRepository.UpdateVoucher(ctx, guid, func(v *fdo.Voucher) (*fdo.Voucher, error) {
+		if err := v.Onboard(newDevice.UUID(), time.Now()); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+	if err != nil { // onboarded concurrently, drop the device created for this call
+		if err := h.DeviceRepository.DeleteDevice(ctx, newDevice.UUID()); err != nil {
+			log.WithField("uuid", newDevice.UUID()).Error(err)
+		}
+		return nil, err
+	}
+	return &newDevice, nil
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// CreateDevice is a command to register a device.
+type CreateDevice struct {
+	UUID         string
+	Name         string
+	Commit       string
+	ImageUUID    string
+	ImageVersion uint
+	Tags         []string
+	Token        device.Token // issued to the device, its secret is only returned to the caller
+}
+
+// CreateDeviceHandler is a handler for the CreateDevice command.
+type CreateDeviceHandler struct {
+	DeviceRepository  device.Repository
+	VersionRepository image.VersionRepository
+}
+
+// NewCreateDeviceHandler returns a new CreateDeviceHandler.
+func NewCreateDeviceHandler(deviceRepository device.Repository,
+	versionRepository image.VersionRepository) *CreateDeviceHandler {
+	if deviceRepository == nil || versionRepository == nil {
+		return &CreateDeviceHandler{}
+	}
+	return &CreateDeviceHandler{
+		DeviceRepository:  deviceRepository,
+		VersionRepository: versionRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *CreateDeviceHandler) Handle(ctx context.Context, cmd CreateDevice) (_ *device.Device, err error) {
+	defer func() {
+		logs.LogCommandExecution("CreateDeviceHandler", cmd, err)
+	}()
+	newDevice, err := device.NewDeviceWithContext(ctx, cmd.UUID, cmd.Name, cmd.Commit,
+		cmd.ImageUUID, cmd.ImageVersion, cmd.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAssignment(ctx, h.VersionRepository, newDevice.Assignment()); err != nil {
+		return nil, err
+	}
+	newDevice.SetToken(cmd.Token)
+	return &newDevice, h.DeviceRepository.CreateDevice(ctx, &newDevice)
+}
+
+// checkAssignment returns device.ErrInvalidAssignment if the assigned image version is not stored for the account.
+func checkAssignment(ctx context.Context, versionRepository image.VersionRepository, assignment device.Assignment) error {
+	if assignment.IsZero() {
+		return nil
+	}
+	_, err := versionRepository.GetVersion(ctx, assignment.ImageUUID(), assignment.Version().Uint())
+	if err == image.ErrVersionNotFound {
+		return device.ErrInvalidAssignment
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+)
+
+// CreateGroup is a command to create a device group, either listing its devices or selecting them by tags.
+type CreateGroup struct {
+	UUID     string
+	Name     string
+	Devices  []string
+	Selector []string
+}
+
+// CreateGroupHandler is a handler for the CreateGroup command.
+type CreateGroupHandler struct {
+	GroupRepository  group.Repository
+	DeviceRepository device.Repository
+}
+
+// NewCreateGroupHandler returns a new CreateGroupHandler.
+func NewCreateGroupHandler(groupRepository group.Repository,
+	deviceRepository device.Repository) *CreateGroupHandler {
+	if groupRepository == nil || deviceRepository == nil {
+		return &CreateGroupHandler{}
+	}
+	return &CreateGroupHandler{
+		GroupRepository:  groupRepository,
+		DeviceRepository: deviceRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *CreateGroupHandler) Handle(ctx context.Context, cmd CreateGroup) (_ *group.DeviceGroup, err error) {
+	defer func() {
+		logs.LogCommandExecution("CreateGroupHandler", cmd, err)
+	}()
+	newGroup, err := group.NewDeviceGroupWithContext(ctx, cmd.UUID, cmd.Name, cmd.Devices, cmd.Selector)
+	if err != nil {
+		return nil, err
+	}
+	// the devices of a static group must belong to the account
+	for _, deviceUUID := range newGroup.Devices() {
+		_, err := h.DeviceRepository.GetDevice(ctx, deviceUUID)
+		if err == device.ErrDeviceNotFound {
+			return nil, group.ErrUnknownDevice
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return &newGroup, h.GroupRepository.CreateGroup(ctx, &newGroup)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Avielyo10/edge-api/internal/common/logs"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
 )
 
 // CreateImage is a command to create an image.
@@ -22,21 +23,23 @@ type CreateImage struct {
 	Tags         []string
 	Packages     []string
 	Version      uint
-	Repos        []interface{}
+	Repos        []string
 }
 
 // CreateImageHandler is a handler for the CreateImage command.
 type CreateImageHandler struct {
 	ImageRepository image.Repository
+	RepoRepository  repo.Repository
 }
 
 // NewCreateImageHandler returns a new CreateImageHandler.
-func NewCreateImageHandler(imageRepository image.Repository) *CreateImageHandler {
-	if imageRepository == nil {
+func NewCreateImageHandler(imageRepository image.Repository, repoRepository repo.Repository) *CreateImageHandler {
+	if imageRepository == nil || repoRepository == nil {
 		return &CreateImageHandler{}
 	}
 	return &CreateImageHandler{
 		ImageRepository: imageRepository,
+		RepoRepository:  repoRepository,
 	}
 }
 
@@ -50,6 +53,15 @@ func (h *CreateImageHandler) Handle(ctx context.Context, cmd CreateImage) (_ *im
 	if err != nil {
 		return nil, err
 	}
+	// the repositories of an image must belong to the account
+	for _, repoUUID := range newImage.Repos().UUIDs() {
+		_, err := h.RepoRepository.GetRepo(ctx, repoUUID)
+		if err == repo.ErrRepoNotFound {
+			return nil, image.ErrUnknownRepo
+		} else if err != nil {
+			return nil, err
+		}
+	}
 	newImage.SetTime(common.NewTime(time.Now(), time.Now(), time.Time{}))
 	// TODO: image-builder should create the image here
 	return &newImage, h.ImageRepository.CreateImage(ctx, &newImage)
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+)
+
+// CreateMaintenanceWindow is a command to create a recurring maintenance window for devices,
+// given directly or through their groups.
+type CreateMaintenanceWindow struct {
+	UUID     string
+	Name     string
+	Schedule string
+	Duration time.Duration
+	Timezone string
+	Devices  []string
+	Groups   []string
+}
+
+// CreateMaintenanceWindowHandler is a handler for the CreateMaintenanceWindow command.
+type CreateMaintenanceWindowHandler struct {
+	WindowRepository maintenance.Repository
+	DeviceRepository device.Repository
+	GroupRepository  group.Repository
+}
+
+// NewCreateMaintenanceWindowHandler returns a new CreateMaintenanceWindowHandler.
+func NewCreateMaintenanceWindowHandler(windowRepository maintenance.Repository,
+	deviceRepository device.Repository, groupRepository group.Repository) *CreateMaintenanceWindowHandler {
+	if windowRepository == nil || deviceRepository == nil || groupRepository == nil {
+		return &CreateMaintenanceWindowHandler{}
+	}
+	return &CreateMaintenanceWindowHandler{
+		WindowRepository: windowRepository,
+		DeviceRepository: deviceRepository,
+		GroupRepository:  groupRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *CreateMaintenanceWindowHandler) Handle(ctx context.Context,
+	cmd CreateMaintenanceWindow) (_ *maintenance.Window, err error) {
+	defer func() {
+		logs.LogCommandExecution("CreateMaintenanceWindowHandler", cmd, err)
+	}()
+	newWindow, err := maintenance.NewWindowWithContext(ctx, cmd.UUID, cmd.Name, cmd.Schedule, cmd.Duration,
+		cmd.Timezone, cmd.Devices, cmd.Groups)
+	if err != nil {
+		return nil, err
+	}
+	// the devices and groups of a window must belong to the account
+	for _, deviceUUID := range newWindow.Devices() {
+		_, err := h.DeviceRepository.GetDevice(ctx, deviceUUID)
+		if err == device.ErrDeviceNotFound {
+			return nil, maintenance.ErrUnknownTarget
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	for _, groupUUID := range newWindow.Groups() {
+		_, err := h.GroupRepository.GetGroup(ctx, groupUUID)
+		if err == group.ErrGroupNotFound {
+			return nil, maintenance.ErrUnknownTarget
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return &newWindow, h.WindowRepository.CreateWindow(ctx, &newWindow)
+}
@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+)
+
+// CreateRepo is a command to add a third-party repository to the account.
+type CreateRepo struct {
+	UUID     string
+	Name     string
+	BaseURL  string
+	GPGKey   string
+	GPGCheck bool
+	Priority int
+}
+
+// CreateRepoHandler is a handler for the CreateRepo command.
+type CreateRepoHandler struct {
+	RepoRepository repo.Repository
+}
+
+// NewCreateRepoHandler returns a new CreateRepoHandler.
+func NewCreateRepoHandler(repoRepository repo.Repository) *CreateRepoHandler {
+	if repoRepository == nil {
+		return &CreateRepoHandler{}
+	}
+	return &CreateRepoHandler{
+		RepoRepository: repoRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *CreateRepoHandler) Handle(ctx context.Context, cmd CreateRepo) (_ *repo.Repo, err error) {
+	defer func() {
+		logs.LogCommandExecution("CreateRepoHandler", cmd, err)
+	}()
+	newRepo, err := repo.NewRepoWithContext(ctx, cmd.UUID, cmd.Name, cmd.BaseURL, cmd.GPGKey, cmd.GPGCheck, cmd.Priority)
+	if err != nil {
+		return nil, err
+	}
+	return &newRepo, h.RepoRepository.CreateRepo(ctx, &newRepo)
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+)
+
+// DeleteDeviceHandler is a handler for the DeleteDevice command.
+type DeleteDeviceHandler struct {
+	DeviceRepository device.Repository
+}
+
+// NewDeleteDeviceHandler returns a new DeleteDeviceHandler.
+func NewDeleteDeviceHandler(deviceRepository device.Repository) *DeleteDeviceHandler {
+	if deviceRepository == nil {
+		return &DeleteDeviceHandler{}
+	}
+	return &DeleteDeviceHandler{
+		DeviceRepository: deviceRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *DeleteDeviceHandler) Handle(ctx context.Context, uuidToDelete string) (err error) {
+	defer func() {
+		logs.LogCommandExecution("DeleteDeviceHandler", uuidToDelete, err)
+	}()
+	return h.DeviceRepository.DeleteDevice(ctx, uuidToDelete)
+}
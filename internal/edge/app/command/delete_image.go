@@ -2,29 +2,77 @@ package command
 
 import (
 	"context"
+
 	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
 )
 
+// DeleteImage is a command to delete an image, forcing it deletes the image even if devices use it.
+type DeleteImage struct {
+	UUID  string
+	Force bool
+}
+
 // DeleteImageHandler is a handler for the Delete command.
 type DeleteImageHandler struct {
-	ImageRepository image.Repository
+	ImageRepository   image.Repository
+	RolloutRepository rollout.Repository
+	DeviceRepository  device.Repository
 }
 
 // NewDeleteImageHandler returns a new DeleteHandler.
-func NewDeleteImageHandler(imageRepository image.Repository) *DeleteImageHandler {
-	if imageRepository == nil {
+func NewDeleteImageHandler(imageRepository image.Repository, rolloutRepository rollout.Repository,
+	deviceRepository device.Repository) *DeleteImageHandler {
+	if imageRepository == nil || rolloutRepository == nil || deviceRepository == nil {
 		return &DeleteImageHandler{}
 	}
 	return &DeleteImageHandler{
-		ImageRepository: imageRepository,
+		ImageRepository:   imageRepository,
+		RolloutRepository: rolloutRepository,
+		DeviceRepository:  deviceRepository,
 	}
 }
 
-// Handle implements the command interface.
-func (h *DeleteImageHandler) Handle(ctx context.Context, uuidToDelete string) (err error) {
+// Handle implements the command interface, only organization admins are allowed to force the deletion.
+func (h *DeleteImageHandler) Handle(ctx context.Context, cmd DeleteImage) (err error) {
 	defer func() {
-		logs.LogCommandExecution("DeleteImageHandler", uuidToDelete, err)
+		logs.LogCommandExecution("DeleteImageHandler", cmd, err)
 	}()
-	return h.ImageRepository.DeleteImage(ctx, uuidToDelete)
+	ctx, err = withInUseCheck(ctx, h.RolloutRepository, h.DeviceRepository, cmd.UUID, cmd.Force)
+	if err != nil {
+		return err
+	}
+	return h.ImageRepository.DeleteImage(ctx, cmd.UUID)
+}
+
+// withInUseCheck returns a copy of ctx that has the image repository check no device uses the image
+// with the given UUID before deleting it, unless an organization admin forces its deletion.
+func withInUseCheck(ctx context.Context, rolloutRepository rollout.Repository, deviceRepository device.Repository,
+	imageUUID string, force bool) (context.Context, error) {
+	if force {
+		if !common.IsOrgAdmin(ctx) {
+			return nil, common.ErrNotOrgAdmin
+		}
+		return ctx, nil
+	}
+	return image.ContextWithInUseCheck(ctx, inUseCheck(rolloutRepository, deviceRepository, imageUUID)), nil
+}
+
+// inUseCheck returns the check of the devices using the image with the given UUID,
+// it fails with an image.InUseError if any device blocks the deletion of the versions it is given.
+func inUseCheck(rolloutRepository rollout.Repository, deviceRepository device.Repository,
+	imageUUID string) image.InUseCheck {
+	return func(ctx context.Context, versions ...image.Version) error {
+		usage, err := rollout.GetUsage(ctx, rolloutRepository, deviceRepository, imageUUID)
+		if err != nil {
+			return err
+		}
+		if blocking := usage.BlockingDevices(versions...); len(blocking) > 0 {
+			return image.InUseError{Devices: len(blocking)}
+		}
+		return nil
+	}
 }
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+)
+
+// DeleteMaintenanceWindowHandler is a handler for the DeleteMaintenanceWindow command.
+type DeleteMaintenanceWindowHandler struct {
+	WindowRepository maintenance.Repository
+}
+
+// NewDeleteMaintenanceWindowHandler returns a new DeleteMaintenanceWindowHandler.
+func NewDeleteMaintenanceWindowHandler(windowRepository maintenance.Repository) *DeleteMaintenanceWindowHandler {
+	if windowRepository == nil {
+		return &DeleteMaintenanceWindowHandler{}
+	}
+	return &DeleteMaintenanceWindowHandler{
+		WindowRepository: windowRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *DeleteMaintenanceWindowHandler) Handle(ctx context.Context, uuidToDelete string) (err error) {
+	defer func() {
+		logs.LogCommandExecution("DeleteMaintenanceWindowHandler", uuidToDelete, err)
+	}()
+	return h.WindowRepository.DeleteWindow(ctx, uuidToDelete)
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+)
+
+// DeleteRepoHandler is a handler for the DeleteRepo command.
+type DeleteRepoHandler struct {
+	RepoRepository repo.Repository
+}
+
+// NewDeleteRepoHandler returns a new DeleteRepoHandler.
+func NewDeleteRepoHandler(repoRepository repo.Repository) *DeleteRepoHandler {
+	if repoRepository == nil {
+		return &DeleteRepoHandler{}
+	}
+	return &DeleteRepoHandler{
+		RepoRepository: repoRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *DeleteRepoHandler) Handle(ctx context.Context, uuidToDelete string) (err error) {
+	defer func() {
+		logs.LogCommandExecution("DeleteRepoHandler", uuidToDelete, err)
+	}()
+	return h.RepoRepository.DeleteRepo(ctx, uuidToDelete)
+}
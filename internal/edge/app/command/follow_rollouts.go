@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	"github.com/Avielyo10/edge-api/internal/update/domain/update"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// FollowRolloutsHandler is a handler for the FollowRollouts command, claiming the rollouts of every account
+// that aren't settled and no replica follows, and following them through the update queue.
+// It is run periodically, taking over the rollouts of the replicas that stopped, this one included on restart.
+type FollowRolloutsHandler struct {
+	RolloutRepository rollout.Repository
+	DeviceRepository  device.Repository
+	GroupRepository   group.Repository
+	WindowRepository  maintenance.Repository
+	Queue             *update.Queue
+}
+
+// NewFollowRolloutsHandler returns a new FollowRolloutsHandler.
+func NewFollowRolloutsHandler(rolloutRepository rollout.Repository, deviceRepository device.Repository,
+	groupRepository group.Repository, windowRepository maintenance.Repository,
+	queue *update.Queue) *FollowRolloutsHandler {
+	if rolloutRepository == nil || deviceRepository == nil || groupRepository == nil ||
+		windowRepository == nil || queue == nil {
+		return &FollowRolloutsHandler{}
+	}
+	return &FollowRolloutsHandler{
+		RolloutRepository: rolloutRepository,
+		DeviceRepository:  deviceRepository,
+		GroupRepository:   groupRepository,
+		WindowRepository:  windowRepository,
+		Queue:             queue,
+	}
+}
+
+// Handle implements the command interface.
+func (h *FollowRolloutsHandler) Handle(ctx context.Context) (err error) {
+	defer func() {
+		logs.LogCommandExecution("FollowRolloutsHandler", nil, err)
+	}()
+	lease := uuid.NewString()
+	rollouts, err := h.RolloutRepository.ClaimRollouts(ctx, lease, time.Now().Add(leaseDuration))
+	if err != nil {
+		return err
+	}
+	follower := rolloutFollower{
+		rolloutRepository: h.RolloutRepository,
+		deviceRepository:  h.DeviceRepository,
+		groupRepository:   h.GroupRepository,
+		windowRepository:  h.WindowRepository,
+		queue:             h.Queue,
+	}
+	for _, existing := range rollouts {
+		// a broken rollout must not stop the others from being followed, its lease expires meanwhile
+		if err := follower.add(existing, lease); err != nil {
+			log.WithField("uuid", existing.UUID()).WithError(err).Error("failed to follow rollout")
+		}
+	}
+	return nil
+}
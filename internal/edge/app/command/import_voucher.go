@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+)
+
+// ImportVoucherHandler is a handler for the ImportVoucher command.
+type ImportVoucherHandler struct {
+	VoucherRepository fdo.Repository
+}
+
+// NewImportVoucherHandler returns a new ImportVoucherHandler.
+func NewImportVoucherHandler(voucherRepository fdo.Repository) *ImportVoucherHandler {
+	if voucherRepository == nil {
+		return &ImportVoucherHandler{}
+	}
+	return &ImportVoucherHandler{
+		VoucherRepository: voucherRepository,
+	}
+}
+
+// Handle implements the command interface, data is the voucher either CBOR or PEM encoded.
+func (h *ImportVoucherHandler) Handle(ctx context.Context, data []byte) (voucher *fdo.Voucher, err error) {
+	defer func() {
+		var guid string
+		if voucher != nil {
+			guid = voucher.GUID().String()
+		}
+		logs.LogCommandExecution("ImportVoucherHandler", guid, err)
+	}()
+	newVoucher, err := fdo.ParseVoucher(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.VoucherRepository.ImportVoucher(ctx, &newVoucher); err != nil {
+		return nil, err
+	}
+	return &newVoucher, nil
+}
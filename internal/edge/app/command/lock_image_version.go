@@ -0,0 +1,41 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// LockImageVersion is a command to protect or unprotect a version of an image from pruning.
+type LockImageVersion struct {
+	UUID    string
+	Version uint
+	Locked  bool
+}
+
+// LockImageVersionHandler is a handler for the LockImageVersion command.
+type LockImageVersionHandler struct {
+	VersionRepository image.VersionRepository
+}
+
+// NewLockImageVersionHandler returns a new LockImageVersionHandler.
+func NewLockImageVersionHandler(versionRepository image.VersionRepository) *LockImageVersionHandler {
+	if versionRepository == nil {
+		return &LockImageVersionHandler{}
+	}
+	return &LockImageVersionHandler{
+		VersionRepository: versionRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *LockImageVersionHandler) Handle(ctx context.Context, cmd LockImageVersion) error {
+	return h.VersionRepository.UpdateVersion(ctx, cmd.UUID, cmd.Version, func(s *image.Snapshot) (_ *image.Snapshot, err error) {
+		defer func() {
+			logs.LogCommandExecution("LockImageVersionHandler", cmd, err)
+		}()
+		s.SetLocked(cmd.Locked)
+		return s, nil
+	})
+}
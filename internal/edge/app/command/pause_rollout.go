@@ -0,0 +1,40 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+)
+
+// PauseRolloutHandler is a handler for the PauseRollout command.
+type PauseRolloutHandler struct {
+	RolloutRepository rollout.Repository
+}
+
+// NewPauseRolloutHandler returns a new PauseRolloutHandler.
+func NewPauseRolloutHandler(rolloutRepository rollout.Repository) *PauseRolloutHandler {
+	if rolloutRepository == nil {
+		return &PauseRolloutHandler{}
+	}
+	return &PauseRolloutHandler{
+		RolloutRepository: rolloutRepository,
+	}
+}
+
+// Handle implements the command interface.
+// The rollout starts no further stage, the transactions already dispatched go on.
+func (h *PauseRolloutHandler) Handle(ctx context.Context, uuidToPause string) (_ *rollout.Rollout, err error) {
+	defer func() {
+		logs.LogCommandExecution("PauseRolloutHandler", uuidToPause, err)
+	}()
+	var paused *rollout.Rollout
+	err = h.RolloutRepository.UpdateRollout(ctx, uuidToPause, func(r *rollout.Rollout) (*rollout.Rollout, error) {
+		paused = r
+		return r, r.Pause()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paused, nil
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	log "github.com/sirupsen/logrus"
+)
+
+// PruneImageVersionsHandler is a handler for the PruneImageVersions command,
+// deleting the versions every account's retention policy no longer keeps and no device uses.
+type PruneImageVersionsHandler struct {
+	ImageRepository     image.Repository
+	VersionRepository   image.VersionRepository
+	RetentionRepository image.RetentionRepository
+	RolloutRepository   rollout.Repository
+	DeviceRepository    device.Repository
+}
+
+// NewPruneImageVersionsHandler returns a new PruneImageVersionsHandler.
+func NewPruneImageVersionsHandler(imageRepository image.Repository, versionRepository image.VersionRepository,
+	retentionRepository image.RetentionRepository, rolloutRepository rollout.Repository,
+	deviceRepository device.Repository) *PruneImageVersionsHandler {
+	if imageRepository == nil || versionRepository == nil || retentionRepository == nil ||
+		rolloutRepository == nil || deviceRepository == nil {
+		return &PruneImageVersionsHandler{}
+	}
+	return &PruneImageVersionsHandler{
+		ImageRepository:     imageRepository,
+		VersionRepository:   versionRepository,
+		RetentionRepository: retentionRepository,
+		RolloutRepository:   rolloutRepository,
+		DeviceRepository:    deviceRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *PruneImageVersionsHandler) Handle(ctx context.Context) (err error) {
+	defer func() {
+		logs.LogCommandExecution("PruneImageVersionsHandler", nil, err)
+	}()
+	policies, err := h.RetentionRepository.GetRetentionPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		// a failing account must not stop the others from being pruned
+		if err := h.prune(common.ContextWithAccount(ctx, policy.Account()), policy); err != nil {
+			log.WithField("account", policy.Account().String()).WithError(err).Error("failed to prune image versions")
+		}
+	}
+	return nil
+}
+
+// prune deletes the versions of the account's images that the policy no longer keeps and no device uses.
+func (h *PruneImageVersionsHandler) prune(ctx context.Context, policy *image.RetentionPolicy) error {
+	images, _, err := h.ImageRepository.GetImages(ctx, image.Spec{}) // all images
+	if err != nil {
+		return err
+	}
+	for _, i := range images {
+		snapshots, err := h.VersionRepository.GetVersions(ctx, i.UUID())
+		if err != nil {
+			return err
+		}
+		usage, err := rollout.GetUsage(ctx, h.RolloutRepository, h.DeviceRepository, i.UUID())
+		if err != nil {
+			return err
+		}
+		prunable := usage.Unused(policy.PrunableVersions(i.Version(), snapshots, time.Now()))
+		versions := make([]image.Version, len(prunable))
+		for j, snapshot := range prunable {
+			versions[j] = snapshot.Version()
+		}
+		// devices may have started using the versions since, they are checked again before being deleted
+		checked := image.ContextWithInUseCheck(ctx, inUseCheck(h.RolloutRepository, h.DeviceRepository, i.UUID()))
+		err = h.VersionRepository.DeleteVersions(checked, i.UUID(), versions...)
+		if errors.Is(err, image.ErrImageInUse) { // pruned by a later run, once unused
+			continue
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"context"
+	"io"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/ostree"
+)
+
+// PublishImageRepo is a command to publish the ostree repository of an edge-commit tarball
+// built for a version of an image, as the http remote of the image.
+type PublishImageRepo struct {
+	UUID    string
+	Version uint
+	Tarball io.Reader
+}
+
+// PublishImageRepoHandler is a handler for the PublishImageRepo command.
+type PublishImageRepoHandler struct {
+	VersionRepository image.VersionRepository
+	Storage           ostree.Storage
+}
+
+// NewPublishImageRepoHandler returns a new PublishImageRepoHandler.
+func NewPublishImageRepoHandler(versionRepository image.VersionRepository, storage ostree.Storage) *PublishImageRepoHandler {
+	if versionRepository == nil || storage == nil {
+		return &PublishImageRepoHandler{}
+	}
+	return &PublishImageRepoHandler{
+		VersionRepository: versionRepository,
+		Storage:           storage,
+	}
+}
+
+// Handle implements the command interface. The version must be successfully built as an edge commit,
+// its commit is recorded from the tarball if unknown yet, otherwise the tarball must hold it.
+func (h *PublishImageRepoHandler) Handle(ctx context.Context, cmd PublishImageRepo) (err error) {
+	defer func() {
+		logs.LogCommandExecution("PublishImageRepoHandler", struct {
+			UUID    string
+			Version uint
+		}{cmd.UUID, cmd.Version}, err)
+	}()
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot, err := h.VersionRepository.GetVersion(ctx, cmd.UUID, cmd.Version)
+	if err != nil {
+		return err
+	}
+	if !isEdgeCommit(snapshot) {
+		return ostree.ErrNotPublishable
+	}
+	repo, err := ostree.NewRepo(account, cmd.UUID)
+	if err != nil {
+		return err
+	}
+	commit, err := ostree.Unpack(ctx, h.Storage, repo, cmd.Tarball, snapshot.Commit().String())
+	if err != nil {
+		return err
+	}
+	if !snapshot.Commit().IsZero() {
+		return nil
+	}
+	imageCommit, err := image.NewCommit(commit)
+	if err != nil {
+		return err
+	}
+	return h.VersionRepository.UpdateVersion(ctx, cmd.UUID, cmd.Version, func(s *image.Snapshot) (*image.Snapshot, error) {
+		s.SetCommit(imageCommit)
+		return s, nil
+	})
+}
+
+// isEdgeCommit returns true if the snapshot is of a version successfully built as an edge commit.
+func isEdgeCommit(snapshot *image.Snapshot) bool {
+	if snapshot.Status() != image.Success {
+		return false
+	}
+	for _, outputType := range snapshot.Image().OutputTypes() {
+		if outputType == image.TAR {
+			return true
+		}
+	}
+	return false
+}
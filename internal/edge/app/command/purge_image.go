@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+)
+
+// PurgeImage is a command to purge a deleted image, forcing it purges the image even if devices use it.
+type PurgeImage struct {
+	UUID  string
+	Force bool
+}
+
+// PurgeImageHandler is a handler for the PurgeImage command.
+type PurgeImageHandler struct {
+	ImageRepository   image.Repository
+	RolloutRepository rollout.Repository
+	DeviceRepository  device.Repository
+}
+
+// NewPurgeImageHandler returns a new PurgeImageHandler.
+func NewPurgeImageHandler(imageRepository image.Repository, rolloutRepository rollout.Repository,
+	deviceRepository device.Repository) *PurgeImageHandler {
+	if imageRepository == nil || rolloutRepository == nil || deviceRepository == nil {
+		return &PurgeImageHandler{}
+	}
+	return &PurgeImageHandler{
+		ImageRepository:   imageRepository,
+		RolloutRepository: rolloutRepository,
+		DeviceRepository:  deviceRepository,
+	}
+}
+
+// Handle implements the command interface, only organization admins are allowed to purge images.
+func (h *PurgeImageHandler) Handle(ctx context.Context, cmd PurgeImage) (err error) {
+	defer func() {
+		logs.LogCommandExecution("PurgeImageHandler", cmd, err)
+	}()
+	if !common.IsOrgAdmin(ctx) {
+		return common.ErrNotOrgAdmin
+	}
+	ctx, err = withInUseCheck(ctx, h.RolloutRepository, h.DeviceRepository, cmd.UUID, cmd.Force)
+	if err != nil {
+		return err
+	}
+	return h.ImageRepository.PurgeImage(ctx, cmd.UUID)
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+)
+
+// ReportTransaction is a command for a device to report the progress of its update within a rollout.
+type ReportTransaction struct {
+	RolloutUUID string
+	DeviceUUID  string
+	Status      string
+	Reason      string
+}
+
+// ReportTransactionHandler is a handler for the ReportTransaction command.
+type ReportTransactionHandler struct {
+	RolloutRepository rollout.Repository
+}
+
+// NewReportTransactionHandler returns a new ReportTransactionHandler.
+func NewReportTransactionHandler(rolloutRepository rollout.Repository) *ReportTransactionHandler {
+	if rolloutRepository == nil {
+		return &ReportTransactionHandler{}
+	}
+	return &ReportTransactionHandler{
+		RolloutRepository: rolloutRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *ReportTransactionHandler) Handle(ctx context.Context, cmd ReportTransaction) (err error) {
+	defer func() {
+		logs.LogCommandExecution("ReportTransactionHandler", cmd, err)
+	}()
+	status, err := rollout.NewTransactionStatusFromString(cmd.Status)
+	if err != nil {
+		return err
+	}
+	return h.RolloutRepository.UpdateTransaction(ctx, cmd.RolloutUUID, cmd.DeviceUUID,
+		func(t *rollout.Transaction) (*rollout.Transaction, error) {
+			now := time.Now()
+			switch status {
+			case rollout.Applying:
+				return t, t.Apply(now)
+			case rollout.Succeeded:
+				return t, t.Succeed(now)
+			case rollout.Failed:
+				return t, t.Fail(cmd.Reason, now)
+			default: // pending and dispatched are set by the rollout itself
+				return nil, rollout.ErrInvalidTransition
+			}
+		})
+}
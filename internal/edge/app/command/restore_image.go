@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// RestoreImageHandler is a handler for the RestoreImage command.
+type RestoreImageHandler struct {
+	ImageRepository image.Repository
+}
+
+// NewRestoreImageHandler returns a new RestoreImageHandler.
+func NewRestoreImageHandler(imageRepository image.Repository) *RestoreImageHandler {
+	if imageRepository == nil {
+		return &RestoreImageHandler{}
+	}
+	return &RestoreImageHandler{
+		ImageRepository: imageRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *RestoreImageHandler) Handle(ctx context.Context, uuidToRestore string) (err error) {
+	defer func() {
+		logs.LogCommandExecution("RestoreImageHandler", uuidToRestore, err)
+	}()
+	return h.ImageRepository.RestoreImage(ctx, uuidToRestore)
+}
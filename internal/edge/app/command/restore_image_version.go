@@ -0,0 +1,49 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// RestoreImageVersion is a command to bring back a stored version of an image as its new version.
+type RestoreImageVersion struct {
+	UUID    string
+	Version uint
+}
+
+// RestoreImageVersionHandler is a handler for the RestoreImageVersion command.
+type RestoreImageVersionHandler struct {
+	ImageRepository   image.Repository
+	VersionRepository image.VersionRepository
+}
+
+// NewRestoreImageVersionHandler returns a new RestoreImageVersionHandler.
+func NewRestoreImageVersionHandler(imageRepository image.Repository,
+	versionRepository image.VersionRepository) *RestoreImageVersionHandler {
+	if imageRepository == nil || versionRepository == nil {
+		return &RestoreImageVersionHandler{}
+	}
+	return &RestoreImageVersionHandler{
+		ImageRepository:   imageRepository,
+		VersionRepository: versionRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *RestoreImageVersionHandler) Handle(ctx context.Context, cmd RestoreImageVersion) (err error) {
+	defer func() {
+		logs.LogCommandExecution("RestoreImageVersionHandler", cmd, err)
+	}()
+	snapshot, err := h.VersionRepository.GetVersion(ctx, cmd.UUID, cmd.Version)
+	if err != nil {
+		return err
+	}
+	return h.ImageRepository.UpdateImage(ctx, cmd.UUID, func(i *image.Image) (*image.Image, error) {
+		if err := i.RestoreVersion(*snapshot); err != nil {
+			return nil, err
+		}
+		return i, nil
+	})
+}
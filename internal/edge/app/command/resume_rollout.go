@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	"github.com/Avielyo10/edge-api/internal/update/domain/update"
+)
+
+// ResumeRolloutHandler is a handler for the ResumeRollout command.
+type ResumeRolloutHandler struct {
+	RolloutRepository rollout.Repository
+	DeviceRepository  device.Repository
+	GroupRepository   group.Repository
+	WindowRepository  maintenance.Repository
+	Queue             *update.Queue
+}
+
+// NewResumeRolloutHandler returns a new ResumeRolloutHandler.
+func NewResumeRolloutHandler(rolloutRepository rollout.Repository, deviceRepository device.Repository,
+	groupRepository group.Repository, windowRepository maintenance.Repository,
+	queue *update.Queue) *ResumeRolloutHandler {
+	if rolloutRepository == nil || deviceRepository == nil || groupRepository == nil ||
+		windowRepository == nil || queue == nil {
+		return &ResumeRolloutHandler{}
+	}
+	return &ResumeRolloutHandler{
+		RolloutRepository: rolloutRepository,
+		DeviceRepository:  deviceRepository,
+		GroupRepository:   groupRepository,
+		WindowRepository:  windowRepository,
+		Queue:             queue,
+	}
+}
+
+// Handle implements the command interface.
+// A halted rollout accepts the failures that halted it, the rollout is then followed again through the update queue,
+// unless another replica follows it already.
+func (h *ResumeRolloutHandler) Handle(ctx context.Context, uuidToResume string) (_ *rollout.Rollout, err error) {
+	defer func() {
+		logs.LogCommandExecution("ResumeRolloutHandler", uuidToResume, err)
+	}()
+	var resumed *rollout.Rollout
+	err = h.RolloutRepository.UpdateRollout(ctx, uuidToResume, func(r *rollout.Rollout) (*rollout.Rollout, error) {
+		resumed = r
+		return r, r.Resume()
+	})
+	if err != nil {
+		return nil, err
+	}
+	follower := rolloutFollower{
+		rolloutRepository: h.RolloutRepository,
+		deviceRepository:  h.DeviceRepository,
+		groupRepository:   h.GroupRepository,
+		windowRepository:  h.WindowRepository,
+		queue:             h.Queue,
+	}
+	if err := follower.follow(ctx, resumed); err != nil {
+		return nil, err
+	}
+	return resumed, nil
+}
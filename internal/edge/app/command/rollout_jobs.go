@@ -0,0 +1,280 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	"github.com/Avielyo10/edge-api/internal/update/domain/update"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// transactionTimeout is how long a device has to apply an update once dispatched before its transaction fails.
+	transactionTimeout = 2 * time.Hour
+	// leaseDuration is how long a replica follows a rollout without renewing its lease before another one may
+	// take it over. It outlasts the interval the jobs of the update queue are checked at, which renew it.
+	leaseDuration = 5 * time.Minute
+)
+
+// rolloutFollower follows rollouts through the update queue, a job per rollout this replica leased.
+// The jobs keep no state of their own, each check starts over from the stored rollout, so a rollout is followed
+// again from where it was by the replica that claims it once its lease expired, e.g. the previous one restarted.
+type rolloutFollower struct {
+	rolloutRepository rollout.Repository
+	deviceRepository  device.Repository
+	groupRepository   group.Repository
+	windowRepository  maintenance.Repository
+	queue             *update.Queue
+}
+
+// jobContext returns the context of the job of a rollout, it outlives the request and only keeps its account.
+func jobContext(existing *rollout.Rollout) (context.Context, error) {
+	account, err := existing.Account()
+	if err != nil {
+		return nil, err
+	}
+	return common.ContextWithAccount(context.Background(), account), nil
+}
+
+// follow leases the rollout and follows it through the update queue, unless another replica follows it already.
+func (f rolloutFollower) follow(ctx context.Context, existing *rollout.Rollout) error {
+	lease := uuid.NewString()
+	err := f.rolloutRepository.ClaimRollout(ctx, existing.UUID(), lease, time.Now().Add(leaseDuration))
+	if err == rollout.ErrRolloutLeased { // the other replica picks up the change as it checks the rollout
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return f.add(existing, lease)
+}
+
+// add adds a job following the rollout to the update queue, the rollout was leased with the given lease.
+func (f rolloutFollower) add(existing *rollout.Rollout, lease string) error {
+	ctx, err := jobContext(existing)
+	if err != nil {
+		return err
+	}
+	f.queue.Add(&rolloutJob{ctx: ctx, rolloutUUID: existing.UUID(), lease: lease, follower: f})
+	return nil
+}
+
+// rolloutJob follows a rollout each time it is checked, implementing the update.UpdatesInterface
+// and update.Scheduler interfaces. It dispatches the transactions its stages reached within the maintenance
+// windows of their devices, fails the ones that timed out and rolls their devices back if the plan says so,
+// halting the rollout or starting its next stage as it goes.
+type rolloutJob struct {
+	ctx         context.Context // carries the account
+	rolloutUUID string
+	lease       string
+	// whether there was nothing left to follow at the last check, and when the rollout is to be checked next,
+	// zero unless sooner than the interval of the workers may be needed
+	done      bool
+	nextCheck time.Time
+
+	follower rolloutFollower
+}
+
+// Upgrade does nothing, the transactions are dispatched as the rollout is checked.
+func (j *rolloutJob) Upgrade() error {
+	return nil
+}
+
+// CheckForUpdate renews the lease of the job, then follows the rollout from its stored state.
+// The job is done once the rollout is settled, or another replica took it over.
+func (j *rolloutJob) CheckForUpdate() error {
+	j.nextCheck = time.Time{}
+	err := j.follower.rolloutRepository.ClaimRollout(j.ctx, j.rolloutUUID, j.lease, time.Now().Add(leaseDuration))
+	if err == rollout.ErrRolloutLeased { // the lease expired before it was renewed
+		j.done = true
+		return nil
+	} else if err != nil {
+		return err
+	}
+	current, err := j.evaluate()
+	if err != nil {
+		return err
+	}
+	if current.IsSettled() {
+		j.done = true
+		return j.follower.rolloutRepository.ReleaseRollout(j.ctx, j.rolloutUUID, j.lease)
+	}
+	now := time.Now()
+	for _, transaction := range current.ReachedTransactions() {
+		switch transaction.Status() {
+		case rollout.Pending:
+			if current.State() == rollout.Running {
+				err = j.dispatch(current, transaction, now)
+			}
+		case rollout.Dispatched, rollout.Applying:
+			if deadline := transaction.UpdatedAt().Add(transactionTimeout); now.Before(deadline) {
+				j.schedule(deadline)
+			} else {
+				err = j.timeout(current, transaction, now)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, transaction := range current.ToRestore() {
+		if err := j.restore(current, transaction); err != nil {
+			return err
+		}
+	}
+	if soakEnd, ok := current.SoakEnd(); ok {
+		j.schedule(soakEnd)
+	}
+	return nil
+}
+
+// evaluate returns the rollout as stored, halting it or starting its next stage first if it is running.
+func (j *rolloutJob) evaluate() (*rollout.Rollout, error) {
+	current, err := j.follower.rolloutRepository.GetRollout(j.ctx, j.rolloutUUID)
+	if err != nil || current.State() != rollout.Running {
+		return current, err
+	}
+	err = j.follower.rolloutRepository.UpdateRollout(j.ctx, j.rolloutUUID,
+		func(r *rollout.Rollout) (*rollout.Rollout, error) {
+			r.Evaluate(time.Now())
+			current = r
+			return r, nil
+		})
+	if err == rollout.ErrInvalidStateChange { // changed by someone else since it was read, evaluate it next time
+		return j.follower.rolloutRepository.GetRollout(j.ctx, j.rolloutUUID)
+	}
+	return current, err
+}
+
+// dispatch assigns the device of the pending transaction to the image version of the rollout and marks
+// the transaction as dispatched, once one of the maintenance windows of the device is open.
+// The device keeps its previous assignment if the transaction can't be dispatched, e.g. the rollout was aborted.
+func (j *rolloutJob) dispatch(current *rollout.Rollout, transaction rollout.Transaction, now time.Time) error {
+	deviceUUID := transaction.DeviceUUID()
+	windows, err := maintenance.GetDeviceWindows(j.ctx, j.follower.windowRepository, j.follower.groupRepository,
+		deviceUUID)
+	if err != nil {
+		return err
+	}
+	if !windows.IsOpen(now) {
+		if start, _, ok := windows.Next(now); ok {
+			j.schedule(start)
+		}
+		return nil
+	}
+	var previous device.Assignment
+	err = j.follower.deviceRepository.UpdateDevice(j.ctx, deviceUUID, func(d *device.Device) (*device.Device, error) {
+		previous = d.Assignment()
+		d.Assign(current.Assignment())
+		return d, nil
+	})
+	if err == device.ErrDeviceNotFound {
+		return j.fail(deviceUUID, err.Error(), now)
+	} else if err != nil {
+		return err
+	}
+	err = j.follower.rolloutRepository.UpdateTransaction(j.ctx, j.rolloutUUID, deviceUUID,
+		func(t *rollout.Transaction) (*rollout.Transaction, error) {
+			return t, t.Dispatch(previous, now)
+		})
+	if err == nil {
+		j.schedule(now.Add(transactionTimeout))
+		return nil
+	}
+	if err := j.reassign(current, deviceUUID, previous); err != nil {
+		log.WithFields(log.Fields{"rollout": j.rolloutUUID, "device": deviceUUID}).
+			WithError(err).Error("failed to restore device assignment")
+	}
+	if err == rollout.ErrInvalidTransition { // moved by someone else since it was read
+		return nil
+	}
+	return err
+}
+
+// timeout fails the dispatched transaction the device didn't report as applied in time,
+// then rolls its device back if the plan of the rollout says so.
+func (j *rolloutJob) timeout(current *rollout.Rollout, transaction rollout.Transaction, now time.Time) error {
+	if err := j.fail(transaction.DeviceUUID(), "timed out", now); err != nil {
+		return err
+	}
+	if !current.Plan().RollbackFailed() {
+		return nil
+	}
+	return j.restore(current, transaction)
+}
+
+// fail marks the transaction of the device as failed for the given reason, unless it is final already.
+func (j *rolloutJob) fail(deviceUUID, reason string, now time.Time) error {
+	err := j.follower.rolloutRepository.UpdateTransaction(j.ctx, j.rolloutUUID, deviceUUID,
+		func(t *rollout.Transaction) (*rollout.Transaction, error) {
+			return t, t.Fail(reason, now)
+		})
+	if err == rollout.ErrInvalidTransition { // reported by the device since it was read
+		return nil
+	}
+	return err
+}
+
+// restore assigns the device of the failed transaction back to its previous assignment,
+// then marks the transaction as restored.
+func (j *rolloutJob) restore(current *rollout.Rollout, transaction rollout.Transaction) error {
+	err := j.reassign(current, transaction.DeviceUUID(), transaction.Previous())
+	if err != nil && err != device.ErrDeviceNotFound {
+		return err
+	}
+	err = j.follower.rolloutRepository.UpdateTransaction(j.ctx, j.rolloutUUID, transaction.DeviceUUID(),
+		func(t *rollout.Transaction) (*rollout.Transaction, error) {
+			return t, t.Restore()
+		})
+	if err == rollout.ErrInvalidTransition { // restored already
+		return nil
+	}
+	return err
+}
+
+// reassign assigns the device back to the given assignment, unless it was assigned elsewhere than the rollout since.
+func (j *rolloutJob) reassign(current *rollout.Rollout, deviceUUID string, previous device.Assignment) error {
+	return j.follower.deviceRepository.UpdateDevice(j.ctx, deviceUUID, func(d *device.Device) (*device.Device, error) {
+		if d.Assignment() == current.Assignment() { // keep any assignment made since
+			d.Assign(previous)
+		}
+		return d, nil
+	})
+}
+
+// schedule checks the rollout again at the given time, if it is sooner than the next check scheduled so far.
+func (j *rolloutJob) schedule(at time.Time) {
+	if j.nextCheck.IsZero() || at.Before(j.nextCheck) {
+		j.nextCheck = at
+	}
+}
+
+// NextCheck returns the soonest of the next opening of the maintenance windows a pending transaction waits for,
+// the timeouts of the dispatched transactions and the end of the soak time of the stage, so a window or deadline
+// shorter than the interval of the workers isn't missed.
+func (j *rolloutJob) NextCheck(now time.Time) (time.Time, bool) {
+	return j.nextCheck, !j.nextCheck.IsZero()
+}
+
+// IsSuccessful returns true once there is nothing left to follow on the rollout, or another replica follows it.
+func (j *rolloutJob) IsSuccessful() bool {
+	return j.done
+}
+
+// Rollback pauses the rollout when it couldn't be followed, it can be resumed once the cause is fixed.
+// Its lease is released meanwhile, so it is claimed again by the next replica looking for rollouts to follow.
+func (j *rolloutJob) Rollback() error {
+	err := j.follower.rolloutRepository.UpdateRollout(j.ctx, j.rolloutUUID,
+		func(r *rollout.Rollout) (*rollout.Rollout, error) {
+			return r, r.Pause()
+		})
+	if err != nil && err != rollout.ErrInvalidStateChange {
+		log.WithField("rollout", j.rolloutUUID).WithError(err).Error("failed to pause rollout")
+	}
+	return j.follower.rolloutRepository.ReleaseRollout(j.ctx, j.rolloutUUID, j.lease)
+}
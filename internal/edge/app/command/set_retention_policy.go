@@ -0,0 +1,41 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// SetRetentionPolicy is a command to set the version retention policy of an account.
+type SetRetentionPolicy struct {
+	KeepLast uint
+	KeepDays uint
+}
+
+// SetRetentionPolicyHandler is a handler for the SetRetentionPolicy command.
+type SetRetentionPolicyHandler struct {
+	RetentionRepository image.RetentionRepository
+}
+
+// NewSetRetentionPolicyHandler returns a new SetRetentionPolicyHandler.
+func NewSetRetentionPolicyHandler(retentionRepository image.RetentionRepository) *SetRetentionPolicyHandler {
+	if retentionRepository == nil {
+		return &SetRetentionPolicyHandler{}
+	}
+	return &SetRetentionPolicyHandler{
+		RetentionRepository: retentionRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *SetRetentionPolicyHandler) Handle(ctx context.Context, cmd SetRetentionPolicy) (_ *image.RetentionPolicy, err error) {
+	defer func() {
+		logs.LogCommandExecution("SetRetentionPolicyHandler", cmd, err)
+	}()
+	policy, err := image.NewRetentionPolicy(cmd.KeepLast, cmd.KeepDays)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, h.RetentionRepository.SetRetentionPolicy(ctx, &policy)
+}
@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// SetVersionCommit is a command to record the ostree commit a version of an image was built into.
+type SetVersionCommit struct {
+	UUID    string
+	Version uint
+	Commit  string
+}
+
+// SetVersionCommitHandler is a handler for the SetVersionCommit command.
+type SetVersionCommitHandler struct {
+	VersionRepository image.VersionRepository
+}
+
+// NewSetVersionCommitHandler returns a new SetVersionCommitHandler.
+func NewSetVersionCommitHandler(versionRepository image.VersionRepository) *SetVersionCommitHandler {
+	if versionRepository == nil {
+		return &SetVersionCommitHandler{}
+	}
+	return &SetVersionCommitHandler{
+		VersionRepository: versionRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *SetVersionCommitHandler) Handle(ctx context.Context, cmd SetVersionCommit) (err error) {
+	defer func() {
+		logs.LogCommandExecution("SetVersionCommitHandler", cmd, err)
+	}()
+	commit, err := image.NewCommit(cmd.Commit)
+	if err != nil {
+		return err
+	}
+	return h.VersionRepository.UpdateVersion(ctx, cmd.UUID, cmd.Version, func(s *image.Snapshot) (*image.Snapshot, error) {
+		s.SetCommit(commit)
+		return s, nil
+	})
+}
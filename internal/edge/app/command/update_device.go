@@ -0,0 +1,79 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// UpdateDevice is a command to update a device.
+type UpdateDevice struct {
+	UUIDToUpdate string
+	Name         string
+	Commit       string
+	// Assign replaces the image assignment, an empty ImageUUID unassigns the device
+	Assign       bool
+	ImageUUID    string
+	ImageVersion uint
+	TagsToRemove []string
+	TagsToAdd    []string
+}
+
+// UpdateDeviceHandler is a handler for the UpdateDevice command.
+type UpdateDeviceHandler struct {
+	DeviceRepository  device.Repository
+	VersionRepository image.VersionRepository
+}
+
+// NewUpdateDeviceHandler returns a new UpdateDeviceHandler.
+func NewUpdateDeviceHandler(deviceRepository device.Repository,
+	versionRepository image.VersionRepository) *UpdateDeviceHandler {
+	if deviceRepository == nil || versionRepository == nil {
+		return &UpdateDeviceHandler{}
+	}
+	return &UpdateDeviceHandler{
+		DeviceRepository:  deviceRepository,
+		VersionRepository: versionRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *UpdateDeviceHandler) Handle(ctx context.Context, cmd UpdateDevice) (err error) {
+	defer func() {
+		logs.LogCommandExecution("UpdateDeviceHandler", cmd, err)
+	}()
+	var newName common.Name
+	if cmd.Name != "" {
+		if newName, err = common.NewName(cmd.Name); err != nil {
+			return err
+		}
+	}
+	newCommit, err := device.NewCommit(cmd.Commit)
+	if err != nil {
+		return err
+	}
+	assignment, err := device.NewAssignment(cmd.ImageUUID, cmd.ImageVersion)
+	if err != nil {
+		return err
+	}
+	if cmd.Assign {
+		if err := checkAssignment(ctx, h.VersionRepository, assignment); err != nil {
+			return err
+		}
+	}
+	return h.DeviceRepository.UpdateDevice(ctx, cmd.UUIDToUpdate, func(d *device.Device) (*device.Device, error) {
+		d.SetName(newName)
+		if !newCommit.IsZero() {
+			d.SetCommit(newCommit)
+		}
+		if cmd.Assign {
+			d.Assign(assignment)
+		}
+		d.RemoveTag(common.NewTags(cmd.TagsToRemove...).Tags()...)
+		d.AddTag(common.NewTags(cmd.TagsToAdd...).Tags()...)
+		return d, nil
+	})
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+)
+
+// UpdateRepo is a command to replace the content of a third-party repository of the account.
+type UpdateRepo struct {
+	UUIDToUpdate string
+	Name         string
+	BaseURL      string
+	GPGKey       string
+	GPGCheck     bool
+	Priority     int
+}
+
+// UpdateRepoHandler is a handler for the UpdateRepo command.
+type UpdateRepoHandler struct {
+	RepoRepository repo.Repository
+}
+
+// NewUpdateRepoHandler returns a new UpdateRepoHandler.
+func NewUpdateRepoHandler(repoRepository repo.Repository) *UpdateRepoHandler {
+	if repoRepository == nil {
+		return &UpdateRepoHandler{}
+	}
+	return &UpdateRepoHandler{
+		RepoRepository: repoRepository,
+	}
+}
+
+// Handle implements the command interface.
+func (h *UpdateRepoHandler) Handle(ctx context.Context, cmd UpdateRepo) error {
+	return h.RepoRepository.UpdateRepo(ctx, cmd.UUIDToUpdate, func(r *repo.Repo) (_ *repo.Repo, err error) {
+		defer func() {
+			logs.LogCommandExecution("UpdateRepoHandler", cmd, err)
+		}()
+		if err := r.Update(cmd.Name, cmd.BaseURL, cmd.GPGKey, cmd.GPGCheck, cmd.Priority); err != nil {
+			return nil, err
+		}
+		return r, nil
+	})
+}
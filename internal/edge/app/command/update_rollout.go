@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/logs"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	"github.com/Avielyo10/edge-api/internal/update/domain/update"
+)
+
+// UpdateRollout is a command to roll out an image version to devices, given directly or through their groups.
+// Without stages, the rollout reaches all the devices at once.
+type UpdateRollout struct {
+	UUID             string
+	ImageUUID        string
+	ImageVersion     uint
+	Devices          []string
+	Groups           []string
+	Stages           []uint
+	SoakTime         time.Duration
+	FailureThreshold uint
+	RollbackFailed   bool
+}
+
+// UpdateRolloutHandler is a handler for the UpdateRollout command.
+type UpdateRolloutHandler struct {
+	RolloutRepository rollout.Repository
+	VersionRepository image.VersionRepository
+	DeviceRepository  device.Repository
+	GroupRepository   group.Repository
+	WindowRepository  maintenance.Repository
+	Queue             *update.Queue
+}
+
+// NewUpdateRolloutHandler returns a new UpdateRolloutHandler.
+func NewUpdateRolloutHandler(rolloutRepository rollout.Repository, versionRepository image.VersionRepository,
+	deviceRepository device.Repository, groupRepository group.Repository, windowRepository maintenance.Repository,
+	queue *update.Queue) *UpdateRolloutHandler {
+	if rolloutRepository == nil || versionRepository == nil || deviceRepository == nil ||
+		groupRepository == nil || windowRepository == nil || queue == nil {
+		return &UpdateRolloutHandler{}
+	}
+	return &UpdateRolloutHandler{
+		RolloutRepository: rolloutRepository,
+		VersionRepository: versionRepository,
+		DeviceRepository:  deviceRepository,
+		GroupRepository:   groupRepository,
+		WindowRepository:  windowRepository,
+		Queue:             queue,
+	}
+}
+
+// Handle implements the command interface.
+// The rollout is stored with a pending transaction per device, then leased and followed through the update queue:
+// the transactions of its stages are dispatched within the maintenance windows of their devices,
+// and followed until the device reports it succeeded, failed or timed out, starting the next stages or halting it.
+func (h *UpdateRolloutHandler) Handle(ctx context.Context, cmd UpdateRollout) (_ *rollout.Rollout, err error) {
+	defer func() {
+		logs.LogCommandExecution("UpdateRolloutHandler", cmd, err)
+	}()
+	snapshot, err := h.VersionRepository.GetVersion(ctx, cmd.ImageUUID, cmd.ImageVersion)
+	if err == image.ErrVersionNotFound {
+		return nil, rollout.ErrInvalidRolloutVersion
+	} else if err != nil {
+		return nil, err
+	}
+	if !snapshot.Status().IsSuccess() {
+		return nil, rollout.ErrVersionNotSuccessful
+	}
+	devices, err := h.resolveDevices(ctx, cmd.Devices, cmd.Groups)
+	if err != nil {
+		return nil, err
+	}
+	stages := cmd.Stages
+	if len(stages) == 0 {
+		stages = rollout.DefaultPlan().Stages()
+	}
+	plan, err := rollout.NewPlan(stages, cmd.SoakTime, cmd.FailureThreshold, cmd.RollbackFailed)
+	if err != nil {
+		return nil, err
+	}
+	newRollout, err := rollout.NewRolloutWithContext(ctx, cmd.UUID, cmd.ImageUUID, cmd.ImageVersion, devices, plan)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.RolloutRepository.CreateRollout(ctx, &newRollout); err != nil {
+		return nil, err
+	}
+	follower := rolloutFollower{
+		rolloutRepository: h.RolloutRepository,
+		deviceRepository:  h.DeviceRepository,
+		groupRepository:   h.GroupRepository,
+		windowRepository:  h.WindowRepository,
+		queue:             h.Queue,
+	}
+	if err := follower.follow(ctx, &newRollout); err != nil {
+		return nil, err
+	}
+	return &newRollout, nil
+}
+
+// resolveDevices returns the given devices along with the members of the given groups,
+// rollout.ErrUnknownTarget is returned if any of them doesn't belong to the account.
+func (h *UpdateRolloutHandler) resolveDevices(ctx context.Context, devices, groups []string) ([]string, error) {
+	for _, deviceUUID := range devices {
+		_, err := h.DeviceRepository.GetDevice(ctx, deviceUUID)
+		if err == device.ErrDeviceNotFound {
+			return nil, rollout.ErrUnknownTarget
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	resolved := append([]string{}, devices...)
+	for _, groupUUID := range groups {
+		members, err := h.GroupRepository.GetMembers(ctx, groupUUID)
+		if err == group.ErrGroupNotFound {
+			return nil, rollout.ErrUnknownTarget
+		} else if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			resolved = append(resolved, member.UUID())
+		}
+	}
+	return resolved, nil
+}
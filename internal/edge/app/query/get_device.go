@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	deviceDomain "github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetDeviceHandler is a handler for the GetDevice query.
+type GetDeviceHandler struct {
+	DeviceRepository deviceDomain.Repository
+}
+
+// NewGetDeviceHandler returns a new GetDeviceHandler.
+func NewGetDeviceHandler(deviceRepository deviceDomain.Repository) *GetDeviceHandler {
+	if deviceRepository == nil {
+		return &GetDeviceHandler{}
+	}
+	return &GetDeviceHandler{
+		DeviceRepository: deviceRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetDeviceHandler) Handle(ctx context.Context, uuid string) (device *deviceDomain.Device, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetDeviceHandler executed")
+	}()
+	return h.DeviceRepository.GetDevice(ctx, uuid)
+}
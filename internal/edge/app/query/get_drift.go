@@ -0,0 +1,37 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	deviceDomain "github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetDriftHandler is a handler for the GetDrift query.
+type GetDriftHandler struct {
+	DeviceRepository deviceDomain.Repository
+}
+
+// NewGetDriftHandler returns a new GetDriftHandler.
+func NewGetDriftHandler(deviceRepository deviceDomain.Repository) *GetDriftHandler {
+	if deviceRepository == nil {
+		return &GetDriftHandler{}
+	}
+	return &GetDriftHandler{
+		DeviceRepository: deviceRepository,
+	}
+}
+
+// Handle implements the query interface.
+// It counts the drift of the devices per image they are assigned to.
+func (h *GetDriftHandler) Handle(ctx context.Context) (summaries []deviceDomain.ImageDrift, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetDriftHandler executed")
+	}()
+	return h.DeviceRepository.GetDrift(ctx)
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetGroupHandler is a handler for the GetGroup query.
+type GetGroupHandler struct {
+	GroupRepository group.Repository
+}
+
+// NewGetGroupHandler returns a new GetGroupHandler.
+func NewGetGroupHandler(groupRepository group.Repository) *GetGroupHandler {
+	if groupRepository == nil {
+		return &GetGroupHandler{}
+	}
+	return &GetGroupHandler{
+		GroupRepository: groupRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetGroupHandler) Handle(ctx context.Context, uuid string) (deviceGroup *group.DeviceGroup, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetGroupHandler executed")
+	}()
+	return h.GroupRepository.GetGroup(ctx, uuid)
+}
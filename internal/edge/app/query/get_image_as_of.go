@@ -0,0 +1,61 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	imageDomain "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetImageAsOf is a query for the state of an image at a point in time.
+type GetImageAsOf struct {
+	UUID string
+	AsOf time.Time
+}
+
+// GetImageAsOfHandler is a handler for the GetImageAsOf query.
+type GetImageAsOfHandler struct {
+	ImageRepository   imageDomain.Repository
+	VersionRepository imageDomain.VersionRepository
+}
+
+// NewGetImageAsOfHandler returns a new GetImageAsOfHandler.
+func NewGetImageAsOfHandler(imageRepository imageDomain.Repository,
+	versionRepository imageDomain.VersionRepository) *GetImageAsOfHandler {
+	if imageRepository == nil || versionRepository == nil {
+		return &GetImageAsOfHandler{}
+	}
+	return &GetImageAsOfHandler{
+		ImageRepository:   imageRepository,
+		VersionRepository: versionRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetImageAsOfHandler) Handle(ctx context.Context, q GetImageAsOf) (image *imageDomain.Image, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetImageAsOfHandler executed")
+	}()
+	// make sure the image exists for the account
+	if _, err := h.ImageRepository.GetImage(ctx, q.UUID); err != nil {
+		return nil, err
+	}
+	snapshots, err := h.VersionRepository.GetVersions(ctx, q.UUID)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := h.VersionRepository.GetMetadataChanges(ctx, q.UUID)
+	if err != nil {
+		return nil, err
+	}
+	asOf, err := imageDomain.AsOf(q.AsOf, snapshots, changes)
+	if err != nil {
+		return nil, err
+	}
+	return &asOf, nil
+}
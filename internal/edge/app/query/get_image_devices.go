@@ -0,0 +1,47 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetImageDevicesHandler is a handler for the GetImageDevices query,
+// listing the devices assigned to or running each version of an image.
+type GetImageDevicesHandler struct {
+	ImageRepository   image.Repository
+	RolloutRepository rollout.Repository
+	DeviceRepository  device.Repository
+}
+
+// NewGetImageDevicesHandler returns a new GetImageDevicesHandler.
+func NewGetImageDevicesHandler(imageRepository image.Repository, rolloutRepository rollout.Repository,
+	deviceRepository device.Repository) *GetImageDevicesHandler {
+	if imageRepository == nil || rolloutRepository == nil || deviceRepository == nil {
+		return &GetImageDevicesHandler{}
+	}
+	return &GetImageDevicesHandler{
+		ImageRepository:   imageRepository,
+		RolloutRepository: rolloutRepository,
+		DeviceRepository:  deviceRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetImageDevicesHandler) Handle(ctx context.Context, uuid string) (usage rollout.Usage, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetImageDevicesHandler executed")
+	}()
+	if _, err := h.ImageRepository.GetImage(ctx, uuid); err != nil {
+		return rollout.Usage{}, err
+	}
+	return rollout.GetUsage(ctx, h.RolloutRepository, h.DeviceRepository, uuid)
+}
@@ -0,0 +1,99 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	imageDomain "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetImageLineageHandler is a handler for the GetImageLineage query.
+type GetImageLineageHandler struct {
+	ImageRepository   imageDomain.Repository
+	VersionRepository imageDomain.VersionRepository
+}
+
+// NewGetImageLineageHandler returns a new GetImageLineageHandler.
+func NewGetImageLineageHandler(imageRepository imageDomain.Repository,
+	versionRepository imageDomain.VersionRepository) *GetImageLineageHandler {
+	if imageRepository == nil || versionRepository == nil {
+		return &GetImageLineageHandler{}
+	}
+	return &GetImageLineageHandler{
+		ImageRepository:   imageRepository,
+		VersionRepository: versionRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetImageLineageHandler) Handle(ctx context.Context, uuid string) (lineage *imageDomain.Lineage, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetImageLineageHandler executed")
+	}()
+	image, err := h.ImageRepository.GetImage(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	ancestors, err := h.ancestors(ctx, uuid, image.Version())
+	if err != nil {
+		return nil, err
+	}
+	descendants, err := h.descendants(ctx, uuid, map[string]bool{uuid: true})
+	if err != nil {
+		return nil, err
+	}
+	result := imageDomain.NewLineage(uuid, image.Version(), ancestors, descendants)
+	return &result, nil
+}
+
+// ancestors follows the parents of the given version, until a version without one or one that is no longer stored.
+func (h *GetImageLineageHandler) ancestors(ctx context.Context, uuid string, version imageDomain.Version) ([]imageDomain.Parent, error) {
+	var ancestors []imageDomain.Parent
+	visited := map[string]bool{}
+	for {
+		key := fmt.Sprintf("%s:%d", uuid, version.Uint())
+		if visited[key] {
+			return ancestors, nil
+		}
+		visited[key] = true
+		snapshot, err := h.VersionRepository.GetVersion(ctx, uuid, version.Uint())
+		if err == imageDomain.ErrVersionNotFound { // pruned or purged, the chain ends here
+			return ancestors, nil
+		} else if err != nil {
+			return nil, err
+		}
+		parent := snapshot.Parent()
+		if parent.IsZero() {
+			return ancestors, nil
+		}
+		ancestors = append(ancestors, parent)
+		uuid, version = parent.UUID(), parent.Version()
+	}
+}
+
+// descendants returns the tree of images derived from the image, skipping images already visited.
+func (h *GetImageLineageHandler) descendants(ctx context.Context, uuid string, visited map[string]bool) ([]imageDomain.Descendant, error) {
+	children, err := h.VersionRepository.GetChildren(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	var descendants []imageDomain.Descendant
+	for _, child := range children {
+		if visited[child.UUID()] {
+			continue
+		}
+		visited[child.UUID()] = true
+		grandchildren, err := h.descendants(ctx, child.UUID(), visited)
+		if err != nil {
+			return nil, err
+		}
+		descendants = append(descendants, imageDomain.NewDescendant(*child, grandchildren))
+	}
+	return descendants, nil
+}
@@ -0,0 +1,51 @@
+package query
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/ostree"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetImageRepoFileHandler is a handler for the GetImageRepoFile query,
+// reading a file of the ostree repository of an image as its http remote serves it.
+type GetImageRepoFileHandler struct {
+	Storage ostree.Storage
+}
+
+// NewGetImageRepoFileHandler returns a new GetImageRepoFileHandler.
+func NewGetImageRepoFileHandler(storage ostree.Storage) *GetImageRepoFileHandler {
+	if storage == nil {
+		return &GetImageRepoFileHandler{}
+	}
+	return &GetImageRepoFileHandler{
+		Storage: storage,
+	}
+}
+
+// Handle implements the query interface, the caller must close the returned file.
+func (h *GetImageRepoFileHandler) Handle(ctx context.Context, uuid, file string) (content io.ReadCloser, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetImageRepoFileHandler executed")
+	}()
+	account, err := common.GetAccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := ostree.NewRepo(account, uuid)
+	if err != nil {
+		return nil, err
+	}
+	path, err := repo.Path(file)
+	if err != nil {
+		return nil, err
+	}
+	return h.Storage.Open(ctx, path)
+}
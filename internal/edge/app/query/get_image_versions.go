@@ -0,0 +1,43 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	imageDomain "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetImageVersionsHandler is a handler for the GetImageVersions query.
+type GetImageVersionsHandler struct {
+	ImageRepository   imageDomain.Repository
+	VersionRepository imageDomain.VersionRepository
+}
+
+// NewGetImageVersionsHandler returns a new GetImageVersionsHandler.
+func NewGetImageVersionsHandler(imageRepository imageDomain.Repository,
+	versionRepository imageDomain.VersionRepository) *GetImageVersionsHandler {
+	if imageRepository == nil || versionRepository == nil {
+		return &GetImageVersionsHandler{}
+	}
+	return &GetImageVersionsHandler{
+		ImageRepository:   imageRepository,
+		VersionRepository: versionRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetImageVersionsHandler) Handle(ctx context.Context, uuid string) (snapshots []*imageDomain.Snapshot, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetImageVersionsHandler executed")
+	}()
+	// make sure the image exists for the account
+	if _, err := h.ImageRepository.GetImage(ctx, uuid); err != nil {
+		return nil, err
+	}
+	return h.VersionRepository.GetVersions(ctx, uuid)
+}
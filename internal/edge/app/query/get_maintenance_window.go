@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetMaintenanceWindowHandler is a handler for the GetMaintenanceWindow query.
+type GetMaintenanceWindowHandler struct {
+	WindowRepository maintenance.Repository
+}
+
+// NewGetMaintenanceWindowHandler returns a new GetMaintenanceWindowHandler.
+func NewGetMaintenanceWindowHandler(windowRepository maintenance.Repository) *GetMaintenanceWindowHandler {
+	if windowRepository == nil {
+		return &GetMaintenanceWindowHandler{}
+	}
+	return &GetMaintenanceWindowHandler{
+		WindowRepository: windowRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetMaintenanceWindowHandler) Handle(ctx context.Context, uuid string) (window *maintenance.Window, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetMaintenanceWindowHandler executed")
+	}()
+	return h.WindowRepository.GetWindow(ctx, uuid)
+}
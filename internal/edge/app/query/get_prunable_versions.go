@@ -0,0 +1,70 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	imageDomain "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetPrunableVersionsHandler is a handler for the GetPrunableVersions query,
+// a dry-run of the version pruning for the account.
+type GetPrunableVersionsHandler struct {
+	ImageRepository     imageDomain.Repository
+	VersionRepository   imageDomain.VersionRepository
+	RetentionRepository imageDomain.RetentionRepository
+	RolloutRepository   rollout.Repository
+	DeviceRepository    device.Repository
+}
+
+// NewGetPrunableVersionsHandler returns a new GetPrunableVersionsHandler.
+func NewGetPrunableVersionsHandler(imageRepository imageDomain.Repository, versionRepository imageDomain.VersionRepository,
+	retentionRepository imageDomain.RetentionRepository, rolloutRepository rollout.Repository,
+	deviceRepository device.Repository) *GetPrunableVersionsHandler {
+	if imageRepository == nil || versionRepository == nil || retentionRepository == nil ||
+		rolloutRepository == nil || deviceRepository == nil {
+		return &GetPrunableVersionsHandler{}
+	}
+	return &GetPrunableVersionsHandler{
+		ImageRepository:     imageRepository,
+		VersionRepository:   versionRepository,
+		RetentionRepository: retentionRepository,
+		RolloutRepository:   rolloutRepository,
+		DeviceRepository:    deviceRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetPrunableVersionsHandler) Handle(ctx context.Context) (prunable []*imageDomain.Snapshot, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetPrunableVersionsHandler executed")
+	}()
+	policy, err := h.RetentionRepository.GetRetentionPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	images, _, err := h.ImageRepository.GetImages(ctx, imageDomain.Spec{}) // all images
+	if err != nil {
+		return nil, err
+	}
+	prunable = []*imageDomain.Snapshot{}
+	for _, image := range images {
+		snapshots, err := h.VersionRepository.GetVersions(ctx, image.UUID())
+		if err != nil {
+			return nil, err
+		}
+		usage, err := rollout.GetUsage(ctx, h.RolloutRepository, h.DeviceRepository, image.UUID())
+		if err != nil {
+			return nil, err
+		}
+		prunable = append(prunable, usage.Unused(policy.PrunableVersions(image.Version(), snapshots, time.Now()))...)
+	}
+	return prunable, nil
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRepoHandler is a handler for the GetRepo query.
+type GetRepoHandler struct {
+	RepoRepository repo.Repository
+}
+
+// NewGetRepoHandler returns a new GetRepoHandler.
+func NewGetRepoHandler(repoRepository repo.Repository) *GetRepoHandler {
+	if repoRepository == nil {
+		return &GetRepoHandler{}
+	}
+	return &GetRepoHandler{
+		RepoRepository: repoRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetRepoHandler) Handle(ctx context.Context, uuid string) (r *repo.Repo, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetRepoHandler executed")
+	}()
+	return h.RepoRepository.GetRepo(ctx, uuid)
+}
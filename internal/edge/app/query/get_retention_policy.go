@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	imageDomain "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRetentionPolicyHandler is a handler for the GetRetentionPolicy query.
+type GetRetentionPolicyHandler struct {
+	RetentionRepository imageDomain.RetentionRepository
+}
+
+// NewGetRetentionPolicyHandler returns a new GetRetentionPolicyHandler.
+func NewGetRetentionPolicyHandler(retentionRepository imageDomain.RetentionRepository) *GetRetentionPolicyHandler {
+	if retentionRepository == nil {
+		return &GetRetentionPolicyHandler{}
+	}
+	return &GetRetentionPolicyHandler{
+		RetentionRepository: retentionRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetRetentionPolicyHandler) Handle(ctx context.Context) (policy *imageDomain.RetentionPolicy, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetRetentionPolicyHandler executed")
+	}()
+	return h.RetentionRepository.GetRetentionPolicy(ctx)
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRolloutHandler is a handler for the GetRollout query.
+type GetRolloutHandler struct {
+	RolloutRepository rollout.Repository
+}
+
+// NewGetRolloutHandler returns a new GetRolloutHandler.
+func NewGetRolloutHandler(rolloutRepository rollout.Repository) *GetRolloutHandler {
+	if rolloutRepository == nil {
+		return &GetRolloutHandler{}
+	}
+	return &GetRolloutHandler{
+		RolloutRepository: rolloutRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetRolloutHandler) Handle(ctx context.Context, uuid string) (r *rollout.Rollout, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetRolloutHandler executed")
+	}()
+	return h.RolloutRepository.GetRollout(ctx, uuid)
+}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetVoucherHandler is a handler for the GetVoucher query.
+type GetVoucherHandler struct {
+	VoucherRepository fdo.Repository
+}
+
+// NewGetVoucherHandler returns a new GetVoucherHandler.
+func NewGetVoucherHandler(voucherRepository fdo.Repository) *GetVoucherHandler {
+	if voucherRepository == nil {
+		return &GetVoucherHandler{}
+	}
+	return &GetVoucherHandler{
+		VoucherRepository: voucherRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetVoucherHandler) Handle(ctx context.Context, guid string) (voucher *fdo.Voucher, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetVoucherHandler executed")
+	}()
+	validGUID, err := fdo.NewGUID(guid)
+	if err != nil {
+		return nil, err
+	}
+	return h.VoucherRepository.GetVoucher(ctx, validGUID)
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	imageDomain "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetDeletedImagesHandler is a handler for the GetDeletedImages query.
+type GetDeletedImagesHandler struct {
+	ImageRepository imageDomain.Repository
+}
+
+// NewGetDeletedImagesHandler returns a new GetDeletedImagesHandler.
+func NewGetDeletedImagesHandler(imageRepository imageDomain.Repository) *GetDeletedImagesHandler {
+	if imageRepository == nil {
+		return &GetDeletedImagesHandler{}
+	}
+	return &GetDeletedImagesHandler{
+		ImageRepository: imageRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetDeletedImagesHandler) Handle(ctx context.Context) (images []*imageDomain.Image, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetDeletedImagesHandler executed")
+	}()
+	return h.ImageRepository.GetDeletedImages(ctx)
+}
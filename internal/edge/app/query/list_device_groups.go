@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetDeviceGroupsHandler is a handler for the GetDeviceGroups query.
+type GetDeviceGroupsHandler struct {
+	GroupRepository group.Repository
+}
+
+// NewGetDeviceGroupsHandler returns a new GetDeviceGroupsHandler.
+func NewGetDeviceGroupsHandler(groupRepository group.Repository) *GetDeviceGroupsHandler {
+	if groupRepository == nil {
+		return &GetDeviceGroupsHandler{}
+	}
+	return &GetDeviceGroupsHandler{
+		GroupRepository: groupRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetDeviceGroupsHandler) Handle(ctx context.Context, deviceUUID string) (groups []*group.DeviceGroup, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetDeviceGroupsHandler executed")
+	}()
+	return h.GroupRepository.GetDeviceGroups(ctx, deviceUUID)
+}
@@ -0,0 +1,41 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetDeviceWindowsHandler is a handler for the GetDeviceWindows query.
+type GetDeviceWindowsHandler struct {
+	WindowRepository maintenance.Repository
+	GroupRepository  group.Repository
+}
+
+// NewGetDeviceWindowsHandler returns a new GetDeviceWindowsHandler.
+func NewGetDeviceWindowsHandler(windowRepository maintenance.Repository,
+	groupRepository group.Repository) *GetDeviceWindowsHandler {
+	if windowRepository == nil || groupRepository == nil {
+		return &GetDeviceWindowsHandler{}
+	}
+	return &GetDeviceWindowsHandler{
+		WindowRepository: windowRepository,
+		GroupRepository:  groupRepository,
+	}
+}
+
+// Handle implements the query interface.
+// It returns the maintenance windows of the device, its own and those of the groups it is a member of.
+func (h *GetDeviceWindowsHandler) Handle(ctx context.Context, deviceUUID string) (windows maintenance.Windows, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetDeviceWindowsHandler executed")
+	}()
+	return maintenance.GetDeviceWindows(ctx, h.WindowRepository, h.GroupRepository, deviceUUID)
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	deviceDomain "github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetDevicesHandler is a handler for the GetDevices query.
+type GetDevicesHandler struct {
+	DeviceRepository deviceDomain.Repository
+}
+
+// NewGetDevicesHandler returns a new GetDevicesHandler.
+func NewGetDevicesHandler(deviceRepository deviceDomain.Repository) *GetDevicesHandler {
+	if deviceRepository == nil {
+		return &GetDevicesHandler{}
+	}
+	return &GetDevicesHandler{
+		DeviceRepository: deviceRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetDevicesHandler) Handle(ctx context.Context) (devices []*deviceDomain.Device, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetDevicesHandler executed")
+	}()
+	return h.DeviceRepository.GetDevices(ctx)
+}
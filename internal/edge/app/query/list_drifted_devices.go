@@ -0,0 +1,37 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	deviceDomain "github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetDriftedDevicesHandler is a handler for the GetDriftedDevices query.
+type GetDriftedDevicesHandler struct {
+	DeviceRepository deviceDomain.Repository
+}
+
+// NewGetDriftedDevicesHandler returns a new GetDriftedDevicesHandler.
+func NewGetDriftedDevicesHandler(deviceRepository deviceDomain.Repository) *GetDriftedDevicesHandler {
+	if deviceRepository == nil {
+		return &GetDriftedDevicesHandler{}
+	}
+	return &GetDriftedDevicesHandler{
+		DeviceRepository: deviceRepository,
+	}
+}
+
+// Handle implements the query interface.
+// It returns the devices not running the image version they are assigned to.
+func (h *GetDriftedDevicesHandler) Handle(ctx context.Context) (drifted []*deviceDomain.Device, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetDriftedDevicesHandler executed")
+	}()
+	return h.DeviceRepository.GetDriftedDevices(ctx)
+}
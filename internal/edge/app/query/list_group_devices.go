@@ -0,0 +1,37 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetGroupDevicesHandler is a handler for the GetGroupDevices query.
+type GetGroupDevicesHandler struct {
+	GroupRepository group.Repository
+}
+
+// NewGetGroupDevicesHandler returns a new GetGroupDevicesHandler.
+func NewGetGroupDevicesHandler(groupRepository group.Repository) *GetGroupDevicesHandler {
+	if groupRepository == nil {
+		return &GetGroupDevicesHandler{}
+	}
+	return &GetGroupDevicesHandler{
+		GroupRepository: groupRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetGroupDevicesHandler) Handle(ctx context.Context, uuid string) (devices []*device.Device, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetGroupDevicesHandler executed")
+	}()
+	return h.GroupRepository.GetMembers(ctx, uuid)
+}
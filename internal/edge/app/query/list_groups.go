@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetGroupsHandler is a handler for the GetGroups query.
+type GetGroupsHandler struct {
+	GroupRepository group.Repository
+}
+
+// NewGetGroupsHandler returns a new GetGroupsHandler.
+func NewGetGroupsHandler(groupRepository group.Repository) *GetGroupsHandler {
+	if groupRepository == nil {
+		return &GetGroupsHandler{}
+	}
+	return &GetGroupsHandler{
+		GroupRepository: groupRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetGroupsHandler) Handle(ctx context.Context) (groups []*group.DeviceGroup, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetGroupsHandler executed")
+	}()
+	return h.GroupRepository.GetGroups(ctx)
+}
@@ -7,6 +7,15 @@ import (
 	"time"
 )
 
+// GetImages is a query to list a page of images, filtered and sorted.
+type GetImages struct {
+	Name   string
+	Status string
+	SortBy string
+	Limit  int
+	Offset int
+}
+
 // GetImagesHandler is a handler for the Get command.
 type GetImagesHandler struct {
 	ImageRepository imageDomain.Repository
@@ -23,7 +32,8 @@ func NewGetImagesHandler(imageRepository imageDomain.Repository) *GetImagesHandl
 }
 
 // Handle implements the command interface.
-func (h *GetImagesHandler) Handle(ctx context.Context) (images []*imageDomain.Image, err error) {
+// It returns the page of images along with the total number of images matching the query.
+func (h *GetImagesHandler) Handle(ctx context.Context, q GetImages) (images []*imageDomain.Image, total int, err error) {
 	start := time.Now()
 	defer func() {
 		log.
@@ -31,5 +41,9 @@ func (h *GetImagesHandler) Handle(ctx context.Context) (images []*imageDomain.Im
 			WithField("duration", time.Since(start)).
 			Debug("GetImagesHandler executed")
 	}()
-	return h.ImageRepository.GetImages(ctx)
+	spec, err := imageDomain.NewSpec(q.Name, q.Status, q.SortBy, q.Limit, q.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.ImageRepository.GetImages(ctx, spec)
 }
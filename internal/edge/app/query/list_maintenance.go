@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetMaintenanceWindowsHandler is a handler for the GetMaintenanceWindows query.
+type GetMaintenanceWindowsHandler struct {
+	WindowRepository maintenance.Repository
+}
+
+// NewGetMaintenanceWindowsHandler returns a new GetMaintenanceWindowsHandler.
+func NewGetMaintenanceWindowsHandler(windowRepository maintenance.Repository) *GetMaintenanceWindowsHandler {
+	if windowRepository == nil {
+		return &GetMaintenanceWindowsHandler{}
+	}
+	return &GetMaintenanceWindowsHandler{
+		WindowRepository: windowRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetMaintenanceWindowsHandler) Handle(ctx context.Context) (windows []*maintenance.Window, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetMaintenanceWindowsHandler executed")
+	}()
+	return h.WindowRepository.GetWindows(ctx)
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetReposHandler is a handler for the GetRepos query.
+type GetReposHandler struct {
+	RepoRepository repo.Repository
+}
+
+// NewGetReposHandler returns a new GetReposHandler.
+func NewGetReposHandler(repoRepository repo.Repository) *GetReposHandler {
+	if repoRepository == nil {
+		return &GetReposHandler{}
+	}
+	return &GetReposHandler{
+		RepoRepository: repoRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetReposHandler) Handle(ctx context.Context) (repos []*repo.Repo, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetReposHandler executed")
+	}()
+	return h.RepoRepository.GetRepos(ctx)
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	imageDomain "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetTagsHandler is a handler for the GetTags query, listing the tags of the account most used first.
+type GetTagsHandler struct {
+	CatalogRepository imageDomain.CatalogRepository
+}
+
+// NewGetTagsHandler returns a new GetTagsHandler.
+func NewGetTagsHandler(catalogRepository imageDomain.CatalogRepository) *GetTagsHandler {
+	if catalogRepository == nil {
+		return &GetTagsHandler{}
+	}
+	return &GetTagsHandler{
+		CatalogRepository: catalogRepository,
+	}
+}
+
+// Handle implements the query interface, only the entries starting with the prefix are listed.
+func (h *GetTagsHandler) Handle(ctx context.Context, prefix string) (entries []*imageDomain.CatalogEntry, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetTagsHandler executed")
+	}()
+	return h.CatalogRepository.GetTagCatalog(ctx, prefix)
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	imageDomain "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetUsedPackagesHandler is a handler for the GetUsedPackages query, listing the used packages of the account most used first.
+type GetUsedPackagesHandler struct {
+	CatalogRepository imageDomain.CatalogRepository
+}
+
+// NewGetUsedPackagesHandler returns a new GetUsedPackagesHandler.
+func NewGetUsedPackagesHandler(catalogRepository imageDomain.CatalogRepository) *GetUsedPackagesHandler {
+	if catalogRepository == nil {
+		return &GetUsedPackagesHandler{}
+	}
+	return &GetUsedPackagesHandler{
+		CatalogRepository: catalogRepository,
+	}
+}
+
+// Handle implements the query interface, only the entries starting with the prefix are listed.
+func (h *GetUsedPackagesHandler) Handle(ctx context.Context, prefix string) (entries []*imageDomain.CatalogEntry, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetUsedPackagesHandler executed")
+	}()
+	return h.CatalogRepository.GetPackageCatalog(ctx, prefix)
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetVouchersHandler is a handler for the GetVouchers query.
+type GetVouchersHandler struct {
+	VoucherRepository fdo.Repository
+}
+
+// NewGetVouchersHandler returns a new GetVouchersHandler.
+func NewGetVouchersHandler(voucherRepository fdo.Repository) *GetVouchersHandler {
+	if voucherRepository == nil {
+		return &GetVouchersHandler{}
+	}
+	return &GetVouchersHandler{
+		VoucherRepository: voucherRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *GetVouchersHandler) Handle(ctx context.Context) (vouchers []*fdo.Voucher, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("GetVouchersHandler executed")
+	}()
+	return h.VoucherRepository.GetVouchers(ctx)
+}
@@ -0,0 +1,46 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	imageDomain "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	log "github.com/sirupsen/logrus"
+)
+
+// SearchImages is a query to find the images of the account by full-text, the most relevant first.
+type SearchImages struct {
+	Text  string
+	Limit int
+}
+
+// SearchImagesHandler is a handler for the SearchImages query.
+type SearchImagesHandler struct {
+	SearchRepository imageDomain.SearchRepository
+}
+
+// NewSearchImagesHandler returns a new SearchImagesHandler.
+func NewSearchImagesHandler(searchRepository imageDomain.SearchRepository) *SearchImagesHandler {
+	if searchRepository == nil {
+		return &SearchImagesHandler{}
+	}
+	return &SearchImagesHandler{
+		SearchRepository: searchRepository,
+	}
+}
+
+// Handle implements the query interface.
+func (h *SearchImagesHandler) Handle(ctx context.Context, q SearchImages) (results []*imageDomain.SearchResult, err error) {
+	start := time.Now()
+	defer func() {
+		log.
+			WithError(err).
+			WithField("duration", time.Since(start)).
+			Debug("SearchImagesHandler executed")
+	}()
+	query, err := imageDomain.NewSearchQuery(q.Text, q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return h.SearchRepository.SearchImages(ctx, query)
+}
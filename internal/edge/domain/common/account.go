@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/redhatinsights/edge-api/config"
 	"github.com/redhatinsights/platform-go-middlewares/identity"
@@ -20,6 +21,17 @@ var DefaultAccount = Account{"0000000"}
 // ErrNoAccount is returned when no account is found in the context
 var ErrNoAccount = errors.New("no account found in context")
 
+// ErrNotOrgAdmin is returned when an action requires an organization admin
+var ErrNotOrgAdmin = errors.New("organization admin required")
+
+// NewAccount creates a new account from its id.
+func NewAccount(id string) (Account, error) {
+	if strings.TrimSpace(id) == "" {
+		return Account{}, ErrInvalidAccount{account: id}
+	}
+	return Account{id}, nil
+}
+
 // IsZero returns true if the account is empty.
 func (a Account) IsZero() bool {
 	return a == Account{}
@@ -51,6 +63,30 @@ func GetAccountFromContext(ctx context.Context) (Account, error) {
 	return Account{}, ErrNoAccount
 }
 
+// IsOrgAdmin determines if the user in the supplied context is an organization admin,
+// every user is considered an admin when authentication is disabled
+func IsOrgAdmin(ctx context.Context) bool {
+	if config.Get() != nil {
+		if !config.Get().Auth {
+			return true
+		}
+		if ctx.Value(identity.Key) != nil {
+			return identity.Get(ctx).Identity.User.OrgAdmin
+		}
+	}
+	return false
+}
+
+// ContextWithAccount returns a copy of ctx carrying the identity of the given account,
+// used when acting on behalf of an account outside of an http request.
+func ContextWithAccount(ctx context.Context, account Account) context.Context {
+	return context.WithValue(ctx, identity.Key, identity.XRHID{
+		Identity: identity.Identity{
+			AccountNumber: account.id,
+		},
+	})
+}
+
 // MarshalJSON marshals account to json
 func (a Account) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + a.id + `"`), nil
@@ -269,3 +269,148 @@ func TestErrInvalidAccount_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAccount(t *testing.T) {
+	type args struct {
+		id string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Account
+		wantErr bool
+	}{
+		{
+			name:    "ok",
+			args:    args{id: "0000000"},
+			want:    DefaultAccount,
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			args:    args{id: ""},
+			want:    Account{},
+			wantErr: true,
+		},
+		{
+			name:    "spaces",
+			args:    args{id: "   "},
+			want:    Account{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewAccount(tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewAccount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextWithAccount(t *testing.T) {
+	config.Init()
+	config.Get().Auth = true
+	defer func() {
+		config.Get().Auth = false
+	}()
+	type args struct {
+		ctx     context.Context
+		account Account
+	}
+	tests := []struct {
+		name string
+		args args
+		want Account
+	}{
+		{
+			name: "ok",
+			args: args{
+				ctx:     context.Background(),
+				account: Account{id: "1111111"},
+			},
+			want: Account{id: "1111111"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAccountFromContext(ContextWithAccount(tt.args.ctx, tt.args.account))
+			if err != nil {
+				t.Errorf("GetAccountFromContext() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContextWithAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsOrgAdmin(t *testing.T) {
+	config.Init()
+	adminCtx := context.WithValue(context.Background(), identity.Key, identity.XRHID{
+		Identity: identity.Identity{
+			AccountNumber: "0000000",
+			User:          identity.User{OrgAdmin: true},
+		},
+	})
+	userCtx := context.WithValue(context.Background(), identity.Key, identity.XRHID{
+		Identity: identity.Identity{
+			AccountNumber: "0000000",
+		},
+	})
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name string
+		args args
+		auth bool
+		want bool
+	}{
+		{
+			name: "admin, no auth",
+			args: args{ctx: context.Background()},
+			auth: false,
+			want: true,
+		},
+		{
+			name: "admin, with auth",
+			args: args{ctx: adminCtx},
+			auth: true,
+			want: true,
+		},
+		{
+			name: "not an admin, with auth",
+			args: args{ctx: userCtx},
+			auth: true,
+			want: false,
+		},
+		{
+			name: "no identity, with auth",
+			args: args{ctx: context.Background()},
+			auth: true,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config.Get().Auth = tt.auth
+			defer func() {
+				config.Get().Auth = false
+			}()
+			if got := IsOrgAdmin(tt.args.ctx); got != tt.want {
+				t.Errorf("IsOrgAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
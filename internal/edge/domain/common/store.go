@@ -0,0 +1,6 @@
+package common
+
+import "errors"
+
+// ErrUnavailable is returned when the configured store doesn't keep the data an action needs.
+var ErrUnavailable = errors.New("not available with the configured store")
@@ -120,10 +120,15 @@ func (t *Tags) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// MarshalGorm marshals the tags to a gorm model.
+// MarshalGorm marshals the tags to a gorm model, once each, as they are entries of the tag catalog of the account.
 func (t Tags) MarshalGorm(account string) []models.Tag {
 	var tags []models.Tag
+	seen := make(map[string]bool)
 	for _, tag := range t.Tags() {
+		if seen[tag.String()] {
+			continue
+		}
+		seen[tag.String()] = true
 		tags = append(tags, models.Tag{
 			Account: account,
 			Name:    tag.String(),
@@ -487,6 +487,23 @@ func TestTags_MarshalGorm(t *testing.T) {
 				{Account: "test", Name: "tag2"},
 			},
 		},
+		{
+			name: "duplicated tags",
+			tr: Tags{
+				tags: []Tag{
+					{"tag1"},
+					{"tag2"},
+					{"tag1"},
+				},
+			},
+			args: args{
+				account: "test",
+			},
+			want: []models.Tag{
+				{Account: "test", Name: "tag1"},
+				{Account: "test", Name: "tag2"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -0,0 +1,72 @@
+package device
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// ErrInvalidAssignment is returned when a device is assigned to an image version that doesn't exist.
+var ErrInvalidAssignment = errors.New("invalid image assignment")
+
+// Assignment is the image version a device is supposed to run.
+type Assignment struct {
+	imageUUID string
+	version   image.Version
+}
+
+// NewAssignment creates a new assignment, an empty uuid means the device is not assigned to any image.
+func NewAssignment(imageUUID string, version uint) (Assignment, error) {
+	if imageUUID == "" {
+		return Assignment{}, nil
+	}
+	validVersion, err := image.NewVersion(version)
+	if err != nil {
+		return Assignment{}, ErrInvalidAssignment
+	}
+	return Assignment{imageUUID: imageUUID, version: validVersion}, nil
+}
+
+// IsZero returns true if the device is not assigned to any image.
+func (a Assignment) IsZero() bool {
+	return a == Assignment{}
+}
+
+// ImageUUID returns the uuid of the assigned image.
+func (a Assignment) ImageUUID() string {
+	return a.imageUUID
+}
+
+// Version returns the assigned version of the image.
+func (a Assignment) Version() image.Version {
+	return a.version
+}
+
+// MarshalJSON marshals the assignment to JSON.
+func (a Assignment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		ImageUUID string `json:"image_uuid,omitempty"`
+		Version   uint   `json:"version,omitempty"`
+	}{
+		ImageUUID: a.imageUUID,
+		Version:   a.version.Uint(),
+	})
+}
+
+// UnmarshalJSON unmarshals the assignment from JSON.
+func (a *Assignment) UnmarshalJSON(data []byte) error {
+	var assignment struct {
+		ImageUUID string `json:"image_uuid,omitempty"`
+		Version   uint   `json:"version,omitempty"`
+	}
+	if err := json.Unmarshal(data, &assignment); err != nil {
+		return err
+	}
+	newAssignment, err := NewAssignment(assignment.ImageUUID, assignment.Version)
+	if err != nil {
+		return err
+	}
+	*a = newAssignment
+	return nil
+}
@@ -0,0 +1,54 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+func TestNewAssignment(t *testing.T) {
+	version, _ := image.NewVersion(2)
+	type args struct {
+		imageUUID string
+		version   uint
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Assignment
+		wantErr error
+	}{
+		{
+			name:    "should return an assignment",
+			args:    args{imageUUID: "image-uuid", version: 2},
+			want:    Assignment{imageUUID: "image-uuid", version: version},
+			wantErr: nil,
+		},
+		{
+			name:    "should return no assignment",
+			args:    args{},
+			want:    Assignment{},
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, no version",
+			args:    args{imageUUID: "image-uuid"},
+			want:    Assignment{},
+			wantErr: ErrInvalidAssignment,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewAssignment(tt.args.imageUUID, tt.args.version)
+			if err != tt.wantErr {
+				t.Errorf("NewAssignment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewAssignment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
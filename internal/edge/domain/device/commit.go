@@ -0,0 +1,14 @@
+package device
+
+import "github.com/Avielyo10/edge-api/internal/edge/domain/image"
+
+// ErrInvalidCommit is returned when the commit is not a valid ostree checksum.
+var ErrInvalidCommit = image.ErrInvalidCommit
+
+// Commit is the ostree commit a device is running, the commit of an image version when it runs a known one.
+type Commit = image.Commit
+
+// NewCommit creates a new commit, an empty checksum means the device didn't report its commit yet.
+func NewCommit(checksum string) (Commit, error) {
+	return image.NewCommit(checksum)
+}
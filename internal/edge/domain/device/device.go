@@ -0,0 +1,305 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+var (
+	// ErrEmptyContext is returned when the context is empty.
+	ErrEmptyContext = errors.New("empty context")
+)
+
+// Device struct represents an edge device of the fleet.
+type Device struct {
+	// context
+	ctx context.Context
+	// identity
+	uuid string
+	name common.Name
+	// time
+	timing   common.Time
+	lastSeen time.Time
+	// state
+	commit     Commit
+	assignment Assignment
+	running    Assignment // image version the commit resolved to, zero if unknown
+	health     Health
+	// properties
+	tags common.Tags
+	// credential
+	token Token // hash of the secret the device checks in with
+}
+
+// NewDevice creates a new device.
+func NewDevice(uuid, name, commit, imageUUID string, imageVersion uint, tags []string) (Device, error) {
+	validName, err := common.NewName(name)
+	if err != nil {
+		return Device{}, err
+	}
+	validCommit, err := NewCommit(commit)
+	if err != nil {
+		return Device{}, err
+	}
+	validAssignment, err := NewAssignment(imageUUID, imageVersion)
+	if err != nil {
+		return Device{}, err
+	}
+	return Device{
+		ctx:        context.Background(),
+		uuid:       uuid,
+		name:       validName,
+		commit:     validCommit,
+		assignment: validAssignment,
+		tags:       common.NewTags(tags...),
+	}, nil
+}
+
+// NewDeviceWithContext creates a new device.
+func NewDeviceWithContext(ctx context.Context, uuid, name, commit, imageUUID string,
+	imageVersion uint, tags []string) (Device, error) {
+	if ctx == nil {
+		return Device{}, ErrEmptyContext
+	}
+	device, err := NewDevice(uuid, name, commit, imageUUID, imageVersion, tags)
+	if err != nil {
+		return Device{}, err
+	}
+	device.ctx = ctx
+	return device, nil
+}
+
+// IsZero returns true if the device is zero.
+func (device Device) IsZero() bool {
+	return device.uuid == "" && device.name.IsZero() && device.commit.IsZero() &&
+		device.assignment.IsZero() && device.running.IsZero() && device.health.IsZero() &&
+		device.lastSeen.IsZero() && len(device.tags.Tags()) == 0 && device.token.IsZero() && device.timing.IsZero()
+}
+
+// Account is a getter for the account of a device.
+func (device Device) Account() (common.Account, error) {
+	return common.GetAccountFromContext(device.ctx)
+}
+
+// Context is a getter for the context of a device.
+func (device Device) Context() context.Context {
+	return device.ctx
+}
+
+// UUID is a getter for the uuid of a device.
+func (device Device) UUID() string {
+	return device.uuid
+}
+
+// Name is a getter for the name of a device.
+func (device Device) Name() common.Name {
+	return device.name
+}
+
+// Commit is a getter for the ostree commit a device is running.
+func (device Device) Commit() Commit {
+	return device.commit
+}
+
+// Assignment is a getter for the image version a device is assigned to.
+func (device Device) Assignment() Assignment {
+	return device.assignment
+}
+
+// Running is a getter for the image version a device is running, zero if its commit isn't one of a known version.
+func (device Device) Running() Assignment {
+	return device.running
+}
+
+// Health is a getter for what a device reported about itself the last time it checked in.
+func (device Device) Health() Health {
+	return device.health
+}
+
+// LastSeen is a getter for the last time a device was seen.
+func (device Device) LastSeen() time.Time {
+	return device.lastSeen
+}
+
+// Tags is a getter for the tags of a device.
+func (device Device) Tags() common.Tags {
+	return device.tags
+}
+
+// Token is a getter for the credential a device checks in with, zero if none was issued.
+func (device Device) Token() Token {
+	return device.token
+}
+
+// CreatedAt is a getter for the created at time of a device.
+func (device Device) CreatedAt() time.Time {
+	return device.timing.CreatedAt()
+}
+
+// UpdatedAt is a getter for the updated at time of a device.
+func (device Device) UpdatedAt() time.Time {
+	return device.timing.UpdatedAt()
+}
+
+// DeletedAt is a getter for the deleted at time of a device.
+func (device Device) DeletedAt() time.Time {
+	return device.timing.DeletedAt()
+}
+
+// SetName sets the name of a device, an empty name is ignored.
+func (device *Device) SetName(name common.Name) {
+	if !name.IsZero() {
+		device.name = name
+	}
+}
+
+// SetCommit sets the ostree commit a device is running.
+func (device *Device) SetCommit(commit Commit) {
+	device.commit = commit
+}
+
+// SetToken sets the credential a device checks in with.
+func (device *Device) SetToken(token Token) {
+	device.token = token
+}
+
+// Authenticate returns ErrInvalidToken unless the presented token is the one the device was issued.
+func (device Device) Authenticate(presented Token) error {
+	return device.token.Verify(presented)
+}
+
+// CheckIn records a check-in of the device at the given time: the commit it booted,
+// the image version that commit resolved to, zero if none did, and its health.
+func (device *Device) CheckIn(booted Commit, running Assignment, health Health, at time.Time) error {
+	if booted.IsZero() {
+		return ErrInvalidCheckIn
+	}
+	device.commit = booted
+	device.running = running
+	device.health = health
+	device.lastSeen = at.UTC().Truncate(time.Microsecond)
+	return nil
+}
+
+// Assign assigns a device to an image version, a zero assignment unassigns it.
+func (device *Device) Assign(assignment Assignment) {
+	device.assignment = assignment
+}
+
+// AddTag adds a tag to the device.
+func (device *Device) AddTag(tag ...common.Tag) {
+	device.tags.Add(tag...)
+}
+
+// RemoveTag removes a tag from the device.
+func (device *Device) RemoveTag(tag ...common.Tag) {
+	device.tags.Remove(tag...)
+}
+
+// SetTime sets the time of a device.
+func (device *Device) SetTime(timing common.Time) {
+	device.timing = timing
+}
+
+// Touch marks the device as stored at the given time, setting its creation time if it has none.
+// Times are kept in UTC with microsecond precision, so they survive a round trip to any database.
+func (device *Device) Touch(now time.Time) {
+	now = now.UTC().Truncate(time.Microsecond)
+	createdAt := device.CreatedAt()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	device.timing = common.NewTime(createdAt, now, device.DeletedAt())
+}
+
+// MarshalJSON creates a custom JSON marshaler for a device.
+func (device Device) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		UUID       string      `json:"uuid,omitempty"`
+		Name       common.Name `json:"name,omitempty"`
+		Commit     Commit      `json:"commit,omitempty"`
+		Assignment Assignment  `json:"assignment,omitempty"`
+		Running    Assignment  `json:"running,omitempty"`
+		Health     Health      `json:"health,omitempty"`
+		Tags       common.Tags `json:"tags,omitempty"`
+		TokenHash  string      `json:"token_hash,omitempty"`
+		LastSeen   string      `json:"last_seen,omitempty"`
+		CreatedAt  string      `json:"created_at,omitempty"`
+		UpdatedAt  string      `json:"updated_at,omitempty"`
+		DeletedAt  string      `json:"deleted_at,omitempty"`
+	}{
+		UUID:       device.uuid,
+		Name:       device.name,
+		Commit:     device.commit,
+		Assignment: device.assignment,
+		Running:    device.running,
+		Health:     device.health,
+		Tags:       device.tags,
+		TokenHash:  device.token.Hash(),
+		LastSeen:   device.lastSeen.Format(time.RFC3339Nano),
+		CreatedAt:  device.timing.CreatedAt().Format(time.RFC3339Nano),
+		UpdatedAt:  device.timing.UpdatedAt().Format(time.RFC3339Nano),
+		DeletedAt:  device.timing.DeletedAt().Format(time.RFC3339Nano),
+	})
+}
+
+// UnmarshalJSON unmarshals the device from JSON.
+func (device *Device) UnmarshalJSON(data []byte) error {
+	var deviceData struct {
+		UUID       string      `json:"uuid,omitempty"`
+		Name       common.Name `json:"name,omitempty"`
+		Commit     Commit      `json:"commit,omitempty"`
+		Assignment Assignment  `json:"assignment,omitempty"`
+		Running    Assignment  `json:"running,omitempty"`
+		Health     Health      `json:"health,omitempty"`
+		Tags       common.Tags `json:"tags,omitempty"`
+		TokenHash  string      `json:"token_hash,omitempty"`
+		LastSeen   string      `json:"last_seen,omitempty"`
+		CreatedAt  string      `json:"created_at,omitempty"`
+		UpdatedAt  string      `json:"updated_at,omitempty"`
+		DeletedAt  string      `json:"deleted_at,omitempty"`
+	}
+	if err := json.Unmarshal(data, &deviceData); err != nil {
+		return err
+	}
+	device.uuid = deviceData.UUID
+	device.name = deviceData.Name
+	device.commit = deviceData.Commit
+	device.assignment = deviceData.Assignment
+	device.running = deviceData.Running
+	device.health = deviceData.Health
+	device.tags = deviceData.Tags
+	device.token = NewTokenFromHash(deviceData.TokenHash)
+
+	var times [4]time.Time
+	for i, value := range []string{deviceData.LastSeen, deviceData.CreatedAt, deviceData.UpdatedAt, deviceData.DeletedAt} {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return err
+		}
+		times[i] = parsed
+	}
+	device.lastSeen = times[0]
+	device.timing = common.NewTime(times[1], times[2], times[3])
+	return nil
+}
+
+// UnmarshalDeviceFromDatabase unmarshals the device from the database.
+func UnmarshalDeviceFromDatabase(ctx context.Context, uuid, name, commit, imageUUID string,
+	imageVersion uint, tags []string, running Assignment, health Health, token Token,
+	lastSeen, createdAt, updatedAt, deletedAt time.Time) (Device, error) {
+	device, err := NewDeviceWithContext(ctx, uuid, name, commit, imageUUID, imageVersion, tags)
+	if err != nil {
+		return Device{}, err
+	}
+	device.running = running
+	device.health = health
+	device.token = token
+	device.lastSeen = lastSeen
+	device.SetTime(common.NewTime(createdAt, updatedAt, deletedAt))
+	return device, nil
+}
@@ -0,0 +1,187 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+func TestNewDeviceWithContext(t *testing.T) {
+	checksum := strings.Repeat("ab", 32)
+	type args struct {
+		ctx          context.Context
+		name         string
+		commit       string
+		imageUUID    string
+		imageVersion uint
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "should create a device",
+			args:    args{ctx: context.Background(), name: "kiosk", commit: checksum, imageUUID: "image-uuid", imageVersion: 1},
+			wantErr: nil,
+		},
+		{
+			name:    "should create an unassigned device",
+			args:    args{ctx: context.Background(), name: "kiosk"},
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, empty context",
+			args:    args{ctx: nil, name: "kiosk"},
+			wantErr: ErrEmptyContext,
+		},
+		{
+			name:    "should fail, invalid name",
+			args:    args{ctx: context.Background(), name: " "},
+			wantErr: common.ErrInvalidName,
+		},
+		{
+			name:    "should fail, invalid commit",
+			args:    args{ctx: context.Background(), name: "kiosk", commit: "abc"},
+			wantErr: ErrInvalidCommit,
+		},
+		{
+			name:    "should fail, invalid assignment",
+			args:    args{ctx: context.Background(), name: "kiosk", imageUUID: "image-uuid"},
+			wantErr: ErrInvalidAssignment,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewDeviceWithContext(tt.args.ctx, "device-uuid", tt.args.name, tt.args.commit,
+				tt.args.imageUUID, tt.args.imageVersion, []string{"kiosk"})
+			if err != tt.wantErr {
+				t.Errorf("NewDeviceWithContext() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.UUID() != "device-uuid" || got.Name().String() != tt.args.name ||
+				got.Commit().String() != tt.args.commit || got.Assignment().ImageUUID() != tt.args.imageUUID {
+				t.Errorf("NewDeviceWithContext() = %+v, want %+v", got, tt.args)
+			}
+		})
+	}
+}
+
+func TestDevice_JSON(t *testing.T) {
+	device, err := NewDeviceWithContext(context.Background(), "device-uuid", "kiosk",
+		strings.Repeat("ab", 32), "image-uuid", 3, []string{"kiosk", "store-42"})
+	if err != nil {
+		t.Fatalf("failed to create device: %s", err)
+	}
+	device.Touch(time.Now())
+	running, _ := NewAssignment("image-uuid", 2)
+	health, _ := NewHealth(strings.Repeat("cd", 32), "idle", "red", time.Hour)
+	if err := device.CheckIn(device.Commit(), running, health, time.Now()); err != nil {
+		t.Fatalf("failed to check in: %s", err)
+	}
+
+	data, err := json.Marshal(device)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	got, err := UnmarshalDeviceFromRedis(context.Background(), data)
+	if err != nil {
+		t.Fatalf("UnmarshalDeviceFromRedis() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, device) {
+		t.Errorf("UnmarshalDeviceFromRedis() = %+v, want %+v", got, device)
+	}
+}
+
+func TestDevice_Assign(t *testing.T) {
+	device, _ := NewDevice("device-uuid", "kiosk", "", "image-uuid", 1, nil)
+	assignment, _ := NewAssignment("other-image-uuid", 2)
+	tests := []struct {
+		name       string
+		assignment Assignment
+	}{
+		{
+			name:       "should assign another image",
+			assignment: assignment,
+		},
+		{
+			name:       "should unassign",
+			assignment: Assignment{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			device := device
+			device.Assign(tt.assignment)
+			if device.Assignment() != tt.assignment {
+				t.Errorf("Device.Assign() = %v, want %v", device.Assignment(), tt.assignment)
+			}
+		})
+	}
+}
+
+func TestDevice_CheckIn(t *testing.T) {
+	device, _ := NewDevice("device-uuid", "kiosk", "", "image-uuid", 2, nil)
+	booted, _ := NewCommit(strings.Repeat("ab", 32))
+	running, _ := NewAssignment("image-uuid", 1)
+	health, _ := NewHealth("", "", "green", time.Minute)
+	tests := []struct {
+		name    string
+		booted  Commit
+		running Assignment
+		wantErr error
+	}{
+		{
+			name:    "should check in running a known version",
+			booted:  booted,
+			running: running,
+			wantErr: nil,
+		},
+		{
+			name:    "should check in running an unknown commit",
+			booted:  booted,
+			running: Assignment{},
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, no booted commit",
+			booted:  Commit{},
+			wantErr: ErrInvalidCheckIn,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			device := device
+			err := device.CheckIn(tt.booted, tt.running, health, time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Device.CheckIn() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				if !device.LastSeen().IsZero() {
+					t.Errorf("Device.CheckIn() last seen = %v, want zero", device.LastSeen())
+				}
+				return
+			}
+			if device.Commit() != tt.booted || device.Running() != tt.running || device.Health() != health ||
+				device.LastSeen().IsZero() {
+				t.Errorf("Device.CheckIn() = %+v, want booted %v running %v", device, tt.booted, tt.running)
+			}
+		})
+	}
+}
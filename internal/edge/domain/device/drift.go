@@ -0,0 +1,137 @@
+package device
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrInvalidDrift is returned when the drift is unknown.
+var ErrInvalidDrift = errors.New("invalid drift")
+
+// Define the available drifts.
+var (
+	// Behind devices run an older version of the image they are assigned to.
+	Behind = Drift{"behind"}
+	// Ahead devices run a newer version of the image they are assigned to, e.g. after a rollback of the assignment.
+	Ahead = Drift{"ahead"}
+	// OtherImage devices run a version of another image than the one they are assigned to.
+	OtherImage = Drift{"other_image"}
+	// UnknownCommit devices run a commit that isn't the one of any known image version.
+	UnknownCommit = Drift{"unknown_commit"}
+)
+
+// All available drifts.
+var availableDrifts = []Drift{
+	Behind,
+	Ahead,
+	OtherImage,
+	UnknownCommit,
+}
+
+// NewDriftFromString returns the drift of the given kind, the empty kind is the zero drift.
+func NewDriftFromString(kind string) (Drift, error) {
+	if kind == "" {
+		return Drift{}, nil
+	}
+	for _, drift := range availableDrifts {
+		if drift.kind == kind {
+			return drift, nil
+		}
+	}
+	return Drift{}, ErrInvalidDrift
+}
+
+// Drift is how the image version a device runs differs from the one it is assigned to,
+// the zero drift means it runs its assigned version, or there is nothing to compare.
+type Drift struct {
+	kind string
+}
+
+// IsZero returns true if the device doesn't drift.
+func (d Drift) IsZero() bool {
+	return d == Drift{}
+}
+
+// String returns the string representation of a drift, empty if the device doesn't drift.
+func (d Drift) String() string {
+	return d.kind
+}
+
+// Drift compares the commit the device reported with its assigned image version,
+// only assigned devices that reported their commit can drift.
+func (device Device) Drift() Drift {
+	if device.assignment.IsZero() || device.commit.IsZero() {
+		return Drift{}
+	}
+	switch {
+	case device.running.IsZero():
+		return UnknownCommit
+	case device.running.ImageUUID() != device.assignment.ImageUUID():
+		return OtherImage
+	case device.running.Version().Uint() < device.assignment.Version().Uint():
+		return Behind
+	case device.running.Version().Uint() > device.assignment.Version().Uint():
+		return Ahead
+	}
+	return Drift{}
+}
+
+// ImageDrift counts the drift of the devices assigned to an image.
+type ImageDrift struct {
+	imageUUID string
+	assigned  int
+	drifts    map[Drift]int
+}
+
+// NewImageDrift returns the drift of the devices assigned to an image, from their count per drift.
+func NewImageDrift(imageUUID string, drifts map[Drift]int) ImageDrift {
+	summary := ImageDrift{imageUUID: imageUUID, drifts: make(map[Drift]int, len(drifts))}
+	for drift, count := range drifts {
+		summary.assigned += count
+		summary.drifts[drift] = count
+	}
+	return summary
+}
+
+// ImageUUID returns the uuid of the image.
+func (d ImageDrift) ImageUUID() string {
+	return d.imageUUID
+}
+
+// Assigned returns the number of devices assigned to the image.
+func (d ImageDrift) Assigned() int {
+	return d.assigned
+}
+
+// Count returns the number of devices assigned to the image having the given drift,
+// the zero drift counts the devices that don't drift.
+func (d ImageDrift) Count(drift Drift) int {
+	return d.drifts[drift]
+}
+
+// SummarizeDrift counts the drift of the given devices per image they are assigned to, sorted by image uuid.
+// Unassigned devices are left out.
+func SummarizeDrift(devices []*Device) []ImageDrift {
+	byImage := make(map[string]*ImageDrift)
+	for _, device := range devices {
+		imageUUID := device.assignment.ImageUUID()
+		if imageUUID == "" {
+			continue
+		}
+		summary, ok := byImage[imageUUID]
+		if !ok {
+			summary = &ImageDrift{imageUUID: imageUUID, drifts: make(map[Drift]int)}
+			byImage[imageUUID] = summary
+		}
+		summary.assigned++
+		summary.drifts[device.Drift()]++
+	}
+	summaries := make([]ImageDrift, 0, len(byImage))
+	for _, summary := range byImage {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].imageUUID < summaries[j].imageUUID
+	})
+	return summaries
+}
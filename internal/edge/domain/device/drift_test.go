@@ -0,0 +1,142 @@
+package device
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func checkedIn(t *testing.T, imageUUID string, version uint, running Assignment) *Device {
+	t.Helper()
+	device, err := NewDevice("device-uuid", "kiosk", "", imageUUID, version, nil)
+	if err != nil {
+		t.Fatalf("NewDevice() error = %v", err)
+	}
+	booted, _ := NewCommit(strings.Repeat("ab", 32))
+	if err := device.CheckIn(booted, running, Health{}, time.Now()); err != nil {
+		t.Fatalf("Device.CheckIn() error = %v", err)
+	}
+	return &device
+}
+
+func TestDevice_Drift(t *testing.T) {
+	v1, _ := NewAssignment("image-uuid", 1)
+	v3, _ := NewAssignment("image-uuid", 3)
+	other, _ := NewAssignment("other-image-uuid", 2)
+	notCheckedIn, _ := NewDevice("device-uuid", "kiosk", "", "image-uuid", 2, nil)
+	tests := []struct {
+		name   string
+		device *Device
+		want   Drift
+	}{
+		{
+			name:   "should not drift, running the assigned version",
+			device: checkedIn(t, "image-uuid", 1, v1),
+			want:   Drift{},
+		},
+		{
+			name:   "should not drift, not reported its commit yet",
+			device: &notCheckedIn,
+			want:   Drift{},
+		},
+		{
+			name:   "should not drift, not assigned",
+			device: checkedIn(t, "", 0, v1),
+			want:   Drift{},
+		},
+		{
+			name:   "should be behind",
+			device: checkedIn(t, "image-uuid", 2, v1),
+			want:   Behind,
+		},
+		{
+			name:   "should be ahead",
+			device: checkedIn(t, "image-uuid", 2, v3),
+			want:   Ahead,
+		},
+		{
+			name:   "should run another image",
+			device: checkedIn(t, "image-uuid", 2, other),
+			want:   OtherImage,
+		},
+		{
+			name:   "should run an unknown commit",
+			device: checkedIn(t, "image-uuid", 2, Assignment{}),
+			want:   UnknownCommit,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.device.Drift(); got != tt.want {
+				t.Errorf("Device.Drift() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeDrift(t *testing.T) {
+	v1, _ := NewAssignment("image-uuid", 1)
+	devices := []*Device{
+		checkedIn(t, "image-uuid", 1, v1),
+		checkedIn(t, "image-uuid", 2, v1),
+		checkedIn(t, "image-uuid", 2, Assignment{}),
+		checkedIn(t, "another-image-uuid", 1, v1),
+		checkedIn(t, "", 0, v1),
+	}
+	got := SummarizeDrift(devices)
+	if len(got) != 2 {
+		t.Fatalf("SummarizeDrift() = %+v, want 2 images", got)
+	}
+	if got[0].ImageUUID() != "another-image-uuid" || got[0].Assigned() != 1 || got[0].Count(OtherImage) != 1 {
+		t.Errorf("SummarizeDrift()[0] = %+v, want 1 device running another image", got[0])
+	}
+	if got[1].ImageUUID() != "image-uuid" || got[1].Assigned() != 3 || got[1].Count(Drift{}) != 1 ||
+		got[1].Count(Behind) != 1 || got[1].Count(UnknownCommit) != 1 || got[1].Count(Ahead) != 0 {
+		t.Errorf("SummarizeDrift()[1] = %+v, want 1 in sync, 1 behind and 1 unknown commit", got[1])
+	}
+}
+
+func TestNewDriftFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		want    Drift
+		wantErr error
+	}{
+		{
+			name: "should parse a drift",
+			kind: "behind",
+			want: Behind,
+		},
+		{
+			name: "should parse the zero drift",
+			kind: "",
+			want: Drift{},
+		},
+		{
+			name:    "should fail, unknown drift",
+			kind:    "sideways",
+			wantErr: ErrInvalidDrift,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewDriftFromString(tt.kind)
+			if err != tt.wantErr || got != tt.want {
+				t.Errorf("NewDriftFromString() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewImageDrift(t *testing.T) {
+	got := NewImageDrift("image-uuid", map[Drift]int{{}: 2, Behind: 1})
+	if got.ImageUUID() != "image-uuid" || got.Assigned() != 3 || got.Count(Drift{}) != 2 ||
+		got.Count(Behind) != 1 || got.Count(Ahead) != 0 {
+		t.Errorf("NewImageDrift() = %+v, want 2 in sync and 1 behind", got)
+	}
+}
@@ -0,0 +1,145 @@
+package device
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidGreenboot is returned when the greenboot result is unknown.
+	ErrInvalidGreenboot = errors.New("invalid greenboot result")
+	// ErrInvalidUptime is returned when the uptime is negative.
+	ErrInvalidUptime = errors.New("invalid uptime")
+	// ErrInvalidCheckIn is returned when a device checks in without its booted commit.
+	ErrInvalidCheckIn = errors.New("a check-in needs the booted commit")
+)
+
+// Define the available greenboot results.
+var (
+	// Green devices passed their health checks on boot.
+	Green = Greenboot{"green"}
+	// Red devices failed their health checks on boot, greenboot rolls them back.
+	Red = Greenboot{"red"}
+)
+
+// Greenboot is the result of the health checks greenboot ran on the last boot of a device.
+type Greenboot struct {
+	result string
+}
+
+// NewGreenbootFromString creates a new greenboot result from a string, an empty one means it wasn't reported.
+func NewGreenbootFromString(result string) (Greenboot, error) {
+	switch result {
+	case "":
+		return Greenboot{}, nil
+	case Green.result:
+		return Green, nil
+	case Red.result:
+		return Red, nil
+	}
+	return Greenboot{}, ErrInvalidGreenboot
+}
+
+// IsZero returns true if the greenboot result wasn't reported.
+func (g Greenboot) IsZero() bool {
+	return g == Greenboot{}
+}
+
+// String returns the string representation of a greenboot result.
+func (g Greenboot) String() string {
+	return g.result
+}
+
+// Health is what a device reported about itself the last time it checked in, besides the commit it booted.
+type Health struct {
+	staged    Commit
+	status    string // rpm-ostree status summary
+	greenboot Greenboot
+	uptime    time.Duration
+}
+
+// NewHealth creates a new health report, every field is optional.
+func NewHealth(staged, status, greenboot string, uptime time.Duration) (Health, error) {
+	validStaged, err := NewCommit(staged)
+	if err != nil {
+		return Health{}, err
+	}
+	validGreenboot, err := NewGreenbootFromString(greenboot)
+	if err != nil {
+		return Health{}, err
+	}
+	if uptime < 0 {
+		return Health{}, ErrInvalidUptime
+	}
+	return Health{
+		staged:    validStaged,
+		status:    status,
+		greenboot: validGreenboot,
+		uptime:    uptime,
+	}, nil
+}
+
+// IsZero returns true if nothing was reported.
+func (h Health) IsZero() bool {
+	return h == Health{}
+}
+
+// Staged returns the commit staged for the next boot of the device, zero if there is none.
+func (h Health) Staged() Commit {
+	return h.staged
+}
+
+// Status returns the rpm-ostree status summary of the device.
+func (h Health) Status() string {
+	return h.status
+}
+
+// Greenboot returns the greenboot result of the last boot of the device.
+func (h Health) Greenboot() Greenboot {
+	return h.greenboot
+}
+
+// Uptime returns how long the device was up when it checked in.
+func (h Health) Uptime() time.Duration {
+	return h.uptime
+}
+
+// MarshalJSON marshals the health report to JSON.
+func (h Health) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Staged    Commit `json:"staged,omitempty"`
+		Status    string `json:"status,omitempty"`
+		Greenboot string `json:"greenboot,omitempty"`
+		Uptime    int64  `json:"uptime,omitempty"`
+	}{
+		Staged:    h.staged,
+		Status:    h.status,
+		Greenboot: h.greenboot.result,
+		Uptime:    int64(h.uptime),
+	})
+}
+
+// UnmarshalJSON unmarshals the health report from JSON.
+func (h *Health) UnmarshalJSON(data []byte) error {
+	var health struct {
+		Staged    Commit `json:"staged,omitempty"`
+		Status    string `json:"status,omitempty"`
+		Greenboot string `json:"greenboot,omitempty"`
+		Uptime    int64  `json:"uptime,omitempty"`
+	}
+	if err := json.Unmarshal(data, &health); err != nil {
+		return err
+	}
+	greenboot, err := NewGreenbootFromString(health.Greenboot)
+	if err != nil {
+		return err
+	}
+	*h = Health{
+		staged:    health.Staged,
+		status:    health.Status,
+		greenboot: greenboot,
+		uptime:    time.Duration(health.Uptime),
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package device
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHealth(t *testing.T) {
+	type args struct {
+		staged    string
+		status    string
+		greenboot string
+		uptime    time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "should create a health report",
+			args:    args{staged: strings.Repeat("ab", 32), status: "idle", greenboot: "green", uptime: time.Hour},
+			wantErr: nil,
+		},
+		{
+			name:    "should create an empty health report",
+			args:    args{},
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, invalid staged commit",
+			args:    args{staged: "abcdef"},
+			wantErr: ErrInvalidCommit,
+		},
+		{
+			name:    "should fail, unknown greenboot result",
+			args:    args{greenboot: "orange"},
+			wantErr: ErrInvalidGreenboot,
+		},
+		{
+			name:    "should fail, negative uptime",
+			args:    args{uptime: -time.Second},
+			wantErr: ErrInvalidUptime,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewHealth(tt.args.staged, tt.args.status, tt.args.greenboot, tt.args.uptime)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewHealth() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Staged().String() != tt.args.staged || got.Status() != tt.args.status ||
+				got.Greenboot().String() != tt.args.greenboot || got.Uptime() != tt.args.uptime {
+				t.Errorf("NewHealth() = %+v, want %+v", got, tt.args)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+var (
+	// ErrDeviceNotFound is the error returned when the device is not found.
+	ErrDeviceNotFound = errors.New("device not found")
+)
+
+// Repository interface for handling device data store/retrieve.
+type Repository interface {
+	// CreateDevice creates a new device.
+	CreateDevice(ctx context.Context, device *Device) error
+	// GetDevice returns the device with the given UUID.
+	GetDevice(ctx context.Context, uuid string) (*Device, error)
+	// UpdateDevice updates the device with the given UUID.
+	UpdateDevice(ctx context.Context, uuid string, updateFn func(d *Device) (*Device, error)) error
+	// DeleteDevice deletes the device with the given UUID.
+	DeleteDevice(ctx context.Context, uuid string) error
+	// GetDevices returns all devices.
+	GetDevices(ctx context.Context) ([]*Device, error)
+	// GetImageDevices returns the devices assigned to or running a version of the image with the given UUID.
+	GetImageDevices(ctx context.Context, imageUUID string) ([]*Device, error)
+	// GetDriftedDevices returns the devices not running the image version they are assigned to.
+	GetDriftedDevices(ctx context.Context) ([]*Device, error)
+	// GetDrift counts the drift of the devices per image they are assigned to, sorted by image uuid.
+	GetDrift(ctx context.Context) ([]ImageDrift, error)
+}
+
+// MarshalGorm converts a domain Device to a database Device.
+func (device Device) MarshalGorm() *models.Device {
+	if device.IsZero() { // if device is nil, return nil
+		return nil
+	}
+	account, err := common.GetAccountFromContext(device.ctx)
+	if err != nil {
+		return nil
+	}
+	model := &models.Device{
+		UUID:         device.UUID(),
+		Account:      account.String(),
+		Name:         device.Name().String(),
+		Commit:       device.Commit().String(),
+		ImageUUID:    device.Assignment().ImageUUID(),
+		ImageVersion: device.Assignment().Version().Uint(),
+		Tags:         device.Tags().StringArray(),
+		LastSeen:     device.LastSeen(),
+
+		RunningImageUUID:    device.Running().ImageUUID(),
+		RunningImageVersion: device.Running().Version().Uint(),
+		StagedCommit:        device.Health().Staged().String(),
+		Status:              device.Health().Status(),
+		Greenboot:           device.Health().Greenboot().String(),
+		Uptime:              device.Health().Uptime(),
+
+		TokenHash: device.Token().Hash(),
+	}
+	// set timing if not exists in the device
+	if !device.timing.IsZero() {
+		model.CreatedAt = device.timing.CreatedAt()
+		model.UpdatedAt = device.timing.UpdatedAt()
+		model.DeletedAt = gorm.DeletedAt{Time: device.timing.DeletedAt(), Valid: !device.timing.DeletedAt().IsZero()}
+	}
+	return model
+}
+
+// MarshalRedis converts a domain Device to byte array.
+func (device Device) MarshalRedis() []byte {
+	if device.IsZero() { // if device is nil, return nil
+		return nil
+	}
+	deviceBytes, _ := json.Marshal(device) // marshal device to byte array, marshal any error is ignored
+	return deviceBytes
+}
+
+// UnmarshalDeviceFromRedis unmarshals the device from redis, the context carries its account.
+func UnmarshalDeviceFromRedis(ctx context.Context, data []byte) (Device, error) {
+	if ctx == nil {
+		return Device{}, ErrEmptyContext
+	}
+	var device Device
+	if err := json.Unmarshal(data, &device); err != nil {
+		return Device{}, err
+	}
+	device.ctx = ctx
+	return device, nil
+}
@@ -0,0 +1,72 @@
+package device
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+)
+
+// ErrInvalidToken is returned when a device presents a token it wasn't issued.
+var ErrInvalidToken = errors.New("invalid device token")
+
+// tokenBytes is the number of random bytes of the secret a device is issued.
+const tokenBytes = 32
+
+// Token is the credential a device checks in with. Only the sha256 of its secret is kept,
+// the secret itself is handed to the device once, when it is created or onboarded.
+type Token struct {
+	hash string
+}
+
+// NewToken returns a new random secret along with the token it hashes to.
+func NewToken() (string, Token, error) {
+	random := make([]byte, tokenBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", Token{}, err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(random)
+	return secret, TokenOf(secret), nil
+}
+
+// TokenOf returns the token the given secret hashes to, zero if the secret is empty.
+func TokenOf(secret string) Token {
+	if secret == "" {
+		return Token{}
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return Token{hash: hex.EncodeToString(sum[:])}
+}
+
+// NewTokenFromHash returns the token of the given hash, as stored.
+func NewTokenFromHash(hash string) Token {
+	return Token{hash: hash}
+}
+
+// IsZero returns true if no token was issued.
+func (t Token) IsZero() bool {
+	return t.hash == ""
+}
+
+// Hash returns the hex encoded sha256 of the secret of the token.
+func (t Token) Hash() string {
+	return t.hash
+}
+
+// Verify returns ErrInvalidToken unless the presented token is this one, a zero token matches none.
+func (t Token) Verify(presented Token) error {
+	if t.IsZero() || subtle.ConstantTimeCompare([]byte(t.hash), []byte(presented.hash)) != 1 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// String hides the hash, so logging a command or device doesn't print it.
+func (t Token) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return "[redacted]"
+}
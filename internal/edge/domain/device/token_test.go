@@ -0,0 +1,84 @@
+package device
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToken_Verify(t *testing.T) {
+	secret, token, err := NewToken()
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+	otherSecret, _, err := NewToken()
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+	tests := []struct {
+		name      string
+		token     Token
+		presented Token
+		wantErr   error
+	}{
+		{
+			name:      "should verify the secret of the token",
+			token:     token,
+			presented: TokenOf(secret),
+			wantErr:   nil,
+		},
+		{
+			name:      "should fail, secret of another token",
+			token:     token,
+			presented: TokenOf(otherSecret),
+			wantErr:   ErrInvalidToken,
+		},
+		{
+			name:      "should fail, no secret",
+			token:     token,
+			presented: TokenOf(""),
+			wantErr:   ErrInvalidToken,
+		},
+		{
+			name:      "should fail, no token issued",
+			token:     Token{},
+			presented: TokenOf(""),
+			wantErr:   ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.token.Verify(tt.presented); err != tt.wantErr {
+				t.Errorf("Token.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDevice_Authenticate(t *testing.T) {
+	secret, token, err := NewToken()
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+	device, err := NewDevice("device-uuid", "kiosk", "", "", 0, nil)
+	if err != nil {
+		t.Fatalf("NewDevice() error = %v", err)
+	}
+	if err := device.Authenticate(TokenOf(secret)); err != ErrInvalidToken {
+		t.Errorf("Device.Authenticate() error = %v, wantErr %v", err, ErrInvalidToken)
+	}
+	device.SetToken(token)
+	// the token survives the round trip to the cache
+	data, err := json.Marshal(device)
+	if err != nil {
+		t.Fatalf("failed to marshal device: %s", err)
+	}
+	var cached Device
+	if err := json.Unmarshal(data, &cached); err != nil {
+		t.Fatalf("failed to unmarshal device: %s", err)
+	}
+	if err := cached.Authenticate(TokenOf(secret)); err != nil {
+		t.Errorf("Device.Authenticate() error = %v, wantErr %v", err, nil)
+	}
+}
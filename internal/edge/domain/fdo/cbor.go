@@ -0,0 +1,149 @@
+package fdo
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// errMalformedCBOR is returned when the voucher isn't well formed CBOR.
+var errMalformedCBOR = errors.New("malformed cbor")
+
+// maxDepth limits the nesting of decoded items, vouchers are shallow.
+const maxDepth = 16
+
+// cborTag is a tagged CBOR item, e.g. a COSE structure.
+type cborTag struct {
+	number uint64
+	value  interface{}
+}
+
+// decodeCBOR decodes a single CBOR item that must span the whole data.
+// Only definite length items are supported, which is what the FDO specification requires.
+// Items decode to uint64, int64, []byte, string, []interface{}, map[interface{}]interface{},
+// cborTag, bool, float64 or nil.
+func decodeCBOR(data []byte) (interface{}, error) {
+	d := cborDecoder{data: data}
+	item, err := d.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.offset != len(d.data) {
+		return nil, errMalformedCBOR
+	}
+	return item, nil
+}
+
+// cborDecoder reads CBOR items from a byte slice.
+type cborDecoder struct {
+	data   []byte
+	offset int
+}
+
+// next returns the next n bytes.
+func (d *cborDecoder) next(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.offset) {
+		return nil, errMalformedCBOR
+	}
+	b := d.data[d.offset : d.offset+int(n)]
+	d.offset += int(n)
+	return b, nil
+}
+
+// head reads the initial byte of an item and its argument.
+func (d *cborDecoder) head() (major byte, info byte, arg uint64, err error) {
+	b, err := d.next(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info = b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		size := uint64(1) << (info - 24)
+		b, err := d.next(size)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		var padded [8]byte
+		copy(padded[8-size:], b)
+		return major, info, binary.BigEndian.Uint64(padded[:]), nil
+	default: // reserved values and indefinite lengths
+		return 0, 0, 0, errMalformedCBOR
+	}
+}
+
+// decode reads the next item.
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, errMalformedCBOR
+	}
+	major, info, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case 0: // unsigned integer
+		return arg, nil
+	case 1: // negative integer
+		if arg > math.MaxInt64 {
+			return nil, errMalformedCBOR
+		}
+		return -1 - int64(arg), nil
+	case 2: // byte string
+		return d.next(arg)
+	case 3: // text string
+		b, err := d.next(arg)
+		return string(b), err
+	case 4: // array
+		if arg > uint64(len(d.data)) { // every item takes at least a byte
+			return nil, errMalformedCBOR
+		}
+		items := make([]interface{}, arg)
+		for i := range items {
+			if items[i], err = d.decode(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case 5: // map
+		if arg > uint64(len(d.data)) {
+			return nil, errMalformedCBOR
+		}
+		items := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case uint64, int64, string, bool: // other items can't be map keys
+			default:
+				return nil, errMalformedCBOR
+			}
+			if items[key], err = d.decode(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case 6: // tag
+		value, err := d.decode(depth + 1)
+		return cborTag{number: arg, value: value}, err
+	default: // simple values and floats
+		switch {
+		case info == 20:
+			return false, nil
+		case info == 21:
+			return true, nil
+		case info == 22 || info == 23:
+			return nil, nil
+		case info == 26:
+			return float64(math.Float32frombits(uint32(arg))), nil
+		case info == 27:
+			return math.Float64frombits(arg), nil
+		default: // half floats and unassigned simple values are not used by vouchers
+			return nil, errMalformedCBOR
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package fdo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeCBOR(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "should decode an unsigned integer",
+			data: []byte{0x19, 0x01, 0x00},
+			want: uint64(256),
+		},
+		{
+			name: "should decode a negative integer",
+			data: []byte{0x20},
+			want: int64(-1),
+		},
+		{
+			name: "should decode an array of strings",
+			data: cborArray(cborText("a"), cborBytes([]byte{0x01})),
+			want: []interface{}{"a", []byte{0x01}},
+		},
+		{
+			name: "should decode a map",
+			data: []byte{0xa1, 0x01, 0xf5},
+			want: map[interface{}]interface{}{uint64(1): true},
+		},
+		{
+			name: "should decode a tag",
+			data: []byte{0xd2, 0x80},
+			want: cborTag{number: 18, value: []interface{}{}},
+		},
+		{
+			name:    "should fail, trailing data",
+			data:    []byte{0x01, 0x02},
+			wantErr: true,
+		},
+		{
+			name:    "should fail, indefinite length",
+			data:    []byte{0x9f, 0x01, 0xff},
+			wantErr: true,
+		},
+		{
+			name:    "should fail, array longer than data",
+			data:    []byte{0x9a, 0xff, 0xff, 0xff, 0xff},
+			wantErr: true,
+		},
+		{
+			name:    "should fail, array map key",
+			data:    []byte{0xa1, 0x80, 0x01},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := decodeCBOR(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeCBOR() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeCBOR() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
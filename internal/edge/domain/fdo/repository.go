@@ -0,0 +1,54 @@
+package fdo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+var (
+	// ErrVoucherNotFound is the error returned when the voucher is not found.
+	ErrVoucherNotFound = errors.New("voucher not found")
+	// ErrVoucherExists is the error returned when a voucher of the device was already imported.
+	ErrVoucherExists = errors.New("voucher already imported")
+)
+
+// Repository interface for handling ownership vouchers store/retrieve.
+type Repository interface {
+	// ImportVoucher stores a new voucher.
+	ImportVoucher(ctx context.Context, voucher *Voucher) error
+	// GetVoucher returns the voucher of the device with the given GUID.
+	GetVoucher(ctx context.Context, guid GUID) (*Voucher, error)
+	// GetVouchers returns all vouchers.
+	GetVouchers(ctx context.Context) ([]*Voucher, error)
+	// UpdateVoucher updates the voucher of the device with the given GUID.
+	UpdateVoucher(ctx context.Context, guid GUID, updateFn func(v *Voucher) (*Voucher, error)) error
+}
+
+// MarshalGorm converts a domain Voucher to a database Voucher.
+func (v Voucher) MarshalGorm() *models.Voucher {
+	if v.IsZero() { // if voucher is nil, return nil
+		return nil
+	}
+	account, err := common.GetAccountFromContext(v.ctx)
+	if err != nil {
+		return nil
+	}
+	model := &models.Voucher{
+		Account:         account.String(),
+		GUID:            v.GUID().String(),
+		DeviceInfo:      v.DeviceInfo(),
+		ProtocolVersion: v.ProtocolVersion(),
+		Data:            v.Data(),
+		Status:          v.Status().String(),
+		DeviceUUID:      v.DeviceUUID(),
+		OnboardedAt:     v.OnboardedAt(),
+	}
+	if !v.timing.IsZero() {
+		model.CreatedAt = v.timing.CreatedAt()
+		model.UpdatedAt = v.timing.UpdatedAt()
+	}
+	return model
+}
@@ -0,0 +1,254 @@
+package fdo
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/google/uuid"
+)
+
+// Voucher errors
+var (
+	ErrEmptyContext     = errors.New("empty context")
+	ErrInvalidVoucher   = errors.New("invalid ownership voucher")
+	ErrInvalidGUID      = errors.New("invalid device guid")
+	ErrAlreadyOnboarded = errors.New("device already onboarded")
+)
+
+// pemType is the type of PEM blocks holding an ownership voucher, as written by the FDO tools.
+const pemType = "OWNERSHIP VOUCHER"
+
+// Define the available statuses of a voucher.
+var (
+	Imported  = Status{"imported"}
+	Onboarded = Status{"onboarded"}
+)
+
+// All available statuses.
+var availableStatuses = []Status{
+	Imported,
+	Onboarded,
+}
+
+// Status is the onboarding status of the device a voucher belongs to.
+type Status struct {
+	status string
+}
+
+// NewStatusFromString creates a new status from a string.
+func NewStatusFromString(status string) (Status, error) {
+	for _, s := range availableStatuses {
+		if s.status == status {
+			return s, nil
+		}
+	}
+	return Status{}, ErrInvalidVoucher
+}
+
+// String returns the string representation of a status.
+func (s Status) String() string {
+	return s.status
+}
+
+// GUID is the FDO identifier of a device, set by the manufacturer in the voucher.
+type GUID struct {
+	id uuid.UUID
+}
+
+// NewGUID parses a GUID from its string representation.
+func NewGUID(guid string) (GUID, error) {
+	id, err := uuid.Parse(guid)
+	if err != nil {
+		return GUID{}, ErrInvalidGUID
+	}
+	return GUID{id: id}, nil
+}
+
+// IsZero returns true if the guid is empty.
+func (g GUID) IsZero() bool {
+	return g == GUID{}
+}
+
+// String returns the guid in its canonical form.
+func (g GUID) String() string {
+	return g.id.String()
+}
+
+// Voucher is an FDO ownership voucher, transferring the ownership of a device to the account.
+type Voucher struct {
+	// context
+	ctx context.Context
+	// identity
+	guid       GUID
+	deviceInfo string
+	// info
+	protocolVersion uint
+	entries         uint
+	data            []byte
+	// onboarding
+	status      Status
+	deviceUUID  string
+	onboardedAt time.Time
+	// time
+	timing common.Time
+}
+
+// ParseVoucher parses an ownership voucher, either raw CBOR or PEM encoded.
+// The header is decoded to identify the device, the voucher itself is kept as is.
+func ParseVoucher(ctx context.Context, data []byte) (Voucher, error) {
+	if ctx == nil {
+		return Voucher{}, ErrEmptyContext
+	}
+	if block, _ := pem.Decode(bytes.TrimSpace(data)); block != nil {
+		if block.Type != pemType {
+			return Voucher{}, ErrInvalidVoucher
+		}
+		data = block.Bytes
+	}
+	// OwnershipVoucher = [OVProtVer, OVHeaderTag, OVHeaderHMac, OVDevCertChain, OVEntryArray]
+	item, err := decodeCBOR(data)
+	if err != nil {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	ov, ok := item.([]interface{})
+	if !ok || len(ov) < 5 {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	protocolVersion, ok := ov[0].(uint64)
+	if !ok {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	entries, ok := ov[4].([]interface{})
+	if !ok {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	// OVHeader = [OVHProtVer, OVGuid, OVRVInfo, OVDeviceInfo, OVPubKey, OVDevCertChainHash / null]
+	headerData, ok := ov[1].([]byte)
+	if !ok {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	item, err = decodeCBOR(headerData)
+	if err != nil {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	header, ok := item.([]interface{})
+	if !ok || len(header) < 5 {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	guidBytes, ok := header[1].([]byte)
+	if !ok {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	id, err := uuid.FromBytes(guidBytes)
+	if err != nil {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	deviceInfo, ok := header[3].(string)
+	if !ok {
+		return Voucher{}, ErrInvalidVoucher
+	}
+	return Voucher{
+		ctx:             ctx,
+		guid:            GUID{id: id},
+		deviceInfo:      deviceInfo,
+		protocolVersion: uint(protocolVersion),
+		entries:         uint(len(entries)),
+		data:            data,
+		status:          Imported,
+	}, nil
+}
+
+// IsZero returns true if the voucher is zero.
+func (v Voucher) IsZero() bool {
+	return v.guid.IsZero() && v.deviceInfo == "" && v.protocolVersion == 0 &&
+		v.entries == 0 && len(v.data) == 0 && v.status == Status{} &&
+		v.deviceUUID == "" && v.onboardedAt.IsZero() && v.timing.IsZero()
+}
+
+// Account is a getter for the account of a voucher.
+func (v Voucher) Account() (common.Account, error) {
+	return common.GetAccountFromContext(v.ctx)
+}
+
+// GUID is a getter for the guid of the device the voucher belongs to.
+func (v Voucher) GUID() GUID {
+	return v.guid
+}
+
+// DeviceInfo is a getter for the device info the manufacturer set in the voucher.
+func (v Voucher) DeviceInfo() string {
+	return v.deviceInfo
+}
+
+// ProtocolVersion is a getter for the FDO protocol version of the voucher.
+func (v Voucher) ProtocolVersion() uint {
+	return v.protocolVersion
+}
+
+// Entries is a getter for the number of ownership transfers recorded in the voucher.
+func (v Voucher) Entries() uint {
+	return v.entries
+}
+
+// Data is a getter for the voucher, CBOR encoded.
+func (v Voucher) Data() []byte {
+	return v.data
+}
+
+// Status is a getter for the onboarding status of the voucher.
+func (v Voucher) Status() Status {
+	return v.status
+}
+
+// DeviceUUID is a getter for the uuid of the device created when the voucher was onboarded.
+func (v Voucher) DeviceUUID() string {
+	return v.deviceUUID
+}
+
+// OnboardedAt is a getter for the time the device completed onboarding.
+func (v Voucher) OnboardedAt() time.Time {
+	return v.onboardedAt
+}
+
+// CreatedAt is a getter for the time the voucher was imported.
+func (v Voucher) CreatedAt() time.Time {
+	return v.timing.CreatedAt()
+}
+
+// SetTime sets the time of a voucher.
+func (v *Voucher) SetTime(timing common.Time) {
+	v.timing = timing
+}
+
+// Onboard marks the device of the voucher as onboarded, as the device with the given uuid.
+func (v *Voucher) Onboard(deviceUUID string, at time.Time) error {
+	if v.status == Onboarded {
+		return ErrAlreadyOnboarded
+	}
+	v.status = Onboarded
+	v.deviceUUID = deviceUUID
+	v.onboardedAt = at.UTC().Truncate(time.Microsecond)
+	return nil
+}
+
+// UnmarshalVoucherFromDatabase unmarshals the voucher from the database.
+func UnmarshalVoucherFromDatabase(ctx context.Context, data []byte, status, deviceUUID string,
+	onboardedAt, createdAt, updatedAt time.Time) (Voucher, error) {
+	voucher, err := ParseVoucher(ctx, data)
+	if err != nil {
+		return Voucher{}, err
+	}
+	validStatus, err := NewStatusFromString(status)
+	if err != nil {
+		return Voucher{}, err
+	}
+	voucher.status = validStatus
+	voucher.deviceUUID = deviceUUID
+	voucher.onboardedAt = onboardedAt
+	voucher.SetTime(common.NewTime(createdAt, updatedAt, time.Time{}))
+	return voucher, nil
+}
@@ -0,0 +1,178 @@
+package fdo
+
+import (
+	"context"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// cborHead encodes the head of a CBOR item.
+func cborHead(major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return []byte{major<<5 | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major<<5 | 24, byte(arg)}
+	default:
+		return []byte{major<<5 | 25, byte(arg >> 8), byte(arg)}
+	}
+}
+
+// cborArray encodes a CBOR array of already encoded items.
+func cborArray(items ...[]byte) []byte {
+	data := cborHead(4, uint64(len(items)))
+	for _, item := range items {
+		data = append(data, item...)
+	}
+	return data
+}
+
+// cborBytes encodes a CBOR byte string.
+func cborBytes(b []byte) []byte {
+	return append(cborHead(2, uint64(len(b))), b...)
+}
+
+// cborText encodes a CBOR text string.
+func cborText(s string) []byte {
+	return append(cborHead(3, uint64(len(s))), s...)
+}
+
+// newTestVoucher encodes a voucher of the device with the given guid, with one ownership transfer.
+func newTestVoucher(guid uuid.UUID, deviceInfo string) []byte {
+	header := cborArray(
+		cborHead(0, 101),           // protocol version
+		cborBytes(guid[:]),         // guid
+		cborArray(),                // rendezvous info
+		cborText(deviceInfo),       // device info
+		cborArray(cborHead(0, 10)), // public key
+		[]byte{0xf6},               // no certificate chain hash
+	)
+	return cborArray(
+		cborHead(0, 101),
+		cborBytes(header),
+		cborArray(cborHead(0, 5), cborBytes([]byte("hmac"))),
+		[]byte{0xf6},
+		cborArray(cborArray(cborText("entry"))),
+	)
+}
+
+func TestParseVoucher(t *testing.T) {
+	guid := uuid.New()
+	voucher := newTestVoucher(guid, "kiosk")
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		data    []byte
+		wantErr error
+	}{
+		{
+			name:    "should parse a cbor voucher",
+			ctx:     context.Background(),
+			data:    voucher,
+			wantErr: nil,
+		},
+		{
+			name:    "should parse a pem voucher",
+			ctx:     context.Background(),
+			data:    pem.EncodeToMemory(&pem.Block{Type: "OWNERSHIP VOUCHER", Bytes: voucher}),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, other pem block",
+			ctx:     context.Background(),
+			data:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: voucher}),
+			wantErr: ErrInvalidVoucher,
+		},
+		{
+			name:    "should fail, truncated voucher",
+			ctx:     context.Background(),
+			data:    voucher[:len(voucher)-3],
+			wantErr: ErrInvalidVoucher,
+		},
+		{
+			name:    "should fail, not a voucher",
+			ctx:     context.Background(),
+			data:    cborArray(cborText("not"), cborText("a"), cborText("voucher")),
+			wantErr: ErrInvalidVoucher,
+		},
+		{
+			name:    "should fail, empty context",
+			ctx:     nil,
+			data:    voucher,
+			wantErr: ErrEmptyContext,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseVoucher(tt.ctx, tt.data)
+			if err != tt.wantErr {
+				t.Errorf("ParseVoucher() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.GUID().String() != guid.String() || got.DeviceInfo() != "kiosk" ||
+				got.ProtocolVersion() != 101 || got.Entries() != 1 || got.Status() != Imported {
+				t.Errorf("ParseVoucher() = %v, %v, %v, %v, %v, want %v, kiosk, 101, 1, imported",
+					got.GUID(), got.DeviceInfo(), got.ProtocolVersion(), got.Entries(), got.Status(), guid)
+			}
+		})
+	}
+}
+
+func TestVoucher_Onboard(t *testing.T) {
+	voucher, err := ParseVoucher(context.Background(), newTestVoucher(uuid.New(), "kiosk"))
+	if err != nil {
+		t.Fatalf("failed to parse voucher: %s", err)
+	}
+	now := time.Now()
+	if err := voucher.Onboard("device-uuid", now); err != nil {
+		t.Errorf("Voucher.Onboard() error = %v", err)
+	}
+	if voucher.Status() != Onboarded || voucher.DeviceUUID() != "device-uuid" ||
+		!voucher.OnboardedAt().Equal(now.Truncate(time.Microsecond)) {
+		t.Errorf("Voucher.Onboard() = %v, %v, %v", voucher.Status(), voucher.DeviceUUID(), voucher.OnboardedAt())
+	}
+	if err := voucher.Onboard("other-device-uuid", now); err != ErrAlreadyOnboarded {
+		t.Errorf("Voucher.Onboard() error = %v, wantErr %v", err, ErrAlreadyOnboarded)
+	}
+}
+
+func TestNewGUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		guid    string
+		wantErr bool
+	}{
+		{
+			name:    "should parse a guid",
+			guid:    "a0e5a8a0-1c4e-4b7a-9c61-4f3f0d8a2b11",
+			wantErr: false,
+		},
+		{
+			name:    "should fail, invalid guid",
+			guid:    "not-a-guid",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewGUID(tt.guid)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGUID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.String() != tt.guid {
+				t.Errorf("NewGUID() = %v, want %v", got, tt.guid)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+)
+
+// Device group errors
+var (
+	ErrEmptyContext      = errors.New("empty context")
+	ErrInvalidMembership = errors.New("invalid membership, a group either lists its devices or selects them by tags")
+	ErrUnknownDevice     = errors.New("unknown device in group")
+)
+
+// Define the available memberships of a group.
+var (
+	// Static groups list their devices explicitly.
+	Static = Membership{"static"}
+	// Dynamic groups select all devices having the tags of their selector.
+	Dynamic = Membership{"dynamic"}
+)
+
+// All available memberships.
+var availableMemberships = []Membership{
+	Static,
+	Dynamic,
+}
+
+// Membership is the way devices are members of a group.
+type Membership struct {
+	membership string
+}
+
+// NewMembershipFromString creates a new membership from a string.
+func NewMembershipFromString(membership string) (Membership, error) {
+	for _, m := range availableMemberships {
+		if m.membership == membership {
+			return m, nil
+		}
+	}
+	return Membership{}, ErrInvalidMembership
+}
+
+// String returns the string representation of a membership.
+func (m Membership) String() string {
+	return m.membership
+}
+
+// DeviceGroup is a group of devices, managed as a whole.
+type DeviceGroup struct {
+	// context
+	ctx context.Context
+	// identity
+	uuid string
+	name common.Name
+	// membership
+	membership Membership
+	devices    []string
+	selector   common.Tags
+	// time
+	timing common.Time
+}
+
+// NewDeviceGroupWithContext creates a new device group. A group with a selector is dynamic,
+// its members are all the devices having every tag of the selector, otherwise it is static
+// and its members are the given devices. A group can't have both.
+func NewDeviceGroupWithContext(ctx context.Context, uuid, name string, devices, selector []string) (DeviceGroup, error) {
+	if ctx == nil {
+		return DeviceGroup{}, ErrEmptyContext
+	}
+	validName, err := common.NewName(name)
+	if err != nil {
+		return DeviceGroup{}, err
+	}
+	validSelector := common.NewTags(unique(selector)...)
+	membership := Static
+	if len(validSelector.Tags()) > 0 {
+		if len(devices) > 0 {
+			return DeviceGroup{}, ErrInvalidMembership
+		}
+		membership = Dynamic
+	}
+	for _, deviceUUID := range devices {
+		if deviceUUID == "" {
+			return DeviceGroup{}, ErrInvalidMembership
+		}
+	}
+	return DeviceGroup{
+		ctx:        ctx,
+		uuid:       uuid,
+		name:       validName,
+		membership: membership,
+		devices:    unique(devices),
+		selector:   validSelector,
+	}, nil
+}
+
+// unique returns the given values without duplicates, keeping their order.
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var uniqueValues []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			uniqueValues = append(uniqueValues, value)
+		}
+	}
+	return uniqueValues
+}
+
+// IsZero returns true if the group is zero.
+func (g DeviceGroup) IsZero() bool {
+	return g.uuid == "" && g.name.IsZero() && g.membership == Membership{} &&
+		len(g.devices) == 0 && len(g.selector.Tags()) == 0 && g.timing.IsZero()
+}
+
+// Account is a getter for the account of a group.
+func (g DeviceGroup) Account() (common.Account, error) {
+	return common.GetAccountFromContext(g.ctx)
+}
+
+// UUID is a getter for the uuid of a group.
+func (g DeviceGroup) UUID() string {
+	return g.uuid
+}
+
+// Name is a getter for the name of a group.
+func (g DeviceGroup) Name() common.Name {
+	return g.name
+}
+
+// Membership is a getter for the membership of a group.
+func (g DeviceGroup) Membership() Membership {
+	return g.membership
+}
+
+// Devices is a getter for the devices listed by a static group.
+func (g DeviceGroup) Devices() []string {
+	return g.devices
+}
+
+// Selector is a getter for the tags a dynamic group selects its devices by.
+func (g DeviceGroup) Selector() common.Tags {
+	return g.selector
+}
+
+// CreatedAt is a getter for the created at time of a group.
+func (g DeviceGroup) CreatedAt() time.Time {
+	return g.timing.CreatedAt()
+}
+
+// UpdatedAt is a getter for the updated at time of a group.
+func (g DeviceGroup) UpdatedAt() time.Time {
+	return g.timing.UpdatedAt()
+}
+
+// Matches returns true if the given device is a member of the group.
+func (g DeviceGroup) Matches(d *device.Device) bool {
+	if g.membership == Static {
+		for _, deviceUUID := range g.devices {
+			if deviceUUID == d.UUID() {
+				return true
+			}
+		}
+		return false
+	}
+	deviceTags := make(map[string]bool)
+	for _, tag := range d.Tags().StringArray() {
+		deviceTags[tag] = true
+	}
+	for _, tag := range g.selector.StringArray() {
+		if !deviceTags[tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// SetTime sets the time of a group.
+func (g *DeviceGroup) SetTime(timing common.Time) {
+	g.timing = timing
+}
+
+// Touch marks the group as stored at the given time, setting its creation time if it has none.
+func (g *DeviceGroup) Touch(now time.Time) {
+	now = now.UTC().Truncate(time.Microsecond)
+	createdAt := g.CreatedAt()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	g.timing = common.NewTime(createdAt, now, time.Time{})
+}
+
+// UnmarshalDeviceGroupFromDatabase unmarshals the group from the database.
+func UnmarshalDeviceGroupFromDatabase(ctx context.Context, uuid, name, membership string,
+	devices, selector []string, createdAt, updatedAt time.Time) (DeviceGroup, error) {
+	group, err := NewDeviceGroupWithContext(ctx, uuid, name, devices, selector)
+	if err != nil {
+		return DeviceGroup{}, err
+	}
+	if group.membership.String() != membership {
+		return DeviceGroup{}, ErrInvalidMembership
+	}
+	group.SetTime(common.NewTime(createdAt, updatedAt, time.Time{}))
+	return group, nil
+}
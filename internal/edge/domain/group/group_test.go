@@ -0,0 +1,141 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+)
+
+func TestNewDeviceGroupWithContext(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		name     string
+		devices  []string
+		selector []string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantMembership Membership
+		wantDevices    []string
+		wantSelector   []string
+		wantErr        error
+	}{
+		{
+			name:           "should create a static group",
+			args:           args{ctx: context.Background(), name: "kiosks", devices: []string{"b", "a", "b"}},
+			wantMembership: Static,
+			wantDevices:    []string{"b", "a"},
+			wantSelector:   []string{},
+			wantErr:        nil,
+		},
+		{
+			name:           "should create an empty static group",
+			args:           args{ctx: context.Background(), name: "kiosks"},
+			wantMembership: Static,
+			wantSelector:   []string{},
+			wantErr:        nil,
+		},
+		{
+			name:           "should create a dynamic group",
+			args:           args{ctx: context.Background(), name: "kiosks", selector: []string{"kiosk", "store-42", "kiosk"}},
+			wantMembership: Dynamic,
+			wantSelector:   []string{"kiosk", "store-42"},
+			wantErr:        nil,
+		},
+		{
+			name:    "should fail, both devices and selector",
+			args:    args{ctx: context.Background(), name: "kiosks", devices: []string{"a"}, selector: []string{"kiosk"}},
+			wantErr: ErrInvalidMembership,
+		},
+		{
+			name:    "should fail, empty device",
+			args:    args{ctx: context.Background(), name: "kiosks", devices: []string{""}},
+			wantErr: ErrInvalidMembership,
+		},
+		{
+			name:    "should fail, invalid name",
+			args:    args{ctx: context.Background(), name: " "},
+			wantErr: common.ErrInvalidName,
+		},
+		{
+			name:    "should fail, empty context",
+			args:    args{ctx: nil, name: "kiosks"},
+			wantErr: ErrEmptyContext,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewDeviceGroupWithContext(tt.args.ctx, "uuid", tt.args.name, tt.args.devices, tt.args.selector)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewDeviceGroupWithContext() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Membership() != tt.wantMembership {
+				t.Errorf("NewDeviceGroupWithContext() membership = %v, want %v", got.Membership(), tt.wantMembership)
+			}
+			if !reflect.DeepEqual(got.Devices(), tt.wantDevices) {
+				t.Errorf("NewDeviceGroupWithContext() devices = %v, want %v", got.Devices(), tt.wantDevices)
+			}
+			if !reflect.DeepEqual(got.Selector().StringArray(), tt.wantSelector) {
+				t.Errorf("NewDeviceGroupWithContext() selector = %v, want %v", got.Selector().StringArray(), tt.wantSelector)
+			}
+		})
+	}
+}
+
+func TestDeviceGroup_Matches(t *testing.T) {
+	kiosk, _ := device.NewDevice("kiosk-uuid", "kiosk", "", "", 0, []string{"kiosk", "store-42"})
+	register, _ := device.NewDevice("register-uuid", "register", "", "", 0, nil)
+	static, _ := NewDeviceGroupWithContext(context.Background(), "uuid", "static", []string{"register-uuid"}, nil)
+	dynamic, _ := NewDeviceGroupWithContext(context.Background(), "uuid", "dynamic", nil, []string{"store-42", "kiosk"})
+	tests := []struct {
+		name   string
+		group  DeviceGroup
+		device device.Device
+		want   bool
+	}{
+		{
+			name:   "should match, listed device",
+			group:  static,
+			device: register,
+			want:   true,
+		},
+		{
+			name:   "should not match, unlisted device",
+			group:  static,
+			device: kiosk,
+			want:   false,
+		},
+		{
+			name:   "should match, device has all tags",
+			group:  dynamic,
+			device: kiosk,
+			want:   true,
+		},
+		{
+			name:   "should not match, device misses tags",
+			group:  dynamic,
+			device: register,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.group.Matches(&tt.device); got != tt.want {
+				t.Errorf("DeviceGroup.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package group
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+)
+
+var (
+	// ErrGroupNotFound is the error returned when the group is not found.
+	ErrGroupNotFound = errors.New("device group not found")
+)
+
+// Repository interface for handling device group data store/retrieve.
+type Repository interface {
+	// CreateGroup creates a new group.
+	CreateGroup(ctx context.Context, group *DeviceGroup) error
+	// GetGroup returns the group with the given UUID.
+	GetGroup(ctx context.Context, uuid string) (*DeviceGroup, error)
+	// GetGroups returns all groups.
+	GetGroups(ctx context.Context) ([]*DeviceGroup, error)
+	// GetMembers returns the devices that are members of the group with the given UUID.
+	GetMembers(ctx context.Context, uuid string) ([]*device.Device, error)
+	// GetDeviceGroups returns the groups the device with the given UUID is a member of.
+	GetDeviceGroups(ctx context.Context, deviceUUID string) ([]*DeviceGroup, error)
+}
+
+// MarshalGorm converts a domain DeviceGroup to a database DeviceGroup.
+// Static members and selector tags are stored in their own tables, so membership is evaluated in SQL.
+func (g DeviceGroup) MarshalGorm() *models.DeviceGroup {
+	if g.IsZero() { // if group is nil, return nil
+		return nil
+	}
+	account, err := g.Account()
+	if err != nil {
+		return nil
+	}
+	model := &models.DeviceGroup{
+		Account:    account.String(),
+		UUID:       g.UUID(),
+		Name:       g.Name().String(),
+		Membership: g.Membership().String(),
+		Selector:   g.Selector().StringArray(),
+	}
+	if !g.timing.IsZero() {
+		model.CreatedAt = g.timing.CreatedAt()
+		model.UpdatedAt = g.timing.UpdatedAt()
+	}
+	return model
+}
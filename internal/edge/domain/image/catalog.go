@@ -0,0 +1,90 @@
+package image
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidCatalogEntry is the error returned when a catalog entry has no name.
+var ErrInvalidCatalogEntry = errors.New("invalid catalog entry, name cannot be empty")
+
+// CatalogEntry is a tag or a package of the catalog of an account, along with the images using it.
+// Every name is stored once per account, whatever the number of images using it.
+type CatalogEntry struct {
+	name   string
+	images []string
+}
+
+// NewCatalogEntry creates a new catalog entry, used by the images with the given uuids.
+func NewCatalogEntry(name string, images ...string) (CatalogEntry, error) {
+	if strings.TrimSpace(name) == "" {
+		return CatalogEntry{}, ErrInvalidCatalogEntry
+	}
+	return CatalogEntry{name: name, images: append([]string(nil), images...)}, nil
+}
+
+// Name returns the name of the tag or package.
+func (e CatalogEntry) Name() string {
+	return e.name
+}
+
+// Images returns the uuids of the images using the entry.
+func (e CatalogEntry) Images() []string {
+	return append([]string(nil), e.images...)
+}
+
+// Count returns the number of images using the entry.
+func (e CatalogEntry) Count() int {
+	return len(e.images)
+}
+
+// MatchesCatalogPrefix returns true if the name starts with the prefix, whatever their case.
+func MatchesCatalogPrefix(name, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix))
+}
+
+// SortCatalog sorts the entries most used first, then by name.
+func SortCatalog(entries []*CatalogEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Count() != entries[j].Count() {
+			return entries[i].Count() > entries[j].Count()
+		}
+		return entries[i].name < entries[j].name
+	})
+}
+
+// TagCatalog returns the entries of the tags the images use starting with the prefix, sorted by SortCatalog.
+func TagCatalog(images []*Image, prefix string) []*CatalogEntry {
+	return buildCatalog(images, prefix, func(image *Image) []string { return image.Tags().StringArray() })
+}
+
+// PackageCatalog returns the entries of the packages the images use starting with the prefix, sorted by SortCatalog.
+func PackageCatalog(images []*Image, prefix string) []*CatalogEntry {
+	return buildCatalog(images, prefix, func(image *Image) []string { return image.Packages().StringArray() })
+}
+
+// buildCatalog returns the entries of the names the images use starting with the prefix,
+// each image being listed once per name, in the order of the images.
+func buildCatalog(images []*Image, prefix string, names func(image *Image) []string) []*CatalogEntry {
+	entries := []*CatalogEntry{}
+	byName := make(map[string]*CatalogEntry)
+	for _, image := range images {
+		for _, name := range names(image) {
+			if !MatchesCatalogPrefix(name, prefix) {
+				continue
+			}
+			entry, ok := byName[name]
+			if !ok {
+				entry = &CatalogEntry{name: name}
+				byName[name] = entry
+				entries = append(entries, entry)
+			}
+			if n := len(entry.images); n == 0 || entry.images[n-1] != image.UUID() {
+				entry.images = append(entry.images, image.UUID())
+			}
+		}
+	}
+	SortCatalog(entries)
+	return entries
+}
@@ -0,0 +1,152 @@
+package image
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+func TestNewCatalogEntry(t *testing.T) {
+	type args struct {
+		name   string
+		images []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    CatalogEntry
+		wantErr bool
+	}{
+		{
+			name:    "should succeed",
+			args:    args{name: "vim", images: []string{"image-1", "image-2"}},
+			want:    CatalogEntry{name: "vim", images: []string{"image-1", "image-2"}},
+			wantErr: false,
+		},
+		{
+			name:    "should succeed, unused",
+			args:    args{name: "vim"},
+			want:    CatalogEntry{name: "vim"},
+			wantErr: false,
+		},
+		{
+			name:    "should fail, blank name",
+			args:    args{name: " ", images: []string{"image-1"}},
+			want:    CatalogEntry{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewCatalogEntry(tt.args.name, tt.args.images...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCatalogEntry() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewCatalogEntry() = %v, want %v", got, tt.want)
+			}
+			if got.Count() != len(tt.want.images) {
+				t.Errorf("CatalogEntry.Count() = %v, want %v", got.Count(), len(tt.want.images))
+			}
+		})
+	}
+}
+
+func TestMatchesCatalogPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		entry  string
+		prefix string
+		want   bool
+	}{
+		{name: "should match, no prefix", entry: "nginx", prefix: "", want: true},
+		{name: "should match, prefix", entry: "nginx", prefix: "ng", want: true},
+		{name: "should match, other case", entry: "Berlin", prefix: "ber", want: true},
+		{name: "should not match, inner part", entry: "nginx", prefix: "gin", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := MatchesCatalogPrefix(tt.entry, tt.prefix); got != tt.want {
+				t.Errorf("MatchesCatalogPrefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagCatalog(t *testing.T) {
+	images := []*Image{
+		{uuid: "image-1", tags: common.NewTags("berlin", "kiosk")},
+		{uuid: "image-2", tags: common.NewTags("kiosk", "kiosk", "Bern")},
+		{uuid: "image-3", tags: common.NewTags("munich")},
+	}
+	tests := []struct {
+		name   string
+		prefix string
+		want   []*CatalogEntry
+	}{
+		{
+			name:   "should list every tag, most used first",
+			prefix: "",
+			want: []*CatalogEntry{
+				{name: "kiosk", images: []string{"image-1", "image-2"}},
+				{name: "Bern", images: []string{"image-2"}},
+				{name: "berlin", images: []string{"image-1"}},
+				{name: "munich", images: []string{"image-3"}},
+			},
+		},
+		{
+			name:   "should list the tags starting with the prefix",
+			prefix: "ber",
+			want: []*CatalogEntry{
+				{name: "Bern", images: []string{"image-2"}},
+				{name: "berlin", images: []string{"image-1"}},
+			},
+		},
+		{
+			name:   "should list nothing",
+			prefix: "paris",
+			want:   []*CatalogEntry{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := TagCatalog(images, tt.prefix); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TagCatalog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageCatalog(t *testing.T) {
+	images := []*Image{
+		{uuid: "image-1", packages: NewPackages("nginx", "vim")},
+		{uuid: "image-2", packages: NewPackages("nginx")},
+	}
+	got := PackageCatalog(images, "")
+	// every image has the required packages, only vim isn't used by both
+	if len(got) != len(NewPackages().StringArray())+2 {
+		t.Fatalf("PackageCatalog() = %v, want the required packages, nginx and vim", got)
+	}
+	for _, entry := range got[:len(got)-1] {
+		if entry.Count() != 2 {
+			t.Errorf("PackageCatalog() %s count = %d, want 2", entry.Name(), entry.Count())
+		}
+	}
+	if last := got[len(got)-1]; last.Name() != "vim" || last.Count() != 1 {
+		t.Errorf("PackageCatalog() last = %s used %d times, want vim used once", last.Name(), last.Count())
+	}
+	want := []*CatalogEntry{
+		{name: "vim", images: []string{"image-1"}},
+	}
+	if got := PackageCatalog(images, "vi"); !reflect.DeepEqual(got, want) {
+		t.Errorf("PackageCatalog() = %v, want %v", got, want)
+	}
+}
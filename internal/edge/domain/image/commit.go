@@ -1,5 +1,64 @@
 package image
 
-// Commit is a commit of an image (ostree).
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCommit is returned when the commit is not a valid ostree checksum.
+var ErrInvalidCommit = errors.New("invalid commit")
+
+// Commit is an ostree commit an image version was built into, identified by its sha256 checksum.
 type Commit struct {
+	checksum string
+}
+
+// NewCommit creates a new commit, an empty checksum means there is no commit yet.
+func NewCommit(checksum string) (Commit, error) {
+	if checksum == "" {
+		return Commit{}, nil
+	}
+	if !isValidChecksum(checksum) {
+		return Commit{}, ErrInvalidCommit
+	}
+	return Commit{checksum: checksum}, nil
+}
+
+// isValidChecksum returns true if the checksum is a hex encoded sha256.
+func isValidChecksum(checksum string) bool {
+	if len(checksum) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(checksum)
+	return err == nil
+}
+
+// IsZero returns true if the commit is empty.
+func (c Commit) IsZero() bool {
+	return c == Commit{}
+}
+
+// String returns the checksum of the commit.
+func (c Commit) String() string {
+	return c.checksum
+}
+
+// MarshalJSON marshals the commit to JSON.
+func (c Commit) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.checksum)
+}
+
+// UnmarshalJSON unmarshals the commit from JSON.
+func (c *Commit) UnmarshalJSON(data []byte) error {
+	var checksum string
+	if err := json.Unmarshal(data, &checksum); err != nil {
+		return err
+	}
+	commit, err := NewCommit(checksum)
+	if err != nil {
+		return err
+	}
+	*c = commit
+	return nil
 }
@@ -0,0 +1,55 @@
+package image
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewCommit(t *testing.T) {
+	checksum := strings.Repeat("ab", 32)
+	tests := []struct {
+		name     string
+		checksum string
+		want     Commit
+		wantErr  bool
+	}{
+		{
+			name:     "should return a commit",
+			checksum: checksum,
+			want:     Commit{checksum: checksum},
+			wantErr:  false,
+		},
+		{
+			name:     "should return no commit",
+			checksum: "",
+			want:     Commit{},
+			wantErr:  false,
+		},
+		{
+			name:     "should fail, too short",
+			checksum: "abcdef",
+			want:     Commit{},
+			wantErr:  true,
+		},
+		{
+			name:     "should fail, not hex encoded",
+			checksum: strings.Repeat("zz", 32),
+			want:     Commit{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewCommit(tt.checksum)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCommit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewCommit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
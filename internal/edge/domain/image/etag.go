@@ -0,0 +1,61 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+// ErrPreconditionFailed is returned when the image was changed since the caller last read it.
+var ErrPreconditionFailed = errors.New("image was modified, precondition failed")
+
+// preconditionKey is the context key of the entity tags the image must match to be changed.
+type preconditionKey struct{}
+
+// ETag returns the entity tag of the image, it changes whenever the image is stored with new content.
+func (image Image) ETag() string {
+	return fmt.Sprintf(`"%d-%d"`, image.Version().Uint(), image.UpdatedAt().UnixMicro())
+}
+
+// Touch marks the image as stored at the given time, setting its creation time if it has none.
+// Times are kept in UTC with microsecond precision, so they survive a round trip to any database.
+func (image *Image) Touch(now time.Time) {
+	now = now.UTC().Truncate(time.Microsecond)
+	createdAt := image.CreatedAt()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	image.timing = common.NewTime(createdAt, now, image.DeletedAt())
+}
+
+// ContextWithPrecondition returns a copy of ctx that requires the image to match the given If-Match value.
+func ContextWithPrecondition(ctx context.Context, ifMatch string) context.Context {
+	return context.WithValue(ctx, preconditionKey{}, ifMatch)
+}
+
+// PreconditionFromContext returns the If-Match value carried by ctx, if any.
+func PreconditionFromContext(ctx context.Context) (string, bool) {
+	ifMatch, ok := ctx.Value(preconditionKey{}).(string)
+	return ifMatch, ok && ifMatch != ""
+}
+
+// CheckPrecondition returns ErrPreconditionFailed if ctx carries an If-Match value the image does not match.
+// The value is either "*" or a comma separated list of entity tags, weak tags never match.
+func CheckPrecondition(ctx context.Context, image Image) error {
+	ifMatch, ok := PreconditionFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	etag := image.ETag()
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return nil
+		}
+	}
+	return ErrPreconditionFailed
+}
@@ -0,0 +1,138 @@
+package image
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestImage_ETag(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 123456000, time.UTC)
+	tests := []struct {
+		name  string
+		image Image
+		other Image
+		equal bool
+	}{
+		{
+			name:  "should be equal for the same version and update time",
+			image: newTestSnapshot(t, 1, "success", false, now).Image(),
+			other: newTestSnapshot(t, 1, "success", false, now).Image(),
+			equal: true,
+		},
+		{
+			name:  "should differ for another version",
+			image: newTestSnapshot(t, 1, "success", false, now).Image(),
+			other: newTestSnapshot(t, 2, "success", false, now).Image(),
+			equal: false,
+		},
+		{
+			name:  "should differ for another update time",
+			image: newTestSnapshot(t, 1, "success", false, now).Image(),
+			other: newTestSnapshot(t, 1, "success", false, now.Add(time.Microsecond)).Image(),
+			equal: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.image.ETag() == tt.other.ETag(); got != tt.equal {
+				t.Errorf("Image.ETag() = %v and %v, want equal %v", tt.image.ETag(), tt.other.ETag(), tt.equal)
+			}
+		})
+	}
+}
+
+func TestImage_Touch(t *testing.T) {
+	created := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	now := time.Date(2022, 3, 2, 10, 0, 0, 123456789, time.FixedZone("IST", 2*60*60))
+	tests := []struct {
+		name        string
+		createdAt   time.Time
+		wantCreated time.Time
+		wantUpdated time.Time
+	}{
+		{
+			name:        "should keep the creation time",
+			createdAt:   created,
+			wantCreated: created,
+			wantUpdated: time.Date(2022, 3, 2, 8, 0, 0, 123456000, time.UTC),
+		},
+		{
+			name:        "should set the creation time if missing",
+			createdAt:   time.Time{},
+			wantCreated: time.Date(2022, 3, 2, 8, 0, 0, 123456000, time.UTC),
+			wantUpdated: time.Date(2022, 3, 2, 8, 0, 0, 123456000, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			image := newTestSnapshot(t, 1, "success", false, tt.createdAt).Image()
+			image.Touch(now)
+			if got := image.CreatedAt(); got != tt.wantCreated {
+				t.Errorf("Image.Touch() CreatedAt = %v, want %v", got, tt.wantCreated)
+			}
+			if got := image.UpdatedAt(); got != tt.wantUpdated {
+				t.Errorf("Image.Touch() UpdatedAt = %v, want %v", got, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestCheckPrecondition(t *testing.T) {
+	image := newTestSnapshot(t, 2, "success", false, time.Now()).Image()
+	stale := newTestSnapshot(t, 1, "success", false, time.Now()).Image()
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{
+			name:    "should pass without a precondition",
+			ctx:     context.Background(),
+			wantErr: nil,
+		},
+		{
+			name:    "should pass with an empty precondition",
+			ctx:     ContextWithPrecondition(context.Background(), ""),
+			wantErr: nil,
+		},
+		{
+			name:    "should pass with a matching etag",
+			ctx:     ContextWithPrecondition(context.Background(), image.ETag()),
+			wantErr: nil,
+		},
+		{
+			name:    "should pass with a matching etag in a list",
+			ctx:     ContextWithPrecondition(context.Background(), stale.ETag()+", "+image.ETag()),
+			wantErr: nil,
+		},
+		{
+			name:    "should pass with a wildcard",
+			ctx:     ContextWithPrecondition(context.Background(), "*"),
+			wantErr: nil,
+		},
+		{
+			name:    "should fail with a stale etag",
+			ctx:     ContextWithPrecondition(context.Background(), stale.ETag()),
+			wantErr: ErrPreconditionFailed,
+		},
+		{
+			name:    "should fail with a weak etag",
+			ctx:     ContextWithPrecondition(context.Background(), "W/"+image.ETag()),
+			wantErr: ErrPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := CheckPrecondition(tt.ctx, image); err != tt.wantErr {
+				t.Errorf("CheckPrecondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
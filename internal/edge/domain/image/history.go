@@ -0,0 +1,150 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+// MetadataChange records the state an image had before it was changed within a version,
+// e.g. its metadata was edited or its build completed.
+type MetadataChange struct {
+	version   Version
+	image     Image
+	changedAt time.Time
+}
+
+// IsZero returns true if the metadata change is empty.
+func (c MetadataChange) IsZero() bool {
+	return c.version.IsZero() && c.image.IsZero() && c.changedAt.IsZero()
+}
+
+// Version returns the version the change was made in.
+func (c MetadataChange) Version() Version {
+	return c.version
+}
+
+// Image returns the image before the change.
+func (c MetadataChange) Image() Image {
+	return c.image
+}
+
+// ChangedAt returns the time the change was made.
+func (c MetadataChange) ChangedAt() time.Time {
+	return c.changedAt
+}
+
+// MetadataChangeTo returns the change from the image to its updated state, if anything but its timing changed
+// within the same version, e.g. its metadata was edited or its build completed. Changes that create a new version
+// are kept by the version's snapshot instead.
+func (image Image) MetadataChangeTo(updated Image) (MetadataChange, bool) {
+	if image.version != updated.version || sameState(image, updated) {
+		return MetadataChange{}, false
+	}
+	return MetadataChange{
+		version:   image.version,
+		image:     image,
+		changedAt: updated.UpdatedAt(),
+	}, true
+}
+
+// sameState returns true if both images are in the same state, whatever their timing and the order of their tags.
+func sameState(a, b Image) bool {
+	if a.name != b.name || a.description != b.description || !sameTags(a.tags, b.tags) {
+		return false
+	}
+	a.timing, b.timing = common.Time{}, common.Time{}
+	a.tags = b.tags
+	return bytes.Equal(a.MarshalRedis(), b.MarshalRedis())
+}
+
+// sameTags returns true if both lists hold the same tags, in any order.
+func sameTags(a, b common.Tags) bool {
+	aTags, bTags := a.StringArray(), b.StringArray()
+	if len(aTags) != len(bTags) {
+		return false
+	}
+	sort.Strings(aTags)
+	sort.Strings(bTags)
+	for i := range aTags {
+		if aTags[i] != bTags[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AsOf reconstructs the image as it was at the given time, from the snapshots of its versions
+// and the metadata changes made within them. ErrImageNotFound is returned if no version was stored by then.
+func AsOf(at time.Time, snapshots []*Snapshot, changes []MetadataChange) (Image, error) {
+	var current *Snapshot
+	for _, snapshot := range snapshots {
+		if snapshot.CreatedAt().After(at) {
+			continue
+		}
+		if current == nil || snapshot.CreatedAt().After(current.CreatedAt()) ||
+			(snapshot.CreatedAt().Equal(current.CreatedAt()) && snapshot.Version().Uint() > current.Version().Uint()) {
+			current = snapshot
+		}
+	}
+	if current == nil {
+		return Image{}, ErrImageNotFound
+	}
+	// the snapshot holds the latest metadata of its version, undo the edits made after the given time
+	var undo []MetadataChange
+	updatedAt := current.CreatedAt()
+	for _, change := range changes {
+		if change.version != current.Version() {
+			continue
+		}
+		if change.changedAt.After(at) {
+			undo = append(undo, change)
+		} else if change.changedAt.After(updatedAt) {
+			updatedAt = change.changedAt
+		}
+	}
+	sort.Slice(undo, func(i, j int) bool { // newest first
+		return undo[i].changedAt.After(undo[j].changedAt)
+	})
+	image := current.Image()
+	for _, change := range undo {
+		image.setState(change.image)
+	}
+	image.timing = common.NewTime(image.CreatedAt(), updatedAt, time.Time{})
+	return image, nil
+}
+
+// MarshalGorm converts a metadata change of the image with the given uuid to a database metadata change.
+func (c MetadataChange) MarshalGorm(account, uuid string) *models.ImageMetadataChange {
+	model := &models.ImageMetadataChange{
+		Account:   account,
+		ImageUUID: uuid,
+		Version:   c.version.Uint(),
+		Data:      string(c.image.MarshalRedis()),
+	}
+	model.CreatedAt = c.changedAt
+	return model
+}
+
+// UnmarshalMetadataChangeFromDatabase unmarshals a metadata change from the database,
+// data being the JSON encoded image before the change.
+func UnmarshalMetadataChangeFromDatabase(ctx context.Context, version uint, data string,
+	changedAt time.Time) (MetadataChange, error) {
+	validVersion, err := NewVersion(version)
+	if err != nil {
+		return MetadataChange{}, err
+	}
+	before, err := UnmarshalImageFromRedis(ctx, []byte(data))
+	if err != nil {
+		return MetadataChange{}, err
+	}
+	return MetadataChange{
+		version:   validVersion,
+		image:     before,
+		changedAt: changedAt,
+	}, nil
+}
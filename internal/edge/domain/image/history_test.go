@@ -0,0 +1,153 @@
+package image
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+func TestImage_MetadataChangeTo(t *testing.T) {
+	now := time.Now()
+	newName, _ := common.NewName("new-name")
+	before := newTestSnapshot(t, 1, "success", false, now).Image()
+	tests := []struct {
+		name     string
+		updateFn func(image *Image)
+		want     bool
+	}{
+		{
+			name:     "should record a name change",
+			updateFn: func(image *Image) { image.SetNameAndDesc(newName, "") },
+			want:     true,
+		},
+		{
+			name:     "should record a tag change",
+			updateFn: func(image *Image) { image.AddTag(common.NewTag("tag2")) },
+			want:     true,
+		},
+		{
+			name:     "should record a status change",
+			updateFn: func(image *Image) { image.status = Error },
+			want:     true,
+		},
+		{
+			name:     "should not record an unchanged image",
+			updateFn: func(image *Image) { image.SetNameAndDesc(before.Name(), before.Description()) },
+			want:     false,
+		},
+		{
+			name: "should not record a new version",
+			updateFn: func(image *Image) {
+				image.SetNameAndDesc(newName, "")
+				_ = image.Upgrade()
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			after := newTestSnapshot(t, 1, "success", false, now).Image()
+			tt.updateFn(&after)
+			got, ok := before.MetadataChangeTo(after)
+			if ok != tt.want {
+				t.Errorf("Image.MetadataChangeTo() ok = %v, want %v", ok, tt.want)
+				return
+			}
+			if ok && (got.Image().Name() != before.Name() || got.Version() != before.Version()) {
+				t.Errorf("Image.MetadataChangeTo() = %+v, want the metadata before the change", got)
+			}
+		})
+	}
+}
+
+func TestAsOf(t *testing.T) {
+	created := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	built := created.Add(12 * time.Hour)
+	renamed := created.Add(24 * time.Hour)
+	upgraded := created.Add(48 * time.Hour)
+
+	// version 1 was built after half a day, renamed from valid-name to renamed after a day,
+	// then upgraded to version 2
+	building := newTestSnapshot(t, 1, "building", false, created).Image()
+	v1 := newTestSnapshot(t, 1, "success", false, created)
+	renamedName, _ := common.NewName("renamed")
+	v1.image.name = renamedName
+	v2 := newTestSnapshot(t, 2, "success", false, upgraded)
+	build, err := UnmarshalMetadataChangeFromDatabase(context.Background(), 1, string(building.MarshalRedis()), built)
+	if err != nil {
+		t.Fatalf("failed to create metadata change: %s", err)
+	}
+	before := newTestSnapshot(t, 1, "success", false, created).Image()
+	rename, err := UnmarshalMetadataChangeFromDatabase(context.Background(), 1, string(before.MarshalRedis()), renamed)
+	if err != nil {
+		t.Fatalf("failed to create metadata change: %s", err)
+	}
+
+	tests := []struct {
+		name        string
+		at          time.Time
+		wantVersion uint
+		wantName    string
+		wantStatus  Status
+		wantErr     error
+	}{
+		{
+			name:    "should fail before the image was created",
+			at:      created.Add(-time.Hour),
+			wantErr: ErrImageNotFound,
+		},
+		{
+			name:        "should undo the rename and the build",
+			at:          created.Add(time.Hour),
+			wantVersion: 1,
+			wantName:    "valid-name",
+			wantStatus:  Building,
+		},
+		{
+			name:        "should undo the rename",
+			at:          built.Add(time.Hour),
+			wantVersion: 1,
+			wantName:    "valid-name",
+			wantStatus:  Success,
+		},
+		{
+			name:        "should keep the rename",
+			at:          renamed.Add(time.Hour),
+			wantVersion: 1,
+			wantName:    "renamed",
+			wantStatus:  Success,
+		},
+		{
+			name:        "should return the upgraded version",
+			at:          upgraded,
+			wantVersion: 2,
+			wantName:    "valid-name",
+			wantStatus:  Success,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := AsOf(tt.at, []*Snapshot{v2, v1}, []MetadataChange{build, rename})
+			if err != tt.wantErr {
+				t.Errorf("AsOf() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Version().Uint() != tt.wantVersion || got.Name().String() != tt.wantName || got.Status() != tt.wantStatus {
+				t.Errorf("AsOf() = version %v named %v %v, want version %v named %v %v",
+					got.Version().Uint(), got.Name(), got.Status(), tt.wantVersion, tt.wantName, tt.wantStatus)
+			}
+			if got.UpdatedAt().After(tt.at) {
+				t.Errorf("AsOf() updated at %v, after %v", got.UpdatedAt(), tt.at)
+			}
+		})
+	}
+}
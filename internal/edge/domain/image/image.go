@@ -37,11 +37,13 @@ type Image struct {
 	installer  Installer
 	outputType []OutputType
 	tags       common.Tags
+	// lineage of the current version, set when it's derived from another version
+	parent Parent
 }
 
 // NewImage creates a new image.
 func NewImage(uuid, name, description, distribution, status, username, sshKey string,
-	outputType, tags, packages []string, version uint, repos []interface{}) (Image, error) {
+	outputType, tags, packages []string, version uint, repos []string) (Image, error) {
 	validName, err := common.NewName(name)
 	if err != nil {
 		return Image{}, err
@@ -58,11 +60,10 @@ func NewImage(uuid, name, description, distribution, status, username, sshKey st
 	if err != nil {
 		return Image{}, err
 	}
-	validRepos, err := ReposFromArray(repos)
+	validRepos, err := NewRepos(repos...)
 	if err != nil {
 		return Image{}, err
 	}
-	newRepos := NewRepos(validRepos...)
 	image := Image{
 		uuid:         uuid,
 		ctx:          context.Background(),
@@ -75,7 +76,7 @@ func NewImage(uuid, name, description, distribution, status, username, sshKey st
 		tags:         common.NewTags(tags...),
 		user:         validUser,
 		outputType:   NewOutputType(outputType...),
-		repos:        newRepos,
+		repos:        validRepos,
 	}
 	image.WithCancel()
 	return image, nil
@@ -84,7 +85,7 @@ func NewImage(uuid, name, description, distribution, status, username, sshKey st
 // NewImageWithContext creates a new image.
 func NewImageWithContext(ctx context.Context, uuid, name, description, distribution,
 	status, username, sshKey string, outputType, tags, packages []string,
-	version uint, repos []interface{}) (image Image, err error) {
+	version uint, repos []string) (image Image, err error) {
 	image, err = NewImage(uuid, name, description, distribution, status, username,
 		sshKey, outputType, tags, packages, version, repos)
 	if ctx == nil {
@@ -140,7 +141,7 @@ func (image Image) Packages() Packages {
 	return image.packages
 }
 
-// Repos is a getter for the repos of an image.
+// Repos is a getter for the repositories an image references.
 func (image Image) Repos() Repos {
 	return image.repos
 }
@@ -317,7 +318,7 @@ func (image *Image) UnmarshalJSON(data []byte) error {
 // UnmarshalImageFromDatabase unmarshals the image from the database.
 func UnmarshalImageFromDatabase(ctx context.Context, uuid, name, description, distribution,
 	status, username, sshKey string,
-	outputType, tags, packages []string, version uint, repos []interface{},
+	outputType, tags, packages []string, version uint, repos []string,
 	createdAt, updatedAt, deletedAt time.Time) (image Image, err error) {
 	image, err = NewImageWithContext(ctx, uuid, name, description, distribution,
 		status, username, sshKey, outputType, tags, packages, version, repos)
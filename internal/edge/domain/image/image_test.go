@@ -17,10 +17,7 @@ func TestNewImage(t *testing.T) {
 	validSSHKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
 	validName, _ := common.NewName("valid-name")
 	validVersion, _ := NewVersion(1)
-	validRepos := NewRepos(
-		NewRepo("test", "http://test.com"),
-		NewRepo("test2", "http://test2.com"),
-	)
+	validRepos, _ := NewRepos(testRepoUUID, anotherTestRepoUUID)
 	type args struct {
 		uuid         string
 		name         string
@@ -33,7 +30,7 @@ func TestNewImage(t *testing.T) {
 		tags         []string
 		packages     []string
 		version      uint
-		repos        []interface{}
+		repos        []string
 	}
 	tests := []struct {
 		name    string
@@ -53,16 +50,7 @@ func TestNewImage(t *testing.T) {
 				tags:       []string{"test"},
 				packages:   []string{"test"},
 				version:    1,
-				repos: []interface{}{
-					map[string]interface{}{
-						"name": "test",
-						"url":  "http://test.com",
-					},
-					map[string]interface{}{
-						"name": "test2",
-						"url":  "http://test2.com",
-					},
-				},
+				repos:      []string{testRepoUUID, anotherTestRepoUUID},
 			},
 			want: Image{
 				uuid:   "00000000-0000-0000-0000-000000000000",
@@ -96,16 +84,7 @@ func TestNewImage(t *testing.T) {
 				tags:       []string{"test"},
 				packages:   []string{"test"},
 				version:    1,
-				repos: []interface{}{
-					map[string]interface{}{
-						"name": "test",
-						"url":  "http://test.com",
-					},
-					map[string]interface{}{
-						"name": "test2",
-						"url":  "http://test2.com",
-					},
-				},
+				repos:      []string{testRepoUUID, anotherTestRepoUUID},
 			},
 			want: Image{
 				uuid:   "00000000-0000-0000-0000-000000000000",
@@ -139,16 +118,7 @@ func TestNewImage(t *testing.T) {
 				tags:       []string{"test"},
 				packages:   []string{"test"},
 				version:    1,
-				repos: []interface{}{
-					map[string]interface{}{
-						"name": "test",
-						"url":  "http://test.com",
-					},
-					map[string]interface{}{
-						"name": "test2",
-						"url":  "http://test2.com",
-					},
-				},
+				repos:      []string{testRepoUUID, anotherTestRepoUUID},
 			},
 			want: Image{
 				uuid:   "00000000-0000-0000-0000-000000000000",
@@ -182,16 +152,7 @@ func TestNewImage(t *testing.T) {
 				tags:       []string{"test"},
 				packages:   []string{"test"},
 				version:    0,
-				repos: []interface{}{
-					map[string]interface{}{
-						"name": "test",
-						"url":  "http://test.com",
-					},
-					map[string]interface{}{
-						"name": "test2",
-						"url":  "http://test2.com",
-					},
-				},
+				repos:      []string{testRepoUUID, anotherTestRepoUUID},
 			},
 			want: Image{
 				uuid:   "00000000-0000-0000-0000-000000000000",
@@ -225,16 +186,7 @@ func TestNewImage(t *testing.T) {
 				tags:       []string{"test"},
 				packages:   []string{"test"},
 				version:    1,
-				repos: []interface{}{
-					map[string]interface{}{
-						"name": "test",
-						"url":  "http://test.com",
-					},
-					map[string]interface{}{
-						"name": "test2",
-						"url":  "http://test2.com",
-					},
-				},
+				repos:      []string{testRepoUUID, anotherTestRepoUUID},
 			},
 			want: Image{
 				uuid:   "00000000-0000-0000-0000-000000000000",
@@ -268,7 +220,7 @@ func TestNewImage(t *testing.T) {
 				tags:       []string{"test"},
 				packages:   []string{"test"},
 				version:    1,
-				repos:      []interface{}{`"`},
+				repos:      []string{"test"},
 			},
 			want: Image{
 				uuid:   "00000000-0000-0000-0000-000000000000",
@@ -314,10 +266,7 @@ func TestNewImageWithContext(t *testing.T) {
 	validSSHKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
 	validName, _ := common.NewName("valid-name")
 	validVersion, _ := NewVersion(1)
-	validRepos := NewRepos(
-		NewRepo("test", "http://test.com"),
-		NewRepo("test2", "http://test2.com"),
-	)
+	validRepos, _ := NewRepos(testRepoUUID, anotherTestRepoUUID)
 	type args struct {
 		ctx          context.Context
 		uuid         string
@@ -331,7 +280,7 @@ func TestNewImageWithContext(t *testing.T) {
 		tags         []string
 		packages     []string
 		version      uint
-		repos        []interface{}
+		repos        []string
 	}
 	tests := []struct {
 		name      string
@@ -353,16 +302,7 @@ func TestNewImageWithContext(t *testing.T) {
 				tags:         []string{"test"},
 				packages:     []string{"test"},
 				version:      1,
-				repos: []interface{}{
-					map[string]interface{}{
-						"name": "test",
-						"url":  "http://test.com",
-					},
-					map[string]interface{}{
-						"name": "test2",
-						"url":  "http://test2.com",
-					},
-				},
+				repos:        []string{testRepoUUID, anotherTestRepoUUID},
 			},
 			wantImage: Image{
 				ctx:    ctx,
@@ -399,16 +339,7 @@ func TestNewImageWithContext(t *testing.T) {
 				tags:       []string{"test"},
 				packages:   []string{"test"},
 				version:    1,
-				repos: []interface{}{
-					map[string]interface{}{
-						"name": "test",
-						"url":  "http://test.com",
-					},
-					map[string]interface{}{
-						"name": "test2",
-						"url":  "http://test2.com",
-					},
-				},
+				repos:      []string{testRepoUUID, anotherTestRepoUUID},
 			},
 			wantImage: Image{},
 			wantErr:   true,
@@ -512,10 +443,7 @@ func TestImage_Account(t *testing.T) {
 	validSSHKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
 	validName, _ := common.NewName("valid-name")
 	validVersion, _ := NewVersion(1)
-	validRepos := NewRepos(
-		NewRepo("test", "http://test.com"),
-		NewRepo("test2", "http://test2.com"),
-	)
+	validRepos, _ := NewRepos(testRepoUUID, anotherTestRepoUUID)
 	config.Init()
 
 	validReq, _ := http.NewRequest("GET", "/", nil)
@@ -991,9 +919,9 @@ func TestImage_Repos(t *testing.T) {
 		{
 			name: "should succeed",
 			fields: fields{
-				repos: Repos{repos: []*Repo{}},
+				repos: Repos{uuids: []string{}},
 			},
-			want: Repos{repos: []*Repo{}},
+			want: Repos{uuids: []string{}},
 		},
 	}
 	for _, tt := range tests {
@@ -1933,10 +1861,7 @@ func TestImage_MarshalJSON(t *testing.T) {
 	validSSHKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
 	validName, _ := common.NewName("valid-name")
 	validVersion, _ := NewVersion(1)
-	validRepos := NewRepos(
-		NewRepo("test", "http://test.com"),
-		NewRepo("test2", "http://test2.com"),
-	)
+	validRepos, _ := NewRepos(testRepoUUID, anotherTestRepoUUID)
 	now := time.Now()
 	type fields struct {
 		ctx          context.Context
@@ -1983,7 +1908,7 @@ func TestImage_MarshalJSON(t *testing.T) {
 				outputType: []OutputType{},
 				tags:       common.Tags{},
 			},
-			want:    []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":[{"name":"test","url":"http://test.com"},{"name":"test2","url":"http://test2.com"}],"installer":{},"tags":[],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
+			want:    []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":["` + testRepoUUID + `","` + anotherTestRepoUUID + `"],"installer":{},"tags":[],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
 			wantErr: false,
 		},
 	}
@@ -2052,7 +1977,7 @@ func TestImage_UnmarshalJSON(t *testing.T) {
 			name:   "should succeed",
 			fields: fields{},
 			args: args{
-				data: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":[{"name":"test","url":"http://test.com"},{"name":"test2","url":"http://test2.com"}],"installer":{},"tags":[],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
+				data: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":["` + testRepoUUID + `","` + anotherTestRepoUUID + `"],"installer":{},"tags":[],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
 			},
 			wantErr: false,
 		},
@@ -2068,7 +1993,7 @@ func TestImage_UnmarshalJSON(t *testing.T) {
 			name:   "should fail, invalid created_at",
 			fields: fields{},
 			args: args{
-				data: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":[{"name":"test","url":"http://test.com"},{"name":"test2","url":"http://test2.com"}],"installer":{},"tags":[],"created_at":"","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
+				data: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":["` + testRepoUUID + `","` + anotherTestRepoUUID + `"],"installer":{},"tags":[],"created_at":"","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
 			},
 			wantErr: true,
 		},
@@ -2076,7 +2001,7 @@ func TestImage_UnmarshalJSON(t *testing.T) {
 			name:   "should fail, invalid updated_at",
 			fields: fields{},
 			args: args{
-				data: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":[{"name":"test","url":"http://test.com"},{"name":"test2","url":"http://test2.com"}],"installer":{},"tags":[],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
+				data: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":["` + testRepoUUID + `","` + anotherTestRepoUUID + `"],"installer":{},"tags":[],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
 			},
 			wantErr: true,
 		},
@@ -2084,7 +2009,7 @@ func TestImage_UnmarshalJSON(t *testing.T) {
 			name:   "should fail, invalid deleted_at",
 			fields: fields{},
 			args: args{
-				data: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":[{"name":"test","url":"http://test.com"},{"name":"test2","url":"http://test2.com"}],"installer":{},"tags":[],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":""}`),
+				data: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":["` + testRepoUUID + `","` + anotherTestRepoUUID + `"],"installer":{},"tags":[],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":""}`),
 			},
 			wantErr: true,
 		},
@@ -2122,10 +2047,7 @@ func TestUnmarshalImageFromDatabase(t *testing.T) {
 	validSSHKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
 	validName, _ := common.NewName("valid-name")
 	validVersion, _ := NewVersion(1)
-	validRepos := NewRepos(
-		NewRepo("test", "http://test.com"),
-		NewRepo("test2", "http://test2.com"),
-	)
+	validRepos, _ := NewRepos(testRepoUUID, anotherTestRepoUUID)
 	now := time.Now()
 	type args struct {
 		ctx          context.Context
@@ -2140,7 +2062,7 @@ func TestUnmarshalImageFromDatabase(t *testing.T) {
 		tags         []string
 		packages     []string
 		version      uint
-		repos        []interface{}
+		repos        []string
 		createdAt    time.Time
 		updatedAt    time.Time
 		deletedAt    time.Time
@@ -2166,19 +2088,10 @@ func TestUnmarshalImageFromDatabase(t *testing.T) {
 				tags:         []string{"tag1", "tag2"},
 				packages:     []string{"package1", "package2"},
 				version:      1,
-				repos: []interface{}{
-					map[string]interface{}{
-						"name": "test",
-						"url":  "http://test.com",
-					},
-					map[string]interface{}{
-						"name": "test2",
-						"url":  "http://test2.com",
-					},
-				},
-				createdAt: now,
-				updatedAt: now,
-				deletedAt: now,
+				repos:        []string{testRepoUUID, anotherTestRepoUUID},
+				createdAt:    now,
+				updatedAt:    now,
+				deletedAt:    now,
 			},
 			wantImage: Image{
 				ctx:         context.Background(),
@@ -0,0 +1,313 @@
+// Package imagetest provides a conformance suite for the implementations of the image.Repository interface.
+package imagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/google/uuid"
+	"github.com/redhatinsights/edge-api/config"
+)
+
+const sshKey = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
+
+// TestRepository runs the conformance suite against the repositories newRepository returns,
+// a new and empty one for each test. Authentication is enabled while it runs, so images are scoped
+// by the account of their context:
+//   - created, updated and deleted images read back with every field of the aggregate
+//   - the images of an account are never seen nor changed from another account
+//   - missing images are reported with image.ErrImageNotFound
+//   - concurrent updates apply one after the other, or fail with image.ErrPreconditionFailed, none is lost
+func TestRepository(t *testing.T, newRepository func(t *testing.T) image.Repository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repository image.Repository)
+	}{
+		{name: "round trip", test: testRoundTrip},
+		{name: "account isolation", test: testAccountIsolation},
+		{name: "not found", test: testNotFound},
+		{name: "concurrent updates", test: testConcurrentUpdates},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newRepository(t)
+			enableAuth(t) // once the repository is set up, it may initialize the configuration
+			tt.test(t, repository)
+		})
+	}
+}
+
+// enableAuth enables authentication until the test is done.
+func enableAuth(t *testing.T) {
+	if config.Get() == nil {
+		config.Init()
+	}
+	auth := config.Get().Auth
+	config.Get().Auth = true
+	t.Cleanup(func() { config.Get().Auth = auth })
+}
+
+// testRoundTrip checks every field of the aggregate is stored, updated and deleted.
+func testRoundTrip(t *testing.T, repository image.Repository) {
+	ctx := accountContext(t, "0000001")
+	created := newImage(t, ctx, "round-trip")
+	if err := repository.CreateImage(ctx, created); err != nil {
+		t.Fatalf("CreateImage() error = %v", err)
+	}
+	got, err := repository.GetImage(ctx, created.UUID())
+	if err != nil {
+		t.Fatalf("GetImage() error = %v", err)
+	}
+	checkImage(t, "GetImage()", got, created)
+
+	page, total, err := repository.GetImages(ctx, newSpec(t))
+	if err != nil {
+		t.Fatalf("GetImages() error = %v", err)
+	}
+	if total != 1 || len(page) != 1 {
+		t.Fatalf("GetImages() = %d images, total %d, want 1, total 1", len(page), total)
+	}
+	checkImage(t, "GetImages()", page[0], created)
+
+	name, _ := common.NewName("round-trip-updated")
+	var updated *image.Image
+	err = repository.UpdateImage(ctx, created.UUID(), func(current *image.Image) (*image.Image, error) {
+		current.SetNameAndDesc(name, "updated description")
+		current.AddTag(common.NewTag("updated-tag"))
+		current.RemoveTag(common.NewTag("tag1"))
+		current.AddPackage(image.NewPackage("git"))
+		current.RemovePackage(image.NewPackage("vim"))
+		if err := current.Upgrade(); err != nil {
+			return nil, err
+		}
+		updated = current
+		return current, nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateImage() error = %v", err)
+	}
+	if got, err = repository.GetImage(ctx, created.UUID()); err != nil {
+		t.Fatalf("GetImage() error = %v", err)
+	}
+	checkImage(t, "GetImage() after UpdateImage()", got, updated)
+	if !got.CreatedAt().Equal(created.CreatedAt()) {
+		t.Errorf("GetImage() after UpdateImage() created at = %v, want %v", got.CreatedAt(), created.CreatedAt())
+	}
+	if got.UpdatedAt().Before(created.UpdatedAt()) {
+		t.Errorf("GetImage() after UpdateImage() updated at = %v, want after %v", got.UpdatedAt(), created.UpdatedAt())
+	}
+
+	if err := repository.DeleteImage(ctx, created.UUID()); err != nil {
+		t.Fatalf("DeleteImage() error = %v", err)
+	}
+	if _, err := repository.GetImage(ctx, created.UUID()); err != image.ErrImageNotFound {
+		t.Errorf("GetImage() after DeleteImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	if page, total, err := repository.GetImages(ctx, newSpec(t)); err != nil || total != 0 || len(page) != 0 {
+		t.Errorf("GetImages() after DeleteImage() = %d images, total %d, error %v, want none", len(page), total, err)
+	}
+}
+
+// testAccountIsolation checks the images of an account are never seen nor changed from another account.
+func testAccountIsolation(t *testing.T, repository image.Repository) {
+	ctx, otherCtx := accountContext(t, "0000001"), accountContext(t, "0000002")
+	created := newImage(t, ctx, "isolated")
+	if err := repository.CreateImage(ctx, created); err != nil {
+		t.Fatalf("CreateImage() error = %v", err)
+	}
+	other := newImage(t, otherCtx, "other")
+	if err := repository.CreateImage(otherCtx, other); err != nil {
+		t.Fatalf("CreateImage() error = %v", err)
+	}
+
+	if _, err := repository.GetImage(otherCtx, created.UUID()); err != image.ErrImageNotFound {
+		t.Errorf("GetImage() from another account error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	err := repository.UpdateImage(otherCtx, created.UUID(), func(current *image.Image) (*image.Image, error) {
+		return current, current.Upgrade()
+	})
+	if err != image.ErrImageNotFound {
+		t.Errorf("UpdateImage() from another account error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	if err := repository.DeleteImage(otherCtx, created.UUID()); err != image.ErrImageNotFound {
+		t.Errorf("DeleteImage() from another account error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	page, total, err := repository.GetImages(otherCtx, newSpec(t))
+	if err != nil {
+		t.Fatalf("GetImages() error = %v", err)
+	}
+	if total != 1 || len(page) != 1 || page[0].UUID() != other.UUID() {
+		t.Errorf("GetImages() = %d images, total %d, want only the image of the account", len(page), total)
+	}
+
+	got, err := repository.GetImage(ctx, created.UUID())
+	if err != nil {
+		t.Fatalf("GetImage() error = %v", err)
+	}
+	checkImage(t, "GetImage() of the account", got, created)
+}
+
+// testNotFound checks missing images are reported with image.ErrImageNotFound.
+func testNotFound(t *testing.T, repository image.Repository) {
+	ctx := accountContext(t, "0000001")
+	missing := uuid.NewString()
+	if _, err := repository.GetImage(ctx, missing); err != image.ErrImageNotFound {
+		t.Errorf("GetImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	err := repository.UpdateImage(ctx, missing, func(current *image.Image) (*image.Image, error) {
+		t.Errorf("UpdateImage() called the update of a missing image")
+		return current, nil
+	})
+	if err != image.ErrImageNotFound {
+		t.Errorf("UpdateImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	if err := repository.DeleteImage(ctx, missing); err != image.ErrImageNotFound {
+		t.Errorf("DeleteImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+	if err := repository.PurgeImage(ctx, missing); err != image.ErrImageNotFound {
+		t.Errorf("PurgeImage() error = %v, wantErr %v", err, image.ErrImageNotFound)
+	}
+}
+
+// testConcurrentUpdates checks concurrent updates don't overwrite each other, each adds its own tag.
+// Pessimistic implementations apply them all, optimistic ones may reject some with image.ErrPreconditionFailed.
+func testConcurrentUpdates(t *testing.T, repository image.Repository) {
+	ctx := accountContext(t, "0000001")
+	created := newImage(t, ctx, "concurrent")
+	if err := repository.CreateImage(ctx, created); err != nil {
+		t.Fatalf("CreateImage() error = %v", err)
+	}
+	const updates = 5
+	errs := make([]error, updates)
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repository.UpdateImage(ctx, created.UUID(), func(current *image.Image) (*image.Image, error) {
+				current.AddTag(common.NewTag(fmt.Sprintf("concurrent-%d", i)))
+				return current, nil
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	got, err := repository.GetImage(ctx, created.UUID())
+	if err != nil {
+		t.Fatalf("GetImage() error = %v", err)
+	}
+	tags := make(map[string]bool)
+	for _, tag := range got.Tags().StringArray() {
+		tags[tag] = true
+	}
+	var applied int
+	for i, err := range errs {
+		tag := fmt.Sprintf("concurrent-%d", i)
+		switch {
+		case err == nil:
+			applied++
+			if !tags[tag] {
+				t.Errorf("UpdateImage() %d succeeded, but its tag %s was lost", i, tag)
+			}
+		case errors.Is(err, image.ErrPreconditionFailed):
+			if tags[tag] {
+				t.Errorf("UpdateImage() %d failed, but its tag %s was stored", i, tag)
+			}
+		default:
+			t.Errorf("UpdateImage() %d error = %v, wantErr nil or %v", i, err, image.ErrPreconditionFailed)
+		}
+	}
+	if applied == 0 {
+		t.Errorf("UpdateImage() applied none of the %d concurrent updates", updates)
+	}
+}
+
+// accountContext returns a context carrying the account with the given id.
+func accountContext(t *testing.T, id string) context.Context {
+	t.Helper()
+	account, err := common.NewAccount(id)
+	if err != nil {
+		t.Fatalf("common.NewAccount() error = %v", err)
+	}
+	return common.ContextWithAccount(context.Background(), account)
+}
+
+// newImage returns a new image of the account of the context, with every field of the aggregate set.
+func newImage(t *testing.T, ctx context.Context, name string) *image.Image {
+	t.Helper()
+	created, err := image.NewImageWithContext(ctx, uuid.NewString(), name, "description of "+name, "rhel-85",
+		"success", "redhat-user", sshKey, []string{"rhel-edge-commit", "rhel-edge-installer"},
+		[]string{"tag1", "tag2"}, []string{"vim", "emacs"}, 1,
+		[]string{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6ba7b811-9dad-11d1-80b4-00c04fd430c8"})
+	if err != nil {
+		t.Fatalf("image.NewImageWithContext() error = %v", err)
+	}
+	return &created
+}
+
+// newSpec returns the spec listing every image.
+func newSpec(t *testing.T) image.Spec {
+	t.Helper()
+	spec, err := image.NewSpec("", "", "", 0, 0)
+	if err != nil {
+		t.Fatalf("image.NewSpec() error = %v", err)
+	}
+	return spec
+}
+
+// checkImage reports the fields of the aggregate got doesn't read back as want has them.
+// Output types, tags and packages are sets, their order isn't checked.
+func checkImage(t *testing.T, read string, got, want *image.Image) {
+	t.Helper()
+	fields := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"uuid", got.UUID(), want.UUID()},
+		{"name", got.Name().String(), want.Name().String()},
+		{"description", got.Description(), want.Description()},
+		{"distribution", got.Distribution().String(), want.Distribution().String()},
+		{"status", got.Status().String(), want.Status().String()},
+		{"version", got.Version().Uint(), want.Version().Uint()},
+		{"user", got.User(), want.User()},
+		{"installer", got.Installer(), want.Installer()},
+		{"output types", outputTypes(got), outputTypes(want)},
+		{"tags", sorted(got.Tags().StringArray()), sorted(want.Tags().StringArray())},
+		{"packages", sorted(got.Packages().StringArray()), sorted(want.Packages().StringArray())},
+		{"repos", fmt.Sprint(got.Repos().UUIDs()), fmt.Sprint(want.Repos().UUIDs())},
+	}
+	for _, field := range fields {
+		if field.got != field.want {
+			t.Errorf("%s %s = %v, want %v", read, field.name, field.got, field.want)
+		}
+	}
+	if !got.CreatedAt().Equal(want.CreatedAt()) {
+		t.Errorf("%s created at = %v, want %v", read, got.CreatedAt(), want.CreatedAt())
+	}
+	if !got.UpdatedAt().Equal(want.UpdatedAt()) {
+		t.Errorf("%s updated at = %v, want %v", read, got.UpdatedAt(), want.UpdatedAt())
+	}
+}
+
+// outputTypes returns the sorted output types of the image, as a string.
+func outputTypes(img *image.Image) string {
+	types := make([]string, len(img.OutputTypes()))
+	for i, outputType := range img.OutputTypes() {
+		types[i] = outputType.String()
+	}
+	return sorted(types)
+}
+
+// sorted returns the sorted values, as a string.
+func sorted(values []string) string {
+	values = append([]string(nil), values...)
+	sort.Strings(values)
+	return fmt.Sprint(values)
+}
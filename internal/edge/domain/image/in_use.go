@@ -0,0 +1,47 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrImageInUse is returned when an image is deleted while devices still use it.
+var ErrImageInUse = errors.New("image in use by devices")
+
+// InUseError is returned when an image is deleted while devices still use it, along with how many do.
+// It matches ErrImageInUse.
+type InUseError struct {
+	Devices int
+}
+
+// Error returns the message of the error.
+func (e InUseError) Error() string {
+	return fmt.Sprintf("image in use by %d devices, force the deletion to delete it anyway", e.Devices)
+}
+
+// Is returns true for ErrImageInUse.
+func (e InUseError) Is(target error) bool {
+	return target == ErrImageInUse
+}
+
+// inUseCheckKey is the context key of the check run before deleting an image or its versions.
+type inUseCheckKey struct{}
+
+// InUseCheck returns an InUseError if devices use the given versions of an image, any version if none is given.
+type InUseCheck func(ctx context.Context, versions ...Version) error
+
+// ContextWithInUseCheck returns a copy of ctx that requires the image or versions deleted with it to pass the check.
+// Repositories run the check once the image is locked, so no device or rollout is assigned to it meanwhile.
+func ContextWithInUseCheck(ctx context.Context, check InUseCheck) context.Context {
+	return context.WithValue(ctx, inUseCheckKey{}, check)
+}
+
+// CheckNotInUse runs the check carried by ctx on the given versions of the image, if any.
+func CheckNotInUse(ctx context.Context, versions ...Version) error {
+	check, ok := ctx.Value(inUseCheckKey{}).(InUseCheck)
+	if !ok || check == nil {
+		return nil
+	}
+	return check(ctx, versions...)
+}
@@ -0,0 +1,238 @@
+package image
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+// Define the available relations between an image version and its parent.
+var (
+	Cloned   = Relation{"cloned"}
+	Upgraded = Relation{"upgraded"}
+	Restored = Relation{"restored"}
+)
+
+// All available relations.
+var availableRelations = []Relation{
+	Cloned,
+	Upgraded,
+	Restored,
+}
+
+// Lineage errors
+var (
+	ErrInvalidRelation = errors.New("invalid relation")
+	ErrInvalidParent   = errors.New("invalid parent")
+)
+
+// Relation describes how an image version was derived from its parent.
+type Relation struct {
+	kind string
+}
+
+// NewRelationFromString creates a new relation from a string.
+func NewRelationFromString(kind string) (Relation, error) {
+	for _, relation := range availableRelations {
+		if relation.kind == kind {
+			return relation, nil
+		}
+	}
+	return Relation{}, ErrInvalidRelation
+}
+
+// IsZero returns true if the relation is empty.
+func (r Relation) IsZero() bool {
+	return r == Relation{}
+}
+
+// String returns the string representation of a relation.
+func (r Relation) String() string {
+	return r.kind
+}
+
+// Parent is the image version another image version was derived from.
+type Parent struct {
+	uuid     string
+	version  Version
+	relation Relation
+}
+
+// NewParent creates a new parent.
+func NewParent(uuid string, version uint, relation string) (Parent, error) {
+	if uuid == "" {
+		return Parent{}, ErrInvalidParent
+	}
+	validVersion, err := NewVersion(version)
+	if err != nil {
+		return Parent{}, err
+	}
+	validRelation, err := NewRelationFromString(relation)
+	if err != nil {
+		return Parent{}, err
+	}
+	return Parent{uuid: uuid, version: validVersion, relation: validRelation}, nil
+}
+
+// IsZero returns true if there is no parent.
+func (p Parent) IsZero() bool {
+	return p == Parent{}
+}
+
+// UUID returns the uuid of the parent image.
+func (p Parent) UUID() string {
+	return p.uuid
+}
+
+// Version returns the version of the parent image.
+func (p Parent) Version() Version {
+	return p.version
+}
+
+// Relation returns how the child was derived from the parent.
+func (p Parent) Relation() Relation {
+	return p.relation
+}
+
+// UnmarshalParentFromDatabase unmarshals a parent from the database, an empty uuid means there is no parent.
+func UnmarshalParentFromDatabase(uuid string, version uint, relation string) (Parent, error) {
+	if uuid == "" {
+		return Parent{}, nil
+	}
+	return NewParent(uuid, version, relation)
+}
+
+// Parent returns the image version the current version was derived from, if it was derived in this change.
+func (image Image) Parent() Parent {
+	return image.parent
+}
+
+// Clone returns a new image with the given uuid and name, built from the current version of the image.
+func (image Image) Clone(ctx context.Context, uuid string, name common.Name) (Image, error) {
+	if ctx == nil {
+		return Image{}, ErrEmptyContext
+	}
+	if uuid == "" || uuid == image.uuid {
+		return Image{}, ErrInvalidParent
+	}
+	clone := image
+	clone.uuid = uuid
+	if !name.IsZero() {
+		clone.name = name
+	}
+	clone.status = Building
+	clone.version = Version{1}
+	clone.timing = common.Time{}
+	clone.parent = Parent{uuid: image.uuid, version: image.version, relation: Cloned}
+	clone.ctx = ctx
+	clone.WithCancel()
+	return clone, nil
+}
+
+// RestoreVersion brings back the content of a stored version of the image, as a new version.
+func (image *Image) RestoreVersion(snapshot Snapshot) error {
+	if image.status.IsBuilding() {
+		return ErrAlreadyBuilding
+	}
+	if snapshot.UUID() != image.uuid {
+		return ErrInvalidParent
+	}
+	if snapshot.Version() == image.version {
+		return ErrInvalidVersion
+	}
+	image.setState(snapshot.Image())
+	image.parent = Parent{uuid: image.uuid, version: snapshot.Version(), relation: Restored}
+	image.version.Update()
+	return nil
+}
+
+// setState sets the content of the image to the one of the other image, keeping its identity,
+// version, lineage and timing.
+func (image *Image) setState(other Image) {
+	image.name = other.name
+	image.description = other.description
+	image.status = other.status
+	image.distribution = other.distribution
+	image.user = other.user
+	image.packages = other.packages
+	image.repos = other.repos
+	image.installer = other.installer
+	image.outputType = other.outputType
+	image.tags = other.tags
+}
+
+// Lineage is the ancestry of an image version, and the tree of images derived from it.
+type Lineage struct {
+	uuid        string
+	version     Version
+	ancestors   []Parent
+	descendants []Descendant
+}
+
+// NewLineage creates a new lineage, ancestors are ordered from the nearest to the origin.
+func NewLineage(uuid string, version Version, ancestors []Parent, descendants []Descendant) Lineage {
+	return Lineage{uuid: uuid, version: version, ancestors: ancestors, descendants: descendants}
+}
+
+// UUID returns the uuid of the image the lineage belongs to.
+func (l Lineage) UUID() string {
+	return l.uuid
+}
+
+// Version returns the version of the image the lineage belongs to.
+func (l Lineage) Version() Version {
+	return l.version
+}
+
+// Ancestors returns the chain of parents, from the nearest to the origin.
+func (l Lineage) Ancestors() []Parent {
+	return l.ancestors
+}
+
+// Descendants returns the images derived from the image.
+func (l Lineage) Descendants() []Descendant {
+	return l.descendants
+}
+
+// Origin returns the version the image originates from, the image itself if it has no ancestors.
+func (l Lineage) Origin() (string, Version) {
+	if len(l.ancestors) == 0 {
+		return l.uuid, l.version
+	}
+	origin := l.ancestors[len(l.ancestors)-1]
+	return origin.uuid, origin.version
+}
+
+// Descendant is an image derived from another one, with the images derived from it in turn.
+type Descendant struct {
+	uuid        string
+	version     Version
+	parent      Parent
+	descendants []Descendant
+}
+
+// NewDescendant creates a new descendant from the snapshot of the derived version.
+func NewDescendant(snapshot Snapshot, descendants []Descendant) Descendant {
+	return Descendant{uuid: snapshot.UUID(), version: snapshot.Version(), parent: snapshot.Parent(), descendants: descendants}
+}
+
+// UUID returns the uuid of the derived image.
+func (d Descendant) UUID() string {
+	return d.uuid
+}
+
+// Version returns the version of the derived image the relation starts at.
+func (d Descendant) Version() Version {
+	return d.version
+}
+
+// Parent returns the version the image was derived from.
+func (d Descendant) Parent() Parent {
+	return d.parent
+}
+
+// Descendants returns the images derived from the derived image.
+func (d Descendant) Descendants() []Descendant {
+	return d.descendants
+}
@@ -0,0 +1,261 @@
+package image
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+func TestNewRelationFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		want    Relation
+		wantErr bool
+	}{
+		{
+			name:    "should return cloned",
+			kind:    "cloned",
+			want:    Cloned,
+			wantErr: false,
+		},
+		{
+			name:    "should return restored",
+			kind:    "restored",
+			want:    Restored,
+			wantErr: false,
+		},
+		{
+			name:    "should fail, unknown relation",
+			kind:    "forked",
+			want:    Relation{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewRelationFromString(tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRelationFromString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewRelationFromString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalParentFromDatabase(t *testing.T) {
+	type args struct {
+		uuid     string
+		version  uint
+		relation string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Parent
+		wantErr bool
+	}{
+		{
+			name:    "should return a parent",
+			args:    args{uuid: "parent-uuid", version: 2, relation: "upgraded"},
+			want:    Parent{uuid: "parent-uuid", version: Version{2}, relation: Upgraded},
+			wantErr: false,
+		},
+		{
+			name:    "should return no parent",
+			args:    args{},
+			want:    Parent{},
+			wantErr: false,
+		},
+		{
+			name:    "should fail, invalid version",
+			args:    args{uuid: "parent-uuid", version: 0, relation: "upgraded"},
+			want:    Parent{},
+			wantErr: true,
+		},
+		{
+			name:    "should fail, invalid relation",
+			args:    args{uuid: "parent-uuid", version: 1, relation: "forked"},
+			want:    Parent{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := UnmarshalParentFromDatabase(tt.args.uuid, tt.args.version, tt.args.relation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalParentFromDatabase() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UnmarshalParentFromDatabase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImage_Clone(t *testing.T) {
+	source := newTestSnapshot(t, 3, "success", false, time.Now()).Image()
+	newName, _ := common.NewName("clone-name")
+	type args struct {
+		ctx  context.Context
+		uuid string
+		name common.Name
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantName common.Name
+		wantErr  error
+	}{
+		{
+			name:     "should clone with a new name",
+			args:     args{ctx: context.Background(), uuid: "clone-uuid", name: newName},
+			wantName: newName,
+			wantErr:  nil,
+		},
+		{
+			name:     "should clone keeping the name",
+			args:     args{ctx: context.Background(), uuid: "clone-uuid"},
+			wantName: source.Name(),
+			wantErr:  nil,
+		},
+		{
+			name:    "should fail, same uuid",
+			args:    args{ctx: context.Background(), uuid: source.UUID()},
+			wantErr: ErrInvalidParent,
+		},
+		{
+			name:    "should fail, empty context",
+			args:    args{ctx: nil, uuid: "clone-uuid"},
+			wantErr: ErrEmptyContext,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := source.Clone(tt.args.ctx, tt.args.uuid, tt.args.name)
+			if err != tt.wantErr {
+				t.Errorf("Image.Clone() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			wantParent := Parent{uuid: source.UUID(), version: Version{3}, relation: Cloned}
+			if got.UUID() != tt.args.uuid || got.Name() != tt.wantName || got.Version() != (Version{1}) ||
+				!got.Status().IsBuilding() || got.Parent() != wantParent || !got.CreatedAt().IsZero() {
+				t.Errorf("Image.Clone() = %+v, want uuid %s, name %s and parent %+v", got, tt.args.uuid, tt.wantName, wantParent)
+			}
+		})
+	}
+}
+
+func TestImage_RestoreVersion(t *testing.T) {
+	old := newTestSnapshot(t, 1, "success", false, time.Now())
+	tests := []struct {
+		name     string
+		image    Image
+		snapshot Snapshot
+		wantErr  error
+	}{
+		{
+			name:     "should restore an older version",
+			image:    newTestSnapshot(t, 3, "success", false, time.Now()).Image(),
+			snapshot: *old,
+			wantErr:  nil,
+		},
+		{
+			name:     "should fail, image is building",
+			image:    newTestSnapshot(t, 3, "building", false, time.Now()).Image(),
+			snapshot: *old,
+			wantErr:  ErrAlreadyBuilding,
+		},
+		{
+			name:     "should fail, current version",
+			image:    newTestSnapshot(t, 1, "success", false, time.Now()).Image(),
+			snapshot: *old,
+			wantErr:  ErrInvalidVersion,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.image.RestoreVersion(tt.snapshot); err != tt.wantErr {
+				t.Errorf("Image.RestoreVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			wantParent := Parent{uuid: tt.image.UUID(), version: tt.snapshot.Version(), relation: Restored}
+			if tt.image.Version() != (Version{4}) || tt.image.Parent() != wantParent {
+				t.Errorf("Image.RestoreVersion() version = %v, parent = %+v, want 4 and %+v",
+					tt.image.Version(), tt.image.Parent(), wantParent)
+			}
+		})
+	}
+}
+
+func TestImage_Upgrade_Parent(t *testing.T) {
+	image := newTestSnapshot(t, 2, "success", false, time.Now()).Image()
+	if err := image.Upgrade(); err != nil {
+		t.Fatalf("Image.Upgrade() error = %v", err)
+	}
+	wantParent := Parent{uuid: image.UUID(), version: Version{2}, relation: Upgraded}
+	if image.Parent() != wantParent {
+		t.Errorf("Image.Upgrade() parent = %+v, want %+v", image.Parent(), wantParent)
+	}
+	if err := image.Rollback(); err != nil {
+		t.Fatalf("Image.Rollback() error = %v", err)
+	}
+	if !image.Parent().IsZero() {
+		t.Errorf("Image.Rollback() parent = %+v, want none", image.Parent())
+	}
+}
+
+func TestLineage_Origin(t *testing.T) {
+	tests := []struct {
+		name        string
+		lineage     Lineage
+		wantUUID    string
+		wantVersion Version
+	}{
+		{
+			name:        "should be the image itself",
+			lineage:     NewLineage("image-uuid", Version{2}, nil, nil),
+			wantUUID:    "image-uuid",
+			wantVersion: Version{2},
+		},
+		{
+			name: "should be the last ancestor",
+			lineage: NewLineage("image-uuid", Version{2}, []Parent{
+				{uuid: "image-uuid", version: Version{1}, relation: Upgraded},
+				{uuid: "template-uuid", version: Version{5}, relation: Cloned},
+			}, nil),
+			wantUUID:    "template-uuid",
+			wantVersion: Version{5},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotUUID, gotVersion := tt.lineage.Origin()
+			if gotUUID != tt.wantUUID || gotVersion != tt.wantVersion {
+				t.Errorf("Lineage.Origin() = %v, %v, want %v, %v", gotUUID, gotVersion, tt.wantUUID, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -162,10 +162,16 @@ func (p Packages) Has(pkg Package) bool {
 	return false
 }
 
-// MarshalGorm marshals the packages to a gorm model.
+// MarshalGorm marshals the packages to a gorm model, once each, as they are entries of the package catalog
+// of the account.
 func (p Packages) MarshalGorm(account string) []models.Package {
 	var packages []models.Package
+	seen := make(map[string]bool)
 	for _, pkg := range p.Packages() {
+		if seen[pkg.name] {
+			continue
+		}
+		seen[pkg.name] = true
 		packages = append(packages, models.Package{Account: account, Name: pkg.name})
 	}
 	return packages
@@ -1018,6 +1018,28 @@ func TestPackages_MarshalGorm(t *testing.T) {
 				{Name: "test", Account: "test"},
 			},
 		},
+		{
+			name: "duplicated packages",
+			fields: fields{
+				requiredPackages: []Package{
+					{"ansible"},
+					{"rhc"},
+				},
+				packages: []Package{
+					{"test"},
+					{"rhc"},
+					{"test"},
+				},
+			},
+			args: args{
+				account: "test",
+			},
+			want: []models.Package{
+				{Name: "ansible", Account: "test"},
+				{Name: "rhc", Account: "test"},
+				{Name: "test", Account: "test"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
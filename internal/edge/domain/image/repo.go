@@ -2,145 +2,70 @@ package image
 
 import (
 	"encoding/json"
-	netURL "net/url"
-	"strings"
+	"errors"
 
-	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/google/uuid"
 )
 
-// Repo is a repository of an image.
-type Repo struct {
-	name string
-	url  string
-}
+// Repo errors
+var (
+	ErrInvalidRepo = errors.New("invalid repository, images reference repositories of the account by uuid")
+	ErrUnknownRepo = errors.New("unknown repository in image")
+)
 
-// Repos is a list of repos.
+// Repos are the third-party repositories of an image, referenced by the uuid of a repository of the account,
+// in the order they're given. Changes to a repository apply to the next build of every image referencing it.
 type Repos struct {
-	repos []*Repo
-}
-
-// NewRepo returns a new repo.
-func NewRepo(name, url string) *Repo {
-	if !isValidRepo(name, url) {
-		return nil
-	}
-	return &Repo{
-		name: name,
-		url:  url,
-	}
+	uuids []string
 }
 
-// NewRepos returns a new list of repos.
-func NewRepos(repos ...*Repo) Repos {
-	if len(repos) == 0 {
-		return Repos{}
-	}
-	r := Repos{repos: make([]*Repo, 0, len(repos))}
-	for _, repo := range repos {
-		r.Add(repo)
+// NewRepos returns a new list of repos referencing the given repository uuids, without duplicates.
+func NewRepos(uuids ...string) (Repos, error) {
+	if len(uuids) == 0 {
+		return Repos{}, nil
 	}
-	return r
-}
-
-// isValidRepo returns true if the repo is valid.
-func isValidRepo(name, url string) bool {
-	_, err := netURL.ParseRequestURI(url)
-	return err == nil && name != "" && strings.TrimSpace(name) != ""
-}
-
-// IsZero returns true if the repo is empty.
-func (r Repo) IsZero() bool {
-	return r == Repo{}
-}
-
-// Add adds a repo to the list.
-func (r *Repos) Add(repos ...*Repo) {
-	for _, repo := range repos {
-		if repo != nil {
-			r.repos = append(r.repos, repo)
+	r := Repos{uuids: make([]string, 0, len(uuids))}
+	seen := make(map[string]bool, len(uuids))
+	for _, repoUUID := range uuids {
+		if _, err := uuid.Parse(repoUUID); err != nil {
+			return Repos{}, ErrInvalidRepo
+		}
+		if !seen[repoUUID] {
+			seen[repoUUID] = true
+			r.uuids = append(r.uuids, repoUUID)
 		}
 	}
+	return r, nil
 }
 
-// String returns the string representation of the repo.
-func (r Repo) String() string {
-	return `{"name":"` + r.name + `","url":"` + r.url + `"}`
-}
-
-// StringArray returns a string array of the repos.
-func (r Repos) StringArray() []string {
-	var repos []string
-	for _, repo := range r.repos {
-		repos = append(repos, repo.String())
-	}
-	return repos
-}
-
-// MarshalJSON creates a custom JSON marshaller.
-func (r Repo) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	}{
-		Name: r.name,
-		URL:  r.url,
-	})
-}
-
-// UnmarshalJSON creates a custom JSON unmarshaller.
-func (r *Repo) UnmarshalJSON(b []byte) error {
-	var tmp struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	}
-	if err := json.Unmarshal(b, &tmp); err != nil {
-		return err
-	}
-	r.name = tmp.Name
-	r.url = tmp.URL
-	return nil
+// UUIDs returns the uuids of the referenced repositories.
+func (r Repos) UUIDs() []string {
+	return r.uuids
 }
 
 // MarshalJSON creates a custom JSON marshaller.
 func (r Repos) MarshalJSON() ([]byte, error) {
-	return json.Marshal(r.repos)
+	return json.Marshal(r.uuids)
 }
 
 // UnmarshalJSON creates a custom JSON unmarshaller.
+// Repositories stored inline by older versions aren't in the catalog of the account, they're dropped.
 func (r *Repos) UnmarshalJSON(b []byte) error {
-	if string(b) == `[]` {
-		return nil
-	}
-	var repos []Repo
-	if err := json.Unmarshal(b, &repos); err != nil {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(b, &entries); err != nil {
 		return err
 	}
-	for _, repo := range repos {
-		r.Add(NewRepo(repo.name, repo.url))
-	}
-	return nil
-}
-
-// ReposFromArray creates a new list of repos from a map.
-func ReposFromArray(repos []interface{}) ([]*Repo, error) {
-	// validate the repos by marshalling & unmarshalling
-	jrepo, _ := json.Marshal(repos) // nolint: errcheck // ignore error, since this is always valid
-	var tmp Repos
-	if err := json.Unmarshal(jrepo, &tmp); err != nil {
-		return nil, err
+	var uuids []string
+	for _, entry := range entries {
+		var repoUUID string
+		if err := json.Unmarshal(entry, &repoUUID); err == nil {
+			uuids = append(uuids, repoUUID)
+		}
 	}
-	return tmp.repos, nil
-}
-
-// MarshalGorm marshals the repos to a gorm model.
-func (r Repos) MarshalGorm(account string) []models.Repo {
-	var repos []models.Repo
-	for _, repo := range r.repos {
-		repos = append(repos, models.Repo{
-			Account: account,
-			Name:    repo.name,
-			URL:     repo.url,
-		})
+	repos, err := NewRepos(uuids...)
+	if err != nil {
+		return err
 	}
-	return repos
+	*r = repos
+	return nil
 }
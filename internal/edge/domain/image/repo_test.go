@@ -3,615 +3,112 @@ package image
 import (
 	"reflect"
 	"testing"
-
-	"github.com/Avielyo10/edge-api/internal/common/models"
 )
 
-func TestNewRepo(t *testing.T) {
-	type args struct {
-		name string
-		url  string
-	}
-	tests := []struct {
-		name string
-		args args
-		want *Repo
-	}{
-		{
-			name: "valid",
-			args: args{
-				name: "test",
-				url:  "http://test.com",
-			},
-			want: &Repo{
-				name: "test",
-				url:  "http://test.com",
-			},
-		},
-		{
-			name: "invalid, no name",
-			args: args{
-				name: "",
-				url:  "http://test.com",
-			},
-			want: nil,
-		},
-		{
-			name: "invalid, invalid url",
-			args: args{
-				name: "test",
-				url:  "test",
-			},
-			want: nil,
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			if got := NewRepo(tt.args.name, tt.args.url); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("NewRepo() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
+const (
+	testRepoUUID        = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	anotherTestRepoUUID = "6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+)
 
 func TestNewRepos(t *testing.T) {
-	type args struct {
-		repos []*Repo
-	}
-	tests := []struct {
-		name string
-		args args
-		want Repos
-	}{
-		{
-			name: "valid",
-			args: args{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-				},
-			},
-			want: Repos{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-				},
-			},
-		},
-		{
-			name: "invalid, no repos",
-			args: args{
-				repos: []*Repo{},
-			},
-			want: Repos{},
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			if got := NewRepos(tt.args.repos...); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("NewRepos() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func Test_isValidRepo(t *testing.T) {
-	type args struct {
-		name string
-		url  string
-	}
-	tests := []struct {
-		name string
-		args args
-		want bool
-	}{
-		{
-			name: "valid",
-			args: args{
-				name: "test",
-				url:  "http://test.com",
-			},
-			want: true,
-		},
-		{
-			name: "invalid, no name",
-			args: args{
-				name: "",
-				url:  "http://test.com",
-			},
-			want: false,
-		},
-		{
-			name: "invalid, invalid url",
-			args: args{
-				name: "test",
-				url:  "test",
-			},
-			want: false,
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			if got := isValidRepo(tt.args.name, tt.args.url); got != tt.want {
-				t.Errorf("isValidRepo() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestRepo_IsZero(t *testing.T) {
-	type fields struct {
-		name string
-		url  string
-	}
 	tests := []struct {
-		name   string
-		fields fields
-		want   bool
+		name    string
+		uuids   []string
+		want    Repos
+		wantErr error
 	}{
 		{
-			name: "valid",
-			fields: fields{
-				name: "test",
-				url:  "http://test.com",
-			},
-			want: false,
-		},
-		{
-			name: "invalid, no name",
-			fields: fields{
-				name: "",
-				url:  "",
-			},
-			want: true,
+			name:  "should reference repositories in order",
+			uuids: []string{anotherTestRepoUUID, testRepoUUID},
+			want:  Repos{uuids: []string{anotherTestRepoUUID, testRepoUUID}},
 		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			r := Repo{
-				name: tt.fields.name,
-				url:  tt.fields.url,
-			}
-			if got := r.IsZero(); got != tt.want {
-				t.Errorf("Repo.IsZero() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestRepos_Add(t *testing.T) {
-	type fields struct {
-		repos []*Repo
-	}
-	type args struct {
-		repos []*Repo
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   *Repos
-	}{
 		{
-			name: "valid",
-			fields: fields{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-				},
-			},
-			args: args{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-				},
-			},
-			want: &Repos{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-					NewRepo("test", "http://test.com"),
-				},
-			},
+			name:  "should drop duplicates",
+			uuids: []string{testRepoUUID, anotherTestRepoUUID, testRepoUUID},
+			want:  Repos{uuids: []string{testRepoUUID, anotherTestRepoUUID}},
 		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			r := &Repos{
-				repos: tt.fields.repos,
-			}
-			r.Add(tt.args.repos...)
-			if !reflect.DeepEqual(r, tt.want) {
-				t.Errorf("Repos.Add() = %v, want %v", r, tt.want)
-			}
-		})
-	}
-}
-
-func TestRepo_String(t *testing.T) {
-	type fields struct {
-		name string
-		url  string
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   string
-	}{
 		{
-			name: "valid",
-			fields: fields{
-				name: "test",
-				url:  "http://test.com",
-			},
-			want: `{"name":"test","url":"http://test.com"}`,
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			r := Repo{
-				name: tt.fields.name,
-				url:  tt.fields.url,
-			}
-			if got := r.String(); got != tt.want {
-				t.Errorf("Repo.String() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestRepos_StringArray(t *testing.T) {
-	type fields struct {
-		repos []*Repo
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   []string
-	}{
-		{
-			name: "valid",
-			fields: fields{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-				},
-			},
-			want: []string{`{"name":"test","url":"http://test.com"}`},
+			name: "should be empty",
+			want: Repos{},
 		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			r := Repos{
-				repos: tt.fields.repos,
-			}
-			if got := r.StringArray(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Repos.StringArray() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestRepo_MarshalJSON(t *testing.T) {
-	type fields struct {
-		name string
-		url  string
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		want    []byte
-		wantErr bool
-	}{
 		{
-			name: "valid",
-			fields: fields{
-				name: "test",
-				url:  "http://test.com",
-			},
-			want:    []byte(`{"name":"test","url":"http://test.com"}`),
-			wantErr: false,
+			name:    "should fail, not a uuid",
+			uuids:   []string{testRepoUUID, "epel"},
+			want:    Repos{},
+			wantErr: ErrInvalidRepo,
 		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			r := Repo{
-				name: tt.fields.name,
-				url:  tt.fields.url,
-			}
-			got, err := r.MarshalJSON()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Repo.MarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			got, err := NewRepos(tt.uuids...)
+			if err != tt.wantErr {
+				t.Errorf("NewRepos() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Repo.MarshalJSON() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestRepo_UnmarshalJSON(t *testing.T) {
-	type fields struct {
-		name string
-		url  string
-	}
-	type args struct {
-		b []byte
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "valid",
-			fields: fields{
-				name: "test",
-				url:  "http://test.com",
-			},
-			args: args{
-				b: []byte(`{"name":"test","url":"http://test.com"}`),
-			},
-			wantErr: false,
-		},
-		{
-			name: "invalid",
-			fields: fields{
-				name: "test",
-				url:  "http://test.com",
-			},
-			args: args{
-				b: []byte(`{""}`),
-			},
-			wantErr: true,
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			r := &Repo{
-				name: tt.fields.name,
-				url:  tt.fields.url,
-			}
-			if err := r.UnmarshalJSON(tt.args.b); (err != nil) != tt.wantErr {
-				t.Errorf("Repo.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("NewRepos() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
 func TestRepos_MarshalJSON(t *testing.T) {
-	type fields struct {
-		repos []*Repo
+	repos, _ := NewRepos(testRepoUUID, anotherTestRepoUUID)
+	got, err := repos.MarshalJSON()
+	if err != nil {
+		t.Fatalf("Repos.MarshalJSON() error = %v", err)
 	}
-	tests := []struct {
-		name    string
-		fields  fields
-		want    []byte
-		wantErr bool
-	}{
-		{
-			name: "valid",
-			fields: fields{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-					NewRepo("test2", "http://test2.com"),
-				},
-			},
-			want:    []byte(`[{"name":"test","url":"http://test.com"},{"name":"test2","url":"http://test2.com"}]`),
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			r := Repos{
-				repos: tt.fields.repos,
-			}
-			got, err := r.MarshalJSON()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Repos.MarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Repos.MarshalJSON() = %s, want %s", got, tt.want)
-			}
-		})
+	if want := `["` + testRepoUUID + `","` + anotherTestRepoUUID + `"]`; string(got) != want {
+		t.Errorf("Repos.MarshalJSON() = %s, want %s", got, want)
 	}
 }
 
 func TestRepos_UnmarshalJSON(t *testing.T) {
-	type fields struct {
-		repos []*Repo
-	}
-	type args struct {
-		b []byte
-	}
 	tests := []struct {
 		name    string
-		fields  fields
-		args    args
+		data    string
+		want    Repos
 		wantErr bool
 	}{
 		{
-			name: "valid",
-			fields: fields{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-					NewRepo("test2", "http://test2.com"),
-				},
-			},
-			args: args{
-				b: []byte(`[{"name":"test","url":"http://test.com"},{"name":"test2","url":"http://test2.com"}]`),
-			},
-			wantErr: false,
+			name: "should unmarshal uuids",
+			data: `["` + testRepoUUID + `","` + anotherTestRepoUUID + `"]`,
+			want: Repos{uuids: []string{testRepoUUID, anotherTestRepoUUID}},
 		},
 		{
-			name: "invalid",
-			fields: fields{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-					NewRepo("test2", "http://test2.com"),
-				},
-			},
-			args: args{
-				b: []byte(`[{""}]`),
-			},
-			wantErr: true,
+			name: "should drop inline repositories",
+			data: `[{"name":"test","url":"http://test.com"},"` + testRepoUUID + `"]`,
+			want: Repos{uuids: []string{testRepoUUID}},
 		},
 		{
-			name: "empty",
-			fields: fields{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-					NewRepo("test2", "http://test2.com"),
-				},
-			},
-			args: args{
-				b: []byte(`[]`),
-			},
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			r := &Repos{
-				repos: tt.fields.repos,
-			}
-			if err := r.UnmarshalJSON(tt.args.b); (err != nil) != tt.wantErr {
-				t.Errorf("Repos.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func TestReposFromArray(t *testing.T) {
-	type fields struct {
-		repos []*Repo
-	}
-	type args struct {
-		repos []interface{}
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "valid",
-			fields: fields{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-					NewRepo("test2", "http://test2.com"),
-				},
-			},
-			args: args{
-				repos: []interface{}{
-					map[string]interface{}{
-						"name": "test",
-						"url":  "http://test.com",
-					},
-					map[string]interface{}{
-						"name": "test2",
-						"url":  "http://test2.com",
-					},
-				},
-			},
-			wantErr: false,
+			name: "should be empty",
+			data: `[]`,
+			want: Repos{},
 		},
 		{
-			name: "invalid, unmarshal error",
-			fields: fields{
-				repos: nil,
-			},
-			args: args{
-				repos: []interface{}{""},
-			},
+			name:    "should fail, not a list",
+			data:    `{"name":"test"}`,
+			want:    Repos{},
 			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			r := &Repos{
-				repos: tt.fields.repos,
-			}
-			repos, err := ReposFromArray(tt.args.repos)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Repos.FromArray() error = %v, wantErr %v", err, tt.wantErr)
-			}
-			if !reflect.DeepEqual(repos, r.repos) {
-				t.Errorf("Repos.FromArray() = %v, want %v", repos, r.repos)
-			}
-		})
-	}
-}
-
-func TestRepos_MarshalGorm(t *testing.T) {
-	type fields struct {
-		repos []*Repo
-	}
-	type args struct {
-		account string
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   []models.Repo
-	}{
 		{
-			name: "valid",
-			fields: fields{
-				repos: []*Repo{
-					NewRepo("test", "http://test.com"),
-					NewRepo("test2", "http://test2.com"),
-				},
-			},
-			args: args{
-				account: "test",
-			},
-			want: []models.Repo{
-				{
-					Account: "test",
-					Name:    "test",
-					URL:     "http://test.com",
-				},
-				{
-					Account: "test",
-					Name:    "test2",
-					URL:     "http://test2.com",
-				},
-			},
+			name:    "should fail, not a uuid",
+			data:    `["epel"]`,
+			want:    Repos{},
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			r := Repos{
-				repos: tt.fields.repos,
+			var got Repos
+			if err := got.UnmarshalJSON([]byte(tt.data)); (err != nil) != tt.wantErr {
+				t.Errorf("Repos.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if got := r.MarshalGorm(tt.args.account); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Repos.MarshalGorm() = %v, want %v", got, tt.want)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Repos.UnmarshalJSON() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -26,8 +26,57 @@ type Repository interface {
 	UpdateImage(ctx context.Context, uuid string, updateFn func(h *Image) (*Image, error)) error
 	// DeleteImage deletes the image with the given UUID.
 	DeleteImage(ctx context.Context, uuid string) error
-	// GetImages returns all images.
-	GetImages(ctx context.Context) ([]*Image, error)
+	// GetImages returns the page of images the spec lists, along with the total number of images matching it.
+	GetImages(ctx context.Context, spec Spec) ([]*Image, int, error)
+	// GetDeletedImages returns all soft-deleted images.
+	GetDeletedImages(ctx context.Context) ([]*Image, error)
+	// RestoreImage restores the soft-deleted image with the given UUID.
+	RestoreImage(ctx context.Context, uuid string) error
+	// PurgeImage permanently deletes the image with the given UUID, along with everything it owns.
+	PurgeImage(ctx context.Context, uuid string) error
+}
+
+// VersionRepository interface for handling image versions store/retrieve.
+type VersionRepository interface {
+	// GetVersions returns the snapshots of all stored versions of the image with the given UUID.
+	GetVersions(ctx context.Context, uuid string) ([]*Snapshot, error)
+	// GetVersion returns the snapshot of the given version of the image with the given UUID.
+	GetVersion(ctx context.Context, uuid string, version uint) (*Snapshot, error)
+	// GetVersionByCommit returns the snapshot of the version built into the given ostree commit.
+	GetVersionByCommit(ctx context.Context, commit Commit) (*Snapshot, error)
+	// GetChildren returns the snapshots of versions of other images derived from the image with the given UUID.
+	GetChildren(ctx context.Context, uuid string) ([]*Snapshot, error)
+	// UpdateVersion updates the snapshot of the given version of the image with the given UUID.
+	UpdateVersion(ctx context.Context, uuid string, version uint, updateFn func(s *Snapshot) (*Snapshot, error)) error
+	// DeleteVersions deletes the given versions of the image with the given UUID.
+	DeleteVersions(ctx context.Context, uuid string, versions ...Version) error
+	// GetMetadataChanges returns the metadata changes made within the versions of the image with the given UUID.
+	GetMetadataChanges(ctx context.Context, uuid string) ([]MetadataChange, error)
+}
+
+// RetentionRepository interface for handling retention policies store/retrieve.
+type RetentionRepository interface {
+	// GetRetentionPolicy returns the retention policy of the account.
+	GetRetentionPolicy(ctx context.Context) (*RetentionPolicy, error)
+	// SetRetentionPolicy creates or replaces the retention policy of the account.
+	SetRetentionPolicy(ctx context.Context, policy *RetentionPolicy) error
+	// GetRetentionPolicies returns the retention policies of all accounts.
+	GetRetentionPolicies(ctx context.Context) ([]*RetentionPolicy, error)
+}
+
+// CatalogRepository interface for handling the catalog of the tags and packages the images of an account use.
+// Deleted images aren't counted.
+type CatalogRepository interface {
+	// GetTagCatalog returns the entries of the tags starting with the prefix, sorted by SortCatalog.
+	GetTagCatalog(ctx context.Context, prefix string) ([]*CatalogEntry, error)
+	// GetPackageCatalog returns the entries of the packages starting with the prefix, sorted by SortCatalog.
+	GetPackageCatalog(ctx context.Context, prefix string) ([]*CatalogEntry, error)
+}
+
+// SearchRepository interface for searching the images of an account by full-text. Deleted images aren't found.
+type SearchRepository interface {
+	// SearchImages returns the images matching the query, the most relevant first.
+	SearchImages(ctx context.Context, query SearchQuery) ([]*SearchResult, error)
 }
 
 // MarshalGorm converts a domain Image to a database Image.
@@ -58,7 +107,6 @@ func (image Image) MarshalGorm() *models.Image {
 
 		Packages: image.Packages().MarshalGorm(account.String()),
 		Tags:     image.Tags().MarshalGorm(account.String()),
-		Repos:    image.Repos().MarshalGorm(account.String()),
 	}
 	model.Installer.Account = account.String()
 	model.User.Account = account.String()
@@ -81,3 +129,17 @@ func (image Image) MarshalRedis() []byte {
 	imageBytes, _ := json.Marshal(image) // marshal image to byte array, marshal any error is ignored
 	return imageBytes
 }
+
+// UnmarshalImageFromRedis unmarshals the image cached in redis, setting its context.
+func UnmarshalImageFromRedis(ctx context.Context, data []byte) (Image, error) {
+	if ctx == nil {
+		return Image{}, ErrEmptyContext
+	}
+	var image Image
+	if err := json.Unmarshal(data, &image); err != nil {
+		return Image{}, err
+	}
+	image.ctx = ctx
+	image.WithCancel()
+	return image, nil
+}
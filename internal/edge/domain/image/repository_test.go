@@ -21,10 +21,7 @@ func TestImage_MarshalGorm(t *testing.T) {
 	validSSHKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
 	validName, _ := common.NewName("valid-name")
 	validVersion, _ := NewVersion(1)
-	validRepos := NewRepos(
-		NewRepo("test", "http://test.com"),
-		NewRepo("test2", "http://test2.com"),
-	)
+	validRepos, _ := NewRepos(testRepoUUID, anotherTestRepoUUID)
 	now := time.Now()
 	ctx := context.WithValue(context.Background(), identity.Key, identity.XRHID{
 		Identity: identity.Identity{
@@ -104,10 +101,6 @@ func TestImage_MarshalGorm(t *testing.T) {
 					{Name: "rhc", Account: account},
 					{Name: "vim", Account: account},
 				},
-				Repos: []models.Repo{
-					{Name: "test", URL: "http://test.com", Account: account},
-					{Name: "test2", URL: "http://test2.com", Account: account},
-				},
 				Installer:   models.Installer{Account: account},
 				OutputTypes: []string{"rhel-edge-commit"},
 				Tags: []models.Tag{
@@ -183,10 +176,7 @@ func TestImage_MarshalRedis(t *testing.T) {
 	validSSHKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
 	validName, _ := common.NewName("valid-name")
 	validVersion, _ := NewVersion(1)
-	validRepos := NewRepos(
-		NewRepo("test", "http://test.com"),
-		NewRepo("test2", "http://test2.com"),
-	)
+	validRepos, _ := NewRepos(testRepoUUID, anotherTestRepoUUID)
 	now := time.Now()
 	type fields struct {
 		ctx          context.Context
@@ -232,7 +222,7 @@ func TestImage_MarshalRedis(t *testing.T) {
 				outputType: []OutputType{},
 				tags:       common.NewTags("tag1", "tag2"),
 			},
-			want: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":[{"name":"test","url":"http://test.com"},{"name":"test2","url":"http://test2.com"}],"installer":{},"tags":["tag1","tag2"],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
+			want: []byte(`{"uuid":"valid-uuid","name":"valid-name","description":"valid-description","status":"success","version":1,"distribution":"rhel8","user":{"username":"valid-username","ssh_key":"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"},"packages":null,"repos":["` + testRepoUUID + `","` + anotherTestRepoUUID + `"],"installer":{},"tags":["tag1","tag2"],"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"` + now.Format(time.RFC3339Nano) + `","deleted_at":"` + now.Format(time.RFC3339Nano) + `"}`),
 		},
 		{
 			name:   "should be nil",
@@ -267,3 +257,79 @@ func TestImage_MarshalRedis(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalImageFromRedis(t *testing.T) {
+	validSSHKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
+	validName, _ := common.NewName("valid-name")
+	validVersion, _ := NewVersion(1)
+	validRepos, _ := NewRepos(testRepoUUID, anotherTestRepoUUID)
+	now := time.Now().UTC()
+	cached := Image{
+		uuid:         "valid-uuid",
+		name:         validName,
+		description:  "valid-description",
+		timing:       common.NewTime(now, now, time.Time{}),
+		status:       Success,
+		version:      validVersion,
+		distribution: Distribution{"rhel8"},
+		user:         User{username: "valid-username", sshKey: validSSHKey},
+		packages:     NewPackages("vim"),
+		repos:        validRepos,
+		tags:         common.NewTags("tag1", "tag2"),
+	}
+	type args struct {
+		ctx  context.Context
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "should succeed",
+			args: args{
+				ctx:  context.Background(),
+				data: cached.MarshalRedis(),
+			},
+			want:    cached.MarshalRedis(),
+			wantErr: false,
+		},
+		{
+			name: "should fail, empty context",
+			args: args{
+				ctx:  nil,
+				data: cached.MarshalRedis(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail, invalid data",
+			args: args{
+				ctx:  context.Background(),
+				data: []byte("not an image"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := UnmarshalImageFromRedis(tt.args.ctx, tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalImageFromRedis() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.ctx == nil {
+				t.Errorf("UnmarshalImageFromRedis() context is nil")
+			}
+			if data := got.MarshalRedis(); !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("UnmarshalImageFromRedis() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package image
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+// Retention policy errors
+var (
+	ErrInvalidRetentionPolicy  = errors.New("invalid retention policy")
+	ErrRetentionPolicyNotFound = errors.New("retention policy not found")
+)
+
+// RetentionPolicy decides which versions of an account's images are kept.
+// It keeps the last keepLast successful versions, plus any version younger than keepDays days.
+type RetentionPolicy struct {
+	account  common.Account
+	keepLast uint
+	keepDays uint
+}
+
+// NewRetentionPolicy creates a new retention policy, at least one of the rules must be set.
+func NewRetentionPolicy(keepLast, keepDays uint) (RetentionPolicy, error) {
+	if keepLast == 0 && keepDays == 0 {
+		return RetentionPolicy{}, ErrInvalidRetentionPolicy
+	}
+	return RetentionPolicy{keepLast: keepLast, keepDays: keepDays}, nil
+}
+
+// IsZero returns true if the retention policy is empty.
+func (p RetentionPolicy) IsZero() bool {
+	return p == RetentionPolicy{}
+}
+
+// Account returns the account the retention policy belongs to.
+func (p RetentionPolicy) Account() common.Account {
+	return p.account
+}
+
+// KeepLast returns the number of successful versions to keep.
+func (p RetentionPolicy) KeepLast() uint {
+	return p.keepLast
+}
+
+// KeepDays returns the number of days a version is kept regardless of its status.
+func (p RetentionPolicy) KeepDays() uint {
+	return p.keepDays
+}
+
+// PrunableVersions returns the snapshots the policy allows to delete, newest first.
+// The current version of the image and locked versions are never returned.
+func (p RetentionPolicy) PrunableVersions(current Version, snapshots []*Snapshot, now time.Time) []*Snapshot {
+	sorted := make([]*Snapshot, len(snapshots))
+	copy(sorted, snapshots)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version().Uint() > sorted[j].Version().Uint()
+	})
+	cutoff := now.AddDate(0, 0, -int(p.keepDays))
+	var kept uint
+	var prunable []*Snapshot
+	for _, snapshot := range sorted {
+		if snapshot.Status().IsSuccess() && kept < p.keepLast {
+			kept++
+			continue
+		}
+		if snapshot.Version() == current || snapshot.Locked() ||
+			(p.keepDays > 0 && snapshot.CreatedAt().After(cutoff)) {
+			continue
+		}
+		prunable = append(prunable, snapshot)
+	}
+	return prunable
+}
+
+// MarshalGorm converts a retention policy to a database retention policy.
+func (p RetentionPolicy) MarshalGorm(account string) *models.RetentionPolicy {
+	return &models.RetentionPolicy{
+		Account:  account,
+		KeepLast: p.keepLast,
+		KeepDays: p.keepDays,
+	}
+}
+
+// UnmarshalRetentionPolicyFromDatabase unmarshals a retention policy from the database.
+func UnmarshalRetentionPolicyFromDatabase(account string, keepLast, keepDays uint) (RetentionPolicy, error) {
+	validAccount, err := common.NewAccount(account)
+	if err != nil {
+		return RetentionPolicy{}, err
+	}
+	policy, err := NewRetentionPolicy(keepLast, keepDays)
+	if err != nil {
+		return RetentionPolicy{}, err
+	}
+	policy.account = validAccount
+	return policy, nil
+}
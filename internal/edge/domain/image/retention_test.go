@@ -0,0 +1,164 @@
+package image
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+func TestNewRetentionPolicy(t *testing.T) {
+	type args struct {
+		keepLast uint
+		keepDays uint
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    RetentionPolicy
+		wantErr bool
+	}{
+		{
+			name:    "should succeed, keep last",
+			args:    args{keepLast: 3},
+			want:    RetentionPolicy{keepLast: 3},
+			wantErr: false,
+		},
+		{
+			name:    "should succeed, keep days",
+			args:    args{keepDays: 30},
+			want:    RetentionPolicy{keepDays: 30},
+			wantErr: false,
+		},
+		{
+			name:    "should fail, no rules",
+			args:    args{},
+			want:    RetentionPolicy{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewRetentionPolicy(tt.args.keepLast, tt.args.keepDays)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRetentionPolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRetentionPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetentionPolicy_PrunableVersions(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(0, 0, -60)
+	snapshots := []*Snapshot{
+		newTestSnapshot(t, 1, "success", false, old),
+		newTestSnapshot(t, 2, "error", false, old),
+		newTestSnapshot(t, 3, "success", true, old),
+		newTestSnapshot(t, 4, "success", false, old),
+		newTestSnapshot(t, 5, "success", false, old),
+		newTestSnapshot(t, 6, "error", false, now.AddDate(0, 0, -1)),
+		newTestSnapshot(t, 7, "building", false, now),
+	}
+	current, _ := NewVersion(7)
+	type args struct {
+		keepLast uint
+		keepDays uint
+	}
+	tests := []struct {
+		name string
+		args args
+		want []uint
+	}{
+		{
+			name: "should keep last 2 successful, current and locked versions",
+			args: args{keepLast: 2},
+			want: []uint{6, 2, 1},
+		},
+		{
+			name: "should keep versions younger than 30 days",
+			args: args{keepDays: 30},
+			want: []uint{5, 4, 2, 1},
+		},
+		{
+			name: "should keep both last 1 successful and younger than 30 days",
+			args: args{keepLast: 1, keepDays: 30},
+			want: []uint{4, 2, 1},
+		},
+		{
+			name: "should keep everything",
+			args: args{keepLast: 10},
+			want: []uint{6, 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := NewRetentionPolicy(tt.args.keepLast, tt.args.keepDays)
+			if err != nil {
+				t.Fatalf("NewRetentionPolicy() error = %v", err)
+			}
+			var got []uint
+			for _, s := range p.PrunableVersions(current, snapshots, now) {
+				got = append(got, s.Version().Uint())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RetentionPolicy.PrunableVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalRetentionPolicyFromDatabase(t *testing.T) {
+	type args struct {
+		account  string
+		keepLast uint
+		keepDays uint
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    RetentionPolicy
+		wantErr bool
+	}{
+		{
+			name:    "should succeed",
+			args:    args{account: "0000000", keepLast: 5, keepDays: 7},
+			want:    RetentionPolicy{account: common.DefaultAccount, keepLast: 5, keepDays: 7},
+			wantErr: false,
+		},
+		{
+			name:    "should fail, invalid account",
+			args:    args{account: "", keepLast: 5},
+			want:    RetentionPolicy{},
+			wantErr: true,
+		},
+		{
+			name:    "should fail, invalid policy",
+			args:    args{account: "0000000"},
+			want:    RetentionPolicy{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := UnmarshalRetentionPolicyFromDatabase(tt.args.account, tt.args.keepLast, tt.args.keepDays)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalRetentionPolicyFromDatabase() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalRetentionPolicyFromDatabase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package image
+
+import (
+	"errors"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidSearchQuery is the error returned when a search query has no word to look for.
+var ErrInvalidSearchQuery = errors.New("invalid search query, it must have at least one word")
+
+// Markers surrounding the matching words of the highlighted fields of search results.
+const (
+	HighlightStart = "<b>"
+	HighlightEnd   = "</b>"
+)
+
+// Marks the stores surround the matching words with. They aren't special to HTML, so NewSearchResult
+// escapes the text the images hold before it replaces them with HighlightStart and HighlightEnd.
+const (
+	HighlightStartMark = "\x02"
+	HighlightEndMark   = "\x03"
+)
+
+// highlightMarks replaces the marks of the escaped text with the HTML markers.
+var highlightMarks = strings.NewReplacer(HighlightStartMark, HighlightStart, HighlightEndMark, HighlightEnd)
+
+// SearchField is a field of the images the full-text search looks into.
+type SearchField string
+
+// Search fields
+const (
+	SearchFieldName        SearchField = "name"
+	SearchFieldDescription SearchField = "description"
+	SearchFieldTags        SearchField = "tags"
+	SearchFieldPackages    SearchField = "packages"
+	SearchFieldRepos       SearchField = "repos"
+)
+
+// SearchFields are the fields the full-text search looks into, from the most relevant to the least.
+var SearchFields = []SearchField{
+	SearchFieldName, SearchFieldTags, SearchFieldPackages, SearchFieldRepos, SearchFieldDescription,
+}
+
+// String returns the name of the field.
+func (f SearchField) String() string {
+	return string(f)
+}
+
+// weight returns how much a match within the field counts, following the default weights of postgres.
+func (f SearchField) weight() float64 {
+	switch f {
+	case SearchFieldName:
+		return 1
+	case SearchFieldTags, SearchFieldPackages:
+		return 0.4
+	case SearchFieldRepos:
+		return 0.2
+	default:
+		return 0.1
+	}
+}
+
+// SearchQuery is a full-text search of the images, looking for the ones having every word of the query
+// as the start of a word of their fields, whatever the case.
+type SearchQuery struct {
+	text  string
+	terms []string
+	limit int
+}
+
+// NewSearchQuery creates a new search query, returning at most limit images.
+func NewSearchQuery(text string, limit int) (SearchQuery, error) {
+	if limit < 1 || limit > MaxLimit {
+		return SearchQuery{}, ErrInvalidPage
+	}
+	var terms []string
+	seen := make(map[string]bool)
+	for _, term := range searchWords(strings.ToLower(text)) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) == 0 {
+		return SearchQuery{}, ErrInvalidSearchQuery
+	}
+	return SearchQuery{text: strings.TrimSpace(text), terms: terms, limit: limit}, nil
+}
+
+// String returns the text of the query.
+func (q SearchQuery) String() string {
+	return q.text
+}
+
+// Terms returns the lower-cased words of the query, without duplicates.
+func (q SearchQuery) Terms() []string {
+	return append([]string(nil), q.terms...)
+}
+
+// Limit returns the maximum number of images the query returns.
+func (q SearchQuery) Limit() int {
+	return q.limit
+}
+
+// SearchDocument is the text of an image the full-text search looks into, by field.
+type SearchDocument struct {
+	fields map[SearchField]string
+}
+
+// NewSearchDocument creates a new search document of an image.
+func NewSearchDocument(name, description string, tags, packages, repos []string) SearchDocument {
+	return SearchDocument{fields: map[SearchField]string{
+		SearchFieldName:        name,
+		SearchFieldDescription: description,
+		SearchFieldTags:        strings.Join(tags, " "),
+		SearchFieldPackages:    strings.Join(packages, " "),
+		SearchFieldRepos:       strings.Join(repos, " "),
+	}}
+}
+
+// Match returns the rank of the document for the query along with its fields marking the matching words,
+// or false if a word of the query matches none of the fields.
+func (d SearchDocument) Match(q SearchQuery) (float64, map[SearchField]string, bool) {
+	var rank float64
+	highlights := make(map[SearchField]string)
+	found := make(map[string]bool)
+	for _, field := range SearchFields {
+		highlighted, matches := highlight(d.fields[field], q.terms, found)
+		if matches > 0 {
+			rank += float64(matches) * field.weight()
+			highlights[field] = highlighted
+		}
+	}
+	return rank, highlights, len(found) == len(q.terms)
+}
+
+// SearchResult is an image found by a full-text search, along with its rank and highlighted fields.
+type SearchResult struct {
+	image      *Image
+	rank       float64
+	highlights map[SearchField]string
+}
+
+// NewSearchResult creates a new search result from the fields marking the matching words with HighlightStartMark
+// and HighlightEndMark, keeping the fields with a marked word only. The fields are HTML-escaped, so only the
+// markers of the matching words are HTML.
+func NewSearchResult(image *Image, rank float64, highlights map[SearchField]string) *SearchResult {
+	kept := make(map[SearchField]string)
+	for field, text := range highlights {
+		if strings.Contains(text, HighlightStartMark) {
+			kept[field] = highlightMarks.Replace(html.EscapeString(text))
+		}
+	}
+	return &SearchResult{image: image, rank: rank, highlights: kept}
+}
+
+// Image returns the image found.
+func (r SearchResult) Image() *Image {
+	return r.image
+}
+
+// Rank returns the relevance of the image for the query, the higher the better.
+// Ranks only compare the results of the same search.
+func (r SearchResult) Rank() float64 {
+	return r.rank
+}
+
+// Highlights returns the fields of the image matching the query, HTML-escaped, their matching words surrounded
+// by HighlightStart and HighlightEnd.
+func (r SearchResult) Highlights() map[SearchField]string {
+	highlights := make(map[SearchField]string, len(r.highlights))
+	for field, text := range r.highlights {
+		highlights[field] = text
+	}
+	return highlights
+}
+
+// SortSearchResults sorts the results the most relevant first, keeping the order of the ones ranked the same.
+func SortSearchResults(results []*SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].rank > results[j].rank
+	})
+}
+
+// SearchImages returns the images matching the query, the most relevant first. It is meant for stores
+// without a full-text index. Their repositories aren't searched, images only hold their uuids while
+// the full-text index holds their names.
+func SearchImages(images []*Image, q SearchQuery) []*SearchResult {
+	results := []*SearchResult{}
+	for _, image := range images {
+		document := NewSearchDocument(image.Name().String(), image.Description(),
+			image.Tags().StringArray(), image.Packages().StringArray(), nil)
+		if rank, highlights, ok := document.Match(q); ok {
+			results = append(results, NewSearchResult(image, rank, highlights))
+		}
+	}
+	SortSearchResults(results)
+	if len(results) > q.limit {
+		results = results[:q.limit]
+	}
+	return results
+}
+
+// isSearchRune returns true if the rune belongs to a word, as the full-text search splits text.
+func isSearchRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// searchWords splits the text into words.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool { return !isSearchRune(r) })
+}
+
+// highlight marks the words of the text starting with one of the terms, adding the terms found to found.
+// It returns the marked text along with the number of words marked.
+func highlight(text string, terms []string, found map[string]bool) (string, int) {
+	var b strings.Builder
+	matches := 0
+	runes := []rune(text)
+	for start := 0; start < len(runes); {
+		if !isSearchRune(runes[start]) {
+			b.WriteRune(runes[start])
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && isSearchRune(runes[end]) {
+			end++
+		}
+		word := string(runes[start:end])
+		matched := false
+		for _, term := range terms {
+			if strings.HasPrefix(strings.ToLower(word), term) {
+				found[term] = true
+				matched = true
+			}
+		}
+		if matched {
+			matches++
+			b.WriteString(HighlightStartMark + word + HighlightEndMark)
+		} else {
+			b.WriteString(word)
+		}
+		start = end
+	}
+	return b.String(), matches
+}
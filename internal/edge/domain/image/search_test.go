@@ -0,0 +1,192 @@
+package image
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+func TestNewSearchQuery(t *testing.T) {
+	type args struct {
+		text  string
+		limit int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantTerms []string
+		wantErr   error
+	}{
+		{
+			name:      "should split the words, whatever the case",
+			args:      args{text: " Nginx, berlin-warehouse ", limit: DefaultLimit},
+			wantTerms: []string{"nginx", "berlin", "warehouse"},
+			wantErr:   nil,
+		},
+		{
+			name:      "should drop duplicated words",
+			args:      args{text: "kiosk KIOSK", limit: 1},
+			wantTerms: []string{"kiosk"},
+			wantErr:   nil,
+		},
+		{
+			name:    "should fail, no word",
+			args:    args{text: " -*- ", limit: DefaultLimit},
+			wantErr: ErrInvalidSearchQuery,
+		},
+		{
+			name:    "should fail, no limit",
+			args:    args{text: "nginx", limit: 0},
+			wantErr: ErrInvalidPage,
+		},
+		{
+			name:    "should fail, limit too high",
+			args:    args{text: "nginx", limit: MaxLimit + 1},
+			wantErr: ErrInvalidPage,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewSearchQuery(tt.args.text, tt.args.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewSearchQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got.Terms(), tt.wantTerms) {
+				t.Errorf("NewSearchQuery() terms = %v, want %v", got.Terms(), tt.wantTerms)
+			}
+		})
+	}
+}
+
+func TestSearchDocument_Match(t *testing.T) {
+	document := NewSearchDocument("kiosk-berlin", "Kiosk of the Berlin warehouse",
+		[]string{"berlin", "kiosk"}, []string{"nginx", "vim"}, []string{"epel"})
+	tests := []struct {
+		name           string
+		text           string
+		wantHighlights map[SearchField]string
+		wantOk         bool
+	}{
+		{
+			name: "should highlight the words starting with the terms, in every field",
+			text: "nginx Berl",
+			wantHighlights: map[SearchField]string{
+				SearchFieldName:        "kiosk-\x02berlin\x03",
+				SearchFieldDescription: "Kiosk of the \x02Berlin\x03 warehouse",
+				SearchFieldTags:        "\x02berlin\x03 kiosk",
+				SearchFieldPackages:    "\x02nginx\x03 vim",
+			},
+			wantOk: true,
+		},
+		{
+			name:   "should not match, a term is missing",
+			text:   "nginx munich",
+			wantOk: false,
+		},
+		{
+			name:   "should not match, inner part of a word",
+			text:   "house",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			q, err := NewSearchQuery(tt.text, DefaultLimit)
+			if err != nil {
+				t.Fatalf("NewSearchQuery() error = %v", err)
+			}
+			_, highlights, ok := document.Match(q)
+			if ok != tt.wantOk {
+				t.Fatalf("SearchDocument.Match() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if tt.wantOk && !reflect.DeepEqual(highlights, tt.wantHighlights) {
+				t.Errorf("SearchDocument.Match() highlights = %v, want %v", highlights, tt.wantHighlights)
+			}
+		})
+	}
+}
+
+func TestNewSearchResult(t *testing.T) {
+	got := NewSearchResult(&Image{uuid: "image-1"}, 1, map[SearchField]string{
+		SearchFieldName:        HighlightStartMark + "kiosk" + HighlightEndMark,
+		SearchFieldDescription: "<script>alert('" + HighlightStartMark + "kiosk" + HighlightEndMark + "')</script>",
+		SearchFieldTags:        "a <b>fragment</b> without match",
+	})
+	want := map[SearchField]string{
+		SearchFieldName:        "<b>kiosk</b>",
+		SearchFieldDescription: "&lt;script&gt;alert(&#39;<b>kiosk</b>&#39;)&lt;/script&gt;",
+	}
+	if !reflect.DeepEqual(got.Highlights(), want) {
+		t.Errorf("NewSearchResult() highlights = %v, want %v", got.Highlights(), want)
+	}
+}
+
+func TestSearchImages(t *testing.T) {
+	images := []*Image{
+		{uuid: "image-1", description: "serves the nginx status page", tags: common.NewTags("berlin")},
+		{uuid: "image-2", tags: common.NewTags("nginx", "berlin")},
+		{uuid: "image-3", tags: common.NewTags("munich"), packages: NewPackages("nginx")},
+		{uuid: "image-4", repos: Repos{uuids: []string{"a0e5a8a0-1c4e-4b7a-9c61-4f3f0d8a2b11"}}},
+	}
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "should find the images, the most relevant first",
+			text:  "nginx",
+			limit: DefaultLimit,
+			want:  []string{"image-2", "image-3", "image-1"},
+		},
+		{
+			name:  "should find the images matching every term",
+			text:  "nginx berlin",
+			limit: DefaultLimit,
+			want:  []string{"image-2", "image-1"},
+		},
+		{
+			name:  "should return at most limit images",
+			text:  "nginx",
+			limit: 1,
+			want:  []string{"image-2"},
+		},
+		{
+			name:  "should find nothing, repositories aren't searched",
+			text:  "a0e5a8a0",
+			limit: DefaultLimit,
+			want:  []string{},
+		},
+		{
+			name:  "should find nothing",
+			text:  "paris",
+			limit: DefaultLimit,
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			q, err := NewSearchQuery(tt.text, tt.limit)
+			if err != nil {
+				t.Fatalf("NewSearchQuery() error = %v", err)
+			}
+			got := []string{}
+			for _, result := range SearchImages(images, q) {
+				got = append(got, result.Image().UUID())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchImages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+// ErrVersionNotFound is returned when the version of an image is not found.
+var ErrVersionNotFound = errors.New("version not found")
+
+// Snapshot is the stored state of an image at one of its versions.
+type Snapshot struct {
+	image     Image
+	parent    Parent
+	locked    bool
+	commit    Commit
+	createdAt time.Time
+}
+
+// Snapshot returns a snapshot of the current version of the image.
+func (image Image) Snapshot() Snapshot {
+	return Snapshot{image: image, parent: image.parent, createdAt: image.UpdatedAt()}
+}
+
+// IsZero returns true if the snapshot is empty.
+func (s Snapshot) IsZero() bool {
+	return s.image.IsZero() && s.parent.IsZero() && !s.locked && s.commit.IsZero() && s.createdAt.IsZero()
+}
+
+// Image returns the image as it was at the snapshot's version.
+func (s Snapshot) Image() Image {
+	return s.image
+}
+
+// UUID returns the uuid of the image the snapshot belongs to.
+func (s Snapshot) UUID() string {
+	return s.image.UUID()
+}
+
+// Version returns the version of the snapshot.
+func (s Snapshot) Version() Version {
+	return s.image.Version()
+}
+
+// Status returns the status of the image at the snapshot's version.
+func (s Snapshot) Status() Status {
+	return s.image.Status()
+}
+
+// Parent returns the version the snapshot's version was derived from, zero if it's an original.
+func (s Snapshot) Parent() Parent {
+	return s.parent
+}
+
+// Locked returns true if the version is protected from pruning.
+func (s Snapshot) Locked() bool {
+	return s.locked
+}
+
+// Commit returns the ostree commit the version was built into, zero until its build completed.
+func (s Snapshot) Commit() Commit {
+	return s.commit
+}
+
+// CreatedAt returns the time the version was first stored.
+func (s Snapshot) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// SetLocked sets whether the version is protected from pruning.
+func (s *Snapshot) SetLocked(locked bool) {
+	s.locked = locked
+}
+
+// SetCommit sets the ostree commit the version was built into.
+func (s *Snapshot) SetCommit(commit Commit) {
+	s.commit = commit
+}
+
+// MarshalGorm converts a snapshot to a database image version.
+func (s Snapshot) MarshalGorm() *models.ImageVersion {
+	if s.image.IsZero() {
+		return nil
+	}
+	account, err := common.GetAccountFromContext(s.image.ctx)
+	if err != nil {
+		return nil
+	}
+	model := &models.ImageVersion{
+		Account:   account.String(),
+		ImageUUID: s.UUID(),
+		Version:   s.Version().Uint(),
+		Status:    s.Status().String(),
+		Locked:    s.locked,
+		Commit:    s.commit.String(),
+		Data:      string(s.image.MarshalRedis()),
+
+		ParentUUID:    s.parent.UUID(),
+		ParentVersion: s.parent.Version().Uint(),
+		Relation:      s.parent.Relation().String(),
+	}
+	model.CreatedAt = s.createdAt // zero lets the database set it
+	return model
+}
+
+// UnmarshalSnapshotFromDatabase unmarshals a snapshot from the database.
+func UnmarshalSnapshotFromDatabase(ctx context.Context, data string, parent Parent, locked bool, commit string,
+	createdAt time.Time) (Snapshot, error) {
+	if ctx == nil {
+		return Snapshot{}, ErrEmptyContext
+	}
+	validCommit, err := NewCommit(commit)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var image Image
+	if err := json.Unmarshal([]byte(data), &image); err != nil {
+		return Snapshot{}, err
+	}
+	image.ctx = ctx
+	image.WithCancel()
+	return Snapshot{image: image, parent: parent, locked: locked, commit: validCommit, createdAt: createdAt}, nil
+}
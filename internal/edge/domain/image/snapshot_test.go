@@ -0,0 +1,245 @@
+package image
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/redhatinsights/edge-api/config"
+)
+
+// newTestSnapshot returns a snapshot of a valid image at the given version.
+func newTestSnapshot(t *testing.T, version uint, status string, locked bool, createdAt time.Time) *Snapshot {
+	validSSHKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
+	image, err := NewImageWithContext(context.Background(), "valid-uuid", "valid-name", "valid-description",
+		"rhel8", status, "valid-username", validSSHKey, nil, []string{"tag1"}, []string{"package1"}, version, nil)
+	if err != nil {
+		t.Fatalf("failed to create image: %s", err)
+	}
+	image.SetTime(common.NewTime(createdAt, createdAt, time.Time{}))
+	snapshot := image.Snapshot()
+	snapshot.SetLocked(locked)
+	return &snapshot
+}
+
+func TestImage_Snapshot(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		version     uint
+		status      string
+		wantVersion uint
+	}{
+		{
+			name:        "should snapshot the current version",
+			version:     3,
+			status:      "success",
+			wantVersion: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := newTestSnapshot(t, tt.version, tt.status, false, now)
+			if got.Version().Uint() != tt.wantVersion {
+				t.Errorf("Image.Snapshot().Version() = %v, want %v", got.Version().Uint(), tt.wantVersion)
+			}
+			if got.Status().String() != tt.status {
+				t.Errorf("Image.Snapshot().Status() = %v, want %v", got.Status(), tt.status)
+			}
+			if !got.CreatedAt().Equal(now) {
+				t.Errorf("Image.Snapshot().CreatedAt() = %v, want %v", got.CreatedAt(), now)
+			}
+			if got.Locked() {
+				t.Errorf("Image.Snapshot().Locked() = %v, want %v", got.Locked(), false)
+			}
+		})
+	}
+}
+
+func TestSnapshot_SetLocked(t *testing.T) {
+	tests := []struct {
+		name   string
+		lock   bool
+		want   bool
+		locked bool
+	}{
+		{
+			name:   "should lock",
+			lock:   true,
+			locked: false,
+			want:   true,
+		},
+		{
+			name:   "should unlock",
+			lock:   false,
+			locked: true,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := newTestSnapshot(t, 1, "success", tt.locked, time.Now())
+			s.SetLocked(tt.lock)
+			if got := s.Locked(); got != tt.want {
+				t.Errorf("Snapshot.Locked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshot_IsZero(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Snapshot
+		want bool
+	}{
+		{
+			name: "empty",
+			s:    Snapshot{},
+			want: true,
+		},
+		{
+			name: "not empty",
+			s:    *newTestSnapshot(t, 1, "success", false, time.Now()),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.s.IsZero(); got != tt.want {
+				t.Errorf("Snapshot.IsZero() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshot_MarshalGorm(t *testing.T) {
+	config.Init()
+	tests := []struct {
+		name      string
+		s         Snapshot
+		wantNil   bool
+		wantValue uint
+	}{
+		{
+			name:    "empty",
+			s:       Snapshot{},
+			wantNil: true,
+		},
+		{
+			name:      "should succeed",
+			s:         *newTestSnapshot(t, 2, "building", true, time.Now()),
+			wantNil:   false,
+			wantValue: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.s.MarshalGorm()
+			if (got == nil) != tt.wantNil {
+				t.Errorf("Snapshot.MarshalGorm() = %v, wantNil %v", got, tt.wantNil)
+				return
+			}
+			if tt.wantNil {
+				return
+			}
+			if got.Version != tt.wantValue || got.Status != tt.s.Status().String() ||
+				got.Locked != tt.s.Locked() || got.ImageUUID != tt.s.UUID() ||
+				got.Account != common.DefaultAccount.String() {
+				t.Errorf("Snapshot.MarshalGorm() = %v", got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalSnapshotFromDatabase(t *testing.T) {
+	config.Init()
+	now := time.Now()
+	valid := newTestSnapshot(t, 4, "success", false, now)
+	parent := Parent{uuid: "valid-uuid", version: Version{3}, relation: Upgraded}
+	type args struct {
+		ctx       context.Context
+		data      string
+		parent    Parent
+		locked    bool
+		commit    string
+		createdAt time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "should succeed",
+			args: args{
+				ctx:       context.Background(),
+				data:      string(valid.Image().MarshalRedis()),
+				parent:    parent,
+				locked:    true,
+				commit:    strings.Repeat("ab", 32),
+				createdAt: now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should fail, invalid commit",
+			args: args{
+				ctx:       context.Background(),
+				data:      string(valid.Image().MarshalRedis()),
+				commit:    "abcdef",
+				createdAt: now,
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail, invalid data",
+			args: args{
+				ctx:       context.Background(),
+				data:      "{",
+				createdAt: now,
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail, empty context",
+			args: args{
+				ctx:       nil,
+				data:      string(valid.Image().MarshalRedis()),
+				createdAt: now,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalSnapshotFromDatabase(tt.args.ctx, tt.args.data, tt.args.parent, tt.args.locked,
+				tt.args.commit, tt.args.createdAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalSnapshotFromDatabase() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Version() != valid.Version() || got.UUID() != valid.UUID() ||
+				got.Parent() != tt.args.parent || got.Locked() != tt.args.locked ||
+				got.Commit().String() != tt.args.commit || !got.CreatedAt().Equal(tt.args.createdAt) {
+				t.Errorf("UnmarshalSnapshotFromDatabase() = %v, want %v", got, valid)
+			}
+			if _, err := got.Image().Account(); err != nil {
+				t.Errorf("UnmarshalSnapshotFromDatabase() account error = %v", err)
+			}
+		})
+	}
+}
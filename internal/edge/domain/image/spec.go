@@ -0,0 +1,191 @@
+package image
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Spec errors
+var (
+	ErrInvalidSortField = errors.New("invalid sort field, must be one of created_at, distribution, name or status")
+	ErrInvalidPage      = errors.New("invalid page, limit must be between 1 and 100 and offset positive")
+)
+
+// Page limits
+const (
+	// DefaultLimit is the number of images in a page when not given.
+	DefaultLimit = 30
+	// MaxLimit is the maximum number of images in a page.
+	MaxLimit = 100
+)
+
+// SortField is a field images can be sorted by.
+type SortField struct {
+	name string
+}
+
+// Available sort fields
+var (
+	SortByCreatedAt    = SortField{"created_at"}
+	SortByDistribution = SortField{"distribution"}
+	SortByName         = SortField{"name"}
+	SortByStatus       = SortField{"status"}
+)
+
+var sortFields = []SortField{SortByCreatedAt, SortByDistribution, SortByName, SortByStatus}
+
+// String returns the name of the field.
+func (f SortField) String() string {
+	return f.name
+}
+
+// Sort is the sorting of images by a field, ascending or descending.
+type Sort struct {
+	field SortField
+	desc  bool
+}
+
+// NewSortFromString creates a new sort from the name of a field, prefixed by "-" to sort descending.
+func NewSortFromString(sort string) (Sort, error) {
+	name := strings.TrimPrefix(sort, "-")
+	for _, field := range sortFields {
+		if field.name == name {
+			return Sort{field: field, desc: name != sort}, nil
+		}
+	}
+	return Sort{}, ErrInvalidSortField
+}
+
+// Field returns the field to sort by.
+func (s Sort) Field() SortField {
+	return s.field
+}
+
+// Desc returns true if the sort is descending.
+func (s Sort) Desc() bool {
+	return s.desc
+}
+
+// Spec specifies which images to list: those matching its filters, in the order of its sorts,
+// limited to a page. The zero spec lists all images by creation date.
+type Spec struct {
+	name   string
+	status Status
+	sorts  []Sort
+	limit  int
+	offset int
+}
+
+// NewSpec creates a new spec. The name filter matches images whose name contains it, ignoring case,
+// an empty status matches any. sortBy is a comma separated list of sort fields, the first one sorting first.
+// A limit of 0 doesn't limit the page.
+func NewSpec(name, status, sortBy string, limit, offset int) (Spec, error) {
+	spec := Spec{name: strings.TrimSpace(name), limit: limit, offset: offset}
+	if status != "" {
+		s, err := NewStatusFromString(status)
+		if err != nil {
+			return Spec{}, err
+		}
+		spec.status = s
+	}
+	for _, field := range strings.Split(sortBy, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		s, err := NewSortFromString(field)
+		if err != nil {
+			return Spec{}, err
+		}
+		spec.sorts = append(spec.sorts, s)
+	}
+	if limit < 0 || limit > MaxLimit || offset < 0 {
+		return Spec{}, ErrInvalidPage
+	}
+	return spec, nil
+}
+
+// Name returns the name filter, empty for any.
+func (s Spec) Name() string {
+	return s.name
+}
+
+// Status returns the status filter, zero for any.
+func (s Spec) Status() Status {
+	return s.status
+}
+
+// Sorts returns the sorts, by creation date if none was given.
+func (s Spec) Sorts() []Sort {
+	if len(s.sorts) == 0 {
+		return []Sort{{field: SortByCreatedAt}}
+	}
+	return s.sorts
+}
+
+// Limit returns the maximum number of images in the page, 0 for no limit.
+func (s Spec) Limit() int {
+	return s.limit
+}
+
+// Offset returns the number of images to skip before the page.
+func (s Spec) Offset() int {
+	return s.offset
+}
+
+// Matches returns true if the image matches the filters of the spec.
+func (s Spec) Matches(image *Image) bool {
+	if s.name != "" && !strings.Contains(strings.ToLower(image.Name().String()), strings.ToLower(s.name)) {
+		return false
+	}
+	return s.status.IsZero() || image.Status() == s.status
+}
+
+// Apply returns the page of the given images the spec lists, along with the total number of matching images.
+// Images equal on all sorts keep their order.
+func (s Spec) Apply(images []*Image) ([]*Image, int) {
+	var matching []*Image
+	for _, image := range images {
+		if s.Matches(image) {
+			matching = append(matching, image)
+		}
+	}
+	sorts := s.Sorts()
+	sort.SliceStable(matching, func(i, j int) bool {
+		for _, sort := range sorts {
+			if c := compare(matching[i], matching[j], sort.field); c != 0 {
+				return (c < 0) != sort.desc
+			}
+		}
+		return false
+	})
+	total := len(matching)
+	if s.offset >= total {
+		return []*Image{}, total
+	}
+	matching = matching[s.offset:]
+	if s.limit > 0 && s.limit < len(matching) {
+		matching = matching[:s.limit]
+	}
+	return matching, total
+}
+
+// compare returns -1, 0 or 1 whether the field of a is lower, equal or greater than the field of b.
+func compare(a, b *Image, field SortField) int {
+	switch field {
+	case SortByCreatedAt:
+		switch {
+		case a.CreatedAt().Before(b.CreatedAt()):
+			return -1
+		case a.CreatedAt().After(b.CreatedAt()):
+			return 1
+		}
+		return 0
+	case SortByDistribution:
+		return strings.Compare(a.Distribution().String(), b.Distribution().String())
+	case SortByName:
+		return strings.Compare(a.Name().String(), b.Name().String())
+	default:
+		return strings.Compare(a.Status().String(), b.Status().String())
+	}
+}
@@ -0,0 +1,147 @@
+package image
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+func TestNewSpec(t *testing.T) {
+	type args struct {
+		name   string
+		status string
+		sortBy string
+		limit  int
+		offset int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantSorts []Sort
+		wantErr   error
+	}{
+		{
+			name:      "should sort by creation date by default",
+			args:      args{},
+			wantSorts: []Sort{{field: SortByCreatedAt}},
+			wantErr:   nil,
+		},
+		{
+			name:      "should sort by several fields",
+			args:      args{sortBy: "-status, name", limit: 10},
+			wantSorts: []Sort{{field: SortByStatus, desc: true}, {field: SortByName}},
+			wantErr:   nil,
+		},
+		{
+			name:    "should fail, unknown sort field",
+			args:    args{sortBy: "-version"},
+			wantErr: ErrInvalidSortField,
+		},
+		{
+			name:    "should fail, unknown status",
+			args:    args{status: "done"},
+			wantErr: ErrInvalidStatus,
+		},
+		{
+			name:    "should fail, limit too high",
+			args:    args{limit: MaxLimit + 1},
+			wantErr: ErrInvalidPage,
+		},
+		{
+			name:    "should fail, negative offset",
+			args:    args{limit: 10, offset: -1},
+			wantErr: ErrInvalidPage,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewSpec(tt.args.name, tt.args.status, tt.args.sortBy, tt.args.limit, tt.args.offset)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewSpec() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got.Sorts(), tt.wantSorts) {
+				t.Errorf("NewSpec() sorts = %v, want %v", got.Sorts(), tt.wantSorts)
+			}
+		})
+	}
+}
+
+func TestSpec_Apply(t *testing.T) {
+	now := time.Now()
+	sshKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDFjRxF1E73z1K9AjltDkuJyGUW3YluTEAW6PvHEZH6vnzNHI+cut716lGGRFHlYk1Fk51Q/92ZlynJ/HqByaK/MJppkQSL4x3KEm6s5ciwXbVEb3ct4waTgqxPD9gy7NN0uzbrhQMillb50yZgox6d9A/JmyRA1Dlai/esrlKfZ4wtSUl+CMsPoVxC6pIsh1YqUWE7S/dvXsQ8V+O7H0sdXAkZMg09kLUOQe3fliTMg6wppW+tb30g4MWAbHSrXksL1TpYjmP0M+stNetO2EIZ07bc8KpQhZybdM8LUhhPGuZXuKzIlwbkDI7C1yLv574wOYCjG/zk7Zu9qO7p6u8x valid@sshkey"
+	newTestImage := func(uuid, name, status string, createdAt time.Time) *Image {
+		image, _ := NewImage(uuid, name, "", "rhel-85", status, "user", sshKey, []string{"rhel-edge-commit"},
+			nil, nil, 1, nil)
+		image.SetTime(common.NewTime(createdAt, createdAt, time.Time{}))
+		return &image
+	}
+	images := []*Image{
+		newTestImage("kiosk-uuid", "Kiosk", "success", now.Add(-3*time.Hour)),
+		newTestImage("kiosk-lab-uuid", "kiosk-lab", "building", now.Add(-2*time.Hour)),
+		newTestImage("register-uuid", "register", "success", now.Add(-time.Hour)),
+	}
+	uuids := func(images []*Image) []string {
+		uuids := []string{}
+		for _, image := range images {
+			uuids = append(uuids, image.UUID())
+		}
+		return uuids
+	}
+	tests := []struct {
+		name      string
+		spec      func() (Spec, error)
+		want      []string
+		wantTotal int
+	}{
+		{
+			name:      "should list all images by creation date",
+			spec:      func() (Spec, error) { return Spec{}, nil },
+			want:      []string{"kiosk-uuid", "kiosk-lab-uuid", "register-uuid"},
+			wantTotal: 3,
+		},
+		{
+			name:      "should filter by name ignoring case",
+			spec:      func() (Spec, error) { return NewSpec("KIOSK", "", "-created_at", 0, 0) },
+			want:      []string{"kiosk-lab-uuid", "kiosk-uuid"},
+			wantTotal: 2,
+		},
+		{
+			name:      "should filter by status and sort by name",
+			spec:      func() (Spec, error) { return NewSpec("", "success", "-name", 0, 0) },
+			want:      []string{"register-uuid", "kiosk-uuid"},
+			wantTotal: 2,
+		},
+		{
+			name:      "should return a page along with the total",
+			spec:      func() (Spec, error) { return NewSpec("", "", "status,created_at", 1, 1) },
+			want:      []string{"kiosk-uuid"},
+			wantTotal: 3,
+		},
+		{
+			name:      "should return an empty page past the end",
+			spec:      func() (Spec, error) { return NewSpec("", "", "", 10, 3) },
+			want:      []string{},
+			wantTotal: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			spec, err := tt.spec()
+			if err != nil {
+				t.Fatalf("NewSpec() error = %v", err)
+			}
+			got, total := spec.Apply(images)
+			if !reflect.DeepEqual(uuids(got), tt.want) || total != tt.wantTotal {
+				t.Errorf("Spec.Apply() = %v, %d, want %v, %d", uuids(got), total, tt.want, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ func (image *Image) Upgrade() error {
 		return ErrAlreadyBuilding
 	}
 	image.status = Building
+	image.parent = Parent{uuid: image.uuid, version: image.version, relation: Upgraded}
 	image.version.Update()
 	image.WithTimeout(90 * time.Minute) // 1.5h timeout
 	// TODO: implement this with image-builder client.
@@ -18,6 +19,8 @@ func (image *Image) Upgrade() error {
 func (image *Image) Rollback() error {
 	image.Cancel()
 	image.status = Success // image rolled back already present
+	// the previous version keeps its own parent
+	image.parent = Parent{}
 	image.version.Rollback()
 	return nil
 }
@@ -0,0 +1,72 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+)
+
+var (
+	// ErrWindowNotFound is the error returned when the window is not found.
+	ErrWindowNotFound = errors.New("maintenance window not found")
+)
+
+// Repository interface for handling maintenance window data store/retrieve.
+type Repository interface {
+	// CreateWindow creates a new window.
+	CreateWindow(ctx context.Context, window *Window) error
+	// GetWindow returns the window with the given UUID.
+	GetWindow(ctx context.Context, uuid string) (*Window, error)
+	// GetWindows returns all windows.
+	GetWindows(ctx context.Context) ([]*Window, error)
+	// DeleteWindow deletes the window with the given UUID.
+	DeleteWindow(ctx context.Context, uuid string) error
+	// GetTargetWindows returns the windows applying to the given device or to any of the given groups.
+	GetTargetWindows(ctx context.Context, deviceUUID string, groupUUIDs []string) ([]*Window, error)
+}
+
+// GetDeviceWindows returns the windows of the device with the given UUID,
+// its own and those of the groups it is a member of.
+func GetDeviceWindows(ctx context.Context, repository Repository, groupRepository group.Repository,
+	deviceUUID string) (Windows, error) {
+	groups, err := groupRepository.GetDeviceGroups(ctx, deviceUUID)
+	if err != nil {
+		return nil, err
+	}
+	groupUUIDs := make([]string, len(groups))
+	for i, deviceGroup := range groups {
+		groupUUIDs[i] = deviceGroup.UUID()
+	}
+	windows, err := repository.GetTargetWindows(ctx, deviceUUID, groupUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	return Windows(windows), nil
+}
+
+// MarshalGorm converts a domain Window to a database MaintenanceWindow.
+// Targets are stored in their own table, so the windows of a device are found in SQL.
+func (w Window) MarshalGorm() *models.MaintenanceWindow {
+	if w.IsZero() { // if window is nil, return nil
+		return nil
+	}
+	account, err := w.Account()
+	if err != nil {
+		return nil
+	}
+	model := &models.MaintenanceWindow{
+		Account:  account.String(),
+		UUID:     w.UUID(),
+		Name:     w.Name().String(),
+		Schedule: w.Schedule().String(),
+		Duration: w.Duration(),
+		Timezone: w.Timezone(),
+	}
+	if !w.timing.IsZero() {
+		model.CreatedAt = w.timing.CreatedAt()
+		model.UpdatedAt = w.timing.UpdatedAt()
+	}
+	return model
+}
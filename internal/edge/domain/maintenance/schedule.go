@@ -0,0 +1,187 @@
+package maintenance
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule is returned when the schedule is not a valid cron expression.
+var ErrInvalidSchedule = errors.New("invalid schedule, expected a cron expression: minute hour day-of-month month day-of-week")
+
+// searchLimit bounds the search for the next start of a schedule, a schedule that never starts
+// within it (e.g. "0 0 30 2 *") has no next start.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+// field is the range of values of a field of a cron expression.
+type field struct {
+	min, max uint
+}
+
+var (
+	minutes  = field{0, 59}
+	hours    = field{0, 23}
+	days     = field{1, 31}
+	months   = field{1, 12}
+	weekdays = field{0, 7} // both 0 and 7 are sunday
+)
+
+// Schedule is a recurring schedule written as a cron expression of five fields:
+// minute, hour, day of month, month and day of week. Each field is either "*", a value, a range "a-b",
+// a step "*/n" or "a-b/n", or a comma separated list of those.
+// Like cron, a restricted day of month and day of week match a day if either of them does.
+type Schedule struct {
+	expr     string
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	// whether the day fields are "*"
+	anyDay     bool
+	anyWeekday bool
+}
+
+// NewSchedule parses a new schedule from a cron expression.
+func NewSchedule(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, ErrInvalidSchedule
+	}
+	var bits [5]uint64
+	for i, f := range []field{minutes, hours, days, months, weekdays} {
+		parsed, err := f.parse(fields[i])
+		if err != nil {
+			return Schedule{}, err
+		}
+		bits[i] = parsed
+	}
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1 // sunday
+	}
+	return Schedule{
+		expr:       strings.Join(fields, " "),
+		minutes:    bits[0],
+		hours:      bits[1],
+		days:       bits[2],
+		months:     bits[3],
+		weekdays:   bits[4],
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}, nil
+}
+
+// parse parses a field of a cron expression into the set of its values.
+func (f field) parse(expr string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeAndStep := strings.SplitN(part, "/", 2)
+		rangeExpr, hasStep := rangeAndStep[0], len(rangeAndStep) == 2
+		step := uint(1)
+		if hasStep {
+			parsed, err := strconv.ParseUint(rangeAndStep[1], 10, 8)
+			if err != nil || parsed == 0 {
+				return 0, ErrInvalidSchedule
+			}
+			step = uint(parsed)
+		}
+		low, high := f.min, f.max
+		if rangeExpr != "*" {
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = f.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			} else if hasStep { // "a/n" means from a to the end of the range
+				high = f.max
+			}
+			if low > high {
+				return 0, ErrInvalidSchedule
+			}
+		}
+		for value := low; value <= high; value += step {
+			set |= 1 << value
+		}
+	}
+	return set, nil
+}
+
+// value parses a single value of the field.
+func (f field) value(expr string) (uint, error) {
+	parsed, err := strconv.ParseUint(expr, 10, 8)
+	if err != nil || uint(parsed) < f.min || uint(parsed) > f.max {
+		return 0, ErrInvalidSchedule
+	}
+	return uint(parsed), nil
+}
+
+// IsZero returns true if the schedule is zero.
+func (s Schedule) IsZero() bool {
+	return s.expr == ""
+}
+
+// String returns the cron expression of the schedule.
+func (s Schedule) String() string {
+	return s.expr
+}
+
+// Matches returns true if the schedule starts at the minute of the given time, in its location.
+func (s Schedule) Matches(t time.Time) bool {
+	return s.matchesDay(t) && has(s.hours, t.Hour()) && has(s.minutes, t.Minute())
+}
+
+// matchesDay returns true if the schedule starts on the day of the given time.
+func (s Schedule) matchesDay(t time.Time) bool {
+	if !has(s.months, int(t.Month())) {
+		return false
+	}
+	day, weekday := has(s.days, t.Day()), has(s.weekdays, int(t.Weekday()))
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	}
+	return day || weekday
+}
+
+// Next returns the first start of the schedule at or after the given time, in the given location.
+// ok is false if the schedule doesn't start within the next five years.
+func (s Schedule) Next(after time.Time, location *time.Location) (next time.Time, ok bool) {
+	t := after.In(location)
+	if !t.Truncate(time.Minute).Equal(t) {
+		t = t.Truncate(time.Minute).Add(time.Minute)
+	}
+	limit := t.Add(searchLimit)
+	for t.Before(limit) {
+		var skipTo time.Time
+		switch {
+		case !s.matchesDay(t):
+			skipTo = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+		case !has(s.hours, t.Hour()):
+			skipTo = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, location)
+		case !has(s.minutes, t.Minute()):
+			skipTo = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+		if !skipTo.After(t) { // a daylight saving time change can move a wall clock date backwards
+			skipTo = t.Add(time.Minute)
+		}
+		t = skipTo
+	}
+	return time.Time{}, false
+}
+
+// has returns true if the value is in the set.
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
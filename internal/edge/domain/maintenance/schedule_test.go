@@ -0,0 +1,127 @@
+package maintenance
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr error
+	}{
+		{name: "should parse every minute", expr: "* * * * *", wantErr: nil},
+		{name: "should parse nightly on weekdays", expr: "0 2 * * 1-5", wantErr: nil},
+		{name: "should parse lists, ranges and steps", expr: "0,30 */4 1-15/2 1,6 0,7", wantErr: nil},
+		{name: "should parse a step from a value", expr: "5/15 * * * *", wantErr: nil},
+		{name: "should fail, missing field", expr: "0 2 * *", wantErr: ErrInvalidSchedule},
+		{name: "should fail, out of range", expr: "0 24 * * *", wantErr: ErrInvalidSchedule},
+		{name: "should fail, reversed range", expr: "0 5-2 * * *", wantErr: ErrInvalidSchedule},
+		{name: "should fail, zero step", expr: "*/0 * * * *", wantErr: ErrInvalidSchedule},
+		{name: "should fail, not a number", expr: "0 2 * * mon", wantErr: ErrInvalidSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewSchedule(tt.expr)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// a monday
+	monday := time.Date(2022, time.March, 7, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		expr     string
+		after    time.Time
+		location *time.Location
+		want     time.Time
+		wantOk   bool
+	}{
+		{
+			name:     "should start at the given time",
+			expr:     "30 10 * * *",
+			after:    monday,
+			location: time.UTC,
+			want:     monday,
+			wantOk:   true,
+		},
+		{
+			name:     "should start the next minute",
+			expr:     "* * * * *",
+			after:    monday.Add(time.Second),
+			location: time.UTC,
+			want:     monday.Add(time.Minute),
+			wantOk:   true,
+		},
+		{
+			name:     "should start the next day",
+			expr:     "0 2 * * *",
+			after:    monday,
+			location: time.UTC,
+			want:     time.Date(2022, time.March, 8, 2, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "should start on saturday",
+			expr:     "0 2 * * 6",
+			after:    monday,
+			location: time.UTC,
+			want:     time.Date(2022, time.March, 12, 2, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "should start on the first of the month or on sunday",
+			expr:     "0 0 1 * 0",
+			after:    time.Date(2022, time.March, 28, 0, 0, 0, 0, time.UTC), // monday
+			location: time.UTC,
+			want:     time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "should start in the timezone",
+			expr:     "0 2 * * *",
+			after:    monday,
+			location: newYork,
+			want:     time.Date(2022, time.March, 8, 7, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "should skip the hour missing on daylight saving time",
+			expr:     "30 2 * * *",
+			after:    time.Date(2022, time.March, 13, 0, 0, 0, 0, newYork),
+			location: newYork,
+			want:     time.Date(2022, time.March, 14, 2, 30, 0, 0, newYork),
+			wantOk:   true,
+		},
+		{
+			name:     "should never start",
+			expr:     "0 0 30 2 *",
+			after:    monday,
+			location: time.UTC,
+			wantOk:   false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			schedule, err := NewSchedule(tt.expr)
+			if err != nil {
+				t.Fatalf("NewSchedule() error = %v", err)
+			}
+			got, ok := schedule.Next(tt.after, tt.location)
+			if ok != tt.wantOk || !got.Equal(tt.want) {
+				t.Errorf("Schedule.Next() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"time"
+	_ "time/tzdata" // embed the timezone database, containers often don't have one
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+// Maintenance window errors
+var (
+	ErrEmptyContext    = errors.New("empty context")
+	ErrInvalidDuration = errors.New("invalid duration, a window lasts between a minute and a day")
+	ErrInvalidTimezone = errors.New("invalid timezone")
+	ErrInvalidTarget   = errors.New("invalid target, a window applies to at least one device or group")
+	ErrUnknownTarget   = errors.New("unknown device or group in window")
+)
+
+// Bounds of the duration of a window.
+const (
+	MinDuration = time.Minute
+	MaxDuration = 24 * time.Hour
+)
+
+// Window is a recurring maintenance window of devices, given directly or through their groups.
+// Devices having windows only get their updates dispatched while one of them is open.
+type Window struct {
+	// context
+	ctx context.Context
+	// identity
+	uuid string
+	name common.Name
+	// recurrence
+	schedule Schedule
+	duration time.Duration
+	location *time.Location
+	// targets
+	devices []string
+	groups  []string
+	// time
+	timing common.Time
+}
+
+// NewWindowWithContext creates a new maintenance window opening at each start of the schedule,
+// in the given timezone, for the given duration. An empty timezone is UTC.
+func NewWindowWithContext(ctx context.Context, uuid, name, schedule string, duration time.Duration,
+	timezone string, devices, groups []string) (Window, error) {
+	if ctx == nil {
+		return Window{}, ErrEmptyContext
+	}
+	validName, err := common.NewName(name)
+	if err != nil {
+		return Window{}, err
+	}
+	validSchedule, err := NewSchedule(schedule)
+	if err != nil {
+		return Window{}, err
+	}
+	if duration < MinDuration || duration > MaxDuration {
+		return Window{}, ErrInvalidDuration
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "Local" {
+		return Window{}, ErrInvalidTimezone
+	}
+	devices, groups = unique(devices), unique(groups)
+	if len(devices) == 0 && len(groups) == 0 {
+		return Window{}, ErrInvalidTarget
+	}
+	for _, target := range append(append([]string{}, devices...), groups...) {
+		if target == "" {
+			return Window{}, ErrInvalidTarget
+		}
+	}
+	return Window{
+		ctx:      ctx,
+		uuid:     uuid,
+		name:     validName,
+		schedule: validSchedule,
+		duration: duration,
+		location: location,
+		devices:  devices,
+		groups:   groups,
+	}, nil
+}
+
+// unique returns the given values without duplicates, keeping their order.
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var uniqueValues []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			uniqueValues = append(uniqueValues, value)
+		}
+	}
+	return uniqueValues
+}
+
+// IsZero returns true if the window is zero.
+func (w Window) IsZero() bool {
+	return w.uuid == "" && w.name.IsZero() && w.schedule.IsZero() && w.duration == 0 &&
+		w.location == nil && len(w.devices) == 0 && len(w.groups) == 0 && w.timing.IsZero()
+}
+
+// Account is a getter for the account of a window.
+func (w Window) Account() (common.Account, error) {
+	return common.GetAccountFromContext(w.ctx)
+}
+
+// UUID is a getter for the uuid of a window.
+func (w Window) UUID() string {
+	return w.uuid
+}
+
+// Name is a getter for the name of a window.
+func (w Window) Name() common.Name {
+	return w.name
+}
+
+// Schedule is a getter for the schedule the window opens at.
+func (w Window) Schedule() Schedule {
+	return w.schedule
+}
+
+// Duration is a getter for how long the window stays open.
+func (w Window) Duration() time.Duration {
+	return w.duration
+}
+
+// Timezone is a getter for the timezone the schedule is read in.
+func (w Window) Timezone() string {
+	return w.location.String()
+}
+
+// Devices is a getter for the devices the window applies to directly.
+func (w Window) Devices() []string {
+	return w.devices
+}
+
+// Groups is a getter for the groups whose members the window applies to.
+func (w Window) Groups() []string {
+	return w.groups
+}
+
+// CreatedAt is a getter for the created at time of a window.
+func (w Window) CreatedAt() time.Time {
+	return w.timing.CreatedAt()
+}
+
+// UpdatedAt is a getter for the updated at time of a window.
+func (w Window) UpdatedAt() time.Time {
+	return w.timing.UpdatedAt()
+}
+
+// Next returns when the window is open next: the opening it is in at the given time, if any,
+// otherwise the first one after it. ok is false if the window never opens again.
+func (w Window) Next(now time.Time) (start, end time.Time, ok bool) {
+	// the window is open if the schedule started within its duration before now
+	start, ok = w.schedule.Next(now.Add(-w.duration).Add(time.Nanosecond), w.location)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(w.duration), true
+}
+
+// IsOpen returns true if the window is open at the given time.
+func (w Window) IsOpen(now time.Time) bool {
+	start, _, ok := w.Next(now)
+	return ok && !start.After(now)
+}
+
+// SetTime sets the time of a window.
+func (w *Window) SetTime(timing common.Time) {
+	w.timing = timing
+}
+
+// Touch marks the window as stored at the given time, setting its creation time if it has none.
+func (w *Window) Touch(now time.Time) {
+	now = now.UTC().Truncate(time.Microsecond)
+	createdAt := w.CreatedAt()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	w.timing = common.NewTime(createdAt, now, time.Time{})
+}
+
+// UnmarshalWindowFromDatabase unmarshals the window from the database.
+func UnmarshalWindowFromDatabase(ctx context.Context, uuid, name, schedule string, duration time.Duration,
+	timezone string, devices, groups []string, createdAt, updatedAt time.Time) (Window, error) {
+	window, err := NewWindowWithContext(ctx, uuid, name, schedule, duration, timezone, devices, groups)
+	if err != nil {
+		return Window{}, err
+	}
+	window.SetTime(common.NewTime(createdAt, updatedAt, time.Time{}))
+	return window, nil
+}
+
+// Windows are the maintenance windows of a device, its own and those of the groups it is a member of.
+type Windows []*Window
+
+// IsOpen returns true if any of the windows is open at the given time,
+// a device without windows can be updated at any time.
+func (ws Windows) IsOpen(now time.Time) bool {
+	if len(ws) == 0 {
+		return true
+	}
+	for _, w := range ws {
+		if w.IsOpen(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the earliest next opening of the windows, see Window.Next.
+// ok is false if there are no windows or none of them opens again.
+func (ws Windows) Next(now time.Time) (start, end time.Time, ok bool) {
+	for _, w := range ws {
+		wStart, wEnd, wOk := w.Next(now)
+		if !wOk {
+			continue
+		}
+		if !ok || wStart.Before(start) || (wStart.Equal(start) && wEnd.After(end)) {
+			start, end, ok = wStart, wEnd, true
+		}
+	}
+	return start, end, ok
+}
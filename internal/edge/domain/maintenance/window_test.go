@@ -0,0 +1,151 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewWindowWithContext(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		schedule string
+		duration time.Duration
+		timezone string
+		devices  []string
+		groups   []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "should create a window for a group",
+			args: args{ctx: context.Background(), schedule: "0 2 * * *", duration: 2 * time.Hour,
+				timezone: "Europe/Paris", groups: []string{"group-uuid"}},
+			wantErr: nil,
+		},
+		{
+			name: "should create a window for a device in UTC",
+			args: args{ctx: context.Background(), schedule: "0 2 * * *", duration: time.Hour,
+				devices: []string{"device-uuid"}},
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, empty context",
+			args:    args{schedule: "0 2 * * *", duration: time.Hour, devices: []string{"device-uuid"}},
+			wantErr: ErrEmptyContext,
+		},
+		{
+			name: "should fail, invalid schedule",
+			args: args{ctx: context.Background(), schedule: "2am", duration: time.Hour,
+				devices: []string{"device-uuid"}},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name: "should fail, too short",
+			args: args{ctx: context.Background(), schedule: "0 2 * * *", duration: time.Second,
+				devices: []string{"device-uuid"}},
+			wantErr: ErrInvalidDuration,
+		},
+		{
+			name: "should fail, too long",
+			args: args{ctx: context.Background(), schedule: "0 2 * * *", duration: 25 * time.Hour,
+				devices: []string{"device-uuid"}},
+			wantErr: ErrInvalidDuration,
+		},
+		{
+			name: "should fail, unknown timezone",
+			args: args{ctx: context.Background(), schedule: "0 2 * * *", duration: time.Hour,
+				timezone: "Mars/Olympus_Mons", devices: []string{"device-uuid"}},
+			wantErr: ErrInvalidTimezone,
+		},
+		{
+			name:    "should fail, no target",
+			args:    args{ctx: context.Background(), schedule: "0 2 * * *", duration: time.Hour},
+			wantErr: ErrInvalidTarget,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewWindowWithContext(tt.args.ctx, "window-uuid", "nightly", tt.args.schedule,
+				tt.args.duration, tt.args.timezone, tt.args.devices, tt.args.groups)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewWindowWithContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWindows_Next(t *testing.T) {
+	nightly, _ := NewWindowWithContext(context.Background(), "nightly-uuid", "nightly", "0 2 * * *",
+		2*time.Hour, "", []string{"device-uuid"}, nil)
+	weekly, _ := NewWindowWithContext(context.Background(), "weekly-uuid", "weekly", "0 22 * * 6",
+		8*time.Hour, "", nil, []string{"group-uuid"})
+	saturday := time.Date(2022, time.March, 12, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		windows   Windows
+		now       time.Time
+		wantStart time.Time
+		wantOk    bool
+		wantOpen  bool
+	}{
+		{
+			name:      "should wait for the nightly window",
+			windows:   Windows{&nightly},
+			now:       saturday,
+			wantStart: saturday.Add(2 * time.Hour),
+			wantOk:    true,
+			wantOpen:  false,
+		},
+		{
+			name:      "should be in the nightly window",
+			windows:   Windows{&nightly},
+			now:       saturday.Add(3 * time.Hour),
+			wantStart: saturday.Add(2 * time.Hour),
+			wantOk:    true,
+			wantOpen:  true,
+		},
+		{
+			name:      "should be in the weekly window opened the day before",
+			windows:   Windows{&nightly, &weekly},
+			now:       saturday.Add(25 * time.Hour),
+			wantStart: saturday.Add(22 * time.Hour),
+			wantOk:    true,
+			wantOpen:  true,
+		},
+		{
+			name:      "should wait for the earliest window",
+			windows:   Windows{&weekly, &nightly},
+			now:       saturday.Add(12 * time.Hour),
+			wantStart: saturday.Add(22 * time.Hour),
+			wantOk:    true,
+			wantOpen:  false,
+		},
+		{
+			name:     "should always be open without windows",
+			windows:  nil,
+			now:      saturday,
+			wantOk:   false,
+			wantOpen: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotStart, _, gotOk := tt.windows.Next(tt.now)
+			if gotOk != tt.wantOk || !gotStart.Equal(tt.wantStart) {
+				t.Errorf("Windows.Next() = %v, %v, want %v, %v", gotStart, gotOk, tt.wantStart, tt.wantOk)
+			}
+			if got := tt.windows.IsOpen(tt.now); got != tt.wantOpen {
+				t.Errorf("Windows.IsOpen() = %v, want %v", got, tt.wantOpen)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package ostree
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+// Repo errors
+var (
+	ErrInvalidRepo    = errors.New("invalid ostree repository, account and image are required")
+	ErrInvalidPath    = errors.New("invalid ostree repository path")
+	ErrNotPublishable = errors.New("only versions successfully built as edge commits can be published")
+)
+
+// served are the files and directories of an ostree repository its http remote serves.
+var served = []string{"config", "summary", "summary.sig", "objects/", "refs/", "deltas/", "delta-indexes/"}
+
+// Repo is the ostree repository of an image, stored per account and served to its devices as an http remote.
+type Repo struct {
+	account   common.Account
+	imageUUID string
+}
+
+// NewRepo returns the repository of the image with the given uuid in the account.
+// The uuid is a single path element of the storage, so it can't be "." or ".." nor contain a "/".
+func NewRepo(account common.Account, imageUUID string) (Repo, error) {
+	if account.IsZero() || strings.TrimSpace(imageUUID) == "" || strings.Contains(imageUUID, "/") ||
+		imageUUID == "." || imageUUID == ".." {
+		return Repo{}, ErrInvalidRepo
+	}
+	return Repo{account: account, imageUUID: imageUUID}, nil
+}
+
+// Account returns the account of the repository.
+func (r Repo) Account() common.Account {
+	return r.account
+}
+
+// ImageUUID returns the uuid of the image of the repository.
+func (r Repo) ImageUUID() string {
+	return r.imageUUID
+}
+
+// Path returns the storage path of the given file of the repository, "<account>/<image>/<file>".
+// Only the files an http remote serves are part of the repository, ErrInvalidPath is returned otherwise.
+func (r Repo) Path(file string) (string, error) {
+	cleaned := path.Clean("/" + file)[1:]
+	if cleaned == "" || cleaned != strings.TrimPrefix(file, "/") {
+		return "", ErrInvalidPath
+	}
+	for _, s := range served {
+		if cleaned == s || (strings.HasSuffix(s, "/") && strings.HasPrefix(cleaned, s)) {
+			return path.Join(r.account.String(), r.imageUUID, cleaned), nil
+		}
+	}
+	return "", ErrInvalidPath
+}
+
+// RemoteURL returns the url of the http remote of the repository, relative to the given base url.
+func (r Repo) RemoteURL(baseURL string) string {
+	return strings.TrimSuffix(baseURL, "/") + "/images/" + r.imageUUID + "/repo"
+}
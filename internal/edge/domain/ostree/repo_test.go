@@ -0,0 +1,69 @@
+package ostree
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+func TestRepo_Path(t *testing.T) {
+	repo, err := NewRepo(common.DefaultAccount, "image-uuid")
+	if err != nil {
+		t.Fatalf("NewRepo() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		file    string
+		want    string
+		wantErr error
+	}{
+		{name: "should serve the config", file: "config", want: "0000000/image-uuid/config"},
+		{name: "should serve the summary", file: "summary", want: "0000000/image-uuid/summary"},
+		{name: "should serve an object", file: "objects/ab/cdef.commit", want: "0000000/image-uuid/objects/ab/cdef.commit"},
+		{name: "should serve a ref", file: "/refs/heads/rhel/8/x86_64/edge", want: "0000000/image-uuid/refs/heads/rhel/8/x86_64/edge"},
+		{name: "should fail, not served", file: "state/lock", wantErr: ErrInvalidPath},
+		{name: "should fail, directory", file: "objects/", wantErr: ErrInvalidPath},
+		{name: "should fail, empty", file: "", wantErr: ErrInvalidPath},
+		{name: "should fail, traversal", file: "objects/../../other-image/config", wantErr: ErrInvalidPath},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := repo.Path(tt.file)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Repo.Path() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Repo.Path() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRepo(t *testing.T) {
+	tests := []struct {
+		name      string
+		account   common.Account
+		imageUUID string
+		wantErr   error
+	}{
+		{name: "should create a repository", account: common.DefaultAccount, imageUUID: "image-uuid"},
+		{name: "should fail, no account", imageUUID: "image-uuid", wantErr: ErrInvalidRepo},
+		{name: "should fail, no image", account: common.DefaultAccount, wantErr: ErrInvalidRepo},
+		{name: "should fail, image is a path", account: common.DefaultAccount, imageUUID: "../image-uuid", wantErr: ErrInvalidRepo},
+		{name: "should fail, image is the account directory", account: common.DefaultAccount, imageUUID: ".", wantErr: ErrInvalidRepo},
+		{name: "should fail, image is the storage root", account: common.DefaultAccount, imageUUID: "..", wantErr: ErrInvalidRepo},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := NewRepo(tt.account, tt.imageUUID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewRepo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package ostree
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrFileNotFound is returned when a file is not in the storage.
+var ErrFileNotFound = errors.New("ostree repository file not found")
+
+// Storage is a backend storing the files of ostree repositories by path.
+type Storage interface {
+	// Write stores the content read from r at the given path, replacing any file stored there.
+	Write(ctx context.Context, path string, r io.Reader) error
+	// Open returns the content of the file stored at the given path, the caller must close it.
+	Open(ctx context.Context, path string) (io.ReadCloser, error)
+}
@@ -0,0 +1,186 @@
+package ostree
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Tarball errors
+var (
+	ErrInvalidTarball  = errors.New("invalid commit tarball")
+	ErrNotArchiveRepo  = errors.New("ostree repository must be in archive mode to be served over http")
+	ErrMetadataTooLong = errors.New("ostree repository metadata too long")
+	ErrCommitMismatch  = errors.New("commit tarball has no ref to the commit of the version")
+)
+
+// MaxMetadataSize is the maximum size of the config, summary and refs files of a repository.
+const MaxMetadataSize = 16 << 20
+
+// Refs are the refs of a repository, by name, pointing to the checksum of their commit.
+type Refs map[string]string
+
+// Unpack stores the ostree repository of a commit tarball, plain or gzipped, as the given repository
+// and returns the checksum of its commit. The repository is either the root of the tarball or its "repo" directory.
+// A ref must point to the given commit, or the repository must have a single ref if none is given.
+// Objects are stored first and the metadata last, so that devices never see refs to missing objects.
+func Unpack(ctx context.Context, storage Storage, repo Repo, tarball io.Reader, commit string) (string, error) {
+	reader, err := decompress(tarball)
+	if err != nil {
+		return "", err
+	}
+	metadata := make(map[string][]byte)
+	metadataSize := 0
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrInvalidTarball, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue // archive repositories only hold regular files, directories are implied
+		}
+		file := repoFile(header.Name)
+		storagePath, err := repo.Path(file)
+		if err != nil {
+			continue // not served, like the tmp and state directories
+		}
+		if strings.HasPrefix(file, "objects/") || strings.HasPrefix(file, "deltas/") ||
+			strings.HasPrefix(file, "delta-indexes/") {
+			if err := storage.Write(ctx, storagePath, archive); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if metadataSize += int(header.Size); metadataSize > MaxMetadataSize {
+			return "", ErrMetadataTooLong
+		}
+		content, err := ioutil.ReadAll(archive)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrInvalidTarball, err)
+		}
+		metadata[file] = content
+	}
+	config, ok := metadata["config"]
+	if !ok {
+		return "", fmt.Errorf("%w: no ostree repository config", ErrInvalidTarball)
+	}
+	if mode := configMode(config); mode != "archive-z2" && mode != "archive" {
+		return "", ErrNotArchiveRepo
+	}
+	refs := make(Refs)
+	for file, content := range metadata {
+		if strings.HasPrefix(file, "refs/heads/") {
+			refs[strings.TrimPrefix(file, "refs/heads/")] = strings.TrimSpace(string(content))
+		}
+	}
+	if commit, err = refs.Commit(commit); err != nil {
+		return "", err
+	}
+	if err := writeMetadata(ctx, storage, repo, metadata); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
+
+// decompress returns a reader of the tarball, gunzipping it if needed.
+func decompress(tarball io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(tarball)
+	magic, err := buffered.Peek(2)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTarball, err)
+	}
+	if magic[0] != 0x1f || magic[1] != 0x8b {
+		return buffered, nil
+	}
+	gz, err := gzip.NewReader(buffered)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTarball, err)
+	}
+	return gz, nil
+}
+
+// repoFile returns the path of a tarball entry within the repository.
+func repoFile(name string) string {
+	file := strings.TrimPrefix(path.Clean("/"+name), "/")
+	return strings.TrimPrefix(file, "repo/")
+}
+
+// configMode returns the mode of the core section of an ostree repository config.
+func configMode(config []byte) string {
+	section := ""
+	for _, line := range strings.Split(string(config), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line[1 : len(line)-1]
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if section == "core" && len(kv) == 2 && strings.TrimSpace(kv[0]) == "mode" {
+			return strings.TrimSpace(kv[1])
+		}
+	}
+	return ""
+}
+
+// writeMetadata stores the metadata files, refs first and config last, as a client starts with the config.
+func writeMetadata(ctx context.Context, storage Storage, repo Repo, metadata map[string][]byte) error {
+	files := make([]string, 0, len(metadata))
+	for file := range metadata {
+		files = append(files, file)
+	}
+	rank := func(file string) int {
+		switch {
+		case strings.HasPrefix(file, "refs/"):
+			return 0
+		case file == "config":
+			return 2
+		default:
+			return 1
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if rank(files[i]) != rank(files[j]) {
+			return rank(files[i]) < rank(files[j])
+		}
+		return files[i] < files[j]
+	})
+	for _, file := range files {
+		storagePath, _ := repo.Path(file)
+		if err := storage.Write(ctx, storagePath, bytes.NewReader(metadata[file])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit returns the given commit checksum if a ref points to it, or the commit of the only ref if none is given.
+// ErrCommitMismatch is returned otherwise.
+func (r Refs) Commit(checksum string) (string, error) {
+	if checksum == "" {
+		if len(r) != 1 {
+			return "", ErrCommitMismatch
+		}
+		for _, commit := range r {
+			return commit, nil
+		}
+	}
+	for _, commit := range r {
+		if commit == checksum {
+			return commit, nil
+		}
+	}
+	return "", ErrCommitMismatch
+}
@@ -0,0 +1,158 @@
+package ostree
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+// memoryStorage is a storage keeping files in memory, in the order they were written.
+type memoryStorage struct {
+	files map[string]string
+	order []string
+}
+
+func (s *memoryStorage) Write(_ context.Context, path string, r io.Reader) error {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.files[path] = string(content)
+	s.order = append(s.order, path)
+	return nil
+}
+
+func (s *memoryStorage) Open(_ context.Context, path string) (io.ReadCloser, error) {
+	content, ok := s.files[path]
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+	return ioutil.NopCloser(strings.NewReader(content)), nil
+}
+
+// newTarball returns a tarball of the given files, in the given order, gzipped if asked to.
+func newTarball(t *testing.T, gzipped bool, files ...[2]string) io.Reader {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gz *gzip.Writer
+	if gzipped {
+		gz = gzip.NewWriter(&buf)
+		w = gz
+	}
+	archive := tar.NewWriter(w)
+	for _, file := range files {
+		if err := archive.WriteHeader(&tar.Header{Name: file[0], Mode: 0644, Size: int64(len(file[1])),
+			Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("failed to write tarball: %s", err)
+		}
+		if _, err := archive.Write([]byte(file[1])); err != nil {
+			t.Fatalf("failed to write tarball: %s", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("failed to write tarball: %s", err)
+	}
+	if gz != nil {
+		_ = gz.Close()
+	}
+	return &buf
+}
+
+func TestUnpack(t *testing.T) {
+	checksum := strings.Repeat("ab", 32)
+	archiveConfig := [2]string{"repo/config", "[core]\nrepo_version=1\nmode=archive-z2\n"}
+	ref := [2]string{"repo/refs/heads/rhel/8/x86_64/edge", checksum + "\n"}
+	object := [2]string{"repo/objects/ab/" + checksum[2:] + ".commit", "commit"}
+	tests := []struct {
+		name      string
+		tarball   func(t *testing.T) io.Reader
+		commit    string
+		want      string
+		wantOrder []string
+		wantErr   error
+	}{
+		{
+			name: "should store objects first and the config last",
+			tarball: func(t *testing.T) io.Reader {
+				return newTarball(t, false, archiveConfig, [2]string{"repo/summary", "summary"}, ref,
+					[2]string{"repo/tmp/cache", "skipped"}, object)
+			},
+			want: checksum,
+			wantOrder: []string{
+				"0000000/image-uuid/objects/ab/" + checksum[2:] + ".commit",
+				"0000000/image-uuid/refs/heads/rhel/8/x86_64/edge",
+				"0000000/image-uuid/summary",
+				"0000000/image-uuid/config",
+			},
+		},
+		{
+			name: "should unpack a gzipped tarball without a repo directory",
+			tarball: func(t *testing.T) io.Reader {
+				return newTarball(t, true, [2]string{"./config", archiveConfig[1]}, [2]string{"./" + ref[0][5:], ref[1]})
+			},
+			commit:    checksum,
+			want:      checksum,
+			wantOrder: []string{"0000000/image-uuid/refs/heads/rhel/8/x86_64/edge", "0000000/image-uuid/config"},
+		},
+		{
+			name: "should fail, no ref to the commit",
+			tarball: func(t *testing.T) io.Reader {
+				return newTarball(t, false, archiveConfig, ref)
+			},
+			commit:  strings.Repeat("cd", 32),
+			wantErr: ErrCommitMismatch,
+		},
+		{
+			name: "should fail, bare repository",
+			tarball: func(t *testing.T) io.Reader {
+				return newTarball(t, false, [2]string{"repo/config", "[core]\nmode=bare\n"}, ref)
+			},
+			wantErr: ErrNotArchiveRepo,
+		},
+		{
+			name: "should fail, no config",
+			tarball: func(t *testing.T) io.Reader {
+				return newTarball(t, false, ref, object)
+			},
+			wantErr: ErrInvalidTarball,
+		},
+		{
+			name: "should fail, not a tarball",
+			tarball: func(t *testing.T) io.Reader {
+				return strings.NewReader("not a tarball at all, but long enough to be read as a header")
+			},
+			wantErr: ErrInvalidTarball,
+		},
+	}
+	repo, _ := NewRepo(common.DefaultAccount, "image-uuid")
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			storage := &memoryStorage{files: make(map[string]string)}
+			got, err := Unpack(context.Background(), storage, repo, tt.tarball(t), tt.commit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Unpack() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Unpack() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(storage.order, tt.wantOrder) {
+				t.Errorf("Unpack() stored %v, want %v", storage.order, tt.wantOrder)
+			}
+		})
+	}
+}
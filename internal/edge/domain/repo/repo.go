@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+)
+
+// Repository errors
+var (
+	ErrEmptyContext    = errors.New("empty context")
+	ErrInvalidBaseURL  = errors.New("invalid baseurl, must be an http, https or ftp url")
+	ErrInvalidGPGKey   = errors.New("invalid gpg key, must be an armored public key block or its url")
+	ErrMissingGPGKey   = errors.New("gpg check requires a gpg key")
+	ErrInvalidPriority = errors.New("invalid priority, must be between 1 and 99")
+)
+
+// Bounds of the priority of a repository, the lower the value the higher the priority.
+const (
+	MinPriority = 1
+	MaxPriority = 99
+	// DefaultPriority is the priority of a repository when not given, the one dnf gives.
+	DefaultPriority = MaxPriority
+)
+
+const (
+	gpgKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	gpgKeyFooter = "-----END PGP PUBLIC KEY BLOCK-----"
+)
+
+// Repo is a third-party rpm repository of an account, images reference it to get their packages from it.
+type Repo struct {
+	// context
+	ctx context.Context
+	// identity
+	uuid string
+	name common.Name
+	// repository
+	baseURL  string
+	gpgKey   string
+	gpgCheck bool
+	priority int
+	// time
+	timing common.Time
+}
+
+// NewRepoWithContext creates a new repository. A priority of 0 is the default priority.
+func NewRepoWithContext(ctx context.Context, uuid, name, baseURL, gpgKey string, gpgCheck bool,
+	priority int) (Repo, error) {
+	if ctx == nil {
+		return Repo{}, ErrEmptyContext
+	}
+	r := Repo{ctx: ctx, uuid: uuid}
+	if err := r.Update(name, baseURL, gpgKey, gpgCheck, priority); err != nil {
+		return Repo{}, err
+	}
+	return r, nil
+}
+
+// isValidBaseURL returns true if the url can be the baseurl of a repository, dnf variables included.
+func isValidBaseURL(baseURL string) bool {
+	u, err := url.ParseRequestURI(baseURL)
+	return err == nil && u.Host != "" && (u.Scheme == "http" || u.Scheme == "https" || u.Scheme == "ftp")
+}
+
+// isValidGPGKey returns true if the key is an armored public key block or an http or https url.
+func isValidGPGKey(gpgKey string) bool {
+	if strings.HasPrefix(gpgKey, gpgKeyHeader) {
+		return strings.HasSuffix(gpgKey, gpgKeyFooter)
+	}
+	u, err := url.ParseRequestURI(gpgKey)
+	return err == nil && u.Host != "" && (u.Scheme == "http" || u.Scheme == "https")
+}
+
+// IsZero returns true if the repository is zero.
+func (r Repo) IsZero() bool {
+	return r.uuid == "" && r.name.IsZero() && r.baseURL == "" && r.gpgKey == "" &&
+		!r.gpgCheck && r.priority == 0 && r.timing.IsZero()
+}
+
+// Account is a getter for the account of a repository.
+func (r Repo) Account() (common.Account, error) {
+	return common.GetAccountFromContext(r.ctx)
+}
+
+// UUID is a getter for the uuid of a repository.
+func (r Repo) UUID() string {
+	return r.uuid
+}
+
+// Name is a getter for the name of a repository.
+func (r Repo) Name() common.Name {
+	return r.name
+}
+
+// BaseURL is a getter for the baseurl of a repository.
+func (r Repo) BaseURL() string {
+	return r.baseURL
+}
+
+// GPGKey is a getter for the gpg key of a repository, empty if it has none.
+func (r Repo) GPGKey() string {
+	return r.gpgKey
+}
+
+// GPGCheck returns true if the packages of a repository must be signed by its gpg key.
+func (r Repo) GPGCheck() bool {
+	return r.gpgCheck
+}
+
+// Priority is a getter for the priority of a repository.
+func (r Repo) Priority() int {
+	return r.priority
+}
+
+// CreatedAt is a getter for the created at time of a repository.
+func (r Repo) CreatedAt() time.Time {
+	return r.timing.CreatedAt()
+}
+
+// UpdatedAt is a getter for the updated at time of a repository.
+func (r Repo) UpdatedAt() time.Time {
+	return r.timing.UpdatedAt()
+}
+
+// Update replaces the content of a repository, it applies to the next build of every image referencing it.
+// The repository is left unchanged if the new content is invalid.
+func (r *Repo) Update(name, baseURL, gpgKey string, gpgCheck bool, priority int) error {
+	validName, err := common.NewName(name)
+	if err != nil {
+		return err
+	}
+	baseURL = strings.TrimSpace(baseURL)
+	if !isValidBaseURL(baseURL) {
+		return ErrInvalidBaseURL
+	}
+	gpgKey = strings.TrimSpace(gpgKey)
+	if gpgKey != "" && !isValidGPGKey(gpgKey) {
+		return ErrInvalidGPGKey
+	}
+	if gpgCheck && gpgKey == "" {
+		return ErrMissingGPGKey
+	}
+	if priority == 0 {
+		priority = DefaultPriority
+	}
+	if priority < MinPriority || priority > MaxPriority {
+		return ErrInvalidPriority
+	}
+	r.name = validName
+	r.baseURL = baseURL
+	r.gpgKey = gpgKey
+	r.gpgCheck = gpgCheck
+	r.priority = priority
+	return nil
+}
+
+// SetTime sets the time of a repository.
+func (r *Repo) SetTime(timing common.Time) {
+	r.timing = timing
+}
+
+// Touch marks the repository as stored at the given time, setting its creation time if it has none.
+func (r *Repo) Touch(now time.Time) {
+	now = now.UTC().Truncate(time.Microsecond)
+	createdAt := r.CreatedAt()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	r.timing = common.NewTime(createdAt, now, time.Time{})
+}
+
+// UnmarshalRepoFromDatabase unmarshals the repository from the database.
+func UnmarshalRepoFromDatabase(ctx context.Context, uuid, name, baseURL, gpgKey string, gpgCheck bool,
+	priority int, createdAt, updatedAt time.Time) (Repo, error) {
+	r, err := NewRepoWithContext(ctx, uuid, name, baseURL, gpgKey, gpgCheck, priority)
+	if err != nil {
+		return Repo{}, err
+	}
+	r.SetTime(common.NewTime(createdAt, updatedAt, time.Time{}))
+	return r, nil
+}
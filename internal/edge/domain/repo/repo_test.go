@@ -0,0 +1,130 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+const validGPGKey = "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nmQINBFzMWxkBEADHrskpBgN9OphmhRkc7P/YrsAGSvvl7kfu+e9KAaU6f5MeAVyn\n-----END PGP PUBLIC KEY BLOCK-----"
+
+func TestNewRepoWithContext(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		name     string
+		baseURL  string
+		gpgKey   string
+		gpgCheck bool
+		priority int
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantPriority int
+		wantErr      error
+	}{
+		{
+			name: "should create a repository with the default priority",
+			args: args{ctx: context.Background(), name: "epel",
+				baseURL: "https://dl.fedoraproject.org/pub/epel/8/Everything/$basearch/"},
+			wantPriority: DefaultPriority,
+		},
+		{
+			name: "should create a checked repository with an armored key",
+			args: args{ctx: context.Background(), name: "epel", baseURL: "https://dl.fedoraproject.org/pub/epel/8/",
+				gpgKey: validGPGKey, gpgCheck: true, priority: 10},
+			wantPriority: 10,
+		},
+		{
+			name: "should create a checked repository with a key url",
+			args: args{ctx: context.Background(), name: "epel", baseURL: "https://dl.fedoraproject.org/pub/epel/8/",
+				gpgKey: "https://dl.fedoraproject.org/pub/epel/RPM-GPG-KEY-EPEL-8", gpgCheck: true, priority: 1},
+			wantPriority: 1,
+		},
+		{
+			name:    "should fail, empty context",
+			args:    args{name: "epel", baseURL: "https://dl.fedoraproject.org/pub/epel/8/"},
+			wantErr: ErrEmptyContext,
+		},
+		{
+			name:    "should fail, relative baseurl",
+			args:    args{ctx: context.Background(), name: "epel", baseURL: "pub/epel/8/"},
+			wantErr: ErrInvalidBaseURL,
+		},
+		{
+			name:    "should fail, local baseurl",
+			args:    args{ctx: context.Background(), name: "epel", baseURL: "file:///srv/repo"},
+			wantErr: ErrInvalidBaseURL,
+		},
+		{
+			name: "should fail, truncated gpg key",
+			args: args{ctx: context.Background(), name: "epel", baseURL: "https://dl.fedoraproject.org/pub/epel/8/",
+				gpgKey: "-----BEGIN PGP PUBLIC KEY BLOCK-----\nmQINBFzMWxkBEADHrskpBgN9OphmhRkc"},
+			wantErr: ErrInvalidGPGKey,
+		},
+		{
+			name: "should fail, gpg check without a key",
+			args: args{ctx: context.Background(), name: "epel", baseURL: "https://dl.fedoraproject.org/pub/epel/8/",
+				gpgCheck: true},
+			wantErr: ErrMissingGPGKey,
+		},
+		{
+			name: "should fail, priority out of bounds",
+			args: args{ctx: context.Background(), name: "epel", baseURL: "https://dl.fedoraproject.org/pub/epel/8/",
+				priority: 100},
+			wantErr: ErrInvalidPriority,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewRepoWithContext(tt.args.ctx, "repo-uuid", tt.args.name, tt.args.baseURL, tt.args.gpgKey,
+				tt.args.gpgCheck, tt.args.priority)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewRepoWithContext() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Priority() != tt.wantPriority {
+				t.Errorf("NewRepoWithContext() priority = %v, want %v", got.Priority(), tt.wantPriority)
+			}
+		})
+	}
+}
+
+func TestRepo_Update(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseURL     string
+		gpgCheck    bool
+		wantBaseURL string
+		wantErr     error
+	}{
+		{
+			name:        "should replace the baseurl",
+			baseURL:     " https://mirror.example.com/epel/8/ ",
+			wantBaseURL: "https://mirror.example.com/epel/8/",
+		},
+		{
+			name:        "should keep the repository on error",
+			baseURL:     "https://mirror.example.com/epel/8/",
+			gpgCheck:    true,
+			wantBaseURL: "https://dl.fedoraproject.org/pub/epel/8/",
+			wantErr:     ErrMissingGPGKey,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, _ := NewRepoWithContext(context.Background(), "repo-uuid", "epel",
+				"https://dl.fedoraproject.org/pub/epel/8/", "", false, 0)
+			if err := r.Update("epel", tt.baseURL, "", tt.gpgCheck, 0); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Repo.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if r.BaseURL() != tt.wantBaseURL {
+				t.Errorf("Repo.BaseURL() = %v, want %v", r.BaseURL(), tt.wantBaseURL)
+			}
+		})
+	}
+}
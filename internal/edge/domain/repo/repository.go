@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+)
+
+var (
+	// ErrRepoNotFound is the error returned when the repository is not found.
+	ErrRepoNotFound = errors.New("repository not found")
+	// ErrRepoInUse is the error returned when deleting a repository images still reference.
+	ErrRepoInUse = errors.New("repository is referenced by images")
+)
+
+// Repository interface for handling third-party repository data store/retrieve.
+type Repository interface {
+	// CreateRepo creates a new repository.
+	CreateRepo(ctx context.Context, repo *Repo) error
+	// GetRepo returns the repository with the given UUID.
+	GetRepo(ctx context.Context, uuid string) (*Repo, error)
+	// GetRepos returns all repositories.
+	GetRepos(ctx context.Context) ([]*Repo, error)
+	// UpdateRepo updates the repository with the given UUID.
+	UpdateRepo(ctx context.Context, uuid string, updateFn func(r *Repo) (*Repo, error)) error
+	// DeleteRepo deletes the repository with the given UUID, unless images, deleted or not, reference it.
+	DeleteRepo(ctx context.Context, uuid string) error
+}
+
+// MarshalGorm converts a domain Repo to a database Repo.
+func (r Repo) MarshalGorm() *models.Repo {
+	if r.IsZero() { // if repo is nil, return nil
+		return nil
+	}
+	account, err := r.Account()
+	if err != nil {
+		return nil
+	}
+	model := &models.Repo{
+		Account:  account.String(),
+		UUID:     r.UUID(),
+		Name:     r.Name().String(),
+		BaseURL:  r.BaseURL(),
+		GPGKey:   r.GPGKey(),
+		GPGCheck: r.GPGCheck(),
+		Priority: r.Priority(),
+	}
+	if !r.timing.IsZero() {
+		model.CreatedAt = r.timing.CreatedAt()
+		model.UpdatedAt = r.timing.UpdatedAt()
+	}
+	return model
+}
@@ -0,0 +1,71 @@
+package rollout
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidPlan is returned when the stages, soak time or failure threshold of a plan are invalid.
+var ErrInvalidPlan = errors.New("invalid rollout plan, stages must increase up to 100% and the threshold be a percentage")
+
+// Plan is how a rollout reaches its devices: in stages, each one a percentage of the devices,
+// waiting the soak time between stages and halting once the failure rate exceeds the threshold.
+type Plan struct {
+	stages           []uint
+	soakTime         time.Duration
+	failureThreshold uint
+	rollbackFailed   bool
+}
+
+// NewPlan creates a new plan, stages are increasing percentages of the devices ending with 100.
+// A failure threshold of 100 never halts the rollout.
+func NewPlan(stages []uint, soakTime time.Duration, failureThreshold uint, rollbackFailed bool) (Plan, error) {
+	if len(stages) == 0 || stages[len(stages)-1] != 100 || soakTime < 0 || failureThreshold > 100 {
+		return Plan{}, ErrInvalidPlan
+	}
+	for i, stage := range stages {
+		if stage == 0 || (i > 0 && stage <= stages[i-1]) {
+			return Plan{}, ErrInvalidPlan
+		}
+	}
+	return Plan{
+		stages:           append([]uint{}, stages...),
+		soakTime:         soakTime,
+		failureThreshold: failureThreshold,
+		rollbackFailed:   rollbackFailed,
+	}, nil
+}
+
+// DefaultPlan reaches all the devices at once, never halts and rolls the failed devices back.
+func DefaultPlan() Plan {
+	return Plan{stages: []uint{100}, failureThreshold: 100, rollbackFailed: true}
+}
+
+// Stages is a getter for the percentages of devices each stage reaches.
+func (p Plan) Stages() []uint {
+	return p.stages
+}
+
+// SoakTime is a getter for the time to wait once a stage is done, before starting the next one.
+func (p Plan) SoakTime() time.Duration {
+	return p.soakTime
+}
+
+// FailureThreshold is a getter for the percentage of failed devices that halts the rollout.
+func (p Plan) FailureThreshold() uint {
+	return p.failureThreshold
+}
+
+// RollbackFailed returns true if failed devices are assigned back to their previous image version.
+func (p Plan) RollbackFailed() bool {
+	return p.rollbackFailed
+}
+
+// stageSize returns the number of devices reached once the given stage started, at least one.
+func (p Plan) stageSize(stage, devices int) int {
+	size := (devices*int(p.stages[stage]) + 99) / 100
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
@@ -0,0 +1,95 @@
+package rollout
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewPlan(t *testing.T) {
+	type args struct {
+		stages           []uint
+		soakTime         time.Duration
+		failureThreshold uint
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "should create a canary plan",
+			args:    args{stages: []uint{1, 10, 50, 100}, soakTime: time.Hour, failureThreshold: 5},
+			wantErr: nil,
+		},
+		{
+			name:    "should create a single stage plan",
+			args:    args{stages: []uint{100}, failureThreshold: 100},
+			wantErr: nil,
+		},
+		{
+			name:    "should fail, no stages",
+			args:    args{failureThreshold: 5},
+			wantErr: ErrInvalidPlan,
+		},
+		{
+			name:    "should fail, last stage doesn't reach all devices",
+			args:    args{stages: []uint{10, 50}, failureThreshold: 5},
+			wantErr: ErrInvalidPlan,
+		},
+		{
+			name:    "should fail, stages don't increase",
+			args:    args{stages: []uint{50, 10, 100}, failureThreshold: 5},
+			wantErr: ErrInvalidPlan,
+		},
+		{
+			name:    "should fail, empty stage",
+			args:    args{stages: []uint{0, 100}, failureThreshold: 5},
+			wantErr: ErrInvalidPlan,
+		},
+		{
+			name:    "should fail, negative soak time",
+			args:    args{stages: []uint{100}, soakTime: -time.Second, failureThreshold: 5},
+			wantErr: ErrInvalidPlan,
+		},
+		{
+			name:    "should fail, threshold isn't a percentage",
+			args:    args{stages: []uint{100}, failureThreshold: 101},
+			wantErr: ErrInvalidPlan,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewPlan(tt.args.stages, tt.args.soakTime, tt.args.failureThreshold, true)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewPlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlan_stageSize(t *testing.T) {
+	plan, _ := NewPlan([]uint{1, 10, 50, 100}, 0, 0, true)
+	tests := []struct {
+		name    string
+		stage   int
+		devices int
+		want    int
+	}{
+		{name: "should reach at least one device", stage: 0, devices: 20, want: 1},
+		{name: "should round up", stage: 1, devices: 15, want: 2},
+		{name: "should reach half the devices", stage: 2, devices: 20, want: 10},
+		{name: "should reach all the devices", stage: 3, devices: 20, want: 20},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := plan.stageSize(tt.stage, tt.devices); got != tt.want {
+				t.Errorf("Plan.stageSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package rollout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/Avielyo10/edge-api/internal/common/models"
+)
+
+var (
+	// ErrRolloutNotFound is the error returned when the rollout is not found.
+	ErrRolloutNotFound = errors.New("rollout not found")
+	// ErrRolloutLeased is the error returned when the rollout is followed by another replica.
+	ErrRolloutLeased = errors.New("rollout is followed by another replica")
+)
+
+// Repository interface for handling rollout data store/retrieve.
+type Repository interface {
+	// CreateRollout creates a new rollout along with its transactions.
+	CreateRollout(ctx context.Context, rollout *Rollout) error
+	// GetRollout returns the rollout with the given UUID.
+	GetRollout(ctx context.Context, uuid string) (*Rollout, error)
+	// UpdateTransaction updates the transaction of the device with the given UUID in the given rollout.
+	UpdateTransaction(ctx context.Context, uuid, deviceUUID string,
+		updateFn func(t *Transaction) (*Transaction, error)) error
+	// UpdateRollout updates the state of the rollout with the given UUID, along with the transactions it moved.
+	UpdateRollout(ctx context.Context, uuid string, updateFn func(r *Rollout) (*Rollout, error)) error
+	// GetActiveRollouts returns the rollouts of the image with the given UUID that are not final.
+	GetActiveRollouts(ctx context.Context, imageUUID string) ([]*Rollout, error)
+	// ClaimRollouts leases the rollouts of all accounts that aren't settled and no replica follows,
+	// until the given time, returning them.
+	ClaimRollouts(ctx context.Context, lease string, until time.Time) ([]*Rollout, error)
+	// ClaimRollout leases the rollout with the given UUID until the given time, renewing the lease if it holds it.
+	ClaimRollout(ctx context.Context, uuid, lease string, until time.Time) error
+	// ReleaseRollout releases the lease on the rollout with the given UUID, if it holds it.
+	ReleaseRollout(ctx context.Context, uuid, lease string) error
+}
+
+// MarshalGorm converts a domain Rollout to a database Rollout, its transactions are stored on their own.
+func (r Rollout) MarshalGorm() *models.Rollout {
+	if r.IsZero() { // if rollout is nil, return nil
+		return nil
+	}
+	account, err := r.Account()
+	if err != nil {
+		return nil
+	}
+	stages := make(pq.Int64Array, len(r.Plan().Stages()))
+	for i, stage := range r.Plan().Stages() {
+		stages[i] = int64(stage)
+	}
+	model := &models.Rollout{
+		Account:          account.String(),
+		UUID:             r.UUID(),
+		ImageUUID:        r.Assignment().ImageUUID(),
+		ImageVersion:     r.Assignment().Version().Uint(),
+		Stages:           stages,
+		SoakTime:         r.Plan().SoakTime(),
+		FailureThreshold: r.Plan().FailureThreshold(),
+		RollbackFailed:   r.Plan().RollbackFailed(),
+		State:            r.State().String(),
+		Stage:            uint(r.Stage()),
+		AcceptedFailures: uint(r.AcceptedFailures()),
+	}
+	if !r.timing.IsZero() {
+		model.CreatedAt = r.timing.CreatedAt()
+		model.UpdatedAt = r.timing.UpdatedAt()
+	}
+	return model
+}
+
+// MarshalGorm converts a domain Transaction of the given rollout to a database RolloutTransaction.
+func (t Transaction) MarshalGorm(account, rolloutUUID string) *models.RolloutTransaction {
+	return &models.RolloutTransaction{
+		Account:              account,
+		RolloutUUID:          rolloutUUID,
+		DeviceUUID:           t.DeviceUUID(),
+		Status:               t.Status().String(),
+		Reason:               t.Reason(),
+		PreviousImageUUID:    t.Previous().ImageUUID(),
+		PreviousImageVersion: t.Previous().Version().Uint(),
+		Restored:             t.Restored(),
+		StatusUpdatedAt:      t.UpdatedAt(),
+	}
+}
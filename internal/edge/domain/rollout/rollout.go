@@ -0,0 +1,409 @@
+package rollout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/common"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+)
+
+// Rollout errors
+var (
+	ErrEmptyContext          = errors.New("empty context")
+	ErrNoDevices             = errors.New("a rollout needs at least one device")
+	ErrUnknownTarget         = errors.New("unknown device or group in rollout")
+	ErrVersionNotSuccessful  = errors.New("only successfully built image versions can be rolled out")
+	ErrTransactionNotFound   = errors.New("device is not part of the rollout")
+	ErrInvalidRolloutVersion = errors.New("invalid image version to roll out")
+	ErrInvalidState          = errors.New("invalid rollout state")
+	ErrInvalidStateChange    = errors.New("the rollout can't change to that state")
+)
+
+// Define the available states of a rollout.
+var (
+	// Running rollouts dispatch their stages.
+	Running = State{"running"}
+	// Paused rollouts dispatch no further stage until they are resumed.
+	Paused = State{"paused"}
+	// Halted rollouts exceeded their failure threshold, they can be resumed.
+	Halted = State{"halted"}
+	// Aborted rollouts failed their remaining transactions.
+	Aborted = State{"aborted"}
+	// Completed rollouts reached all their devices.
+	Completed = State{"completed"}
+)
+
+// All available states.
+var availableStates = []State{
+	Running,
+	Paused,
+	Halted,
+	Aborted,
+	Completed,
+}
+
+// State is the state of a rollout.
+type State struct {
+	state string
+}
+
+// NewStateFromString creates a new state from a string.
+func NewStateFromString(state string) (State, error) {
+	for _, s := range availableStates {
+		if s.state == state {
+			return s, nil
+		}
+	}
+	return State{}, ErrInvalidState
+}
+
+// String returns the string representation of a state.
+func (s State) String() string {
+	return s.state
+}
+
+// IsFinal returns true if the rollout can't change anymore.
+func (s State) IsFinal() bool {
+	return s == Aborted || s == Completed
+}
+
+// Rollout dispatches an image version to a set of devices, one transaction per device.
+type Rollout struct {
+	// context
+	ctx context.Context
+	// identity
+	uuid string
+	// target
+	assignment   device.Assignment
+	transactions []Transaction
+	// progress
+	plan             Plan
+	state            State
+	stage            int
+	acceptedFailures int
+	// time
+	timing common.Time
+}
+
+// NewRolloutWithContext creates a new running rollout of the given image version to the given devices,
+// following the given plan. Each device gets a pending transaction, in the order the devices are given.
+func NewRolloutWithContext(ctx context.Context, uuid, imageUUID string, version uint, devices []string,
+	plan Plan) (Rollout, error) {
+	if ctx == nil {
+		return Rollout{}, ErrEmptyContext
+	}
+	assignment, err := device.NewAssignment(imageUUID, version)
+	if err != nil || assignment.IsZero() {
+		return Rollout{}, ErrInvalidRolloutVersion
+	}
+	seen := make(map[string]bool, len(devices))
+	var transactions []Transaction
+	for _, deviceUUID := range devices {
+		if deviceUUID != "" && !seen[deviceUUID] {
+			seen[deviceUUID] = true
+			transactions = append(transactions, NewTransaction(deviceUUID))
+		}
+	}
+	if len(transactions) == 0 {
+		return Rollout{}, ErrNoDevices
+	}
+	if len(plan.stages) == 0 {
+		return Rollout{}, ErrInvalidPlan
+	}
+	return Rollout{
+		ctx:          ctx,
+		uuid:         uuid,
+		assignment:   assignment,
+		transactions: transactions,
+		plan:         plan,
+		state:        Running,
+	}, nil
+}
+
+// IsZero returns true if the rollout is zero.
+func (r Rollout) IsZero() bool {
+	return r.uuid == "" && r.assignment.IsZero() && len(r.transactions) == 0 && r.timing.IsZero()
+}
+
+// Account is a getter for the account of a rollout.
+func (r Rollout) Account() (common.Account, error) {
+	return common.GetAccountFromContext(r.ctx)
+}
+
+// UUID is a getter for the uuid of a rollout.
+func (r Rollout) UUID() string {
+	return r.uuid
+}
+
+// Assignment is a getter for the image version the rollout assigns its devices to.
+func (r Rollout) Assignment() device.Assignment {
+	return r.assignment
+}
+
+// Transactions is a getter for the transactions of a rollout, one per device.
+func (r Rollout) Transactions() []Transaction {
+	return r.transactions
+}
+
+// Plan is a getter for the plan of a rollout.
+func (r Rollout) Plan() Plan {
+	return r.plan
+}
+
+// State is a getter for the state of a rollout.
+func (r Rollout) State() State {
+	return r.state
+}
+
+// Stage is a getter for the index of the current stage of a rollout.
+func (r Rollout) Stage() int {
+	return r.stage
+}
+
+// AcceptedFailures is a getter for the number of failures accepted when the rollout was last resumed.
+func (r Rollout) AcceptedFailures() int {
+	return r.acceptedFailures
+}
+
+// StageDevices returns the devices of the current stage that weren't reached by the previous ones.
+func (r Rollout) StageDevices() []string {
+	from := 0
+	if r.stage > 0 {
+		from = r.plan.stageSize(r.stage-1, len(r.transactions))
+	}
+	var devices []string
+	for _, transaction := range r.transactions[from:r.plan.stageSize(r.stage, len(r.transactions))] {
+		devices = append(devices, transaction.deviceUUID)
+	}
+	return devices
+}
+
+// ReachedTransactions returns the transactions of the devices the stages started so far reached.
+func (r Rollout) ReachedTransactions() []Transaction {
+	return append([]Transaction(nil), r.transactions[:r.plan.stageSize(r.stage, len(r.transactions))]...)
+}
+
+// Evaluate moves a running rollout forward, given the state of its transactions.
+// The rollout halts once the failure rate of the devices it reached exceeds the threshold of its plan,
+// failures accepted when it was resumed aside. Once every transaction it reached is final, it completes
+// after its last stage, otherwise it starts the next stage after the soak time.
+// It returns true if a new stage started, whose devices are to be dispatched.
+func (r *Rollout) Evaluate(now time.Time) bool {
+	if r.state != Running {
+		return false
+	}
+	reached := r.transactions[:r.plan.stageSize(r.stage, len(r.transactions))]
+	var failed int
+	for _, transaction := range reached {
+		if transaction.status == Failed {
+			failed++
+		}
+	}
+	if (failed-r.acceptedFailures)*100 > int(r.plan.failureThreshold)*len(reached) {
+		r.state = Halted
+		return false
+	}
+	lastFinal, done := r.stageDone()
+	if !done {
+		return false
+	}
+	if r.stage == len(r.plan.stages)-1 {
+		r.state = Completed
+		return false
+	}
+	if now.Before(lastFinal.Add(r.plan.soakTime)) {
+		return false
+	}
+	r.stage++
+	return true
+}
+
+// SoakEnd returns when the soak time of the current stage ends, ok is false unless the running rollout
+// waits for it to start its next stage.
+func (r Rollout) SoakEnd() (at time.Time, ok bool) {
+	if r.state != Running || r.stage == len(r.plan.stages)-1 {
+		return time.Time{}, false
+	}
+	lastFinal, done := r.stageDone()
+	if !done {
+		return time.Time{}, false
+	}
+	return lastFinal.Add(r.plan.soakTime), true
+}
+
+// stageDone returns true once every transaction the stages reached so far is final,
+// along with the last time one of them became final.
+func (r Rollout) stageDone() (lastFinal time.Time, done bool) {
+	for _, transaction := range r.transactions[:r.plan.stageSize(r.stage, len(r.transactions))] {
+		if !transaction.status.IsFinal() {
+			return time.Time{}, false
+		}
+		if transaction.updatedAt.After(lastFinal) {
+			lastFinal = transaction.updatedAt
+		}
+	}
+	return lastFinal, true
+}
+
+// ToRestore returns the failed transactions whose devices are still to be assigned back to their previous
+// assignment, none unless the plan of the rollout rolls the failed devices back.
+func (r Rollout) ToRestore() []Transaction {
+	if !r.plan.rollbackFailed {
+		return nil
+	}
+	var transactions []Transaction
+	for _, transaction := range r.transactions {
+		if transaction.status == Failed && !transaction.restored {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions
+}
+
+// IsSettled returns true once there is nothing left to follow on the rollout, unless it is resumed:
+// it isn't running, none of its devices is applying its update and the failed ones were restored.
+func (r Rollout) IsSettled() bool {
+	if r.state == Running || len(r.ToRestore()) > 0 {
+		return false
+	}
+	for _, transaction := range r.transactions {
+		if transaction.status == Dispatched || transaction.status == Applying {
+			return false
+		}
+	}
+	return true
+}
+
+// Pause stops a running rollout from starting further stages, dispatched transactions go on.
+func (r *Rollout) Pause() error {
+	if r.state != Running {
+		return ErrInvalidStateChange
+	}
+	r.state = Paused
+	return nil
+}
+
+// Resume resumes a paused or halted rollout, the failures that halted it are accepted.
+func (r *Rollout) Resume() error {
+	if r.state != Paused && r.state != Halted {
+		return ErrInvalidStateChange
+	}
+	if r.state == Halted {
+		r.acceptedFailures = r.Progress().Count(Failed)
+	}
+	r.state = Running
+	return nil
+}
+
+// Abort aborts a rollout that isn't final, failing all its transactions that aren't.
+func (r *Rollout) Abort(now time.Time) error {
+	if r.state.IsFinal() {
+		return ErrInvalidStateChange
+	}
+	for i := range r.transactions {
+		if !r.transactions[i].status.IsFinal() {
+			_ = r.transactions[i].Fail("aborted", now)
+		}
+	}
+	r.state = Aborted
+	return nil
+}
+
+// Transaction returns the transaction of the device with the given uuid.
+func (r Rollout) Transaction(deviceUUID string) (Transaction, error) {
+	for _, transaction := range r.transactions {
+		if transaction.deviceUUID == deviceUUID {
+			return transaction, nil
+		}
+	}
+	return Transaction{}, ErrTransactionNotFound
+}
+
+// CreatedAt is a getter for the created at time of a rollout.
+func (r Rollout) CreatedAt() time.Time {
+	return r.timing.CreatedAt()
+}
+
+// UpdatedAt is a getter for the updated at time of a rollout.
+func (r Rollout) UpdatedAt() time.Time {
+	return r.timing.UpdatedAt()
+}
+
+// Progress returns the progress of the rollout.
+func (r Rollout) Progress() Progress {
+	progress := Progress{counts: make(map[TransactionStatus]int)}
+	for _, transaction := range r.transactions {
+		progress.counts[transaction.status]++
+		progress.total++
+	}
+	return progress
+}
+
+// SetTime sets the time of a rollout.
+func (r *Rollout) SetTime(timing common.Time) {
+	r.timing = timing
+}
+
+// Touch marks the rollout as stored at the given time, setting its creation time if it has none.
+func (r *Rollout) Touch(now time.Time) {
+	now = now.UTC().Truncate(time.Microsecond)
+	createdAt := r.CreatedAt()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	r.timing = common.NewTime(createdAt, now, time.Time{})
+}
+
+// Progress counts the transactions of a rollout by status.
+type Progress struct {
+	counts map[TransactionStatus]int
+	total  int
+}
+
+// Count returns the number of transactions with the given status.
+func (p Progress) Count(status TransactionStatus) int {
+	return p.counts[status]
+}
+
+// Total returns the number of transactions.
+func (p Progress) Total() int {
+	return p.total
+}
+
+// IsDone returns true once every transaction is final.
+func (p Progress) IsDone() bool {
+	return p.counts[Succeeded]+p.counts[Failed] == p.total
+}
+
+// UnmarshalRolloutFromDatabase unmarshals the rollout from the database.
+func UnmarshalRolloutFromDatabase(ctx context.Context, uuid, imageUUID string, version uint,
+	transactions []Transaction, plan Plan, state string, stage, acceptedFailures int,
+	createdAt, updatedAt time.Time) (Rollout, error) {
+	if ctx == nil {
+		return Rollout{}, ErrEmptyContext
+	}
+	assignment, err := device.NewAssignment(imageUUID, version)
+	if err != nil {
+		return Rollout{}, err
+	}
+	validState, err := NewStateFromString(state)
+	if err != nil {
+		return Rollout{}, err
+	}
+	if stage < 0 || stage >= len(plan.stages) {
+		return Rollout{}, ErrInvalidPlan
+	}
+	return Rollout{
+		ctx:              ctx,
+		uuid:             uuid,
+		assignment:       assignment,
+		transactions:     transactions,
+		plan:             plan,
+		state:            validState,
+		stage:            stage,
+		acceptedFailures: acceptedFailures,
+		timing:           common.NewTime(createdAt, updatedAt, time.Time{}),
+	}, nil
+}
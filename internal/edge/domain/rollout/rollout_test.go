@@ -0,0 +1,362 @@
+package rollout
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewRolloutWithContext(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		imageUUID string
+		version   uint
+		devices   []string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantDevices []string
+		wantErr     error
+	}{
+		{
+			name:        "should create a rollout, one transaction per device",
+			args:        args{ctx: context.Background(), imageUUID: "image-uuid", version: 2, devices: []string{"a", "b", "a", ""}},
+			wantDevices: []string{"a", "b"},
+			wantErr:     nil,
+		},
+		{
+			name:    "should fail, no devices",
+			args:    args{ctx: context.Background(), imageUUID: "image-uuid", version: 2},
+			wantErr: ErrNoDevices,
+		},
+		{
+			name:    "should fail, no image",
+			args:    args{ctx: context.Background(), version: 2, devices: []string{"a"}},
+			wantErr: ErrInvalidRolloutVersion,
+		},
+		{
+			name:    "should fail, invalid version",
+			args:    args{ctx: context.Background(), imageUUID: "image-uuid", devices: []string{"a"}},
+			wantErr: ErrInvalidRolloutVersion,
+		},
+		{
+			name:    "should fail, empty context",
+			args:    args{imageUUID: "image-uuid", version: 2, devices: []string{"a"}},
+			wantErr: ErrEmptyContext,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewRolloutWithContext(tt.args.ctx, "uuid", tt.args.imageUUID, tt.args.version, tt.args.devices,
+				DefaultPlan())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewRolloutWithContext() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			var devices []string
+			for _, transaction := range got.Transactions() {
+				if transaction.Status() != Pending {
+					t.Errorf("NewRolloutWithContext() transaction status = %v, want %v", transaction.Status(), Pending)
+				}
+				devices = append(devices, transaction.DeviceUUID())
+			}
+			if !reflect.DeepEqual(devices, tt.wantDevices) {
+				t.Errorf("NewRolloutWithContext() devices = %v, want %v", devices, tt.wantDevices)
+			}
+		})
+	}
+}
+
+func TestRollout_Progress(t *testing.T) {
+	newRollout, _ := NewRolloutWithContext(context.Background(), "uuid", "image-uuid", 1, []string{"a", "b", "c"},
+		DefaultPlan())
+	now := time.Now()
+	_ = newRollout.transactions[0].Dispatch(newRollout.Assignment(), now)
+	_ = newRollout.transactions[0].Succeed(now)
+	_ = newRollout.transactions[1].Fail("no space left", now)
+
+	progress := newRollout.Progress()
+	if progress.Total() != 3 || progress.Count(Succeeded) != 1 || progress.Count(Failed) != 1 || progress.Count(Pending) != 1 {
+		t.Errorf("Rollout.Progress() = %+v, want 1 succeeded, 1 failed and 1 pending of 3", progress)
+	}
+	if progress.IsDone() {
+		t.Errorf("Rollout.Progress().IsDone() = true, want false while a transaction is pending")
+	}
+	_ = newRollout.transactions[2].Fail("timed out", now)
+	if !newRollout.Progress().IsDone() {
+		t.Errorf("Rollout.Progress().IsDone() = false, want true once every transaction is final")
+	}
+}
+
+func TestRollout_Transaction(t *testing.T) {
+	newRollout, _ := NewRolloutWithContext(context.Background(), "uuid", "image-uuid", 1, []string{"a"}, DefaultPlan())
+	if _, err := newRollout.Transaction("a"); err != nil {
+		t.Errorf("Rollout.Transaction() error = %v", err)
+	}
+	if _, err := newRollout.Transaction("b"); !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("Rollout.Transaction() error = %v, wantErr %v", err, ErrTransactionNotFound)
+	}
+}
+
+func TestRollout_Evaluate(t *testing.T) {
+	now := time.Now()
+	devices := []string{"a", "b", "c", "d"}
+	plan, _ := NewPlan([]uint{25, 50, 100}, time.Hour, 50, true)
+	succeed := func(r *Rollout, i int) {
+		_ = r.transactions[i].Dispatch(r.Assignment(), now)
+		_ = r.transactions[i].Succeed(now)
+	}
+	fail := func(r *Rollout, i int) {
+		_ = r.transactions[i].Fail("no space left", now)
+	}
+	tests := []struct {
+		name        string
+		prepare     func(r *Rollout)
+		at          time.Time
+		wantStarted bool
+		wantStage   int
+		wantState   State
+	}{
+		{
+			name:      "should wait for the stage to be done",
+			prepare:   func(r *Rollout) {},
+			at:        now,
+			wantStage: 0,
+			wantState: Running,
+		},
+		{
+			name:      "should soak once the stage is done",
+			prepare:   func(r *Rollout) { succeed(r, 0) },
+			at:        now.Add(time.Minute),
+			wantStage: 0,
+			wantState: Running,
+		},
+		{
+			name:        "should start the next stage after the soak time",
+			prepare:     func(r *Rollout) { succeed(r, 0) },
+			at:          now.Add(time.Hour),
+			wantStarted: true,
+			wantStage:   1,
+			wantState:   Running,
+		},
+		{
+			name:      "should halt, failure rate exceeds the threshold",
+			prepare:   func(r *Rollout) { fail(r, 0) },
+			at:        now.Add(time.Hour),
+			wantStage: 0,
+			wantState: Halted,
+		},
+		{
+			name: "should complete once the last stage is done",
+			prepare: func(r *Rollout) {
+				r.stage = 2
+				succeed(r, 0)
+				succeed(r, 1)
+				succeed(r, 2)
+				fail(r, 3)
+			},
+			at:        now,
+			wantStage: 2,
+			wantState: Completed,
+		},
+		{
+			name: "should ignore accepted failures",
+			prepare: func(r *Rollout) {
+				r.stage = 1
+				r.acceptedFailures = 1
+				fail(r, 0)
+				succeed(r, 1)
+			},
+			at:          now.Add(time.Hour),
+			wantStarted: true,
+			wantStage:   2,
+			wantState:   Running,
+		},
+		{
+			name: "should not move while paused",
+			prepare: func(r *Rollout) {
+				r.state = Paused
+				succeed(r, 0)
+			},
+			at:        now.Add(time.Hour),
+			wantStage: 0,
+			wantState: Paused,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, _ := NewRolloutWithContext(context.Background(), "uuid", "image-uuid", 1, devices, plan)
+			tt.prepare(&r)
+			if got := r.Evaluate(tt.at); got != tt.wantStarted {
+				t.Errorf("Rollout.Evaluate() = %v, want %v", got, tt.wantStarted)
+			}
+			if r.Stage() != tt.wantStage || r.State() != tt.wantState {
+				t.Errorf("Rollout.Evaluate() stage %d %v, want stage %d %v", r.Stage(), r.State(), tt.wantStage, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestRollout_SoakEnd(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond) // as the transactions keep it
+	plan, _ := NewPlan([]uint{50, 100}, time.Hour, 100, false)
+	r, _ := NewRolloutWithContext(context.Background(), "uuid", "image-uuid", 1, []string{"a", "b"}, plan)
+	if _, ok := r.SoakEnd(); ok {
+		t.Errorf("Rollout.SoakEnd() ok = %v, want none until the stage is done", ok)
+	}
+	_ = r.transactions[0].Dispatch(r.Assignment(), now)
+	_ = r.transactions[0].Succeed(now)
+	if got, ok := r.SoakEnd(); !ok || !got.Equal(now.Add(time.Hour)) {
+		t.Errorf("Rollout.SoakEnd() = %v, %v, want %v", got, ok, now.Add(time.Hour))
+	}
+	r.stage = 1
+	if _, ok := r.SoakEnd(); ok {
+		t.Errorf("Rollout.SoakEnd() ok = %v, want none on the last stage", ok)
+	}
+}
+
+func TestRollout_IsSettled(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		rollbackFailed bool
+		prepare        func(r *Rollout)
+		wantRestore    int
+		want           bool
+	}{
+		{
+			name:    "should not settle while running",
+			prepare: func(r *Rollout) {},
+			want:    false,
+		},
+		{
+			name: "should settle once aborted before its devices were dispatched",
+			prepare: func(r *Rollout) {
+				_ = r.Abort(now)
+			},
+			rollbackFailed: true,
+			want:           true,
+		},
+		{
+			name: "should not settle while a device applies its update",
+			prepare: func(r *Rollout) {
+				_ = r.transactions[0].Dispatch(r.Assignment(), now)
+				_ = r.Pause()
+			},
+			want: false,
+		},
+		{
+			name: "should not settle until the failed devices are rolled back",
+			prepare: func(r *Rollout) {
+				_ = r.transactions[0].Dispatch(r.Assignment(), now)
+				_ = r.Abort(now)
+			},
+			rollbackFailed: true,
+			wantRestore:    1,
+			want:           false,
+		},
+		{
+			name: "should settle once the failed devices are rolled back",
+			prepare: func(r *Rollout) {
+				_ = r.transactions[0].Dispatch(r.Assignment(), now)
+				_ = r.Abort(now)
+				_ = r.transactions[0].Restore()
+			},
+			rollbackFailed: true,
+			want:           true,
+		},
+		{
+			name: "should settle with failed devices the plan doesn't roll back",
+			prepare: func(r *Rollout) {
+				_ = r.transactions[0].Dispatch(r.Assignment(), now)
+				_ = r.Abort(now)
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			plan, _ := NewPlan([]uint{100}, 0, 100, tt.rollbackFailed)
+			r, _ := NewRolloutWithContext(context.Background(), "uuid", "image-uuid", 1, []string{"a", "b"}, plan)
+			tt.prepare(&r)
+			if got := len(r.ToRestore()); got != tt.wantRestore {
+				t.Errorf("Rollout.ToRestore() returned %d transactions, want %d", got, tt.wantRestore)
+			}
+			if got := r.IsSettled(); got != tt.want {
+				t.Errorf("Rollout.IsSettled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRollout_StageDevices(t *testing.T) {
+	plan, _ := NewPlan([]uint{25, 50, 100}, 0, 100, true)
+	r, _ := NewRolloutWithContext(context.Background(), "uuid", "image-uuid", 1, []string{"a", "b", "c", "d"}, plan)
+	for stage, want := range [][]string{{"a"}, {"b"}, {"c", "d"}} {
+		r.stage = stage
+		if got := r.StageDevices(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Rollout.StageDevices() of stage %d = %v, want %v", stage, got, want)
+		}
+	}
+}
+
+func TestRollout_ReachedTransactions(t *testing.T) {
+	plan, _ := NewPlan([]uint{25, 50, 100}, 0, 100, true)
+	r, _ := NewRolloutWithContext(context.Background(), "uuid", "image-uuid", 1, []string{"a", "b", "c", "d"}, plan)
+	for stage, want := range [][]string{{"a"}, {"a", "b"}, {"a", "b", "c", "d"}} {
+		r.stage = stage
+		var got []string
+		for _, transaction := range r.ReachedTransactions() {
+			got = append(got, transaction.DeviceUUID())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Rollout.ReachedTransactions() of stage %d = %v, want %v", stage, got, want)
+		}
+	}
+}
+
+func TestRollout_StateChanges(t *testing.T) {
+	r, _ := NewRolloutWithContext(context.Background(), "uuid", "image-uuid", 1, []string{"a", "b"}, DefaultPlan())
+	if err := r.Resume(); !errors.Is(err, ErrInvalidStateChange) {
+		t.Errorf("Rollout.Resume() error = %v, wantErr %v", err, ErrInvalidStateChange)
+	}
+	if err := r.Pause(); err != nil || r.State() != Paused {
+		t.Errorf("Rollout.Pause() error = %v, state %v", err, r.State())
+	}
+	if err := r.Pause(); !errors.Is(err, ErrInvalidStateChange) {
+		t.Errorf("Rollout.Pause() error = %v, wantErr %v", err, ErrInvalidStateChange)
+	}
+	if err := r.Resume(); err != nil || r.State() != Running {
+		t.Errorf("Rollout.Resume() error = %v, state %v", err, r.State())
+	}
+
+	now := time.Now()
+	_ = r.transactions[0].Fail("no space left", now)
+	r.state = Halted
+	if err := r.Resume(); err != nil || r.AcceptedFailures() != 1 {
+		t.Errorf("Rollout.Resume() error = %v, accepted failures %d, want 1", err, r.AcceptedFailures())
+	}
+
+	_ = r.transactions[1].Dispatch(r.Assignment(), now)
+	if err := r.Abort(now); err != nil || r.State() != Aborted || r.Progress().Count(Failed) != 2 {
+		t.Errorf("Rollout.Abort() error = %v, state %v, progress %+v", err, r.State(), r.Progress())
+	}
+	if transaction, _ := r.Transaction("b"); transaction.Reason() != "aborted" {
+		t.Errorf("Rollout.Abort() reason = %q, want %q", transaction.Reason(), "aborted")
+	}
+	if err := r.Abort(now); !errors.Is(err, ErrInvalidStateChange) {
+		t.Errorf("Rollout.Abort() error = %v, wantErr %v", err, ErrInvalidStateChange)
+	}
+}
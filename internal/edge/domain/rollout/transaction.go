@@ -0,0 +1,181 @@
+package rollout
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+)
+
+// Transaction errors
+var (
+	ErrInvalidStatus     = errors.New("invalid transaction status")
+	ErrInvalidTransition = errors.New("invalid transaction status transition")
+	ErrTransactionFailed = errors.New("update transaction failed")
+)
+
+// Define the available statuses of a transaction, in the order a transaction moves through them.
+var (
+	Pending    = TransactionStatus{"pending"}
+	Dispatched = TransactionStatus{"dispatched"}
+	Applying   = TransactionStatus{"applying"}
+	Succeeded  = TransactionStatus{"succeeded"}
+	Failed     = TransactionStatus{"failed"}
+)
+
+// All available statuses.
+var availableStatuses = []TransactionStatus{
+	Pending,
+	Dispatched,
+	Applying,
+	Succeeded,
+	Failed,
+}
+
+// TransactionStatus is the status of the update of a single device.
+type TransactionStatus struct {
+	status string
+}
+
+// NewTransactionStatusFromString creates a new status from a string.
+func NewTransactionStatusFromString(status string) (TransactionStatus, error) {
+	for _, s := range availableStatuses {
+		if s.status == status {
+			return s, nil
+		}
+	}
+	return TransactionStatus{}, ErrInvalidStatus
+}
+
+// String returns the string representation of a status.
+func (s TransactionStatus) String() string {
+	return s.status
+}
+
+// IsFinal returns true if the transaction can't move anymore.
+func (s TransactionStatus) IsFinal() bool {
+	return s == Succeeded || s == Failed
+}
+
+// Transaction is the update of a single device to the image version of a rollout.
+type Transaction struct {
+	deviceUUID string
+	status     TransactionStatus
+	reason     string
+	// assignment of the device before it was dispatched, restored if the transaction fails
+	previous device.Assignment
+	// whether the failed transaction left nothing to restore on its device
+	restored  bool
+	updatedAt time.Time
+}
+
+// NewTransaction creates a new pending transaction for the device with the given uuid.
+func NewTransaction(deviceUUID string) Transaction {
+	return Transaction{deviceUUID: deviceUUID, status: Pending}
+}
+
+// DeviceUUID is a getter for the uuid of the device of a transaction.
+func (t Transaction) DeviceUUID() string {
+	return t.deviceUUID
+}
+
+// Status is a getter for the status of a transaction.
+func (t Transaction) Status() TransactionStatus {
+	return t.status
+}
+
+// Reason is a getter for the reason a transaction failed.
+func (t Transaction) Reason() string {
+	return t.reason
+}
+
+// Previous is a getter for the assignment the device had before it was dispatched.
+func (t Transaction) Previous() device.Assignment {
+	return t.previous
+}
+
+// Restored returns true once the device of a failed transaction was assigned back to its previous assignment,
+// or if it failed before it was dispatched, leaving the device untouched.
+func (t Transaction) Restored() bool {
+	return t.restored
+}
+
+// UpdatedAt is a getter for the last time the status of a transaction changed.
+func (t Transaction) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+// Dispatch marks a pending transaction as dispatched to its device, previously assigned as given.
+func (t *Transaction) Dispatch(previous device.Assignment, at time.Time) error {
+	if t.status != Pending {
+		return ErrInvalidTransition
+	}
+	t.previous = previous
+	t.moveTo(Dispatched, at)
+	return nil
+}
+
+// Apply marks a dispatched transaction as being applied by its device.
+func (t *Transaction) Apply(at time.Time) error {
+	if t.status != Dispatched {
+		return ErrInvalidTransition
+	}
+	t.moveTo(Applying, at)
+	return nil
+}
+
+// Succeed marks a dispatched or applying transaction as succeeded.
+func (t *Transaction) Succeed(at time.Time) error {
+	if t.status != Dispatched && t.status != Applying {
+		return ErrInvalidTransition
+	}
+	t.moveTo(Succeeded, at)
+	return nil
+}
+
+// Fail marks a transaction that isn't final as failed, for the given reason.
+func (t *Transaction) Fail(reason string, at time.Time) error {
+	if t.status.IsFinal() {
+		return ErrInvalidTransition
+	}
+	t.reason = reason
+	t.restored = t.status == Pending // never reached its device
+	t.moveTo(Failed, at)
+	return nil
+}
+
+// Restore marks the device of a failed transaction as assigned back to its previous assignment.
+func (t *Transaction) Restore() error {
+	if t.status != Failed || t.restored {
+		return ErrInvalidTransition
+	}
+	t.restored = true
+	return nil
+}
+
+// moveTo sets the status of a transaction.
+func (t *Transaction) moveTo(status TransactionStatus, at time.Time) {
+	t.status = status
+	t.updatedAt = at.UTC().Truncate(time.Microsecond)
+}
+
+// UnmarshalTransactionFromDatabase unmarshals the transaction from the database.
+func UnmarshalTransactionFromDatabase(deviceUUID, status, reason, previousImageUUID string,
+	previousVersion uint, restored bool, updatedAt time.Time) (Transaction, error) {
+	validStatus, err := NewTransactionStatusFromString(status)
+	if err != nil {
+		return Transaction{}, err
+	}
+	previous, err := device.NewAssignment(previousImageUUID, previousVersion)
+	if err != nil {
+		return Transaction{}, err
+	}
+	return Transaction{
+		deviceUUID: deviceUUID,
+		status:     validStatus,
+		reason:     reason,
+		previous:   previous,
+		restored:   restored,
+		updatedAt:  updatedAt,
+	}, nil
+}
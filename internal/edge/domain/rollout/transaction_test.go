@@ -0,0 +1,148 @@
+package rollout
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+)
+
+func TestTransaction_Transitions(t *testing.T) {
+	now := time.Now()
+	previous, _ := device.NewAssignment("image-uuid", 1)
+	dispatch := func(t *Transaction) error { return t.Dispatch(previous, now) }
+	apply := func(t *Transaction) error { return t.Apply(now) }
+	succeed := func(t *Transaction) error { return t.Succeed(now) }
+	fail := func(t *Transaction) error { return t.Fail("no space left", now) }
+	tests := []struct {
+		name       string
+		steps      []func(t *Transaction) error
+		wantStatus TransactionStatus
+		wantErr    error
+	}{
+		{
+			name:       "should succeed after applying",
+			steps:      []func(t *Transaction) error{dispatch, apply, succeed},
+			wantStatus: Succeeded,
+			wantErr:    nil,
+		},
+		{
+			name:       "should succeed without reporting it applies",
+			steps:      []func(t *Transaction) error{dispatch, succeed},
+			wantStatus: Succeeded,
+			wantErr:    nil,
+		},
+		{
+			name:       "should fail while pending",
+			steps:      []func(t *Transaction) error{fail},
+			wantStatus: Failed,
+			wantErr:    nil,
+		},
+		{
+			name:       "should fail, can't apply before dispatch",
+			steps:      []func(t *Transaction) error{apply},
+			wantStatus: Pending,
+			wantErr:    ErrInvalidTransition,
+		},
+		{
+			name:       "should fail, can't succeed while pending",
+			steps:      []func(t *Transaction) error{succeed},
+			wantStatus: Pending,
+			wantErr:    ErrInvalidTransition,
+		},
+		{
+			name:       "should fail, can't fail once succeeded",
+			steps:      []func(t *Transaction) error{dispatch, succeed, fail},
+			wantStatus: Succeeded,
+			wantErr:    ErrInvalidTransition,
+		},
+		{
+			name:       "should fail, can't dispatch twice",
+			steps:      []func(t *Transaction) error{dispatch, dispatch},
+			wantStatus: Dispatched,
+			wantErr:    ErrInvalidTransition,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			transaction := NewTransaction("device-uuid")
+			var err error
+			for _, step := range tt.steps {
+				if err = step(&transaction); err != nil {
+					break
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Transaction transition error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if transaction.Status() != tt.wantStatus {
+				t.Errorf("Transaction.Status() = %v, want %v", transaction.Status(), tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestTransaction_Dispatch(t *testing.T) {
+	previous, _ := device.NewAssignment("image-uuid", 1)
+	transaction := NewTransaction("device-uuid")
+	if err := transaction.Dispatch(previous, time.Now()); err != nil {
+		t.Fatalf("Transaction.Dispatch() error = %v", err)
+	}
+	if transaction.Previous() != previous || transaction.UpdatedAt().IsZero() {
+		t.Errorf("Transaction.Dispatch() = %v, want the previous assignment kept", transaction)
+	}
+}
+
+func TestTransaction_Restore(t *testing.T) {
+	now := time.Now()
+	previous, _ := device.NewAssignment("image-uuid", 1)
+	tests := []struct {
+		name         string
+		dispatched   bool
+		fail         bool
+		wantRestored bool
+		wantErr      error
+	}{
+		{
+			name:         "should restore the device the failed transaction was dispatched to",
+			dispatched:   true,
+			fail:         true,
+			wantRestored: true,
+			wantErr:      nil,
+		},
+		{
+			name:         "should fail, nothing to restore before it was dispatched",
+			fail:         true,
+			wantRestored: true,
+			wantErr:      ErrInvalidTransition,
+		},
+		{
+			name:         "should fail, can't restore before it failed",
+			dispatched:   true,
+			wantRestored: false,
+			wantErr:      ErrInvalidTransition,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			transaction := NewTransaction("device-uuid")
+			if tt.dispatched {
+				_ = transaction.Dispatch(previous, now)
+			}
+			if tt.fail {
+				_ = transaction.Fail("no space left", now)
+			}
+			if err := transaction.Restore(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Transaction.Restore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if transaction.Restored() != tt.wantRestored {
+				t.Errorf("Transaction.Restored() = %v, want %v", transaction.Restored(), tt.wantRestored)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package rollout
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+// Usage is what uses an image: the devices assigned to or running its versions,
+// and its active rollouts, which are about to assign devices to it.
+type Usage struct {
+	imageUUID string
+	devices   []*device.Device
+	rollouts  []*Rollout
+}
+
+// NewUsage creates the usage of an image, ignoring the given devices and rollouts unrelated to it.
+func NewUsage(imageUUID string, devices []*device.Device, rollouts []*Rollout) Usage {
+	usage := Usage{imageUUID: imageUUID}
+	for _, d := range devices {
+		if d.Assignment().ImageUUID() == imageUUID || d.Running().ImageUUID() == imageUUID {
+			usage.devices = append(usage.devices, d)
+		}
+	}
+	for _, r := range rollouts {
+		if r.Assignment().ImageUUID() == imageUUID && !r.State().IsFinal() {
+			usage.rollouts = append(usage.rollouts, r)
+		}
+	}
+	return usage
+}
+
+// ImageUUID returns the uuid of the image.
+func (u Usage) ImageUUID() string {
+	return u.imageUUID
+}
+
+// BlockingDevices returns the uuids of the devices that keep the given versions of the image from being deleted,
+// those assigned to them and those an active rollout of them didn't finish with yet. No version means all of them.
+func (u Usage) BlockingDevices(versions ...image.Version) []string {
+	matches := func(version image.Version) bool {
+		if len(versions) == 0 {
+			return true
+		}
+		for _, v := range versions {
+			if v == version {
+				return true
+			}
+		}
+		return false
+	}
+	blocking := make(map[string]bool)
+	for _, d := range u.devices {
+		if d.Assignment().ImageUUID() == u.imageUUID && matches(d.Assignment().Version()) {
+			blocking[d.UUID()] = true
+		}
+	}
+	for _, r := range u.rollouts {
+		if !matches(r.Assignment().Version()) {
+			continue
+		}
+		for _, transaction := range r.Transactions() {
+			if !transaction.Status().IsFinal() {
+				blocking[transaction.DeviceUUID()] = true
+			}
+		}
+	}
+	uuids := make([]string, 0, len(blocking))
+	for uuid := range blocking {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	return uuids
+}
+
+// Unused returns the given snapshots of the image that no device blocks from being deleted.
+func (u Usage) Unused(snapshots []*image.Snapshot) []*image.Snapshot {
+	var unused []*image.Snapshot
+	for _, snapshot := range snapshots {
+		if len(u.BlockingDevices(snapshot.Version())) == 0 {
+			unused = append(unused, snapshot)
+		}
+	}
+	return unused
+}
+
+// VersionDevices are the devices assigned to or running a version of an image.
+type VersionDevices struct {
+	version  image.Version
+	assigned []*device.Device
+	running  []*device.Device
+}
+
+// Version returns the version of the image.
+func (v VersionDevices) Version() image.Version {
+	return v.version
+}
+
+// Assigned returns the devices assigned to the version.
+func (v VersionDevices) Assigned() []*device.Device {
+	return v.assigned
+}
+
+// Running returns the devices running the version.
+func (v VersionDevices) Running() []*device.Device {
+	return v.running
+}
+
+// Versions returns the devices assigned to or running each version of the image, sorted by version.
+func (u Usage) Versions() []VersionDevices {
+	byVersion := make(map[uint]*VersionDevices)
+	get := func(version image.Version) *VersionDevices {
+		if _, ok := byVersion[version.Uint()]; !ok {
+			byVersion[version.Uint()] = &VersionDevices{version: version}
+		}
+		return byVersion[version.Uint()]
+	}
+	for _, d := range u.devices {
+		if d.Assignment().ImageUUID() == u.imageUUID {
+			v := get(d.Assignment().Version())
+			v.assigned = append(v.assigned, d)
+		}
+		if d.Running().ImageUUID() == u.imageUUID {
+			v := get(d.Running().Version())
+			v.running = append(v.running, d)
+		}
+	}
+	versions := make([]VersionDevices, 0, len(byVersion))
+	for _, v := range byVersion {
+		versions = append(versions, *v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].version.Uint() < versions[j].version.Uint()
+	})
+	return versions
+}
+
+// GetUsage returns the usage of the image with the given UUID.
+func GetUsage(ctx context.Context, repository Repository, deviceRepository device.Repository,
+	imageUUID string) (Usage, error) {
+	devices, err := deviceRepository.GetImageDevices(ctx, imageUUID)
+	if err != nil {
+		return Usage{}, err
+	}
+	rollouts, err := repository.GetActiveRollouts(ctx, imageUUID)
+	if err != nil {
+		return Usage{}, err
+	}
+	return NewUsage(imageUUID, devices, rollouts), nil
+}
@@ -0,0 +1,101 @@
+package rollout
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+)
+
+func TestUsage_BlockingDevices(t *testing.T) {
+	assigned, _ := device.NewDevice("assigned-uuid", "kiosk", "", "image-uuid", 1, nil)
+	other, _ := device.NewDevice("other-uuid", "kiosk", "", "other-image-uuid", 1, nil)
+	active, _ := NewRolloutWithContext(context.Background(), "rollout-uuid", "image-uuid", 2,
+		[]string{"pending-uuid", "done-uuid"}, DefaultPlan())
+	_ = active.transactions[1].Dispatch(device.Assignment{}, time.Now())
+	_ = active.transactions[1].Succeed(time.Now())
+	aborted, _ := NewRolloutWithContext(context.Background(), "aborted-uuid", "image-uuid", 2,
+		[]string{"aborted-device-uuid"}, DefaultPlan())
+	_ = aborted.Abort(time.Now())
+	usage := NewUsage("image-uuid", []*device.Device{&assigned, &other}, []*Rollout{&active, &aborted})
+	tests := []struct {
+		name     string
+		versions []uint
+		want     []string
+	}{
+		{
+			name: "should block all versions",
+			want: []string{"assigned-uuid", "pending-uuid"},
+		},
+		{
+			name:     "should block the assigned version",
+			versions: []uint{1},
+			want:     []string{"assigned-uuid"},
+		},
+		{
+			name:     "should block the version being rolled out",
+			versions: []uint{2},
+			want:     []string{"pending-uuid"},
+		},
+		{
+			name:     "should not block an unused version",
+			versions: []uint{3},
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			versions := make([]image.Version, len(tt.versions))
+			for i, number := range tt.versions {
+				versions[i], _ = image.NewVersion(number)
+			}
+			if got := usage.BlockingDevices(versions...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Usage.BlockingDevices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsage_Versions(t *testing.T) {
+	v1, _ := device.NewAssignment("image-uuid", 1)
+	upgrading, _ := device.NewDevice("upgrading-uuid", "kiosk", "", "image-uuid", 2, nil)
+	upgrading.Assign(v1)
+	booted, _ := device.NewCommit("abababababababababababababababababababababababababababababababab")
+	_ = upgrading.CheckIn(booted, v1, device.Health{}, time.Now())
+	v2, _ := device.NewAssignment("image-uuid", 2)
+	upgrading.Assign(v2)
+	idle, _ := device.NewDevice("idle-uuid", "kiosk", "", "image-uuid", 1, nil)
+
+	versions := NewUsage("image-uuid", []*device.Device{&upgrading, &idle}, nil).Versions()
+	if len(versions) != 2 {
+		t.Fatalf("Usage.Versions() = %d versions, want 2", len(versions))
+	}
+	uuids := func(devices []*device.Device) []string {
+		uuids := []string{}
+		for _, d := range devices {
+			uuids = append(uuids, d.UUID())
+		}
+		return uuids
+	}
+	tests := []struct {
+		version      uint
+		wantAssigned []string
+		wantRunning  []string
+	}{
+		{version: 1, wantAssigned: []string{"idle-uuid"}, wantRunning: []string{"upgrading-uuid"}},
+		{version: 2, wantAssigned: []string{"upgrading-uuid"}, wantRunning: []string{}},
+	}
+	for i, tt := range tests {
+		got := versions[i]
+		if got.Version().Uint() != tt.version || !reflect.DeepEqual(uuids(got.Assigned()), tt.wantAssigned) ||
+			!reflect.DeepEqual(uuids(got.Running()), tt.wantRunning) {
+			t.Errorf("Usage.Versions()[%d] = %v %v %v, want %v %v %v", i, got.Version().Uint(), uuids(got.Assigned()),
+				uuids(got.Running()), tt.version, tt.wantAssigned, tt.wantRunning)
+		}
+	}
+}
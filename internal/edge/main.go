@@ -2,26 +2,50 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/Avielyo10/edge-api/internal/common/logs"
 	"github.com/Avielyo10/edge-api/internal/common/server"
-	"github.com/Avielyo10/edge-api/internal/edge/ports/images"
+	devicePorts "github.com/Avielyo10/edge-api/internal/edge/ports/devices"
+	imagePorts "github.com/Avielyo10/edge-api/internal/edge/ports/images"
 	"github.com/Avielyo10/edge-api/internal/edge/service"
 	"github.com/go-chi/chi/v5"
 	"github.com/redhatinsights/edge-api/config"
 	"net/http"
+	"os"
+
+	log "github.com/sirupsen/logrus"
 )
 
 func main() {
 	config.Init() // init config
 	logs.Init()   // init logger
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); errors.Is(err, errMigrateUsage) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	ctx := context.Background()
 
 	application := service.NewApplication(ctx)
 
+	go imagePorts.RunVersionPruner(ctx, application, imagePorts.DefaultPruneInterval)
+	go devicePorts.RunRolloutFollower(ctx, application, devicePorts.DefaultFollowInterval)
+
 	server.RunHTTPServer(config.Get(), func(router chi.Router) http.Handler {
-		return ports.HandlerFromMux(
-			ports.NewHttpServer(application),
+		// both servers register their routes on the same router
+		imagePorts.HandlerWithRepo(
+			imagePorts.NewHttpServer(application),
+			router,
+		)
+		return devicePorts.HandlerFromMux(
+			devicePorts.NewHttpServer(application),
 			router,
 		)
 	})
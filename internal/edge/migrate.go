@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/adapters"
+	"github.com/redhatinsights/edge-api/config"
+)
+
+const migrateUsage = `usage: edge migrate <command>
+
+commands:
+  up            apply every pending migration
+  down          roll back the last applied migration
+  status        list the migrations and whether they are applied
+  to <version>  apply or roll back the migrations up to the version, 0 rolls back all of them`
+
+var errMigrateUsage = errors.New(migrateUsage)
+
+// runMigrate runs the migrate subcommand with its arguments against the configured database,
+// then prints the status of the migrations.
+func runMigrate(args []string) error {
+	var migrate func(migrator *adapters.GormMigrator) error
+	switch {
+	case len(args) == 1 && args[0] == "up":
+		migrate = (*adapters.GormMigrator).Up
+	case len(args) == 1 && args[0] == "down":
+		migrate = (*adapters.GormMigrator).Down
+	case len(args) == 1 && args[0] == "status":
+		migrate = func(*adapters.GormMigrator) error { return nil }
+	case len(args) == 2 && args[0] == "to":
+		version, err := strconv.ParseUint(args[1], 10, 0)
+		if err != nil {
+			return errMigrateUsage
+		}
+		migrate = func(migrator *adapters.GormMigrator) error { return migrator.To(uint(version)) }
+	default:
+		return errMigrateUsage
+	}
+	db, err := adapters.OpenGormDB(config.Get())
+	if err != nil {
+		return err
+	}
+	migrator := adapters.NewGormMigrator(db, adapters.GormMigrations)
+	if err := migrate(migrator); err != nil {
+		return err
+	}
+	return printMigrationStatus(migrator)
+}
+
+// printMigrationStatus prints the status of every migration, one per line.
+func printMigrationStatus(migrator *adapters.GormMigrator) error {
+	statuses, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.Applied {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		if !status.Known {
+			appliedAt += " (unknown to this binary)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/app"
+)
+
+// DefaultFollowInterval is the default interval between two claims of the rollouts no replica follows.
+const DefaultFollowInterval = time.Minute
+
+// RunRolloutFollower follows the rollouts no replica follows, at startup and then once every interval,
+// until the context is done.
+func RunRolloutFollower(ctx context.Context, application app.Application, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// errors are logged by the command itself, keep following on the next tick
+		_ = application.Commands.FollowRollouts.Handle(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
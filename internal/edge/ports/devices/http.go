@@ -0,0 +1,895 @@
+package ports
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	httperr "github.com/Avielyo10/edge-api/internal/common/server/httperr"
+	"github.com/Avielyo10/edge-api/internal/edge/app"
+	"github.com/Avielyo10/edge-api/internal/edge/app/command"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// HttpServer is a port for the http server.
+type HttpServer struct {
+	app app.Application
+}
+
+// NewHttpServer returns a new HttpServer.
+func NewHttpServer(application app.Application) HttpServer {
+	return HttpServer{
+		app: application,
+	}
+}
+
+// CreateDevice registers a new device. Implementing ports.ServerInterface
+func (h HttpServer) CreateDevice(w http.ResponseWriter, r *http.Request) {
+	var req CreateDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	if err := CheckCreateRequest(req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	secret, token, err := device.NewToken()
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	cmd := command.CreateDevice{
+		UUID:  uuid.NewString(),
+		Name:  string(*req.Name),
+		Token: token,
+	}
+	if req.Commit != nil {
+		cmd.Commit = string(*req.Commit)
+	}
+	if req.Image != nil {
+		cmd.ImageUUID, cmd.ImageVersion = imageFromRequest(*req.Image)
+	}
+	if req.Tags != nil {
+		cmd.Tags = *req.Tags
+	}
+	created, err := h.app.Commands.CreateDevice.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	resp := deviceToResponse(created)
+	resp.Token = &secret // handed out once, only its hash is stored
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, resp)
+}
+
+// GetDevice returns the device with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) GetDevice(w http.ResponseWriter, r *http.Request, deviceId string) {
+	device, err := h.app.Queries.GetDevice.Handle(r.Context(), deviceId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, deviceToResponse(device))
+}
+
+// UpdateDevice updates the device with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) UpdateDevice(w http.ResponseWriter, r *http.Request, deviceId string) {
+	var req UpdateDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	cmd := command.UpdateDevice{
+		UUIDToUpdate: deviceId,
+	}
+	if req.Name != nil {
+		cmd.Name = string(*req.Name)
+	}
+	if req.Commit != nil {
+		cmd.Commit = string(*req.Commit)
+	}
+	if req.Image != nil {
+		cmd.Assign = true
+		cmd.ImageUUID, cmd.ImageVersion = imageFromRequest(*req.Image)
+	}
+	if req.Tags != nil {
+		if req.Tags.Add != nil {
+			cmd.TagsToAdd = *req.Tags.Add
+		}
+		if req.Tags.Remove != nil {
+			cmd.TagsToRemove = *req.Tags.Remove
+		}
+	}
+	if err := h.app.Commands.UpdateDevice.Handle(r.Context(), cmd); err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// DeleteDevice deletes the device with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) DeleteDevice(w http.ResponseWriter, r *http.Request, deviceId string) {
+	if err := h.app.Commands.DeleteDevice.Handle(r.Context(), deviceId); err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// GetDevices returns all devices, or only the drifted ones. Implementing ports.ServerInterface
+func (h HttpServer) GetDevices(w http.ResponseWriter, r *http.Request, params GetDevicesParams) {
+	ctx := r.Context()
+	var devices []*device.Device
+	var err error
+	if params.Drift != nil && *params.Drift {
+		devices, err = h.app.Queries.GetDriftedDevices.Handle(ctx)
+	} else {
+		devices, err = h.app.Queries.GetDevices.Handle(ctx)
+	}
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	devicesRes := devicesToResponse(devices)
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, map[string]interface{}{
+		"count": len(devicesRes),
+		"items": devicesRes,
+	})
+}
+
+// GetDrift counts the drift of the devices per image they are assigned to. Implementing ports.ServerInterface
+func (h HttpServer) GetDrift(w http.ResponseWriter, r *http.Request) {
+	summaries, err := h.app.Queries.GetDrift.Handle(r.Context())
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	summariesRes := make([]ImageDriftResponse, 0, len(summaries))
+	for _, summary := range summaries {
+		summariesRes = append(summariesRes, imageDriftToResponse(summary))
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, map[string]interface{}{
+		"count": len(summariesRes),
+		"items": summariesRes,
+	})
+}
+
+// CreateGroup creates a new device group. Implementing ports.ServerInterface
+func (h HttpServer) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req CreateGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	if err := CheckCreateGroupRequest(req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	cmd := command.CreateGroup{
+		UUID: uuid.NewString(),
+		Name: string(*req.Name),
+	}
+	if req.Devices != nil {
+		for _, deviceUUID := range *req.Devices {
+			cmd.Devices = append(cmd.Devices, string(deviceUUID))
+		}
+	}
+	if req.Selector != nil {
+		cmd.Selector = *req.Selector
+	}
+	deviceGroup, err := h.app.Commands.CreateGroup.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, groupToResponse(deviceGroup))
+}
+
+// GetGroup returns the group with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) GetGroup(w http.ResponseWriter, r *http.Request, groupId string) {
+	deviceGroup, err := h.app.Queries.GetGroup.Handle(r.Context(), groupId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, groupToResponse(deviceGroup))
+}
+
+// GetGroups returns all groups. Implementing ports.ServerInterface
+func (h HttpServer) GetGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.app.Queries.GetGroups.Handle(r.Context())
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	groupsRes := groupsToResponse(groups)
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, map[string]interface{}{
+		"count": len(groupsRes),
+		"items": groupsRes,
+	})
+}
+
+// GetGroupDevices returns the devices that are members of the group with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) GetGroupDevices(w http.ResponseWriter, r *http.Request, groupId string) {
+	devices, err := h.app.Queries.GetGroupDevices.Handle(r.Context(), groupId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	devicesRes := devicesToResponse(devices)
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, map[string]interface{}{
+		"count": len(devicesRes),
+		"items": devicesRes,
+	})
+}
+
+// CheckInDevice records what a device reports about itself. Implementing ports.ServerInterface
+func (h HttpServer) CheckInDevice(w http.ResponseWriter, r *http.Request, deviceId string, params CheckInDeviceParams) {
+	var req CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	cmd := command.CheckInDevice{
+		UUID:         deviceId,
+		Token:        device.TokenOf(params.XDeviceToken),
+		BootedCommit: string(req.BootedCommit),
+	}
+	if req.StagedCommit != nil {
+		cmd.StagedCommit = string(*req.StagedCommit)
+	}
+	if req.Status != nil {
+		cmd.Status = *req.Status
+	}
+	if req.Greenboot != nil {
+		cmd.Greenboot = string(*req.Greenboot)
+	}
+	if req.Uptime != nil {
+		cmd.Uptime = time.Duration(*req.Uptime) * time.Second
+	}
+	checkedIn, err := h.app.Commands.CheckInDevice.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, deviceToResponse(checkedIn))
+}
+
+// GetDeviceGroups returns the groups the device with the given uuid is a member of. Implementing ports.ServerInterface
+func (h HttpServer) GetDeviceGroups(w http.ResponseWriter, r *http.Request, deviceId string) {
+	groups, err := h.app.Queries.GetDeviceGroups.Handle(r.Context(), deviceId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	groupsRes := groupsToResponse(groups)
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, map[string]interface{}{
+		"count": len(groupsRes),
+		"items": groupsRes,
+	})
+}
+
+// CreateRollout rolls out an image version to devices and groups. Implementing ports.ServerInterface
+func (h HttpServer) CreateRollout(w http.ResponseWriter, r *http.Request) {
+	var req CreateRolloutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	cmd := command.UpdateRollout{
+		UUID: uuid.NewString(),
+	}
+	cmd.ImageUUID, cmd.ImageVersion = imageFromRequest(req.Image)
+	if req.Devices != nil {
+		for _, deviceUUID := range *req.Devices {
+			cmd.Devices = append(cmd.Devices, string(deviceUUID))
+		}
+	}
+	if req.Groups != nil {
+		for _, groupUUID := range *req.Groups {
+			cmd.Groups = append(cmd.Groups, string(groupUUID))
+		}
+	}
+	if err := planFromRequest(&cmd, req.Plan); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	newRollout, err := h.app.Commands.UpdateRollout.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, rolloutToResponse(newRollout))
+}
+
+// GetRollout returns the rollout with the given uuid and its progress. Implementing ports.ServerInterface
+func (h HttpServer) GetRollout(w http.ResponseWriter, r *http.Request, rolloutId string) {
+	existing, err := h.app.Queries.GetRollout.Handle(r.Context(), rolloutId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, rolloutToResponse(existing))
+}
+
+// ReportTransaction reports the update progress of a device within a rollout. Implementing ports.ServerInterface
+func (h HttpServer) ReportTransaction(w http.ResponseWriter, r *http.Request, rolloutId string, deviceId string) {
+	var req ReportTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	cmd := command.ReportTransaction{
+		RolloutUUID: rolloutId,
+		DeviceUUID:  deviceId,
+		Status:      string(req.Status),
+	}
+	if req.Reason != nil {
+		cmd.Reason = *req.Reason
+	}
+	if err := h.app.Commands.ReportTransaction.Handle(r.Context(), cmd); err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// PauseRollout pauses a running rollout. Implementing ports.ServerInterface
+func (h HttpServer) PauseRollout(w http.ResponseWriter, r *http.Request, rolloutId string) {
+	existing, err := h.app.Commands.PauseRollout.Handle(r.Context(), rolloutId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, rolloutToResponse(existing))
+}
+
+// ResumeRollout resumes a paused or halted rollout. Implementing ports.ServerInterface
+func (h HttpServer) ResumeRollout(w http.ResponseWriter, r *http.Request, rolloutId string) {
+	existing, err := h.app.Commands.ResumeRollout.Handle(r.Context(), rolloutId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, rolloutToResponse(existing))
+}
+
+// AbortRollout aborts a rollout, failing its transactions that are not final. Implementing ports.ServerInterface
+func (h HttpServer) AbortRollout(w http.ResponseWriter, r *http.Request, rolloutId string) {
+	existing, err := h.app.Commands.AbortRollout.Handle(r.Context(), rolloutId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, rolloutToResponse(existing))
+}
+
+// CreateMaintenanceWindow creates a maintenance window for devices and groups. Implementing ports.ServerInterface
+func (h HttpServer) CreateMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	var req CreateMaintenanceWindowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	cmd := command.CreateMaintenanceWindow{
+		UUID:     uuid.NewString(),
+		Name:     string(req.Name),
+		Schedule: req.Schedule,
+		Duration: time.Duration(req.Duration) * time.Second,
+	}
+	if req.Timezone != nil {
+		cmd.Timezone = *req.Timezone
+	}
+	if req.Devices != nil {
+		for _, deviceUUID := range *req.Devices {
+			cmd.Devices = append(cmd.Devices, string(deviceUUID))
+		}
+	}
+	if req.Groups != nil {
+		for _, groupUUID := range *req.Groups {
+			cmd.Groups = append(cmd.Groups, string(groupUUID))
+		}
+	}
+	window, err := h.app.Commands.CreateMaintenanceWindow.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, windowToResponse(window))
+}
+
+// GetMaintenanceWindow returns the maintenance window with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) GetMaintenanceWindow(w http.ResponseWriter, r *http.Request, windowId string) {
+	window, err := h.app.Queries.GetMaintenanceWindow.Handle(r.Context(), windowId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, windowToResponse(window))
+}
+
+// GetMaintenanceWindows returns all maintenance windows. Implementing ports.ServerInterface
+func (h HttpServer) GetMaintenanceWindows(w http.ResponseWriter, r *http.Request) {
+	windows, err := h.app.Queries.GetMaintenanceWindows.Handle(r.Context())
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	windowsRes := windowsToResponse(windows)
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, map[string]interface{}{
+		"count": len(windowsRes),
+		"items": windowsRes,
+	})
+}
+
+// DeleteMaintenanceWindow deletes the maintenance window with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) DeleteMaintenanceWindow(w http.ResponseWriter, r *http.Request, windowId string) {
+	if err := h.app.Commands.DeleteMaintenanceWindow.Handle(r.Context(), windowId); err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// GetDeviceMaintenanceWindows returns the maintenance windows of the device with the given uuid
+// along with the next one it gets. Implementing ports.ServerInterface
+func (h HttpServer) GetDeviceMaintenanceWindows(w http.ResponseWriter, r *http.Request, deviceId string) {
+	windows, err := h.app.Queries.GetDeviceWindows.Handle(r.Context(), deviceId)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	windowsRes := windowsToResponse(windows)
+	res := map[string]interface{}{
+		"count": len(windowsRes),
+		"items": windowsRes,
+	}
+	if start, end, ok := windows.Next(time.Now()); ok {
+		res["next"] = NextMaintenanceWindow{Start: &start, End: &end}
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, res)
+}
+
+// ImportVoucher imports an ownership voucher, the body is the voucher itself. Implementing ports.ServerInterface
+func (h HttpServer) ImportVoucher(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	voucher, err := h.app.Commands.ImportVoucher.Handle(r.Context(), data)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, voucherToResponse(voucher))
+}
+
+// GetVoucher returns the voucher of the device with the given guid. Implementing ports.ServerInterface
+func (h HttpServer) GetVoucher(w http.ResponseWriter, r *http.Request, guid string) {
+	voucher, err := h.app.Queries.GetVoucher.Handle(r.Context(), guid)
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, voucherToResponse(voucher))
+}
+
+// GetVouchers returns all vouchers. Implementing ports.ServerInterface
+func (h HttpServer) GetVouchers(w http.ResponseWriter, r *http.Request) {
+	vouchers, err := h.app.Queries.GetVouchers.Handle(r.Context())
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	vouchersRes := make([]VoucherResponse, len(vouchers))
+	for i, voucher := range vouchers {
+		vouchersRes[i] = voucherToResponse(voucher)
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, map[string]interface{}{
+		"count": len(vouchersRes),
+		"items": vouchersRes,
+	})
+}
+
+// OnboardingCompleted registers the device of a voucher once it completed FDO onboarding.
+// Called by the owner onboarding server. Implementing ports.ServerInterface
+func (h HttpServer) OnboardingCompleted(w http.ResponseWriter, r *http.Request) {
+	var req OnboardingCompletedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	secret, token, err := device.NewToken()
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	onboarded, err := h.app.Commands.CompleteOnboarding.Handle(r.Context(), command.CompleteOnboarding{
+		GUID:       string(req.Guid),
+		DeviceUUID: uuid.NewString(),
+		Token:      token,
+	})
+	if err != nil {
+		httperr.HandleDeviceErrors(w, r, err)
+		return
+	}
+	resp := deviceToResponse(onboarded)
+	resp.Token = &secret // handed out once, the onboarding server passes it on to the device
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, resp)
+}
+
+// devicesToResponse converts a list of devices to a list of device responses.
+func devicesToResponse(devices []*device.Device) []DeviceResponse {
+	devicesRes := make([]DeviceResponse, len(devices))
+	for i, device := range devices {
+		devicesRes[i] = deviceToResponse(device)
+	}
+	return devicesRes
+}
+
+// deviceToResponse converts a device to a device response.
+func imageDriftToResponse(summary device.ImageDrift) ImageDriftResponse {
+	imageUUID := UUID(summary.ImageUUID())
+	assigned := summary.Assigned()
+	inSync := summary.Count(device.Drift{})
+	behind := summary.Count(device.Behind)
+	ahead := summary.Count(device.Ahead)
+	otherImage := summary.Count(device.OtherImage)
+	unknownCommit := summary.Count(device.UnknownCommit)
+	return ImageDriftResponse{
+		ImageUuid:     &imageUUID,
+		Assigned:      &assigned,
+		InSync:        &inSync,
+		Behind:        &behind,
+		Ahead:         &ahead,
+		OtherImage:    &otherImage,
+		UnknownCommit: &unknownCommit,
+	}
+}
+
+func deviceToResponse(device *device.Device) DeviceResponse {
+	uuid := UUID(device.UUID())
+	name := Name(device.Name().String())
+	tags := Tags(device.Tags().StringArray())
+	resp := DeviceResponse{
+		Uuid: &uuid,
+		Name: &name,
+		Tags: &tags,
+	}
+	if !device.Commit().IsZero() {
+		commit := Commit(device.Commit().String())
+		resp.Commit = &commit
+	}
+	if assignment := device.Assignment(); !assignment.IsZero() {
+		imageUUID := assignment.ImageUUID()
+		version := int(assignment.Version().Uint())
+		resp.Image = &DeviceImage{Uuid: &imageUUID, Version: &version}
+	}
+	if running := device.Running(); !running.IsZero() {
+		imageUUID := running.ImageUUID()
+		version := int(running.Version().Uint())
+		resp.Running = &DeviceImage{Uuid: &imageUUID, Version: &version}
+	}
+	if health := device.Health(); !health.IsZero() {
+		status := health.Status()
+		uptime := int(health.Uptime() / time.Second)
+		resp.Health = &DeviceHealth{Status: &status, Uptime: &uptime}
+		if !health.Staged().IsZero() {
+			staged := Commit(health.Staged().String())
+			resp.Health.StagedCommit = &staged
+		}
+		if !health.Greenboot().IsZero() {
+			greenboot := Greenboot(health.Greenboot().String())
+			resp.Health.Greenboot = &greenboot
+		}
+	}
+	if drift := device.Drift(); !drift.IsZero() {
+		driftRes := Drift(drift.String())
+		resp.Drift = &driftRes
+	}
+	if !device.LastSeen().IsZero() {
+		lastSeen := LastSeen(device.LastSeen())
+		resp.LastSeen = &lastSeen
+	}
+	if !device.CreatedAt().IsZero() {
+		createdAt := CreatedAt(device.CreatedAt())
+		resp.CreatedAt = &createdAt
+	}
+	if !device.UpdatedAt().IsZero() {
+		updatedAt := UpdatedAt(device.UpdatedAt())
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+// groupsToResponse converts a list of groups to a list of group responses.
+func groupsToResponse(groups []*group.DeviceGroup) []GroupResponse {
+	groupsRes := make([]GroupResponse, len(groups))
+	for i, deviceGroup := range groups {
+		groupsRes[i] = groupToResponse(deviceGroup)
+	}
+	return groupsRes
+}
+
+// groupToResponse converts a group to a group response.
+func groupToResponse(deviceGroup *group.DeviceGroup) GroupResponse {
+	uuid := UUID(deviceGroup.UUID())
+	name := Name(deviceGroup.Name().String())
+	membership := GroupResponseMembership(deviceGroup.Membership().String())
+	resp := GroupResponse{
+		Uuid:       &uuid,
+		Name:       &name,
+		Membership: &membership,
+	}
+	if deviceGroup.Membership() == group.Static {
+		devices := make([]UUID, len(deviceGroup.Devices()))
+		for i, deviceUUID := range deviceGroup.Devices() {
+			devices[i] = UUID(deviceUUID)
+		}
+		resp.Devices = &devices
+	} else {
+		selector := Tags(deviceGroup.Selector().StringArray())
+		resp.Selector = &selector
+	}
+	if !deviceGroup.CreatedAt().IsZero() {
+		createdAt := CreatedAt(deviceGroup.CreatedAt())
+		resp.CreatedAt = &createdAt
+	}
+	if !deviceGroup.UpdatedAt().IsZero() {
+		updatedAt := UpdatedAt(deviceGroup.UpdatedAt())
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+// rolloutToResponse converts a rollout to a rollout response.
+func rolloutToResponse(existing *rollout.Rollout) RolloutResponse {
+	uuid := UUID(existing.UUID())
+	imageUUID := existing.Assignment().ImageUUID()
+	version := int(existing.Assignment().Version().Uint())
+	progress := existing.Progress()
+	count := func(status rollout.TransactionStatus) *int {
+		n := progress.Count(status)
+		return &n
+	}
+	total := progress.Total()
+	done := progress.IsDone()
+	transactions := make([]TransactionResponse, len(existing.Transactions()))
+	for i, transaction := range existing.Transactions() {
+		deviceUUID := UUID(transaction.DeviceUUID())
+		status := TransactionStatus(transaction.Status().String())
+		transactions[i] = TransactionResponse{
+			DeviceUuid: &deviceUUID,
+			Status:     &status,
+		}
+		if transaction.Reason() != "" {
+			reason := transaction.Reason()
+			transactions[i].Reason = &reason
+		}
+		if !transaction.UpdatedAt().IsZero() {
+			updatedAt := UpdatedAt(transaction.UpdatedAt())
+			transactions[i].UpdatedAt = &updatedAt
+		}
+	}
+	stages := make([]int, len(existing.Plan().Stages()))
+	for i, stage := range existing.Plan().Stages() {
+		stages[i] = int(stage)
+	}
+	soakTime := int(existing.Plan().SoakTime() / time.Second)
+	failureThreshold := int(existing.Plan().FailureThreshold())
+	rollbackFailed := existing.Plan().RollbackFailed()
+	state := RolloutState(existing.State().String())
+	stage := existing.Stage()
+	resp := RolloutResponse{
+		Uuid:  &uuid,
+		Image: &DeviceImage{Uuid: &imageUUID, Version: &version},
+		Plan: &RolloutPlan{
+			Stages:           &stages,
+			SoakTime:         &soakTime,
+			FailureThreshold: &failureThreshold,
+			RollbackFailed:   &rollbackFailed,
+		},
+		State: &state,
+		Stage: &stage,
+		Progress: &RolloutProgress{
+			Total:      &total,
+			Pending:    count(rollout.Pending),
+			Dispatched: count(rollout.Dispatched),
+			Applying:   count(rollout.Applying),
+			Succeeded:  count(rollout.Succeeded),
+			Failed:     count(rollout.Failed),
+			Done:       &done,
+		},
+		Transactions: &transactions,
+	}
+	if !existing.CreatedAt().IsZero() {
+		createdAt := CreatedAt(existing.CreatedAt())
+		resp.CreatedAt = &createdAt
+	}
+	if !existing.UpdatedAt().IsZero() {
+		updatedAt := UpdatedAt(existing.UpdatedAt())
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+// voucherToResponse converts a voucher to a voucher response.
+func windowsToResponse(windows []*maintenance.Window) []MaintenanceWindowResponse {
+	windowsRes := make([]MaintenanceWindowResponse, 0, len(windows))
+	for _, window := range windows {
+		windowsRes = append(windowsRes, windowToResponse(window))
+	}
+	return windowsRes
+}
+
+func windowToResponse(window *maintenance.Window) MaintenanceWindowResponse {
+	uuid := UUID(window.UUID())
+	name := Name(window.Name().String())
+	schedule := window.Schedule().String()
+	duration := int(window.Duration() / time.Second)
+	timezone := window.Timezone()
+	devices := make([]UUID, len(window.Devices()))
+	for i, deviceUUID := range window.Devices() {
+		devices[i] = UUID(deviceUUID)
+	}
+	groups := make([]UUID, len(window.Groups()))
+	for i, groupUUID := range window.Groups() {
+		groups[i] = UUID(groupUUID)
+	}
+	resp := MaintenanceWindowResponse{
+		Uuid:     &uuid,
+		Name:     &name,
+		Schedule: &schedule,
+		Duration: &duration,
+		Timezone: &timezone,
+		Devices:  &devices,
+		Groups:   &groups,
+	}
+	if !window.CreatedAt().IsZero() {
+		createdAt := CreatedAt(window.CreatedAt())
+		resp.CreatedAt = &createdAt
+	}
+	if !window.UpdatedAt().IsZero() {
+		updatedAt := UpdatedAt(window.UpdatedAt())
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+func voucherToResponse(voucher *fdo.Voucher) VoucherResponse {
+	guid := UUID(voucher.GUID().String())
+	deviceInfo := voucher.DeviceInfo()
+	protocolVersion := int(voucher.ProtocolVersion())
+	status := VoucherResponseStatus(voucher.Status().String())
+	resp := VoucherResponse{
+		Guid:            &guid,
+		DeviceInfo:      &deviceInfo,
+		ProtocolVersion: &protocolVersion,
+		Status:          &status,
+	}
+	if voucher.DeviceUUID() != "" {
+		deviceUUID := UUID(voucher.DeviceUUID())
+		resp.DeviceUuid = &deviceUUID
+	}
+	if !voucher.OnboardedAt().IsZero() {
+		var onboardedAt interface{} = voucher.OnboardedAt()
+		resp.OnboardedAt = &onboardedAt
+	}
+	if !voucher.CreatedAt().IsZero() {
+		createdAt := CreatedAt(voucher.CreatedAt())
+		resp.CreatedAt = &createdAt
+	}
+	return resp
+}
+
+// imageFromRequest returns the image uuid and version a request assigns a device to.
+func imageFromRequest(image DeviceImage) (string, uint) {
+	var imageUUID string
+	var version uint
+	if image.Uuid != nil {
+		imageUUID = *image.Uuid
+	}
+	if image.Version != nil && *image.Version > 0 {
+		version = uint(*image.Version)
+	}
+	return imageUUID, version
+}
+
+// planFromRequest sets the plan of the rollout command from the request,
+// a rollout without a plan reaches all its devices at once, never halts and rolls failed devices back.
+func planFromRequest(cmd *command.UpdateRollout, plan *RolloutPlan) error {
+	cmd.FailureThreshold = 100
+	cmd.RollbackFailed = true
+	if plan == nil {
+		return nil
+	}
+	if plan.Stages != nil {
+		for _, stage := range *plan.Stages {
+			if stage <= 0 {
+				return errors.New("stages must be positive percentages")
+			}
+			cmd.Stages = append(cmd.Stages, uint(stage))
+		}
+	}
+	if plan.SoakTime != nil {
+		if *plan.SoakTime < 0 {
+			return errors.New("soak_time must not be negative")
+		}
+		cmd.SoakTime = time.Duration(*plan.SoakTime) * time.Second
+	}
+	if plan.FailureThreshold != nil {
+		if *plan.FailureThreshold < 0 {
+			return errors.New("failure_threshold must not be negative")
+		}
+		cmd.FailureThreshold = uint(*plan.FailureThreshold)
+	}
+	if plan.RollbackFailed != nil {
+		cmd.RollbackFailed = *plan.RollbackFailed
+	}
+	return nil
+}
+
+// CheckCreateRequest checks if the create request is valid.
+func CheckCreateRequest(req CreateDeviceRequest) error {
+	if req.Name == nil {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+// CheckCreateGroupRequest checks if the create group request is valid.
+func CheckCreateGroupRequest(req CreateGroupRequest) error {
+	if req.Name == nil {
+		return errors.New("name is required")
+	}
+	return nil
+}
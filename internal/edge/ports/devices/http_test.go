@@ -0,0 +1,257 @@
+package ports
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Avielyo10/edge-api/internal/edge/adapters"
+	"github.com/Avielyo10/edge-api/internal/edge/app"
+	"github.com/Avielyo10/edge-api/internal/edge/app/command"
+	"github.com/Avielyo10/edge-api/internal/edge/app/query"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/redhatinsights/edge-api/config"
+)
+
+// newTestVoucher returns a voucher of the device with the given guid, CBOR encoded by hand with short items only.
+func newTestVoucher(guid uuid.UUID, deviceInfo string) []byte {
+	// header: [101, guid, [], deviceInfo, [10], null]
+	header := append([]byte{0x86, 0x18, 0x65, 0x50}, guid[:]...)
+	header = append(header, 0x80, 0x60+byte(len(deviceInfo)))
+	header = append(header, deviceInfo...)
+	header = append(header, 0x81, 0x0a, 0xf6)
+	// voucher: [101, bstr(header), [], null, []]
+	data := append([]byte{0x85, 0x18, 0x65, 0x58, byte(len(header))}, header...)
+	return append(data, 0x80, 0xf6, 0x80)
+}
+
+// newTestServer returns the devices http server backed by a sqlite db, removed once the test is done.
+func newTestServer(t *testing.T) *httptest.Server {
+	config.Init()
+	dbName := fmt.Sprintf("%s.db", t.Name())
+	config.Get().Database.Name = dbName
+	gormClient, err := adapters.NewGormClient(config.Get(), true)
+	t.Cleanup(func() {
+		if err := os.Remove(dbName); err != nil {
+			t.Errorf("failed to remove db file: %s", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("failed to open db: %s", err)
+	}
+	deviceRepository := adapters.NewGormDeviceRepository(gormClient)
+	voucherRepository := adapters.NewGormVoucherRepository(gormClient)
+	versionRepository := adapters.NewGormVersionRepository(gormClient)
+	application := app.Application{
+		Commands: app.Commands{
+			CreateDevice:       *command.NewCreateDeviceHandler(deviceRepository, versionRepository),
+			CheckInDevice:      *command.NewCheckInDeviceHandler(deviceRepository, versionRepository),
+			ImportVoucher:      *command.NewImportVoucherHandler(voucherRepository),
+			CompleteOnboarding: *command.NewCompleteOnboardingHandler(voucherRepository, deviceRepository),
+		},
+		Queries: app.Queries{
+			GetDevice:  *query.NewGetDeviceHandler(deviceRepository),
+			GetVoucher: *query.NewGetVoucherHandler(voucherRepository),
+		},
+	}
+	server := httptest.NewServer(HandlerFromMux(NewHttpServer(application), chi.NewRouter()))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newOwnerOnboardingServer returns a stand-in of the owner onboarding server,
+// calling the onboarding completed webhook of the edge service once a device onboards at /onboard/{guid}.
+func newOwnerOnboardingServer(t *testing.T, edgeURL string) *httptest.Server {
+	router := chi.NewRouter()
+	router.Post("/onboard/{guid}", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := json.Marshal(OnboardingCompletedRequest{Guid: UUID(chi.URLParam(r, "guid"))})
+		resp, err := http.Post(edgeURL+"/fdo/onboarding/completed", "application/json", bytes.NewReader(body))
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, resp.Body)
+	})
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHttpServer_OnboardingCompleted(t *testing.T) {
+	edge := newTestServer(t)
+	owner := newOwnerOnboardingServer(t, edge.URL)
+
+	guid := uuid.New()
+	resp, err := http.Post(edge.URL+"/fdo/vouchers", "application/cbor", bytes.NewReader(newTestVoucher(guid, "kiosk")))
+	if err != nil {
+		t.Fatalf("failed to import voucher: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("ImportVoucher() status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	tests := []struct {
+		name       string
+		guid       string
+		wantStatus int
+	}{
+		{
+			name:       "should create the device",
+			guid:       guid.String(),
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "should fail, device already onboarded",
+			guid:       guid.String(),
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "should fail, unknown voucher",
+			guid:       uuid.NewString(),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "should fail, invalid guid",
+			guid:       "kiosk",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(owner.URL+"/onboard/"+tt.guid, "", nil)
+			if err != nil {
+				t.Fatalf("failed to onboard: %s", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("OnboardingCompleted() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+
+	resp, err = http.Get(edge.URL + "/fdo/vouchers/" + guid.String())
+	if err != nil {
+		t.Fatalf("failed to get voucher: %s", err)
+	}
+	defer resp.Body.Close()
+	var voucher VoucherResponse
+	if err := json.NewDecoder(resp.Body).Decode(&voucher); err != nil {
+		t.Fatalf("failed to decode voucher: %s", err)
+	}
+	if voucher.Status == nil || *voucher.Status != VoucherResponseStatusOnboarded || voucher.DeviceUuid == nil {
+		t.Fatalf("GetVoucher() = %+v, want an onboarded voucher", voucher)
+	}
+	resp, err = http.Get(edge.URL + "/devices/" + string(*voucher.DeviceUuid))
+	if err != nil {
+		t.Fatalf("failed to get device: %s", err)
+	}
+	defer resp.Body.Close()
+	var device DeviceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&device); err != nil {
+		t.Fatalf("failed to decode device: %s", err)
+	}
+	if device.Name == nil || *device.Name != "kiosk" {
+		t.Errorf("GetDevice() = %+v, want the device named after its voucher", device)
+	}
+}
+
+func TestHttpServer_CheckInDevice(t *testing.T) {
+	edge := newTestServer(t)
+	owner := newOwnerOnboardingServer(t, edge.URL)
+
+	// a device registered through the api, another one onboarded with its voucher
+	decode := func(resp *http.Response) (string, string) {
+		defer resp.Body.Close()
+		var device DeviceResponse
+		if err := json.NewDecoder(resp.Body).Decode(&device); err != nil || device.Uuid == nil || device.Token == nil {
+			t.Fatalf("failed to decode device: %v, %+v", err, device)
+		}
+		return string(*device.Uuid), *device.Token
+	}
+	name := Name("kiosk")
+	body, _ := json.Marshal(CreateDeviceRequest{Name: &name})
+	resp, err := http.Post(edge.URL+"/devices", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to create device: %s", err)
+	}
+	created, createdToken := decode(resp)
+	guid := uuid.New()
+	resp, err = http.Post(edge.URL+"/fdo/vouchers", "application/cbor", bytes.NewReader(newTestVoucher(guid, "kiosk")))
+	if err != nil {
+		t.Fatalf("failed to import voucher: %s", err)
+	}
+	resp.Body.Close()
+	resp, err = http.Post(owner.URL+"/onboard/"+guid.String(), "", nil)
+	if err != nil {
+		t.Fatalf("failed to onboard: %s", err)
+	}
+	onboarded, onboardedToken := decode(resp)
+
+	tests := []struct {
+		name       string
+		device     string
+		token      string
+		wantStatus int
+	}{
+		{
+			name:       "should check the created device in",
+			device:     created,
+			token:      createdToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should check the onboarded device in",
+			device:     onboarded,
+			token:      onboardedToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should fail, token of another device",
+			device:     created,
+			token:      onboardedToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "should fail, unknown token",
+			device:     created,
+			token:      "kiosk",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "should fail, no token",
+			device:     created,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(CheckInRequest{
+				BootedCommit: Commit(strings.Repeat("a", 64)),
+			})
+			req, _ := http.NewRequest(http.MethodPost, edge.URL+"/devices/"+tt.device+"/check-in", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.token != "" {
+				req.Header.Set("X-Device-Token", tt.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("failed to check in: %s", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("CheckInDevice() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
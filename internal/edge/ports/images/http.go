@@ -1,16 +1,25 @@
 package ports
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
 
+	"github.com/Avielyo10/edge-api/internal/common/server"
 	httperr "github.com/Avielyo10/edge-api/internal/common/server/httperr"
 	"github.com/Avielyo10/edge-api/internal/edge/app"
 	"github.com/Avielyo10/edge-api/internal/edge/app/command"
+	"github.com/Avielyo10/edge-api/internal/edge/app/query"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
 	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/ostree"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
+	"github.com/redhatinsights/edge-api/config"
 	"github.com/sirupsen/logrus"
 )
 
@@ -52,7 +61,7 @@ func (h HttpServer) CreateImage(w http.ResponseWriter, r *http.Request) {
 		Packages:     *req.Packages,
 		Status:       image.Building.String(),
 		Version:      1,
-		Repos:        reposToInterfaces(req.Repositories),
+		Repos:        reposFromRequest(req.Repositories),
 	}
 	image, err := h.app.Commands.CreateImage.Handle(ctx, cmd)
 	if err != nil {
@@ -65,10 +74,28 @@ func (h HttpServer) CreateImage(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetImage returns the image with the given uuid. Implementing ports.ServerInterface
-func (h HttpServer) GetImage(w http.ResponseWriter, r *http.Request, imageId string) {
+func (h HttpServer) GetImage(w http.ResponseWriter, r *http.Request, imageId string, params GetImageParams) {
 	ctx := r.Context()
+	if params.AsOf != nil {
+		h.getImageAsOf(w, r, imageId, *params.AsOf)
+		return
+	}
 	image, err := h.app.Queries.GetImage.Handle(ctx, imageId)
 
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	imageRes := imageToResponse(image)
+	w.Header().Set("ETag", image.ETag())
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, imageRes)
+}
+
+// getImageAsOf returns the image with the given uuid as it was at the given time, it can't be changed so no ETag is set.
+func (h HttpServer) getImageAsOf(w http.ResponseWriter, r *http.Request, imageId string, asOf time.Time) {
+	ctx := r.Context()
+	image, err := h.app.Queries.GetImageAsOf.Handle(ctx, query.GetImageAsOf{UUID: imageId, AsOf: asOf})
 	if err != nil {
 		httperr.HandleImageErrors(w, r, err)
 		return
@@ -79,7 +106,7 @@ func (h HttpServer) GetImage(w http.ResponseWriter, r *http.Request, imageId str
 }
 
 // UpdateImage updates the image with the given uuid. Implementing ports.ServerInterface
-func (h HttpServer) UpdateImage(w http.ResponseWriter, r *http.Request, imageId string) {
+func (h HttpServer) UpdateImage(w http.ResponseWriter, r *http.Request, imageId string, params UpdateImageParams) {
 	var req UpdateImageRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -87,7 +114,7 @@ func (h HttpServer) UpdateImage(w http.ResponseWriter, r *http.Request, imageId
 		return
 	}
 
-	ctx := r.Context()
+	ctx := withPrecondition(r.Context(), params.IfMatch)
 	cmd := command.UpdateImage{
 		UUIDToUpdate: imageId,
 	}
@@ -115,9 +142,15 @@ func (h HttpServer) UpdateImage(w http.ResponseWriter, r *http.Request, imageId
 }
 
 // DeleteImage deletes the image with the given uuid. Implementing ports.ServerInterface
-func (h HttpServer) DeleteImage(w http.ResponseWriter, r *http.Request, imageId string) {
-	ctx := r.Context()
-	err := h.app.Commands.DeleteImage.Handle(ctx, imageId)
+func (h HttpServer) DeleteImage(w http.ResponseWriter, r *http.Request, imageId string, params DeleteImageParams) {
+	ctx := withPrecondition(r.Context(), params.IfMatch)
+	var err error
+	force := params.Force != nil && *params.Force
+	if params.Purge != nil && *params.Purge {
+		err = h.app.Commands.PurgeImage.Handle(ctx, command.PurgeImage{UUID: imageId, Force: force})
+	} else {
+		err = h.app.Commands.DeleteImage.Handle(ctx, command.DeleteImage{UUID: imageId, Force: force})
+	}
 	if err != nil {
 		httperr.HandleImageErrors(w, r, err)
 		return
@@ -126,10 +159,38 @@ func (h HttpServer) DeleteImage(w http.ResponseWriter, r *http.Request, imageId
 	render.Respond(w, r, nil)
 }
 
-// GetImages returns all images. Implementing ports.ServerInterface - TODO: work on params
+// GetImages returns a page of the images, filtered and sorted. Implementing ports.ServerInterface
 func (h HttpServer) GetImages(w http.ResponseWriter, r *http.Request, params GetImagesParams) {
 	ctx := r.Context()
-	images, err := h.app.Queries.GetImages.Handle(ctx)
+	var images []*image.Image
+	var total int
+	var err error
+	if params.Deleted != nil && *params.Deleted {
+		images, err = h.app.Queries.GetDeletedImages.Handle(ctx)
+		total = len(images)
+	} else {
+		q := query.GetImages{Limit: image.DefaultLimit}
+		if params.Name != nil {
+			q.Name = *params.Name
+		}
+		if params.Status != nil {
+			q.Status = *params.Status
+		}
+		if params.SortBy != nil {
+			q.SortBy = *params.SortBy
+		}
+		if params.Limit != nil {
+			if *params.Limit < 1 { // no limit is for internal use only
+				httperr.HandleImageErrors(w, r, image.ErrInvalidPage)
+				return
+			}
+			q.Limit = *params.Limit
+		}
+		if params.Offset != nil {
+			q.Offset = *params.Offset
+		}
+		images, total, err = h.app.Queries.GetImages.Handle(ctx, q)
+	}
 	if err != nil {
 		httperr.HandleImageErrors(w, r, err)
 		return
@@ -137,15 +198,50 @@ func (h HttpServer) GetImages(w http.ResponseWriter, r *http.Request, params Get
 	imagesRes := imagesToResponse(images)
 	render.Status(r, http.StatusOK)
 	res := map[string]interface{}{
-		"count": len(imagesRes),
+		"count": total,
 		"items": imagesRes,
 	}
 	render.Respond(w, r, res)
 }
 
-// DeleteImagesImageIdUpdate cancels the image update with the given uuid. Implementing ports.ServerInterface
-func (h HttpServer) DeleteImagesImageIdUpdate(w http.ResponseWriter, r *http.Request, imageId string) {
+// SearchImages returns the images matching the full-text query, the most relevant first. Implementing ports.ServerInterface
+func (h HttpServer) SearchImages(w http.ResponseWriter, r *http.Request, params SearchImagesParams) {
+	q := query.SearchImages{Text: params.Q, Limit: image.DefaultLimit}
+	if params.Limit != nil {
+		q.Limit = *params.Limit
+	}
+	results, err := h.app.Queries.SearchImages.Handle(r.Context(), q)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	resultsRes := make([]ImageSearchResult, len(results))
+	for i, result := range results {
+		resultsRes[i] = searchResultToResponse(result)
+	}
+	render.Status(r, http.StatusOK)
+	res := map[string]interface{}{
+		"count": len(resultsRes),
+		"items": resultsRes,
+	}
+	render.Respond(w, r, res)
+}
+
+// RestoreImage restores the deleted image with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) RestoreImage(w http.ResponseWriter, r *http.Request, imageId string) {
 	ctx := r.Context()
+	err := h.app.Commands.RestoreImage.Handle(ctx, imageId)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// DeleteImagesImageIdUpdate cancels the image update with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) DeleteImagesImageIdUpdate(w http.ResponseWriter, r *http.Request, imageId string, params DeleteImagesImageIdUpdateParams) {
+	ctx := withPrecondition(r.Context(), params.IfMatch)
 	err := h.app.Commands.CancelUpgradeImage.Handle(ctx, imageId)
 	if err != nil {
 		httperr.HandleImageErrors(w, r, err)
@@ -156,7 +252,7 @@ func (h HttpServer) DeleteImagesImageIdUpdate(w http.ResponseWriter, r *http.Req
 }
 
 // CreateNewVersion creates a new version of the image with the given uuid (upgrade process). Implementing ports.ServerInterface
-func (h HttpServer) CreateNewVersion(w http.ResponseWriter, r *http.Request, imageId string) {
+func (h HttpServer) CreateNewVersion(w http.ResponseWriter, r *http.Request, imageId string, params CreateNewVersionParams) {
 	var req UpgradeImageRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -164,7 +260,7 @@ func (h HttpServer) CreateNewVersion(w http.ResponseWriter, r *http.Request, ima
 		return
 	}
 
-	ctx := r.Context()
+	ctx := withPrecondition(r.Context(), params.IfMatch)
 	cmd := command.UpgradeImage{
 		UUIDToUpgrade:    imageId,
 		Name:             string(*req.Name),
@@ -183,6 +279,504 @@ func (h HttpServer) CreateNewVersion(w http.ResponseWriter, r *http.Request, ima
 	render.Respond(w, r, nil)
 }
 
+// CloneImage creates a new image from the image with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) CloneImage(w http.ResponseWriter, r *http.Request, imageId string) {
+	var req CloneImageRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	cmd := command.CloneImage{
+		UUIDToClone: imageId,
+		UUID:        uuid.NewString(),
+	}
+	if req.Name != nil {
+		cmd.Name = string(*req.Name)
+	}
+	clone, err := h.app.Commands.CloneImage.Handle(ctx, cmd)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	imageRes := imageToResponse(clone)
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, imageRes)
+}
+
+// GetImageLineage returns the ancestry and descendants of the image with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) GetImageLineage(w http.ResponseWriter, r *http.Request, imageId string) {
+	ctx := r.Context()
+	lineage, err := h.app.Queries.GetImageLineage.Handle(ctx, imageId)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, lineageToResponse(lineage))
+}
+
+// GetImageDevices returns the devices assigned to or running each version of the image with the given uuid.
+// Implementing ports.ServerInterface
+func (h HttpServer) GetImageDevices(w http.ResponseWriter, r *http.Request, imageId string) {
+	ctx := r.Context()
+	usage, err := h.app.Queries.GetImageDevices.Handle(ctx, imageId)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	versionsRes := usageToResponse(usage)
+	render.Status(r, http.StatusOK)
+	res := map[string]interface{}{
+		"count": len(versionsRes),
+		"items": versionsRes,
+	}
+	render.Respond(w, r, res)
+}
+
+// GetImageVersions returns all stored versions of the image with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) GetImageVersions(w http.ResponseWriter, r *http.Request, imageId string) {
+	ctx := r.Context()
+	snapshots, err := h.app.Queries.GetImageVersions.Handle(ctx, imageId)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	versionsRes := snapshotsToResponse(snapshots)
+	render.Status(r, http.StatusOK)
+	res := map[string]interface{}{
+		"count": len(versionsRes),
+		"items": versionsRes,
+	}
+	render.Respond(w, r, res)
+}
+
+// LockImageVersion protects the given version of the image from pruning. Implementing ports.ServerInterface
+func (h HttpServer) LockImageVersion(w http.ResponseWriter, r *http.Request, imageId string, version int) {
+	h.lockImageVersion(w, r, imageId, version, true)
+}
+
+// UnlockImageVersion removes the pruning protection from the given version of the image. Implementing ports.ServerInterface
+func (h HttpServer) UnlockImageVersion(w http.ResponseWriter, r *http.Request, imageId string, version int) {
+	h.lockImageVersion(w, r, imageId, version, false)
+}
+
+// lockImageVersion sets the lock of the given version of the image.
+func (h HttpServer) lockImageVersion(w http.ResponseWriter, r *http.Request, imageId string, version int, locked bool) {
+	if version < 1 {
+		httperr.HandleImageErrors(w, r, image.ErrInvalidVersion)
+		return
+	}
+	ctx := r.Context()
+	cmd := command.LockImageVersion{
+		UUID:    imageId,
+		Version: uint(version),
+		Locked:  locked,
+	}
+	err := h.app.Commands.LockImageVersion.Handle(ctx, cmd)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// SetImageVersionCommit records the ostree commit the given version of the image was built into.
+// Implementing ports.ServerInterface
+func (h HttpServer) SetImageVersionCommit(w http.ResponseWriter, r *http.Request, imageId string, version int) {
+	if version < 1 {
+		httperr.HandleImageErrors(w, r, image.ErrInvalidVersion)
+		return
+	}
+	var req SetImageVersionCommitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest(err.Error()))
+		return
+	}
+	cmd := command.SetVersionCommit{
+		UUID:    imageId,
+		Version: uint(version),
+		Commit:  string(req.Commit),
+	}
+	if err := h.app.Commands.SetVersionCommit.Handle(r.Context(), cmd); err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// RestoreImageVersion brings back a stored version of the image with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) RestoreImageVersion(w http.ResponseWriter, r *http.Request, imageId string, version int) {
+	if version < 1 {
+		httperr.HandleImageErrors(w, r, image.ErrInvalidVersion)
+		return
+	}
+	ctx := r.Context()
+	cmd := command.RestoreImageVersion{
+		UUID:    imageId,
+		Version: uint(version),
+	}
+	err := h.app.Commands.RestoreVersion.Handle(ctx, cmd)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// GetRetentionPolicy returns the version retention policy of the account. Implementing ports.ServerInterface
+func (h HttpServer) GetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	policy, err := h.app.Queries.GetRetentionPolicy.Handle(ctx)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, retentionPolicyToResponse(policy))
+}
+
+// SetRetentionPolicy sets the version retention policy of the account. Implementing ports.ServerInterface
+func (h HttpServer) SetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	var req RetentionPolicy
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	if (req.KeepLast != nil && *req.KeepLast < 0) || (req.KeepDays != nil && *req.KeepDays < 0) {
+		render.Status(r, http.StatusBadRequest)
+		render.Respond(w, r, httperr.NewBadRequest("keep_last and keep_days cannot be negative"))
+		return
+	}
+	ctx := r.Context()
+	cmd := command.SetRetentionPolicy{}
+	if req.KeepLast != nil {
+		cmd.KeepLast = uint(*req.KeepLast)
+	}
+	if req.KeepDays != nil {
+		cmd.KeepDays = uint(*req.KeepDays)
+	}
+	policy, err := h.app.Commands.SetRetentionPolicy.Handle(ctx, cmd)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, retentionPolicyToResponse(policy))
+}
+
+// GetRetentionDryRun returns the image versions the retention policy would prune. Implementing ports.ServerInterface
+func (h HttpServer) GetRetentionDryRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	snapshots, err := h.app.Queries.GetPrunableVersions.Handle(ctx)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	versionsRes := snapshotsToResponse(snapshots)
+	render.Status(r, http.StatusOK)
+	res := map[string]interface{}{
+		"count": len(versionsRes),
+		"items": versionsRes,
+	}
+	render.Respond(w, r, res)
+}
+
+// GetTags returns the tags of the account images, most used first. Implementing ports.ServerInterface
+func (h HttpServer) GetTags(w http.ResponseWriter, r *http.Request, params GetTagsParams) {
+	var prefix string
+	if params.Prefix != nil {
+		prefix = *params.Prefix
+	}
+	entries, err := h.app.Queries.GetTags.Handle(r.Context(), prefix)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	renderCatalog(w, r, entries)
+}
+
+// GetUsedPackages returns the packages of the account images, most used first. Implementing ports.ServerInterface
+func (h HttpServer) GetUsedPackages(w http.ResponseWriter, r *http.Request, params GetUsedPackagesParams) {
+	var prefix string
+	if params.Prefix != nil {
+		prefix = *params.Prefix
+	}
+	entries, err := h.app.Queries.GetUsedPackages.Handle(r.Context(), prefix)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	renderCatalog(w, r, entries)
+}
+
+// renderCatalog responds with the catalog entries.
+func renderCatalog(w http.ResponseWriter, r *http.Request, entries []*image.CatalogEntry) {
+	entriesRes := make([]CatalogEntry, len(entries))
+	for i, entry := range entries {
+		entriesRes[i] = catalogEntryToResponse(entry)
+	}
+	render.Status(r, http.StatusOK)
+	res := map[string]interface{}{
+		"count": len(entriesRes),
+		"items": entriesRes,
+	}
+	render.Respond(w, r, res)
+}
+
+// GetRepositories returns the third-party repositories of the account. Implementing ports.ServerInterface
+func (h HttpServer) GetRepositories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repos, err := h.app.Queries.GetRepos.Handle(ctx)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	reposRes := make([]RepositoryResponse, len(repos))
+	for i, repository := range repos {
+		reposRes[i] = repoToResponse(repository)
+	}
+	render.Status(r, http.StatusOK)
+	res := map[string]interface{}{
+		"count": len(reposRes),
+		"items": reposRes,
+	}
+	render.Respond(w, r, res)
+}
+
+// CreateRepository adds a third-party repository to the account. Implementing ports.ServerInterface
+func (h HttpServer) CreateRepository(w http.ResponseWriter, r *http.Request) {
+	var req RepositoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	cmd := createRepoFromRequest(req)
+	cmd.UUID = uuid.NewString()
+	repository, err := h.app.Commands.CreateRepo.Handle(r.Context(), cmd)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.Respond(w, r, repoToResponse(repository))
+}
+
+// GetRepository returns the third-party repository with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) GetRepository(w http.ResponseWriter, r *http.Request, repoId string) {
+	repository, err := h.app.Queries.GetRepo.Handle(r.Context(), repoId)
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, repoToResponse(repository))
+}
+
+// UpdateRepository replaces the content of the third-party repository with the given uuid.
+// Implementing ports.ServerInterface
+func (h HttpServer) UpdateRepository(w http.ResponseWriter, r *http.Request, repoId string) {
+	var req RepositoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	content := createRepoFromRequest(req)
+	cmd := command.UpdateRepo{
+		UUIDToUpdate: repoId,
+		Name:         content.Name,
+		BaseURL:      content.BaseURL,
+		GPGKey:       content.GPGKey,
+		GPGCheck:     content.GPGCheck,
+		Priority:     content.Priority,
+	}
+	if err := h.app.Commands.UpdateRepo.Handle(ctx, cmd); err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	h.GetRepository(w, r, repoId)
+}
+
+// DeleteRepository deletes the third-party repository with the given uuid. Implementing ports.ServerInterface
+func (h HttpServer) DeleteRepository(w http.ResponseWriter, r *http.Request, repoId string) {
+	if err := h.app.Commands.DeleteRepo.Handle(r.Context(), repoId); err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// createRepoFromRequest returns the command creating the repository of a request, missing fields being empty.
+func createRepoFromRequest(req RepositoryRequest) command.CreateRepo {
+	var cmd command.CreateRepo
+	if req.Name != nil {
+		cmd.Name = string(*req.Name)
+	}
+	if req.BaseUrl != nil {
+		cmd.BaseURL = *req.BaseUrl
+	}
+	if req.GpgKey != nil {
+		cmd.GPGKey = *req.GpgKey
+	}
+	if req.GpgCheck != nil {
+		cmd.GPGCheck = *req.GpgCheck
+	}
+	if req.Priority != nil {
+		cmd.Priority = *req.Priority
+	}
+	return cmd
+}
+
+// catalogEntryToResponse converts a catalog entry to a response.
+func catalogEntryToResponse(entry *image.CatalogEntry) CatalogEntry {
+	images := make([]UUID, entry.Count())
+	for i, uuid := range entry.Images() {
+		images[i] = UUID(uuid)
+	}
+	return CatalogEntry{
+		Name:   entry.Name(),
+		Count:  entry.Count(),
+		Images: images,
+	}
+}
+
+// repoToResponse converts a repository to a response.
+func repoToResponse(r *repo.Repo) RepositoryResponse {
+	uuid := UUID(r.UUID())
+	name := Name(r.Name().String())
+	baseURL := r.BaseURL()
+	gpgKey := r.GPGKey()
+	gpgCheck := r.GPGCheck()
+	priority := r.Priority()
+	createdAt := CreatedAt(r.CreatedAt())
+	updatedAt := UpdatedAt(r.UpdatedAt())
+	return RepositoryResponse{
+		Uuid:      &uuid,
+		Name:      &name,
+		BaseUrl:   &baseURL,
+		GpgKey:    &gpgKey,
+		GpgCheck:  &gpgCheck,
+		Priority:  &priority,
+		CreatedAt: &createdAt,
+		UpdatedAt: &updatedAt,
+	}
+}
+
+// snapshotsToResponse converts a slice of snapshots to a slice of image version responses.
+func snapshotsToResponse(snapshots []*image.Snapshot) []ImageVersionResponse {
+	versionsRes := make([]ImageVersionResponse, len(snapshots))
+	for i, snapshot := range snapshots {
+		uuid := UUID(snapshot.UUID())
+		version := Version(snapshot.Version().Uint())
+		status := Status(snapshot.Status().String())
+		locked := snapshot.Locked()
+		createdAt := CreatedAt(snapshot.CreatedAt())
+		versionsRes[i] = ImageVersionResponse{
+			Uuid:      &uuid,
+			Version:   &version,
+			Status:    &status,
+			Locked:    &locked,
+			Parent:    parentToResponse(snapshot.Parent()),
+			CreatedAt: &createdAt,
+		}
+		if !snapshot.Commit().IsZero() {
+			commit := Commit(snapshot.Commit().String())
+			versionsRes[i].Commit = &commit
+		}
+	}
+	return versionsRes
+}
+
+// parentToResponse converts a domain parent to a response parent, nil if there is no parent
+func parentToResponse(parent image.Parent) *ImageParent {
+	if parent.IsZero() {
+		return nil
+	}
+	uuid := UUID(parent.UUID())
+	version := Version(parent.Version().Uint())
+	relation := ImageParentRelation(parent.Relation().String())
+	return &ImageParent{
+		Uuid:     &uuid,
+		Version:  &version,
+		Relation: &relation,
+	}
+}
+
+// lineageToResponse converts a domain lineage to a response lineage
+func lineageToResponse(lineage *image.Lineage) ImageLineageResponse {
+	uuid := UUID(lineage.UUID())
+	version := Version(lineage.Version().Uint())
+	ancestors := make([]ImageParent, len(lineage.Ancestors()))
+	for i, ancestor := range lineage.Ancestors() {
+		ancestors[i] = *parentToResponse(ancestor)
+	}
+	descendants := descendantsToResponse(lineage.Descendants())
+	return ImageLineageResponse{
+		Uuid:        &uuid,
+		Version:     &version,
+		Ancestors:   &ancestors,
+		Descendants: &descendants,
+	}
+}
+
+// descendantsToResponse converts a tree of domain descendants to response descendants
+func descendantsToResponse(descendants []image.Descendant) []ImageDescendant {
+	descendantsRes := make([]ImageDescendant, len(descendants))
+	for i, descendant := range descendants {
+		uuid := UUID(descendant.UUID())
+		version := Version(descendant.Version().Uint())
+		children := descendantsToResponse(descendant.Descendants())
+		descendantsRes[i] = ImageDescendant{
+			Uuid:        &uuid,
+			Version:     &version,
+			Parent:      parentToResponse(descendant.Parent()),
+			Descendants: &children,
+		}
+	}
+	return descendantsRes
+}
+
+// usageToResponse converts the usage of an image to the devices of each of its versions.
+func usageToResponse(usage rollout.Usage) []ImageVersionDevicesResponse {
+	versions := usage.Versions()
+	versionsRes := make([]ImageVersionDevicesResponse, len(versions))
+	for i, v := range versions {
+		versionsRes[i] = ImageVersionDevicesResponse{
+			Version:  Version(v.Version().Uint()),
+			Assigned: imageDevicesToResponse(v.Assigned()),
+			Running:  imageDevicesToResponse(v.Running()),
+		}
+	}
+	return versionsRes
+}
+
+// imageDevicesToResponse converts devices to their uuid and name.
+func imageDevicesToResponse(devices []*device.Device) []ImageDevice {
+	devicesRes := make([]ImageDevice, len(devices))
+	for i, d := range devices {
+		devicesRes[i] = ImageDevice{Uuid: UUID(d.UUID()), Name: Name(d.Name().String())}
+	}
+	return devicesRes
+}
+
+// retentionPolicyToResponse converts a retention policy to a response.
+func retentionPolicyToResponse(policy *image.RetentionPolicy) RetentionPolicy {
+	keepLast := int(policy.KeepLast())
+	keepDays := int(policy.KeepDays())
+	return RetentionPolicy{
+		KeepLast: &keepLast,
+		KeepDays: &keepDays,
+	}
+}
+
 // imagesToResponse converts a slice of images to a slice of image responses.
 func imagesToResponse(images []*image.Image) []ImageResponse {
 	imagesRes := make([]ImageResponse, len(images))
@@ -192,6 +786,31 @@ func imagesToResponse(images []*image.Image) []ImageResponse {
 	return imagesRes
 }
 
+// searchResultToResponse converts a search result to a response.
+func searchResultToResponse(result *image.SearchResult) ImageSearchResult {
+	var highlights ImageSearchHighlights
+	for field, text := range result.Highlights() {
+		text := text
+		switch field {
+		case image.SearchFieldName:
+			highlights.Name = &text
+		case image.SearchFieldDescription:
+			highlights.Description = &text
+		case image.SearchFieldTags:
+			highlights.Tags = &text
+		case image.SearchFieldPackages:
+			highlights.Packages = &text
+		case image.SearchFieldRepos:
+			highlights.Repos = &text
+		}
+	}
+	return ImageSearchResult{
+		Image:      imageToResponse(result.Image()),
+		Rank:       result.Rank(),
+		Highlights: highlights,
+	}
+}
+
 // imageToResponse converts an image to a response.
 func imageToResponse(image *image.Image) ImageResponse {
 	description := Description(image.Description())
@@ -203,13 +822,16 @@ func imageToResponse(image *image.Image) ImageResponse {
 	for i, outputType := range image.OutputTypes() {
 		outputTypes[i] = outputType.String()
 	}
+	repos := Repositories(image.Repos().UUIDs())
 	resp := ImageResponse{
-		Description: &description,
-		Name:        &name,
-		Status:      &status,
-		Uuid:        &uuid,
-		Version:     &version,
-		OutputType:  &outputTypes,
+		Description:  &description,
+		Name:         &name,
+		Status:       &status,
+		Uuid:         &uuid,
+		Version:      &version,
+		OutputType:   &outputTypes,
+		Repositories: &repos,
+		RepoUrl:      repoURL(image),
 	}
 	createdAt := CreatedAt(image.CreatedAt())
 	if !image.CreatedAt().IsZero() {
@@ -229,17 +851,32 @@ func imageToResponse(image *image.Image) ImageResponse {
 	return resp
 }
 
-// reposToInterfaces converts repositories to a slice of repository interfaces.
-func reposToInterfaces(repos *Repositories) []interface{} {
-	// iterate over repositories
-	repositories := make([]interface{}, len(*repos))
-	for i, repo := range *repos {
-		repositories[i] = map[string]interface{}{
-			"name": repo.Name,
-			"url":  repo.Url,
+// repoURL returns the url of the ostree http remote of the image, if it is built as an edge commit.
+func repoURL(i *image.Image) *string {
+	for _, outputType := range i.OutputTypes() {
+		if outputType != image.TAR {
+			continue
 		}
+		account, err := i.Account()
+		if err != nil {
+			return nil
+		}
+		repo, err := ostree.NewRepo(account, i.UUID())
+		if err != nil {
+			return nil
+		}
+		url := repo.RemoteURL(config.Get().EdgeAPIBaseURL + server.APIPath)
+		return &url
 	}
-	return repositories
+	return nil
+}
+
+// reposFromRequest returns the uuids of the repositories referenced by a request, if any.
+func reposFromRequest(repos *Repositories) []string {
+	if repos == nil {
+		return nil
+	}
+	return *repos
 }
 
 // CheckCreateRequest checks the create a new image request.
@@ -261,3 +898,11 @@ func CheckCreateRequest(req CreateImageRequest) error {
 	}
 	return nil
 }
+
+// withPrecondition returns a context requiring the image to match the If-Match header, if sent.
+func withPrecondition(ctx context.Context, ifMatch *string) context.Context {
+	if ifMatch == nil {
+		return ctx
+	}
+	return image.ContextWithPrecondition(ctx, *ifMatch)
+}
@@ -19,21 +19,84 @@ type ServerInterface interface {
 	// Composes an image from Image Builder service.
 	// (POST /images)
 	CreateImage(w http.ResponseWriter, r *http.Request)
+	// Searches the images of the account by full-text, the most relevant first.
+	// (GET /images/search)
+	SearchImages(w http.ResponseWriter, r *http.Request, params SearchImagesParams)
 	// Deletes an image.
 	// (DELETE /images/{imageId})
-	DeleteImage(w http.ResponseWriter, r *http.Request, imageId string)
+	DeleteImage(w http.ResponseWriter, r *http.Request, imageId string, params DeleteImageParams)
 	// Gets an image by ID.
 	// (GET /images/{imageId})
-	GetImage(w http.ResponseWriter, r *http.Request, imageId string)
+	GetImage(w http.ResponseWriter, r *http.Request, imageId string, params GetImageParams)
 	// Updates an image.
 	// (PATCH /images/{imageId})
-	UpdateImage(w http.ResponseWriter, r *http.Request, imageId string)
+	UpdateImage(w http.ResponseWriter, r *http.Request, imageId string, params UpdateImageParams)
+	// Composes a new image from the current version of an image.
+	// (POST /images/{imageId}/clone)
+	CloneImage(w http.ResponseWriter, r *http.Request, imageId string)
+	// Lists the devices assigned to or running each version of an image.
+	// (GET /images/{imageId}/devices)
+	GetImageDevices(w http.ResponseWriter, r *http.Request, imageId string)
+	// Gets the ancestry of an image and the tree of images derived from it.
+	// (GET /images/{imageId}/lineage)
+	GetImageLineage(w http.ResponseWriter, r *http.Request, imageId string)
+	// Restores a deleted image.
+	// (POST /images/{imageId}/restore)
+	RestoreImage(w http.ResponseWriter, r *http.Request, imageId string)
 	// Cancels an image update.
 	// (DELETE /images/{imageId}/update)
-	DeleteImagesImageIdUpdate(w http.ResponseWriter, r *http.Request, imageId string)
+	DeleteImagesImageIdUpdate(w http.ResponseWriter, r *http.Request, imageId string, params DeleteImagesImageIdUpdateParams)
 	// Upgrades an image to a new version.
 	// (POST /images/{imageId}/update)
-	CreateNewVersion(w http.ResponseWriter, r *http.Request, imageId string)
+	CreateNewVersion(w http.ResponseWriter, r *http.Request, imageId string, params CreateNewVersionParams)
+	// Lists all stored versions of an image.
+	// (GET /images/{imageId}/versions)
+	GetImageVersions(w http.ResponseWriter, r *http.Request, imageId string)
+	// Records the ostree commit a version of an image was built into.
+	// (PUT /images/{imageId}/versions/{version}/commit)
+	SetImageVersionCommit(w http.ResponseWriter, r *http.Request, imageId string, version int)
+	// Unlocks a version of an image.
+	// (DELETE /images/{imageId}/versions/{version}/lock)
+	UnlockImageVersion(w http.ResponseWriter, r *http.Request, imageId string, version int)
+	// Locks a version of an image, protecting it from pruning.
+	// (PUT /images/{imageId}/versions/{version}/lock)
+	LockImageVersion(w http.ResponseWriter, r *http.Request, imageId string, version int)
+	// Publishes the ostree repository of a version of an image.
+	// (PUT /images/{imageId}/versions/{version}/repo)
+	PublishImageRepo(w http.ResponseWriter, r *http.Request, imageId string, version int)
+	// Brings back a stored version of an image as its new version.
+	// (POST /images/{imageId}/versions/{version}/restore)
+	RestoreImageVersion(w http.ResponseWriter, r *http.Request, imageId string, version int)
+	// Lists the packages of the account images, most used first.
+	// (GET /packages/used)
+	GetUsedPackages(w http.ResponseWriter, r *http.Request, params GetUsedPackagesParams)
+	// Lists the third-party repositories of the account.
+	// (GET /repositories)
+	GetRepositories(w http.ResponseWriter, r *http.Request)
+	// Adds a third-party repository to the account, images then reference it by uuid.
+	// (POST /repositories)
+	CreateRepository(w http.ResponseWriter, r *http.Request)
+	// Deletes a third-party repository of the account.
+	// (DELETE /repositories/{repoId})
+	DeleteRepository(w http.ResponseWriter, r *http.Request, repoId string)
+	// Gets a third-party repository of the account.
+	// (GET /repositories/{repoId})
+	GetRepository(w http.ResponseWriter, r *http.Request, repoId string)
+	// Updates a third-party repository of the account.
+	// (PUT /repositories/{repoId})
+	UpdateRepository(w http.ResponseWriter, r *http.Request, repoId string)
+	// Gets the version retention policy of the account.
+	// (GET /retention-policy)
+	GetRetentionPolicy(w http.ResponseWriter, r *http.Request)
+	// Sets the version retention policy of the account.
+	// (PUT /retention-policy)
+	SetRetentionPolicy(w http.ResponseWriter, r *http.Request)
+	// Lists the image versions the retention policy would prune.
+	// (GET /retention-policy/dry-run)
+	GetRetentionDryRun(w http.ResponseWriter, r *http.Request)
+	// Lists the tags of the account images, most used first.
+	// (GET /tags)
+	GetTags(w http.ResponseWriter, r *http.Request, params GetTagsParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -87,8 +150,467 @@ func (siw *ServerInterfaceWrapper) GetImages(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+	if paramValue := r.URL.Query().Get("offset"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "deleted" -------------
+	if paramValue := r.URL.Query().Get("deleted"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "deleted", r.URL.Query(), &params.Deleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deleted", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImages(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CreateImage operation middleware
+func (siw *ServerInterfaceWrapper) CreateImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateImage(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// SearchImages operation middleware
+func (siw *ServerInterfaceWrapper) SearchImages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchImagesParams
+
+	// ------------- Required query parameter "q" -------------
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchImages(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteImage operation middleware
+func (siw *ServerInterfaceWrapper) DeleteImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteImageParams
+
+	// ------------- Optional query parameter "purge" -------------
+	if paramValue := r.URL.Query().Get("purge"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "purge", r.URL.Query(), &params.Purge)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "purge", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "force" -------------
+	if paramValue := r.URL.Query().Get("force"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteImage(w, r, imageId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetImage operation middleware
+func (siw *ServerInterfaceWrapper) GetImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetImageParams
+
+	// ------------- Optional query parameter "as_of" -------------
+	if paramValue := r.URL.Query().Get("as_of"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "as_of", r.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "as_of", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImage(w, r, imageId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// UpdateImage operation middleware
+func (siw *ServerInterfaceWrapper) UpdateImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateImageParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateImage(w, r, imageId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CloneImage operation middleware
+func (siw *ServerInterfaceWrapper) CloneImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CloneImage(w, r, imageId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetImageDevices operation middleware
+func (siw *ServerInterfaceWrapper) GetImageDevices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImageDevices(w, r, imageId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetImageLineage operation middleware
+func (siw *ServerInterfaceWrapper) GetImageLineage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImageLineage(w, r, imageId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// RestoreImage operation middleware
+func (siw *ServerInterfaceWrapper) RestoreImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreImage(w, r, imageId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteImagesImageIdUpdate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteImagesImageIdUpdate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteImagesImageIdUpdateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteImagesImageIdUpdate(w, r, imageId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CreateNewVersion operation middleware
+func (siw *ServerInterfaceWrapper) CreateNewVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateNewVersionParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetImages(w, r, params)
+		siw.Handler.CreateNewVersion(w, r, imageId, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -98,12 +620,23 @@ func (siw *ServerInterfaceWrapper) GetImages(w http.ResponseWriter, r *http.Requ
 	handler(w, r.WithContext(ctx))
 }
 
-// CreateImage operation middleware
-func (siw *ServerInterfaceWrapper) CreateImage(w http.ResponseWriter, r *http.Request) {
+// GetImageVersions operation middleware
+func (siw *ServerInterfaceWrapper) GetImageVersions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameter("simple", false, "imageId", chi.URLParam(r, "imageId"), &imageId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "imageId", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateImage(w, r)
+		siw.Handler.GetImageVersions(w, r, imageId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -113,8 +646,8 @@ func (siw *ServerInterfaceWrapper) CreateImage(w http.ResponseWriter, r *http.Re
 	handler(w, r.WithContext(ctx))
 }
 
-// DeleteImage operation middleware
-func (siw *ServerInterfaceWrapper) DeleteImage(w http.ResponseWriter, r *http.Request) {
+// SetImageVersionCommit operation middleware
+func (siw *ServerInterfaceWrapper) SetImageVersionCommit(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -128,8 +661,17 @@ func (siw *ServerInterfaceWrapper) DeleteImage(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameter("simple", false, "version", chi.URLParam(r, "version"), &version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteImage(w, r, imageId)
+		siw.Handler.SetImageVersionCommit(w, r, imageId, version)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -139,8 +681,8 @@ func (siw *ServerInterfaceWrapper) DeleteImage(w http.ResponseWriter, r *http.Re
 	handler(w, r.WithContext(ctx))
 }
 
-// GetImage operation middleware
-func (siw *ServerInterfaceWrapper) GetImage(w http.ResponseWriter, r *http.Request) {
+// UnlockImageVersion operation middleware
+func (siw *ServerInterfaceWrapper) UnlockImageVersion(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -154,8 +696,17 @@ func (siw *ServerInterfaceWrapper) GetImage(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameter("simple", false, "version", chi.URLParam(r, "version"), &version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetImage(w, r, imageId)
+		siw.Handler.UnlockImageVersion(w, r, imageId, version)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -165,8 +716,8 @@ func (siw *ServerInterfaceWrapper) GetImage(w http.ResponseWriter, r *http.Reque
 	handler(w, r.WithContext(ctx))
 }
 
-// UpdateImage operation middleware
-func (siw *ServerInterfaceWrapper) UpdateImage(w http.ResponseWriter, r *http.Request) {
+// LockImageVersion operation middleware
+func (siw *ServerInterfaceWrapper) LockImageVersion(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -180,8 +731,17 @@ func (siw *ServerInterfaceWrapper) UpdateImage(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameter("simple", false, "version", chi.URLParam(r, "version"), &version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateImage(w, r, imageId)
+		siw.Handler.LockImageVersion(w, r, imageId, version)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -191,8 +751,8 @@ func (siw *ServerInterfaceWrapper) UpdateImage(w http.ResponseWriter, r *http.Re
 	handler(w, r.WithContext(ctx))
 }
 
-// DeleteImagesImageIdUpdate operation middleware
-func (siw *ServerInterfaceWrapper) DeleteImagesImageIdUpdate(w http.ResponseWriter, r *http.Request) {
+// PublishImageRepo operation middleware
+func (siw *ServerInterfaceWrapper) PublishImageRepo(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -206,8 +766,17 @@ func (siw *ServerInterfaceWrapper) DeleteImagesImageIdUpdate(w http.ResponseWrit
 		return
 	}
 
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameter("simple", false, "version", chi.URLParam(r, "version"), &version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteImagesImageIdUpdate(w, r, imageId)
+		siw.Handler.PublishImageRepo(w, r, imageId, version)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -217,8 +786,8 @@ func (siw *ServerInterfaceWrapper) DeleteImagesImageIdUpdate(w http.ResponseWrit
 	handler(w, r.WithContext(ctx))
 }
 
-// CreateNewVersion operation middleware
-func (siw *ServerInterfaceWrapper) CreateNewVersion(w http.ResponseWriter, r *http.Request) {
+// RestoreImageVersion operation middleware
+func (siw *ServerInterfaceWrapper) RestoreImageVersion(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -232,8 +801,232 @@ func (siw *ServerInterfaceWrapper) CreateNewVersion(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameter("simple", false, "version", chi.URLParam(r, "version"), &version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreImageVersion(w, r, imageId, version)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetUsedPackages operation middleware
+func (siw *ServerInterfaceWrapper) GetUsedPackages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsedPackagesParams
+
+	// ------------- Optional query parameter "prefix" -------------
+	if paramValue := r.URL.Query().Get("prefix"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", r.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prefix", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsedPackages(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRepositories operation middleware
+func (siw *ServerInterfaceWrapper) GetRepositories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepositories(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CreateRepository operation middleware
+func (siw *ServerInterfaceWrapper) CreateRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateRepository(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteRepository operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repoId" -------------
+	var repoId string
+
+	err = runtime.BindStyledParameter("simple", false, "repoId", chi.URLParam(r, "repoId"), &repoId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repoId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRepository(w, r, repoId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRepository operation middleware
+func (siw *ServerInterfaceWrapper) GetRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repoId" -------------
+	var repoId string
+
+	err = runtime.BindStyledParameter("simple", false, "repoId", chi.URLParam(r, "repoId"), &repoId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repoId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepository(w, r, repoId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// UpdateRepository operation middleware
+func (siw *ServerInterfaceWrapper) UpdateRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repoId" -------------
+	var repoId string
+
+	err = runtime.BindStyledParameter("simple", false, "repoId", chi.URLParam(r, "repoId"), &repoId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repoId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateRepository(w, r, repoId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRetentionPolicy operation middleware
+func (siw *ServerInterfaceWrapper) GetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRetentionPolicy(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// SetRetentionPolicy operation middleware
+func (siw *ServerInterfaceWrapper) SetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetRetentionPolicy(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRetentionDryRun operation middleware
+func (siw *ServerInterfaceWrapper) GetRetentionDryRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRetentionDryRun(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTags operation middleware
+func (siw *ServerInterfaceWrapper) GetTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTagsParams
+
+	// ------------- Optional query parameter "prefix" -------------
+	if paramValue := r.URL.Query().Get("prefix"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", r.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prefix", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateNewVersion(w, r, imageId)
+		siw.Handler.GetTags(w, r, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -362,6 +1155,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/images", wrapper.CreateImage)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/images/search", wrapper.SearchImages)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/images/{imageId}", wrapper.DeleteImage)
 	})
@@ -371,12 +1167,72 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Patch(options.BaseURL+"/images/{imageId}", wrapper.UpdateImage)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/images/{imageId}/clone", wrapper.CloneImage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/images/{imageId}/devices", wrapper.GetImageDevices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/images/{imageId}/lineage", wrapper.GetImageLineage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/images/{imageId}/restore", wrapper.RestoreImage)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/images/{imageId}/update", wrapper.DeleteImagesImageIdUpdate)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/images/{imageId}/update", wrapper.CreateNewVersion)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/images/{imageId}/versions", wrapper.GetImageVersions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/images/{imageId}/versions/{version}/commit", wrapper.SetImageVersionCommit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/images/{imageId}/versions/{version}/lock", wrapper.UnlockImageVersion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/images/{imageId}/versions/{version}/lock", wrapper.LockImageVersion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/images/{imageId}/versions/{version}/repo", wrapper.PublishImageRepo)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/images/{imageId}/versions/{version}/restore", wrapper.RestoreImageVersion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/packages/used", wrapper.GetUsedPackages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repositories", wrapper.GetRepositories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/repositories", wrapper.CreateRepository)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/repositories/{repoId}", wrapper.DeleteRepository)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repositories/{repoId}", wrapper.GetRepository)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/repositories/{repoId}", wrapper.UpdateRepository)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/retention-policy", wrapper.GetRetentionPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/retention-policy", wrapper.SetRetentionPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/retention-policy/dry-run", wrapper.GetRetentionDryRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tags", wrapper.GetTags)
+	})
 
 	return r
 }
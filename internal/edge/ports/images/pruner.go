@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Avielyo10/edge-api/internal/edge/app"
+)
+
+// DefaultPruneInterval is the default interval between two runs of the version pruner.
+const DefaultPruneInterval = time.Hour
+
+// RunVersionPruner prunes the image versions no longer kept by the accounts' retention policies,
+// once every interval, until the context is done.
+func RunVersionPruner(ctx context.Context, application app.Application, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// errors are logged by the command itself, keep pruning on the next tick
+			_ = application.Commands.PruneImageVersions.Handle(ctx)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ports
+
+import (
+	"io"
+	"net/http"
+
+	httperr "github.com/Avielyo10/edge-api/internal/common/server/httperr"
+	"github.com/Avielyo10/edge-api/internal/edge/app/command"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/sirupsen/logrus"
+)
+
+// RepoRoute is the route of the ostree http remotes of images, registered out of the openapi spec
+// as ostree clients request arbitrary paths under it.
+const RepoRoute = "/images/{imageId}/repo/*"
+
+// HandlerWithRepo registers the ostree http remotes of images on the router, along with the routes of the spec.
+func HandlerWithRepo(si HttpServer, r chi.Router) http.Handler {
+	r.Get(RepoRoute, si.ServeImageRepo)
+	r.Head(RepoRoute, si.ServeImageRepo)
+	return HandlerFromMux(si, r)
+}
+
+// PublishImageRepo publishes the ostree repository of the edge-commit tarball of the given version of the image.
+// Implementing ports.ServerInterface
+func (h HttpServer) PublishImageRepo(w http.ResponseWriter, r *http.Request, imageId string, version int) {
+	if version < 1 {
+		httperr.HandleImageErrors(w, r, image.ErrInvalidVersion)
+		return
+	}
+	cmd := command.PublishImageRepo{
+		UUID:    imageId,
+		Version: uint(version),
+		Tarball: r.Body,
+	}
+	if err := h.app.Commands.PublishImageRepo.Handle(r.Context(), cmd); err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusNoContent)
+	render.Respond(w, r, nil)
+}
+
+// ServeImageRepo serves a file of the ostree repository of the image, as an ostree http remote.
+func (h HttpServer) ServeImageRepo(w http.ResponseWriter, r *http.Request) {
+	file, err := h.app.Queries.GetImageRepoFile.Handle(r.Context(), chi.URLParam(r, "imageId"), chi.URLParam(r, "*"))
+	if err != nil {
+		httperr.HandleImageErrors(w, r, err)
+		return
+	}
+	defer file.Close()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, file); err != nil {
+		logrus.WithError(err).Error("failed to serve ostree repository file")
+	}
+}
@@ -2,34 +2,224 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/Avielyo10/edge-api/internal/edge/adapters"
 	"github.com/Avielyo10/edge-api/internal/edge/app"
 	"github.com/Avielyo10/edge-api/internal/edge/app/command"
 	"github.com/Avielyo10/edge-api/internal/edge/app/query"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/device"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/fdo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/group"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/image"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/maintenance"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/repo"
+	"github.com/Avielyo10/edge-api/internal/edge/domain/rollout"
+	"github.com/Avielyo10/edge-api/internal/update/domain/update"
 	"github.com/redhatinsights/edge-api/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 )
 
-// NewApplication returns a new Application.
+// Image stores, selected by the IMAGE_STORE environment variable.
+const (
+	// ImageStoreCache stores the images in the database, cached in redis. It is the default.
+	ImageStoreCache = "cache"
+	// ImageStoreMemory stores the images in memory, without their versions, and they are lost on restart.
+	// No database is opened, so the service runs without any external dependency, but only images are stored.
+	ImageStoreMemory = "memory"
+)
+
+// NewApplication returns a new Application, backed by the store IMAGE_STORE selects.
 func NewApplication(ctx context.Context) app.Application {
 	cfg := config.Get()
+	options := newOptions()
+	stores := newRepositories(ctx, cfg, options)
+	repoStorage := adapters.NewFilesystemOstreeStorage(cfg.RepoTempPath)
 
-	redisClient := adapters.NewRedisClient(cfg)
-	gormClient := adapters.NewGormClient(cfg)
-
-	writeThroughRepository := adapters.NewReadThroughImageRepository(redisClient, gormClient)
+	// the rollouts this replica leased are followed by the workers of an update queue
+	updateQueue := update.NewUpdateQueue()
+	update.Run(updateQueue, 10, 1*time.Minute)
 
 	return app.Application{
 		Commands: app.Commands{
-			CreateImage:        *command.NewCreateImageHandler(writeThroughRepository),
-			UpdateImage:        *command.NewUpdateImageHandler(writeThroughRepository),
-			DeleteImage:        *command.NewDeleteImageHandler(writeThroughRepository),
-			UpgradeImage:       *command.NewUpgradeImageHandler(writeThroughRepository),
-			CancelUpgradeImage: *command.NewCancelUpgradeImageHandler(writeThroughRepository),
+			CreateImage:        *command.NewCreateImageHandler(stores.image, stores.repo),
+			UpdateImage:        *command.NewUpdateImageHandler(stores.image),
+			DeleteImage:        *command.NewDeleteImageHandler(stores.image, stores.rollout, stores.device),
+			RestoreImage:       *command.NewRestoreImageHandler(stores.image),
+			PurgeImage:         *command.NewPurgeImageHandler(stores.image, stores.rollout, stores.device),
+			UpgradeImage:       *command.NewUpgradeImageHandler(stores.image),
+			CancelUpgradeImage: *command.NewCancelUpgradeImageHandler(stores.image),
+			CloneImage:         *command.NewCloneImageHandler(stores.image),
+			LockImageVersion:   *command.NewLockImageVersionHandler(stores.version),
+			SetVersionCommit:   *command.NewSetVersionCommitHandler(stores.version),
+			RestoreVersion:     *command.NewRestoreImageVersionHandler(stores.image, stores.version),
+			SetRetentionPolicy: *command.NewSetRetentionPolicyHandler(stores.retention),
+			PruneImageVersions: *command.NewPruneImageVersionsHandler(stores.image, stores.version,
+				stores.retention, stores.rollout, stores.device),
+			PublishImageRepo: *command.NewPublishImageRepoHandler(stores.version, repoStorage),
+
+			CreateRepo: *command.NewCreateRepoHandler(stores.repo),
+			UpdateRepo: *command.NewUpdateRepoHandler(stores.repo),
+			DeleteRepo: *command.NewDeleteRepoHandler(stores.repo),
+
+			CreateDevice:  *command.NewCreateDeviceHandler(stores.device, stores.version),
+			UpdateDevice:  *command.NewUpdateDeviceHandler(stores.device, stores.version),
+			DeleteDevice:  *command.NewDeleteDeviceHandler(stores.device),
+			CheckInDevice: *command.NewCheckInDeviceHandler(stores.device, stores.version),
+
+			ImportVoucher:      *command.NewImportVoucherHandler(stores.voucher),
+			CompleteOnboarding: *command.NewCompleteOnboardingHandler(stores.voucher, stores.device),
+
+			CreateGroup: *command.NewCreateGroupHandler(stores.group, stores.device),
+
+			UpdateRollout: *command.NewUpdateRolloutHandler(stores.rollout, stores.version,
+				stores.device, stores.group, stores.window, updateQueue),
+			ReportTransaction: *command.NewReportTransactionHandler(stores.rollout),
+			PauseRollout:      *command.NewPauseRolloutHandler(stores.rollout),
+			ResumeRollout: *command.NewResumeRolloutHandler(stores.rollout, stores.device,
+				stores.group, stores.window, updateQueue),
+			AbortRollout: *command.NewAbortRolloutHandler(stores.rollout),
+			FollowRollouts: *command.NewFollowRolloutsHandler(stores.rollout, stores.device,
+				stores.group, stores.window, updateQueue),
+
+			CreateMaintenanceWindow: *command.NewCreateMaintenanceWindowHandler(stores.window,
+				stores.device, stores.group),
+			DeleteMaintenanceWindow: *command.NewDeleteMaintenanceWindowHandler(stores.window),
 		},
 		Queries: app.Queries{
-			GetImage:  *query.NewGetImageHandler(writeThroughRepository),
-			GetImages: *query.NewGetImagesHandler(writeThroughRepository),
+			GetImage:  *query.NewGetImageHandler(stores.image),
+			GetImages: *query.NewGetImagesHandler(stores.image),
+
+			SearchImages: *query.NewSearchImagesHandler(stores.search),
+
+			GetDeletedImages: *query.NewGetDeletedImagesHandler(stores.image),
+
+			GetTags:         *query.NewGetTagsHandler(stores.catalog),
+			GetUsedPackages: *query.NewGetUsedPackagesHandler(stores.catalog),
+
+			GetImageVersions:    *query.NewGetImageVersionsHandler(stores.image, stores.version),
+			GetImageLineage:     *query.NewGetImageLineageHandler(stores.image, stores.version),
+			GetImageAsOf:        *query.NewGetImageAsOfHandler(stores.image, stores.version),
+			GetImageDevices:     *query.NewGetImageDevicesHandler(stores.image, stores.rollout, stores.device),
+			GetImageRepoFile:    *query.NewGetImageRepoFileHandler(repoStorage),
+			GetRetentionPolicy:  *query.NewGetRetentionPolicyHandler(stores.retention),
+			GetPrunableVersions: *query.NewGetPrunableVersionsHandler(stores.image, stores.version, stores.retention, stores.rollout, stores.device),
+
+			GetRepo:  *query.NewGetRepoHandler(stores.repo),
+			GetRepos: *query.NewGetReposHandler(stores.repo),
+
+			GetDevice:         *query.NewGetDeviceHandler(stores.device),
+			GetDevices:        *query.NewGetDevicesHandler(stores.device),
+			GetDriftedDevices: *query.NewGetDriftedDevicesHandler(stores.device),
+			GetDrift:          *query.NewGetDriftHandler(stores.device),
+
+			GetVoucher:  *query.NewGetVoucherHandler(stores.voucher),
+			GetVouchers: *query.NewGetVouchersHandler(stores.voucher),
+
+			GetGroup:        *query.NewGetGroupHandler(stores.group),
+			GetGroups:       *query.NewGetGroupsHandler(stores.group),
+			GetGroupDevices: *query.NewGetGroupDevicesHandler(stores.group),
+			GetDeviceGroups: *query.NewGetDeviceGroupsHandler(stores.group),
+
+			GetRollout: *query.NewGetRolloutHandler(stores.rollout),
+
+			GetMaintenanceWindow:  *query.NewGetMaintenanceWindowHandler(stores.window),
+			GetMaintenanceWindows: *query.NewGetMaintenanceWindowsHandler(stores.window),
+			GetDeviceWindows:      *query.NewGetDeviceWindowsHandler(stores.window, stores.group),
 		},
 	}
 }
+
+// newOptions returns the options of the service read from the environment, along with their defaults.
+func newOptions() *viper.Viper {
+	options := viper.New()
+	options.SetDefault("IMAGE_STORE", ImageStoreCache)
+	options.SetDefault("DB_AUTO_MIGRATE", true)
+	options.AutomaticEnv()
+	return options
+}
+
+// repositories are the repositories the application is backed by.
+type repositories struct {
+	image     image.Repository
+	catalog   image.CatalogRepository
+	search    image.SearchRepository
+	version   image.VersionRepository
+	retention image.RetentionRepository
+	device    device.Repository
+	voucher   fdo.Repository
+	group     group.Repository
+	rollout   rollout.Repository
+	window    maintenance.Repository
+	repo      repo.Repository
+}
+
+// newRepositories returns the repositories of the configured image store.
+func newRepositories(ctx context.Context, cfg *config.EdgeConfig, options *viper.Viper) repositories {
+	switch store := options.GetString("IMAGE_STORE"); store {
+	case ImageStoreMemory:
+		return newMemoryRepositories()
+	case ImageStoreCache:
+	default:
+		log.WithField("store", store).Warnf("unknown image store, using %q", ImageStoreCache)
+	}
+	return newCacheRepositories(ctx, cfg, options)
+}
+
+// newMemoryRepositories returns the repositories of the memory image store. Only images are stored,
+// without their versions, and no database is opened: everything else is unavailable rather than kept elsewhere.
+func newMemoryRepositories() repositories {
+	log.Warn("images are stored in memory, they are lost on restart, and nothing else is stored")
+	images := adapters.NewMemoryImageRepository()
+	unavailable := adapters.NewUnavailableRepository()
+	return repositories{
+		image:     images,
+		catalog:   images,
+		search:    images,
+		version:   unavailable,
+		retention: unavailable,
+		device:    unavailable,
+		voucher:   unavailable,
+		group:     unavailable,
+		rollout:   unavailable,
+		window:    unavailable,
+		repo:      unavailable,
+	}
+}
+
+// newCacheRepositories returns the repositories stored in the database, with images and devices cached in redis.
+// The pending migrations of the database are applied unless DB_AUTO_MIGRATE is false,
+// the service exits if its schema is newer than it knows.
+func newCacheRepositories(ctx context.Context, cfg *config.EdgeConfig, options *viper.Viper) repositories {
+	gormClient, err := adapters.NewGormClient(cfg, options.GetBool("DB_AUTO_MIGRATE"))
+	switch {
+	case errors.Is(err, adapters.ErrSchemaTooNew):
+		log.WithError(err).Fatal("the database was migrated by a newer release, run it instead")
+	case err != nil:
+		log.WithError(err).Fatal("failed to open the database")
+	}
+
+	redisConfig := adapters.NewRedisConfig()
+	redisClient := adapters.NewRedisClient(redisConfig)
+	// reads fall back to the database while redis is unreachable, so the service starts anyway
+	if err := adapters.PingRedis(ctx, redisClient, 5*time.Second); err != nil {
+		log.WithField("addrs", redisConfig.Addrs).Warnf("redis is unreachable, reads go to the database until it is: %v", err)
+	} else {
+		log.WithField("addrs", redisConfig.Addrs).Info("redis is reachable")
+	}
+	return repositories{
+		image:     adapters.NewReadThroughImageRepository(redisClient, gormClient, redisConfig),
+		catalog:   adapters.NewGormCatalogRepository(gormClient),
+		search:    adapters.NewGormSearchRepository(gormClient),
+		version:   adapters.NewGormVersionRepository(gormClient),
+		retention: adapters.NewGormRetentionRepository(gormClient),
+		device:    adapters.NewReadThroughDeviceRepository(redisClient, gormClient, redisConfig),
+		voucher:   adapters.NewGormVoucherRepository(gormClient),
+		group:     adapters.NewGormGroupRepository(gormClient),
+		rollout:   adapters.NewGormRolloutRepository(gormClient),
+		window:    adapters.NewGormMaintenanceRepository(gormClient),
+		repo:      adapters.NewGormRepoRepository(gormClient),
+	}
+}
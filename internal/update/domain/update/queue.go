@@ -1,26 +1,66 @@
 package update
 
-// Queue is a queue for updates.
+import (
+	"sync"
+	"time"
+)
+
+// Queue is a queue for updates. Adding an update never blocks, the queue grows as needed.
 type Queue struct {
-	queue chan UpdatesInterface
+	mu      sync.Mutex
+	ready   *sync.Cond // signaled once an update is added or the queue is closed
+	updates []UpdatesInterface
+	closed  bool
 }
 
 // NewUpdateQueue returns a new update queue.
 func NewUpdateQueue() *Queue {
-	return &Queue{queue: make(chan UpdatesInterface)}
+	uq := &Queue{}
+	uq.ready = sync.NewCond(&uq.mu)
+	return uq
 }
 
-// Add adds an update to the queue.
+// Add adds an update to the queue, it is dropped if the queue is closed.
 func (uq *Queue) Add(update UpdatesInterface) {
-	uq.queue <- update
+	uq.mu.Lock()
+	defer uq.mu.Unlock()
+	if uq.closed {
+		return
+	}
+	uq.updates = append(uq.updates, update)
+	uq.ready.Signal()
+}
+
+// AddAfter adds an update to the queue once the given delay elapsed, without holding a worker meanwhile.
+func (uq *Queue) AddAfter(update UpdatesInterface, delay time.Duration) {
+	if delay <= 0 {
+		uq.Add(update)
+		return
+	}
+	time.AfterFunc(delay, func() { uq.Add(update) })
 }
 
-// Get returns the next update in the queue.
-func (uq *Queue) Get() UpdatesInterface {
-	return <-uq.queue
+// Get returns the next update in the queue, blocking until there is one.
+// ok is false once the queue is closed and the updates it held were returned.
+func (uq *Queue) Get() (update UpdatesInterface, ok bool) {
+	uq.mu.Lock()
+	defer uq.mu.Unlock()
+	for len(uq.updates) == 0 && !uq.closed {
+		uq.ready.Wait()
+	}
+	if len(uq.updates) == 0 {
+		return nil, false
+	}
+	update = uq.updates[0]
+	uq.updates[0] = nil // release the update once it is returned
+	uq.updates = uq.updates[1:]
+	return update, true
 }
 
-// Close closes the update queue.
+// Close closes the update queue, the updates added afterwards are dropped.
 func (uq *Queue) Close() {
-	close(uq.queue)
+	uq.mu.Lock()
+	defer uq.mu.Unlock()
+	uq.closed = true
+	uq.ready.Broadcast()
 }
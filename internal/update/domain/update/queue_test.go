@@ -0,0 +1,70 @@
+package update
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueue(t *testing.T) {
+	first, second, third := newFakeUpdate(1, nil), newFakeUpdate(1, nil), newFakeUpdate(1, nil)
+	tests := []struct {
+		name  string
+		setup func(queue *Queue)
+		want  []UpdatesInterface
+	}{
+		{
+			name: "should return the updates in order, adding without a worker",
+			setup: func(queue *Queue) {
+				queue.Add(first)
+				queue.Add(second)
+				queue.Close()
+			},
+			want: []UpdatesInterface{first, second},
+		},
+		{
+			name: "should return the delayed update once its delay elapsed",
+			setup: func(queue *Queue) {
+				queue.AddAfter(first, 10*time.Millisecond)
+				queue.Add(second)
+				queue.AddAfter(third, 0)
+				go func() {
+					time.Sleep(50 * time.Millisecond)
+					queue.Close()
+				}()
+			},
+			want: []UpdatesInterface{second, third, first},
+		},
+		{
+			name: "should drop the updates added once closed",
+			setup: func(queue *Queue) {
+				queue.Close()
+				queue.Add(first)
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			queue := NewUpdateQueue()
+			tt.setup(queue)
+			var got []UpdatesInterface
+			for {
+				update, ok := queue.Get()
+				if !ok {
+					break
+				}
+				got = append(got, update)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Queue.Get() returned %d updates, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Queue.Get() update %d = %p, want %p", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package update
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Doner is implemented by updates that can be cancelled or time out while their result is awaited.
+type Doner interface {
+	Done() <-chan struct{}
+}
+
+// Scheduler is implemented by updates that know when they are to be checked next, e.g. once a maintenance window
+// opens. They are checked then if it is sooner than the interval of the workers.
+type Scheduler interface {
+	// NextCheck returns when the update is to be checked next, ok is false if it has no such time.
+	NextCheck(now time.Time) (at time.Time, ok bool)
+}
+
+// Work takes the next update from the queue and follows it, it returns false once the queue is closed.
+//
+// Workflow:
+// 1. Get a job from the queue.
+// 2. Check for updates.
+// 2.1. If error, rollback.
+// 2.2. If updated successfully - return.
+// 2.3. If no error, continue.
+// 2.3.1. If done (cancelled or timed out), rollback.
+// 2.3.2. If not done, add it back to the queue once the given interval elapsed, or sooner if the update
+// schedules its next check, the worker moves on meanwhile.
+func Work(queue *Queue, interval time.Duration) bool {
+	job, ok := queue.Get() // block here if no updates are available in the queue.
+	if !ok {
+		return false
+	}
+	if err := job.CheckForUpdate(); err != nil {
+		log.WithField("error", err).Error("error while checking for updates, rolling back")
+		if err := job.Rollback(); err != nil {
+			log.WithField("error", err).Error("error while rolling back")
+		}
+		return true
+	}
+	if job.IsSuccessful() {
+		return true
+	}
+	var done <-chan struct{} // never done, unless the update can be
+	if doner, ok := job.(Doner); ok {
+		done = doner.Done()
+	}
+	select {
+	case <-done:
+		if err := job.Rollback(); err != nil {
+			log.WithField("error", err).Error("error while rolling back")
+		}
+	default:
+		delay := interval
+		if scheduler, ok := job.(Scheduler); ok {
+			if at, ok := scheduler.NextCheck(time.Now()); ok && time.Until(at) < delay {
+				delay = time.Until(at)
+			}
+		}
+		queue.AddAfter(job, delay)
+	}
+	return true
+}
+
+// Run runs the given number of workers on the queue until it is closed.
+func Run(queue *Queue, workers int, interval time.Duration) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			for Work(queue, interval) {
+			}
+		}()
+	}
+}
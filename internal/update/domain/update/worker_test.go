@@ -0,0 +1,124 @@
+package update
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeUpdate is an update that succeeds after the given number of checks, or fails to check.
+type fakeUpdate struct {
+	checksLeft int
+	checkErr   error
+	done       chan struct{}
+	rolledBack chan struct{}
+}
+
+func newFakeUpdate(checks int, checkErr error) *fakeUpdate {
+	return &fakeUpdate{checksLeft: checks, checkErr: checkErr, done: make(chan struct{}), rolledBack: make(chan struct{})}
+}
+
+func (u *fakeUpdate) IsSuccessful() bool { return u.checksLeft == 0 }
+func (u *fakeUpdate) Upgrade() error     { return nil }
+func (u *fakeUpdate) CheckForUpdate() error {
+	u.checksLeft--
+	if u.checksLeft > 0 {
+		return nil
+	}
+	return u.checkErr
+}
+func (u *fakeUpdate) Rollback() error {
+	close(u.rolledBack)
+	return nil
+}
+func (u *fakeUpdate) Done() <-chan struct{} { return u.done }
+
+func TestWork(t *testing.T) {
+	tests := []struct {
+		name         string
+		update       *fakeUpdate
+		cancel       bool
+		wantRollback bool
+	}{
+		{
+			name:         "should finish, successful update",
+			update:       newFakeUpdate(1, nil),
+			wantRollback: false,
+		},
+		{
+			name:         "should finish, successful after being checked again",
+			update:       newFakeUpdate(3, nil),
+			wantRollback: false,
+		},
+		{
+			name:         "should roll back, failed check",
+			update:       newFakeUpdate(1, errors.New("failed")),
+			wantRollback: true,
+		},
+		{
+			name:         "should roll back, cancelled update",
+			update:       newFakeUpdate(100, nil),
+			cancel:       true,
+			wantRollback: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			queue := NewUpdateQueue()
+			Run(queue, 1, time.Millisecond)
+			if tt.cancel {
+				close(tt.update.done)
+			}
+			queue.Add(tt.update)
+			select {
+			case <-tt.update.rolledBack:
+				if !tt.wantRollback {
+					t.Errorf("Work() rolled back, want no rollback")
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tt.wantRollback {
+					t.Errorf("Work() didn't roll back, want a rollback")
+				}
+			}
+		})
+	}
+}
+
+func TestWork_RecheckDoesNotHoldWorker(t *testing.T) {
+	queue := NewUpdateQueue()
+	defer queue.Close()
+	// a single worker, re-checking the slow update once an hour
+	Run(queue, 1, time.Hour)
+	slow, quick := newFakeUpdate(100, nil), newFakeUpdate(1, errors.New("failed"))
+	queue.Add(slow)
+	queue.Add(quick)
+	select {
+	case <-quick.rolledBack:
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Work() didn't check the quick update, the worker is held by the slow one")
+	}
+}
+
+// scheduledUpdate is a fake update to be checked next at the given time.
+type scheduledUpdate struct {
+	*fakeUpdate
+	at time.Time
+}
+
+func (u *scheduledUpdate) NextCheck(now time.Time) (time.Time, bool) { return u.at, true }
+
+func TestWork_ScheduledCheck(t *testing.T) {
+	queue := NewUpdateQueue()
+	defer queue.Close()
+	Run(queue, 1, time.Hour)
+	// failing on its second check, due well before the interval
+	scheduled := &scheduledUpdate{fakeUpdate: newFakeUpdate(2, errors.New("failed")), at: time.Now().Add(10 * time.Millisecond)}
+	queue.Add(scheduled)
+	select {
+	case <-scheduled.rolledBack:
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Work() didn't check the update at the time it scheduled")
+	}
+}